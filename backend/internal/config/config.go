@@ -24,7 +24,10 @@ type Config struct {
 	Log       LogConfig
 	Scheduler SchedulerConfig
 	Vault     VaultConfig
+	Keys      KeysConfig
 	LLM       LLMConfig
+	Catalog   CatalogConfig
+	Review    ReviewConfig
 }
 
 // ServerConfig holds server-related configuration
@@ -130,10 +133,16 @@ type SchedulerConfig struct {
 	DraftReminderCron         string // e.g., "0 9 * * 1" (Monday 9 AM)
 	ReviewerSummaryCron       string // e.g., "0 8 * * *" (Daily 8 AM)
 	HashChainValidationCron   string // e.g., "0 3 * * *" (Daily 3 AM)
+	CatalogLifecycleCron      string // e.g., "5 0 * * *" (Daily 00:05)
+	PanelAssignmentCron       string // e.g., "*/15 * * * *" (Every 15 minutes)
 	ReminderIntervalMins      int    // Interval in minutes for draft reminders (default: 10080 = 7 days)
 	EnableDraftReminders      bool   // Enable/disable draft reminders
 	EnableReviewerSummary     bool   // Enable/disable reviewer summaries
 	EnableHashChainValidation bool   // Enable/disable hash chain validation
+	EnableCatalogLifecycle    bool   // Enable/disable automatic catalog activation and archiving
+	EnablePanelAssignment     bool   // Enable/disable automatic reviewer panel assignment for submitted self-assessments
+	CloseDraftsOnExpiry       bool   // Close draft self-assessments when their catalog expires
+	NotifyOnExpiry            bool   // Notify owners and reviewers of open self-assessments when their catalog expires
 }
 
 // VaultConfig holds Vault-related configuration
@@ -144,6 +153,14 @@ type VaultConfig struct {
 	Enabled      bool
 }
 
+// KeysConfig holds the configuration of the key management backend that wraps user and process keys
+type KeysConfig struct {
+	Provider          string // "vault", "local" (keyring file) or "none" (encryption disabled)
+	MigrateFrom       string // Provider whose wrapped keys are rewrapped with Provider at startup ("" = none)
+	KeyringPath       string // Keyring file of the local provider
+	KeyringPassphrase string // Passphrase protecting the local keyring
+}
+
 // LLMConfig holds LLM-related configuration
 type LLMConfig struct {
 	BaseURL string
@@ -151,6 +168,34 @@ type LLMConfig struct {
 	Enabled bool
 }
 
+// CatalogConfig holds catalog content configuration
+type CatalogConfig struct {
+	Locales             []string // Supported content locales, the first one is the default locale of the base texts
+	RequireTranslations bool     // Require translations for all locales before a catalog can be activated
+	RequiredApprovals   int      // Number of approver sign-offs required before a catalog under review can be activated
+}
+
+// ReviewConfig holds reviewer panel configuration
+type ReviewConfig struct {
+	MinPanelSize int  // Minimum number of reviewers assigned to a self-assessment
+	MaxPanelSize int  // Maximum number of reviewers assigned to a self-assessment
+	AutoAssign   bool // Assign a balanced reviewer panel when a self-assessment is submitted
+	// DisagreementThreshold is the maximum level spread (highest - lowest reviewer level) of a category;
+	// above it a category discussion comment is required before the result can be approved
+	DisagreementThreshold int
+	// Default quorum for consolidation approvals (catalogs may define their own); the higher of the
+	// absolute count and the percentage of the reviewer panel applies
+	QuorumAveragedCount   int  // Approvals required for an averaged category result
+	QuorumAveragedPercent int  // Percentage of the panel required for an averaged category result
+	QuorumOverrideCount   int  // Approvals required for an override
+	QuorumOverridePercent int  // Percentage of the panel required for an override
+	QuorumFinalCount      int  // Approvals required for the final consolidation
+	QuorumFinalPercent    int  // Percentage of the panel required for the final consolidation
+	QuorumSelfApproval    bool // Allow the creator of an override to approve it
+	AppealWindowDays      int  // Days after the start of the discussion meeting in which the owner can appeal the result
+	AppealPanelSize       int  // Number of reviewers automatically assigned to decide an appeal
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	// Load .env file if it exists (ignore error if not found)
@@ -200,8 +245,8 @@ func Load() (*Config, error) {
 		CORS: CORSConfig{
 			AllowedOrigins:   getSliceEnv("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
 			AllowedMethods:   getSliceEnv("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-			AllowedHeaders:   getSliceEnv("CORS_ALLOWED_HEADERS", []string{"Accept", "Authorization", "Content-Type"}),
-			ExposedHeaders:   getSliceEnv("CORS_EXPOSED_HEADERS", []string{"Link"}),
+			AllowedHeaders:   getSliceEnv("CORS_ALLOWED_HEADERS", []string{"Accept", "Authorization", "Content-Type", "If-Match"}),
+			ExposedHeaders:   getSliceEnv("CORS_EXPOSED_HEADERS", []string{"Link", "ETag"}),
 			AllowCredentials: getBoolEnv("CORS_ALLOW_CREDENTIALS", true),
 			MaxAge:           getIntEnv("CORS_MAX_AGE", 300),
 		},
@@ -225,10 +270,16 @@ func Load() (*Config, error) {
 			DraftReminderCron:         getEnv("SCHEDULER_DRAFT_REMINDER_CRON", "0 9 * * 1"),        // Monday 9 AM
 			ReviewerSummaryCron:       getEnv("SCHEDULER_REVIEWER_SUMMARY_CRON", "0 8 * * *"),      // Daily 8 AM
 			HashChainValidationCron:   getEnv("SCHEDULER_HASH_CHAIN_VALIDATION_CRON", "0 3 * * *"), // Daily 3 AM
+			CatalogLifecycleCron:      getEnv("SCHEDULER_CATALOG_LIFECYCLE_CRON", "5 0 * * *"),     // Daily 00:05
+			PanelAssignmentCron:       getEnv("SCHEDULER_PANEL_ASSIGNMENT_CRON", "*/15 * * * *"),   // Every 15 minutes
 			ReminderIntervalMins:      getIntEnv("SCHEDULER_REMINDER_INTERVAL_MINS", 10080),        // 7 days = 10080 minutes
 			EnableDraftReminders:      getBoolEnv("SCHEDULER_ENABLE_DRAFT_REMINDERS", true),
 			EnableReviewerSummary:     getBoolEnv("SCHEDULER_ENABLE_REVIEWER_SUMMARY", true),
 			EnableHashChainValidation: getBoolEnv("SCHEDULER_ENABLE_HASH_CHAIN_VALIDATION", true),
+			EnableCatalogLifecycle:    getBoolEnv("SCHEDULER_ENABLE_CATALOG_LIFECYCLE", true),
+			EnablePanelAssignment:     getBoolEnv("SCHEDULER_ENABLE_PANEL_ASSIGNMENT", true),
+			CloseDraftsOnExpiry:       getBoolEnv("SCHEDULER_EXPIRY_CLOSE_DRAFTS", true),
+			NotifyOnExpiry:            getBoolEnv("SCHEDULER_EXPIRY_NOTIFY", true),
 		},
 		Vault: VaultConfig{
 			Address:      getEnv("VAULT_ADDR", "http://localhost:8200"),
@@ -236,11 +287,37 @@ func Load() (*Config, error) {
 			TransitMount: getEnv("VAULT_TRANSIT_MOUNT", "transit"),
 			Enabled:      getBoolEnv("VAULT_ENABLED", true),
 		},
+		Keys: KeysConfig{
+			Provider:          getEnv("KEY_PROVIDER", defaultKeyProvider()),
+			MigrateFrom:       getEnv("KEY_PROVIDER_MIGRATE_FROM", ""),
+			KeyringPath:       getEnv("KEYRING_PATH", "./data/keyring.json"),
+			KeyringPassphrase: getEnv("KEYRING_PASSPHRASE", ""),
+		},
 		LLM: LLMConfig{
 			BaseURL: getEnv("LLM_BASE_URL", "http://localhost:11434"),
 			Model:   getEnv("LLM_MODEL", "llama3"),
 			Enabled: getBoolEnv("LLM_ENABLED", true),
 		},
+		Catalog: CatalogConfig{
+			Locales:             getSliceEnv("CATALOG_LOCALES", []string{"de", "en"}),
+			RequireTranslations: getBoolEnv("CATALOG_REQUIRE_TRANSLATIONS", false),
+			RequiredApprovals:   getIntEnv("CATALOG_REQUIRED_APPROVALS", 1),
+		},
+		Review: ReviewConfig{
+			MinPanelSize:          getIntEnv("REVIEW_PANEL_MIN_SIZE", 3),
+			MaxPanelSize:          getIntEnv("REVIEW_PANEL_MAX_SIZE", 5),
+			AutoAssign:            getBoolEnv("REVIEW_AUTO_ASSIGN", true),
+			DisagreementThreshold: getIntEnv("REVIEW_DISAGREEMENT_THRESHOLD", 1),
+			QuorumAveragedCount:   getIntEnv("REVIEW_QUORUM_AVERAGED_COUNT", 2),
+			QuorumAveragedPercent: getIntEnv("REVIEW_QUORUM_AVERAGED_PERCENT", 0),
+			QuorumOverrideCount:   getIntEnv("REVIEW_QUORUM_OVERRIDE_COUNT", 1),
+			QuorumOverridePercent: getIntEnv("REVIEW_QUORUM_OVERRIDE_PERCENT", 0),
+			QuorumFinalCount:      getIntEnv("REVIEW_QUORUM_FINAL_COUNT", 0),
+			QuorumFinalPercent:    getIntEnv("REVIEW_QUORUM_FINAL_PERCENT", 100),
+			QuorumSelfApproval:    getBoolEnv("REVIEW_QUORUM_SELF_APPROVAL", false),
+			AppealWindowDays:      getIntEnv("REVIEW_APPEAL_WINDOW_DAYS", 14),
+			AppealPanelSize:       getIntEnv("REVIEW_APPEAL_PANEL_SIZE", 1),
+		},
 	}
 
 	// Validate required configuration
@@ -330,11 +407,59 @@ func (c *Config) Validate() error {
 	if c.Database.Password == "" && c.App.Env == "production" {
 		return fmt.Errorf("DB_PASSWORD is required in production")
 	}
+	if c.Review.MinPanelSize < 1 || c.Review.MaxPanelSize < c.Review.MinPanelSize {
+		return fmt.Errorf("REVIEW_PANEL_MIN_SIZE must be at least 1 and not greater than REVIEW_PANEL_MAX_SIZE")
+	}
+	if c.Review.DisagreementThreshold < 0 {
+		return fmt.Errorf("REVIEW_DISAGREEMENT_THRESHOLD must not be negative")
+	}
+	if c.Review.AppealWindowDays < 1 {
+		return fmt.Errorf("REVIEW_APPEAL_WINDOW_DAYS must be at least 1")
+	}
+	if c.Review.AppealPanelSize < 1 {
+		return fmt.Errorf("REVIEW_APPEAL_PANEL_SIZE must be at least 1")
+	}
+	switch c.Keys.Provider {
+	case "vault", "local", "none":
+	default:
+		return fmt.Errorf("KEY_PROVIDER must be vault, local or none")
+	}
+	if c.Keys.Provider == "local" && c.Keys.KeyringPassphrase == "" {
+		return fmt.Errorf("KEYRING_PASSPHRASE is required for KEY_PROVIDER=local")
+	}
+	if c.Keys.MigrateFrom != "" {
+		if c.Keys.MigrateFrom != "vault" && c.Keys.MigrateFrom != "local" {
+			return fmt.Errorf("KEY_PROVIDER_MIGRATE_FROM must be vault or local")
+		}
+		if c.Keys.MigrateFrom == c.Keys.Provider || c.Keys.Provider == "none" {
+			return fmt.Errorf("KEY_PROVIDER_MIGRATE_FROM must differ from KEY_PROVIDER and KEY_PROVIDER must not be none")
+		}
+	}
+	for _, q := range []struct {
+		name           string
+		count, percent int
+	}{
+		{"AVERAGED", c.Review.QuorumAveragedCount, c.Review.QuorumAveragedPercent},
+		{"OVERRIDE", c.Review.QuorumOverrideCount, c.Review.QuorumOverridePercent},
+		{"FINAL", c.Review.QuorumFinalCount, c.Review.QuorumFinalPercent},
+	} {
+		if q.count < 0 || q.percent < 0 || q.percent > 100 || (q.count == 0 && q.percent == 0) {
+			return fmt.Errorf("REVIEW_QUORUM_%s_COUNT must not be negative, REVIEW_QUORUM_%s_PERCENT must be between 0 and 100 and at least one of them must be set", q.name, q.name)
+		}
+	}
 	return nil
 }
 
 // Helper functions
 
+// defaultKeyProvider keeps the previous behavior: Vault if it is enabled, otherwise no encryption
+func defaultKeyProvider() string {
+	if getBoolEnv("VAULT_ENABLED", true) {
+		return "vault"
+	}
+	return "none"
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -3,11 +3,15 @@ package email
 import (
 	"bytes"
 	"fmt"
+	"html"
 	"html/template"
 	"io"
 	"log/slog"
+	"mime"
+	"mime/multipart"
 	"net"
 	"net/smtp"
+	"net/textproto"
 	"time"
 
 	"new-pay/internal/config"
@@ -142,6 +146,11 @@ func (s *Service) sendEmail(to, subject, body string) error {
 	message.WriteString("\r\n")
 	message.WriteString(body)
 
+	return s.deliver(to, message.Bytes())
+}
+
+// deliver sends a complete message (headers and body) to a recipient via SMTP
+func (s *Service) deliver(to string, message []byte) error {
 	// Connect to SMTP server
 	addr := net.JoinHostPort(s.config.SMTPHost, s.config.SMTPPort)
 	slog.Debug("Attempting to connect to SMTP server",
@@ -218,7 +227,7 @@ func (s *Service) sendEmail(to, subject, body string) error {
 		}
 	}(wc)
 
-	if _, err := wc.Write(message.Bytes()); err != nil {
+	if _, err := wc.Write(message); err != nil {
 		slog.Error("Failed to write message", "error", err)
 		return fmt.Errorf("failed to write message: %w", err)
 	}
@@ -462,6 +471,263 @@ func (s *Service) SendReviewCompletedNotification(to, userName, catalogName stri
 	return s.sendEmail(to, subject, body)
 }
 
+// SendCatalogExpiredNotification informs the owner of an open self-assessment that its catalog has expired
+func (s *Service) SendCatalogExpiredNotification(to, userName, catalogName string, assessmentID uint, closed bool) error {
+	subject := fmt.Sprintf("Katalog '%s' abgelaufen", catalogName)
+
+	statusHTML := `<p style="margin: 5px 0;"><strong>Status:</strong> Ihre Selbsteinschätzung bleibt offen und wird weiter bearbeitet.</p>`
+	if closed {
+		statusHTML = `<p style="margin: 5px 0;"><strong>Status:</strong> Ihr nicht eingereichter Entwurf wurde automatisch geschlossen.</p>`
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Katalog abgelaufen</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #e74c3c;">Katalog abgelaufen</h2>
+        <p>Hallo %s,</p>
+        <p>Der Gültigkeitszeitraum des Katalogs <strong>%s</strong> ist abgelaufen. Der Katalog wurde archiviert.</p>
+        
+        <div style="background-color: #fff3cd; border-left: 4px solid #ffc107; padding: 15px; margin: 20px 0;">
+            %s
+            <p style="margin: 5px 0;"><strong>Assessment-ID:</strong> #%d</p>
+        </div>
+        
+        <p>Bei Fragen wenden Sie sich bitte an das Review-Team.</p>
+        
+        <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
+        <p style="color: #999; font-size: 12px;">Dies ist eine automatische Benachrichtigung. Bitte antworten Sie nicht auf diese E-Mail.</p>
+    </div>
+</body>
+</html>
+	`, userName, catalogName, statusHTML, assessmentID)
+
+	return s.sendEmail(to, subject, body)
+}
+
+// SendAssessmentStatusNotification informs a user that a self-assessment entered a new workflow status
+func (s *Service) SendAssessmentStatusNotification(to, userName, catalogName, ownerName, status string, assessmentID uint) error {
+	subject := fmt.Sprintf("Selbsteinschätzung #%d: neuer Status '%s'", assessmentID, status)
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Statusänderung</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #3498db;">Statusänderung</h2>
+        <p>Hallo %s,</p>
+        <p>Die Selbsteinschätzung von <strong>%s</strong> für den Katalog <strong>%s</strong> hat einen neuen Status.</p>
+        
+        <div style="background-color: #e8f4fd; border-left: 4px solid #3498db; padding: 15px; margin: 20px 0;">
+            <p style="margin: 5px 0;"><strong>Status:</strong> %s</p>
+            <p style="margin: 5px 0;"><strong>Assessment-ID:</strong> #%d</p>
+        </div>
+        
+        <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
+        <p style="color: #999; font-size: 12px;">Dies ist eine automatische Benachrichtigung. Bitte antworten Sie nicht auf diese E-Mail.</p>
+    </div>
+</body>
+</html>
+	`, userName, ownerName, catalogName, status, assessmentID)
+
+	return s.sendEmail(to, subject, body)
+}
+
+// SendChangesRequestedNotification informs the owner that a reviewer requested changes to the self-assessment
+// The message itself is encrypted and only shown in the application
+func (s *Service) SendChangesRequestedNotification(to, userName, catalogName, reviewerName string, assessmentID uint) error {
+	subject := "Änderungen an Ihrer Selbsteinschätzung angefordert"
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Änderungen angefordert</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #e67e22;">Änderungen angefordert</h2>
+        <p>Hallo %s,</p>
+        <p><strong>%s</strong> hat Änderungen an Ihrer Selbsteinschätzung für den Katalog <strong>%s</strong> angefordert.</p>
+        
+        <div style="background-color: #fef5e7; border-left: 4px solid #e67e22; padding: 15px; margin: 20px 0;">
+            <p style="margin: 5px 0;"><strong>Status:</strong> Änderungen angefordert (changes_requested)</p>
+            <p style="margin: 5px 0;"><strong>Assessment-ID:</strong> #%d</p>
+        </div>
+        
+        <p>Die Nachricht des Reviewers finden Sie in der Anwendung. Bitte überarbeiten Sie Ihre Angaben und reichen Sie die Selbsteinschätzung erneut ein.</p>
+        
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s/self-assessments/%d" style="background-color: #e67e22; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block;">Selbsteinschätzung öffnen</a>
+        </div>
+        
+        <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
+        <p style="color: #999; font-size: 12px;">Dies ist eine automatische Benachrichtigung. Bitte antworten Sie nicht auf diese E-Mail.</p>
+    </div>
+</body>
+</html>
+	`, userName, reviewerName, catalogName, assessmentID, s.config.VerificationURL, assessmentID)
+
+	return s.sendEmail(to, subject, body)
+}
+
+// SendMeetingInvitation sends a discussion meeting as iCalendar invitation (iMIP, RFC 6047).
+// The event method decides between invitation/update (REQUEST) and cancellation (CANCEL).
+func (s *Service) SendMeetingInvitation(to, userName string, event CalendarEvent) error {
+	title, color, intro := "Einladung zum Gespräch", "#3498db", "Sie sind zu folgendem Gespräch eingeladen:"
+	switch {
+	case event.Method == CalendarMethodCancel:
+		title, color, intro = "Gespräch abgesagt", "#e74c3c", "Das folgende Gespräch wurde abgesagt:"
+	case event.Sequence > 0:
+		title, color, intro = "Gespräch geändert", "#e67e22", "Das folgende Gespräch wurde geändert:"
+	}
+	subject := fmt.Sprintf("%s: %s", title, event.Summary)
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: %s;">%s</h2>
+        <p>Hallo %s,</p>
+        <p>%s</p>
+
+        <div style="background-color: #f8f9fa; border-left: 4px solid %s; padding: 15px; margin: 20px 0;">
+            <p style="margin: 5px 0;"><strong>Thema:</strong> %s</p>
+            <p style="margin: 5px 0;"><strong>Beginn:</strong> %s</p>
+            <p style="margin: 5px 0;"><strong>Ende:</strong> %s</p>
+            <p style="margin: 5px 0;"><strong>Ort:</strong> %s</p>
+            <p style="margin: 5px 0;"><strong>Organisiert von:</strong> %s</p>
+        </div>
+
+        <p>Der Termin ist als Kalendereinladung angehängt.</p>
+
+        <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
+        <p style="color: #999; font-size: 12px;">Dies ist eine automatische Benachrichtigung.</p>
+    </div>
+</body>
+</html>
+	`, title, color, title, html.EscapeString(userName), intro, color,
+		html.EscapeString(event.Summary),
+		event.Start.Format("02.01.2006 15:04 MST"),
+		event.End.Format("02.01.2006 15:04 MST"),
+		html.EscapeString(event.Location),
+		html.EscapeString(event.Organizer.Name))
+
+	return s.sendCalendarEmail(to, subject, body, event.Method, BuildICalendar(event))
+}
+
+// sendCalendarEmail sends an HTML email with an iCalendar alternative part that calendar clients
+// process as invitation
+func (s *Service) sendCalendarEmail(to, subject, body, method, ics string) error {
+	var parts bytes.Buffer
+	writer := multipart.NewWriter(&parts)
+
+	htmlPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"8bit"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create html part: %w", err)
+	}
+	htmlPart.Write([]byte(body))
+
+	calendarPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {fmt.Sprintf("text/calendar; charset=UTF-8; method=%s", method)},
+		"Content-Transfer-Encoding": {"8bit"},
+		"Content-Disposition":       {`inline; filename="invite.ics"`},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create calendar part: %w", err)
+	}
+	calendarPart.Write([]byte(ics))
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart message: %w", err)
+	}
+
+	var message bytes.Buffer
+	message.WriteString(fmt.Sprintf("From: %s\r\n", s.config.SMTPFrom))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", to))
+	message.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject)))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n", writer.Boundary()))
+	message.WriteString("\r\n")
+	message.Write(parts.Bytes())
+
+	return s.deliver(to, message.Bytes())
+}
+
+// SendCatalogExpiredReviewerNotification informs reviewers about self-assessments still open when their catalog expired
+func (s *Service) SendCatalogExpiredReviewerNotification(to, catalogName string, items []ReviewSummaryItem) error {
+	if len(items) == 0 {
+		return nil // Nothing left to review
+	}
+
+	subject := fmt.Sprintf("Katalog '%s' abgelaufen: %d offene Selbsteinschätzungen", catalogName, len(items))
+
+	itemsHTML := ""
+	for _, item := range items {
+		itemsHTML += fmt.Sprintf(`
+		<tr style="border-bottom: 1px solid #eee;">
+			<td style="padding: 12px 8px;">%s<br><span style="color: #999; font-size: 12px;">%s</span></td>
+			<td style="padding: 12px 8px;">%s</td>
+			<td style="padding: 12px 8px;">
+				<a href="%s/admin/self-assessments/%d" style="color: #4a90e2; text-decoration: none;">Öffnen</a>
+			</td>
+		</tr>
+		`, item.UserName, item.UserEmail, item.Status, s.config.VerificationURL, item.ID)
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Katalog abgelaufen</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 800px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #e74c3c;">Katalog abgelaufen: Offene Selbsteinschätzungen</h2>
+        <p>Der Katalog <strong>%s</strong> wurde archiviert. Folgende Selbsteinschätzungen sind noch nicht abgeschlossen:</p>
+        
+        <table style="width: 100%%; border-collapse: collapse; margin: 20px 0; background-color: white; box-shadow: 0 2px 4px rgba(0,0,0,0.1);">
+			<thead>
+				<tr style="background-color: #f5f5f5; border-bottom: 2px solid #ddd;">
+					<th style="padding: 12px 8px; text-align: left;">Benutzer</th>
+					<th style="padding: 12px 8px; text-align: left;">Status</th>
+					<th style="padding: 12px 8px; text-align: left;">Aktion</th>
+				</tr>
+			</thead>
+			<tbody>
+				%s
+			</tbody>
+		</table>
+        
+        <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
+        <p style="color: #999; font-size: 12px;">Dies ist eine automatische Benachrichtigung.</p>
+    </div>
+</body>
+</html>
+	`, catalogName, itemsHTML)
+
+	return s.sendEmail(to, subject, body)
+}
+
 // SendHashChainAlert sends critical alert to admins when hash chain validation fails
 func (s *Service) SendHashChainAlert(to, adminName string, totalProcesses, validProcesses int, failedProcesses, errors []string) error {
 	subject := "🚨 CRITICAL: Hash Chain Validation Failed - Data Integrity Issue"
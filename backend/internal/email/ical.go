@@ -0,0 +1,137 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar methods (RFC 5546)
+const (
+	CalendarMethodRequest = "REQUEST"
+	CalendarMethodCancel  = "CANCEL"
+)
+
+// icalLineLimit is the maximum line length in octets before a line is folded (RFC 5545, 3.1)
+const icalLineLimit = 75
+
+// CalendarAttendee is the organizer or an attendee of a calendar event
+type CalendarAttendee struct {
+	Name  string
+	Email string
+}
+
+// CalendarEvent describes a meeting sent as iCalendar invitation. UID stays the same for
+// updates and the cancellation of a meeting; Sequence has to increase with every change.
+type CalendarEvent struct {
+	Method      string // CalendarMethodRequest or CalendarMethodCancel
+	UID         string
+	Sequence    int
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time // DTSTAMP, time the invitation was created
+	Summary     string
+	Description string
+	Location    string
+	Organizer   CalendarAttendee
+	Attendees   []CalendarAttendee
+}
+
+// BuildICalendar renders an event as RFC 5545 iCalendar object with CRLF line endings
+func BuildICalendar(event CalendarEvent) string {
+	status := "CONFIRMED"
+	if event.Method == CalendarMethodCancel {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//NewPay//Discussion Meetings//DE",
+		"VERSION:2.0",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + event.Method,
+		"BEGIN:VEVENT",
+		"UID:" + escapeICalText(event.UID),
+		fmt.Sprintf("SEQUENCE:%d", event.Sequence),
+		"DTSTAMP:" + formatICalTime(event.Stamp),
+		"DTSTART:" + formatICalTime(event.Start),
+		"DTEND:" + formatICalTime(event.End),
+		"SUMMARY:" + escapeICalText(event.Summary),
+	}
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeICalText(event.Location))
+	}
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICalText(event.Description))
+	}
+	lines = append(lines, fmt.Sprintf("ORGANIZER;CN=%s:mailto:%s", quoteICalParam(event.Organizer.Name), event.Organizer.Email))
+	for _, attendee := range event.Attendees {
+		lines = append(lines, fmt.Sprintf("ATTENDEE;CN=%s;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:%s",
+			quoteICalParam(attendee.Name), attendee.Email))
+	}
+	lines = append(lines,
+		"STATUS:"+status,
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICalLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// formatICalTime formats a time as UTC date-time (RFC 5545, 3.3.5 form #2)
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeICalText escapes a TEXT value (RFC 5545, 3.3.11)
+func escapeICalText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// quoteICalParam quotes a parameter value; double quotes and control characters are not allowed inside
+func quoteICalParam(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r == '"' || r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	return `"` + value + `"`
+}
+
+// foldICalLine splits a content line into lines of at most icalLineLimit octets; continuation
+// lines start with a space (RFC 5545, 3.1). Multi-byte characters are never split.
+func foldICalLine(line string) string {
+	if len(line) <= icalLineLimit {
+		return line
+	}
+
+	var b strings.Builder
+	limit := icalLineLimit
+	length := 0
+	for len(line) > 0 {
+		_, size := utf8.DecodeRuneInString(line)
+		if length+size > limit {
+			b.WriteString("\r\n ")
+			length = 0
+			limit = icalLineLimit - 1 // The leading space counts towards the limit
+		}
+		b.WriteString(line[:size])
+		length += size
+		line = line[size:]
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package email
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildICalendar(t *testing.T) {
+	start := time.Date(2026, 10, 20, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	event := CalendarEvent{
+		Method:      CalendarMethodRequest,
+		UID:         "discussion-meeting-7@newpay",
+		Sequence:    2,
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Stamp:       time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC),
+		Summary:     "Gespräch: Selbsteinschätzung #7",
+		Description: "Zeile 1\nZeile 2; mit Komma, Semikolon",
+		Location:    "Raum 3.14",
+		Organizer:   CalendarAttendee{Name: "Rita Reviewer", Email: "rita@example.com"},
+		Attendees:   []CalendarAttendee{{Name: `Max "M" Muster`, Email: "max@example.com"}},
+	}
+
+	ics := BuildICalendar(event)
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"METHOD:REQUEST\r\n",
+		"UID:discussion-meeting-7@newpay\r\n",
+		"SEQUENCE:2\r\n",
+		"DTSTAMP:20261016T093000Z\r\n",
+		"DTSTART:20261020T120000Z\r\n",
+		"DTEND:20261020T130000Z\r\n",
+		"DESCRIPTION:Zeile 1\\nZeile 2\\; mit Komma\\, Semikolon\r\n",
+		"ORGANIZER;CN=\"Rita Reviewer\":mailto:rita@example.com\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("missing %q in\n%s", want, ics)
+		}
+	}
+	if !strings.Contains(ics, `ATTENDEE;CN="Max M Muster";`) {
+		t.Errorf("attendee name not sanitized:\n%s", ics)
+	}
+
+	event.Method = CalendarMethodCancel
+	if ics := BuildICalendar(event); !strings.Contains(ics, "METHOD:CANCEL\r\n") || !strings.Contains(ics, "STATUS:CANCELLED\r\n") {
+		t.Errorf("cancellation not marked:\n%s", ics)
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("Gespräch über Ergebnisse ", 10)
+	folded := foldICalLine(line)
+
+	for i, part := range strings.Split(folded, "\r\n") {
+		if len(part) > icalLineLimit {
+			t.Errorf("line %d has %d octets", i, len(part))
+		}
+		if i > 0 && !strings.HasPrefix(part, " ") {
+			t.Errorf("continuation line %d does not start with a space", i)
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != line {
+		t.Errorf("unfolding does not restore the line:\n%q\n%q", unfolded, line)
+	}
+
+	if short := "SUMMARY:kurz"; foldICalLine(short) != short {
+		t.Error("short line was folded")
+	}
+}
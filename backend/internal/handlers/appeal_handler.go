@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/models"
+	"new-pay/internal/service"
+)
+
+// AppealRequest represents the request body for filing an appeal against the discussion result
+type AppealRequest struct {
+	Reason string `json:"reason"`
+}
+
+// AppealPanelRequest represents the request body for assigning the appeal panel
+type AppealPanelRequest struct {
+	ReviewerIDs []uint `json:"reviewer_ids"`
+}
+
+// AppealDecisionRequest represents the request body for deciding an appeal
+type AppealDecisionRequest struct {
+	Outcome string                        `json:"outcome"` // "upheld" or "changed"
+	Reason  string                        `json:"reason"`
+	Changes []models.AppealCategoryChange `json:"changes,omitempty"`
+}
+
+// AppealHandler handles appeals against discussion results
+type AppealHandler struct {
+	appealService *service.AppealService
+}
+
+// NewAppealHandler creates a new appeal handler
+func NewAppealHandler(appealService *service.AppealService) *AppealHandler {
+	return &AppealHandler{
+		appealService: appealService,
+	}
+}
+
+// GetAppeal retrieves the appeal of a self-assessment
+// @Summary Get appeal
+// @Description Retrieve the appeal against the discussion result with decrypted reason, decision and appeal panel (owner, reviewer panel, appeal panel and admins)
+// @Tags Discussion
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Success 200 {object} models.Appeal
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "No appeal filed"
+// @Router /discussion/{id}/appeal [get]
+func (h *AppealHandler) GetAppeal(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	appeal, err := h.appealService.GetAppeal(uint(assessmentID), userID, userRoles)
+	if err != nil {
+		writeAppealError(w, err)
+		return
+	}
+	if appeal == nil {
+		http.Error(w, "appeal not found", http.StatusNotFound)
+		return
+	}
+
+	JSONResponse(w, appeal)
+}
+
+// FileAppeal files the owner's appeal against the discussion result
+// @Summary File appeal
+// @Description Appeal the discussion result (status appeal). Possible once, from the start of the discussion meeting until the end of the appeal window and before the owner confirmed the discussion. The reason is stored encrypted; an appeal panel outside the original reviewer panel is assigned. Owner only.
+// @Tags Discussion
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Param request body AppealRequest true "Reason"
+// @Success 201 {object} models.Appeal
+// @Failure 400 {object} map[string]string "Invalid request, appeal window closed or transition not allowed"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /discussion/{id}/appeal [post]
+func (h *AppealHandler) FileAppeal(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return
+	}
+
+	var req AppealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	appeal, err := h.appealService.FileAppeal(uint(assessmentID), userID, userRoles, req.Reason)
+	if err != nil {
+		writeAppealError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, appeal)
+}
+
+// SetAppealPanel assigns the panel of an open appeal
+// @Summary Assign appeal panel
+// @Description Replace the panel of an open appeal. Only reviewers outside the original reviewer panel without conflict of interest. Admin only.
+// @Tags Discussion
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Param request body AppealPanelRequest true "Reviewers"
+// @Success 200 {object} models.Appeal
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Appeal not found"
+// @Router /discussion/{id}/appeal/panel [put]
+func (h *AppealHandler) SetAppealPanel(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return
+	}
+
+	var req AppealPanelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	appeal, err := h.appealService.SetPanel(uint(assessmentID), req.ReviewerIDs, userID)
+	if err != nil {
+		writeAppealError(w, err)
+		return
+	}
+
+	JSONResponse(w, appeal)
+}
+
+// DecideAppeal records the decision of the appeal panel
+// @Summary Decide appeal
+// @Description Uphold the discussion result or change category results. The decision is stored encrypted, the original discussion result is superseded (not deleted) and the self-assessment returns to discussion. Only reviewers of the appeal panel.
+// @Tags Discussion
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Param request body AppealDecisionRequest true "Decision"
+// @Success 200 {object} models.Appeal
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Appeal not found"
+// @Router /discussion/{id}/appeal/decision [post]
+func (h *AppealHandler) DecideAppeal(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return
+	}
+
+	var req AppealDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	appeal, err := h.appealService.DecideAppeal(uint(assessmentID), userID, req.Outcome, req.Reason, req.Changes)
+	if err != nil {
+		writeAppealError(w, err)
+		return
+	}
+
+	JSONResponse(w, appeal)
+}
+
+func writeAppealError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), ErrMsgPermissionDenied) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	} else if strings.Contains(err.Error(), ErrMsgNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +11,8 @@ import (
 	"new-pay/internal/middleware"
 	"new-pay/internal/models"
 	"new-pay/internal/service"
+
+	"gopkg.in/yaml.v3"
 )
 
 // CatalogRequest represents the request body for creating/updating catalogs
@@ -19,21 +22,37 @@ type CatalogRequest struct {
 	ValidFrom   string  `json:"valid_from"`  // Date string in YYYY-MM-DD format
 	ValidUntil  string  `json:"valid_until"` // Date string in YYYY-MM-DD format
 	Phase       *string `json:"phase,omitempty"`
+	// Scoring settings (optional): mean, median, trimmed_mean or mode / nearest or down
+	ScoringStrategy *string `json:"scoring_strategy,omitempty"`
+	LevelRounding   *string `json:"level_rounding,omitempty"`
+}
+
+// applyScoringSettings copies the optional scoring settings of a request to a catalog
+func applyScoringSettings(catalog *models.CriteriaCatalog, req CatalogRequest) {
+	if req.ScoringStrategy != nil {
+		catalog.ScoringStrategy = *req.ScoringStrategy
+	}
+	if req.LevelRounding != nil {
+		catalog.LevelRounding = *req.LevelRounding
+	}
 }
 
 // CatalogHandler handles criteria catalog requests
 type CatalogHandler struct {
 	catalogService *service.CatalogService
+	localizer      *service.CatalogLocalizer
 	auditMw        *middleware.AuditMiddleware
 }
 
 // NewCatalogHandler creates a new catalog handler
 func NewCatalogHandler(
 	catalogService *service.CatalogService,
+	localizer *service.CatalogLocalizer,
 	auditMw *middleware.AuditMiddleware,
 ) *CatalogHandler {
 	return &CatalogHandler{
 		catalogService: catalogService,
+		localizer:      localizer,
 		auditMw:        auditMw,
 	}
 }
@@ -70,11 +89,12 @@ func (h *CatalogHandler) GetAllCatalogs(w http.ResponseWriter, r *http.Request)
 
 // GetCatalogByID retrieves a catalog by ID
 // @Summary Get catalog by ID
-// @Description Get a specific catalog with all details
+// @Description Get a specific catalog with all details. Texts are translated into the locale given by the lang parameter or the Accept-Language header
 // @Tags Catalogs
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Catalog ID"
+// @Param lang query string false "Content locale (overrides Accept-Language)"
 // @Success 200 {object} models.CatalogWithDetails
 // @Failure 400 {object} map[string]string "Invalid ID"
 // @Failure 401 {object} map[string]string "Unauthorized"
@@ -82,6 +102,31 @@ func (h *CatalogHandler) GetAllCatalogs(w http.ResponseWriter, r *http.Request)
 // @Failure 404 {object} map[string]string "Catalog not found"
 // @Router /catalogs/{id} [get]
 func (h *CatalogHandler) GetCatalogByID(w http.ResponseWriter, r *http.Request) {
+	h.getCatalogByID(w, r, requestLocale(w, r, h.localizer))
+}
+
+// GetCatalogByIDAdmin retrieves a catalog by ID for editing
+// @Summary Get catalog by ID (admin)
+// @Description Get a specific catalog with all details. Returns the base texts unless a locale is requested explicitly via lang, so that the editor never saves translated texts as base texts
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param lang query string false "Content locale"
+// @Success 200 {object} models.CatalogWithDetails
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id} [get]
+func (h *CatalogHandler) GetCatalogByIDAdmin(w http.ResponseWriter, r *http.Request) {
+	locale := h.localizer.ResolveLocale(r.URL.Query().Get("lang"), "")
+	w.Header().Set("Content-Language", locale)
+	h.getCatalogByID(w, r, locale)
+}
+
+// getCatalogByID writes a catalog with all details translated into the given locale
+func (h *CatalogHandler) getCatalogByID(w http.ResponseWriter, r *http.Request, locale string) {
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
@@ -106,6 +151,11 @@ func (h *CatalogHandler) GetCatalogByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := h.localizer.LocalizeCatalog(catalog, locale); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	JSONResponse(w, catalog)
 }
 
@@ -149,6 +199,7 @@ func (h *CatalogHandler) CreateCatalog(w http.ResponseWriter, r *http.Request) {
 		ValidFrom:   validFrom,
 		ValidUntil:  validUntil,
 	}
+	applyScoringSettings(&catalog, req)
 
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
@@ -222,6 +273,7 @@ func (h *CatalogHandler) UpdateCatalog(w http.ResponseWriter, r *http.Request) {
 	if req.Phase != nil {
 		catalog.Phase = *req.Phase
 	}
+	applyScoringSettings(&catalog, req)
 
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
@@ -339,7 +391,7 @@ func (h *CatalogHandler) DeleteCatalog(w http.ResponseWriter, r *http.Request) {
 
 // TransitionToActive transitions a catalog to active phase
 // @Summary Transition to active
-// @Description Move catalog from draft to active phase (admin only)
+// @Description Move catalog from review to active phase once it has the required number of approvals (admin only)
 // @Tags Catalogs
 // @Security BearerAuth
 // @Param id path int true "Catalog ID"
@@ -1003,3 +1055,951 @@ func (h *CatalogHandler) GetChanges(w http.ResponseWriter, r *http.Request) {
 
 	JSONResponse(w, changes)
 }
+
+// CloneCatalogRequest represents the request body for cloning a catalog
+type CloneCatalogRequest struct {
+	Name       string `json:"name,omitempty"` // Defaults to the source catalog name
+	ValidFrom  string `json:"valid_from"`     // Date string in YYYY-MM-DD format
+	ValidUntil string `json:"valid_until"`    // Date string in YYYY-MM-DD format
+}
+
+// CloneCatalog clones a catalog into a new draft catalog
+// @Summary Clone catalog
+// @Description Deep-copy a catalog (any phase) with all categories, levels, paths and descriptions into a new draft catalog (admin only)
+// @Tags Catalogs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Source catalog ID"
+// @Param request body CloneCatalogRequest true "Name and validity period of the new catalog"
+// @Success 201 {object} models.CatalogWithDetails
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Failure 409 {object} map[string]string "Validity period overlaps"
+// @Router /admin/catalogs/{id}/clone [post]
+func (h *CatalogHandler) CloneCatalog(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	var req CloneCatalogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Parse date strings
+	validFrom, err := time.Parse("2006-01-02", req.ValidFrom)
+	if err != nil {
+		http.Error(w, "Invalid valid_from date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+
+	validUntil, err := time.Parse("2006-01-02", req.ValidUntil)
+	if err != nil {
+		http.Error(w, "Invalid valid_until date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	catalog, err := h.catalogService.CloneCatalog(uint(id), req.Name, validFrom, validUntil, userID, userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else if strings.Contains(err.Error(), "overlaps") {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, catalog)
+}
+
+// GetCatalogLineage retrieves the clone lineage of a catalog
+// @Summary Get catalog lineage
+// @Description Get a catalog and the chain of catalogs it was cloned from, newest first (admin only)
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 200 {array} models.CriteriaCatalog
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/lineage [get]
+func (h *CatalogHandler) GetCatalogLineage(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	lineage, err := h.catalogService.GetCatalogLineage(uint(id), userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, lineage)
+}
+
+// ExportCatalog exports a catalog as a portable JSON or YAML document
+// @Summary Export catalog
+// @Description Export a catalog with categories, levels, paths and descriptions as a versioned document (admin only)
+// @Tags Catalogs
+// @Produce json
+// @Produce application/yaml
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param format query string false "Document format (json or yaml, default json)"
+// @Success 200 {object} models.CatalogDocument
+// @Failure 400 {object} map[string]string "Invalid ID or format"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/export [get]
+func (h *CatalogHandler) ExportCatalog(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "yaml" {
+		http.Error(w, "Invalid format (expected json or yaml)", http.StatusBadRequest)
+		return
+	}
+
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	doc, err := h.catalogService.ExportCatalog(uint(id), userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"catalog-%d.%s\"", id, format))
+	if format == "yaml" {
+		w.Header().Set("Content-Type", "application/yaml")
+		if err := yaml.NewEncoder(w).Encode(doc); err != nil {
+			http.Error(w, "Failed to encode catalog", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, doc)
+}
+
+// ImportCatalog creates a new draft catalog from a JSON or YAML catalog document
+// @Summary Import catalog
+// @Description Validate a catalog document and create a new draft catalog from it in one transaction (admin only). With dry_run=true only the validation report is returned.
+// @Tags Catalogs
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Security BearerAuth
+// @Param dry_run query bool false "Only validate, do not write anything"
+// @Param document body models.CatalogDocument true "Catalog document"
+// @Success 200 {object} models.CatalogImportResult "Dry run report"
+// @Success 201 {object} models.CatalogImportResult "Catalog created"
+// @Failure 400 {object} map[string]string "Invalid document"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 422 {object} models.CatalogImportResult "Validation failed"
+// @Router /admin/catalogs/import [post]
+func (h *CatalogHandler) ImportCatalog(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	var doc models.CatalogDocument
+	contentType := r.Header.Get("Content-Type")
+	if strings.Contains(contentType, "yaml") {
+		decoder := yaml.NewDecoder(r.Body)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&doc); err != nil {
+			http.Error(w, "Invalid catalog document: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&doc); err != nil {
+			http.Error(w, "Invalid catalog document: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	result, err := h.catalogService.ImportCatalog(&doc, userID, userRoles, dryRun)
+	if err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	switch {
+	case !result.Valid:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	case !dryRun:
+		w.WriteHeader(http.StatusCreated)
+	}
+	JSONResponse(w, result)
+}
+
+// parseCatalogTime parses a point in time given as RFC3339 timestamp or YYYY-MM-DD date
+func parseCatalogTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// DiffCatalogs compares two catalogs or two points in time of the same catalog
+// @Summary Diff catalogs
+// @Description Structured diff of categories, levels, paths, cells and weights between two catalogs or two points in a catalog's change log (admin and reviewer)
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param from query int true "Source catalog ID"
+// @Param to query int false "Target catalog ID (defaults to the source catalog)"
+// @Param from_at query string false "Reconstruct the source catalog at this time (RFC3339 or YYYY-MM-DD)"
+// @Param to_at query string false "Reconstruct the target catalog at this time (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} models.CatalogDiff
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /catalogs/diff [get]
+func (h *CatalogHandler) DiffCatalogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	fromID, err := strconv.ParseUint(query.Get("from"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid from catalog ID", http.StatusBadRequest)
+		return
+	}
+	toID := fromID
+	if toStr := query.Get("to"); toStr != "" {
+		toID, err = strconv.ParseUint(toStr, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid to catalog ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var fromAt, toAt *time.Time
+	if fromAtStr := query.Get("from_at"); fromAtStr != "" {
+		t, err := parseCatalogTime(fromAtStr)
+		if err != nil {
+			http.Error(w, "Invalid from_at format (expected RFC3339 or YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		fromAt = &t
+	}
+	if toAtStr := query.Get("to_at"); toAtStr != "" {
+		t, err := parseCatalogTime(toAtStr)
+		if err != nil {
+			http.Error(w, "Invalid to_at format (expected RFC3339 or YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		toAt = &t
+	}
+
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	diff, err := h.catalogService.DiffCatalogs(uint(fromID), fromAt, uint(toID), toAt, userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, diff)
+}
+
+// GetCatalogAt reconstructs a catalog at a point in time from its change log
+// @Summary Get catalog at point in time
+// @Description Reconstruct a catalog as it was at the given time by rolling back logged changes (admin and reviewer)
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param time query string true "Point in time (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} models.CatalogSnapshot
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /catalogs/{id}/at [get]
+func (h *CatalogHandler) GetCatalogAt(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	at, err := parseCatalogTime(r.URL.Query().Get("time"))
+	if err != nil {
+		http.Error(w, "Invalid time format (expected RFC3339 or YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	snapshot, err := h.catalogService.GetCatalogAt(uint(id), at, userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, snapshot)
+}
+
+// RevertChange reverts a single change log entry of a catalog
+// @Summary Revert catalog change
+// @Description Write the old value of a change log entry back through the regular update paths (admin only, draft catalogs)
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param changeId path int true "Change ID"
+// @Success 200 {object} models.CatalogRevertResult
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog, change or changed entity not found"
+// @Router /admin/catalogs/{id}/changes/{changeId}/revert [post]
+func (h *CatalogHandler) RevertChange(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	catalogID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	changeIDStr := r.PathValue("changeId")
+	changeID, err := strconv.ParseUint(changeIDStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid change ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	result, err := h.catalogService.RevertChange(uint(catalogID), uint(changeID), userID, userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	JSONResponse(w, result)
+}
+
+// RevertChangesSince reverts all change log entries of a catalog after a point in time
+// @Summary Revert catalog changes since
+// @Description Revert all change log entries made after the given time, newest first (admin only, draft catalogs)
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param since query string true "Point in time (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} models.CatalogRevertResult
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/changes/revert [post]
+func (h *CatalogHandler) RevertChangesSince(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	catalogID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	since, err := parseCatalogTime(r.URL.Query().Get("since"))
+	if err != nil {
+		http.Error(w, "Invalid since format (expected RFC3339 or YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	result, err := h.catalogService.RevertChangesSince(uint(catalogID), since, userID, userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "catalog not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	JSONResponse(w, result)
+}
+
+// ExportCatalogMatrix exports the path x level matrix of a catalog as spreadsheet
+// @Summary Export catalog matrix
+// @Description Export the criteria matrix (rows = category/path, columns = levels) as CSV or XLSX (admin only)
+// @Tags Catalogs
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param format query string false "Spreadsheet format (xlsx or csv, default xlsx)"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string "Invalid ID or format"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/matrix [get]
+func (h *CatalogHandler) ExportCatalogMatrix(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = spreadsheetFormatXLSX
+	}
+	if format != spreadsheetFormatXLSX && format != spreadsheetFormatCSV {
+		http.Error(w, "Invalid format (expected xlsx or csv)", http.StatusBadRequest)
+		return
+	}
+
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	rows, err := h.catalogService.ExportCatalogMatrix(uint(id), userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", spreadsheetContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"catalog-%d-matrix.%s\"", id, format))
+	if err := writeSpreadsheet(w, format, "Matrix", rows); err != nil {
+		http.Error(w, "Failed to write spreadsheet", http.StatusInternalServerError)
+	}
+}
+
+// ImportCatalogMatrix imports the path x level matrix of a catalog from a spreadsheet
+// @Summary Import catalog matrix
+// @Description Upsert levels, categories, paths and descriptions from a CSV or XLSX matrix (rows = category/path, columns = levels). The file is sent as request body. Errors are reported per row and cell (admin only)
+// @Tags Catalogs
+// @Accept text/csv
+// @Accept application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param format query string false "Spreadsheet format (xlsx or csv, default derived from Content-Type)"
+// @Param dry_run query bool false "Only validate the spreadsheet without saving"
+// @Success 200 {object} models.CatalogMatrixImportResult
+// @Failure 400 {object} map[string]string "Invalid spreadsheet"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Failure 422 {object} models.CatalogMatrixImportResult "Validation errors"
+// @Router /admin/catalogs/{id}/matrix [post]
+func (h *CatalogHandler) ImportCatalogMatrix(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = spreadsheetFormatXLSX
+		if strings.Contains(r.Header.Get("Content-Type"), "csv") {
+			format = spreadsheetFormatCSV
+		}
+	}
+	if format != spreadsheetFormatXLSX && format != spreadsheetFormatCSV {
+		http.Error(w, "Invalid format (expected xlsx or csv)", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := readSpreadsheet(http.MaxBytesReader(w, r.Body, maxSpreadsheetSize), format)
+	if err != nil {
+		http.Error(w, "Invalid spreadsheet: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	result, err := h.catalogService.ImportCatalogMatrix(uint(id), rows, userID, userRoles, dryRun)
+	if err != nil {
+		if strings.Contains(err.Error(), "catalog not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if !result.Valid {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	JSONResponse(w, result)
+}
+
+// GetTranslations retrieves the translations of a catalog
+// @Summary Get catalog translations
+// @Description Get the translations of category, level, path and cell texts, optionally filtered by locale (admin only)
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param locale query string false "Locale filter"
+// @Success 200 {array} models.CatalogTranslation
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/translations [get]
+func (h *CatalogHandler) GetTranslations(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	translations, err := h.catalogService.GetTranslations(uint(id), r.URL.Query().Get("locale"), userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, translations)
+}
+
+// SetTranslations creates, updates or deletes translations of a catalog
+// @Summary Set catalog translations
+// @Description Upsert translations of category, level, path and cell texts; an empty value deletes the translation (admin only, draft catalogs)
+// @Tags Catalogs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param translations body []models.CatalogTranslation true "Translations (entity_type, entity_id, locale, field_name, value)"
+// @Success 200 {object} map[string]string "Translations saved"
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/translations [put]
+func (h *CatalogHandler) SetTranslations(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	var translations []models.CatalogTranslation
+	if err := json.NewDecoder(r.Body).Decode(&translations); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	if err := h.catalogService.SetTranslations(uint(id), translations, userID, userRoles); err != nil {
+		if strings.Contains(err.Error(), "catalog not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	JSONResponse(w, map[string]string{"message": "Translations saved"})
+}
+
+// SubmitForReview submits a draft catalog for review
+// @Summary Submit catalog for review
+// @Description Move a complete draft catalog to review phase; approvals of earlier reviews are discarded (admin only)
+// @Tags Catalogs
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/submit-for-review [post]
+func (h *CatalogHandler) SubmitForReview(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	if err := h.catalogService.SubmitForReview(uint(id), userID, userRoles); err != nil {
+		if strings.Contains(err.Error(), "catalog not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	JSONResponse(w, map[string]string{
+		"message": "Catalog submitted for review",
+	})
+}
+
+// ReturnToDraft returns a catalog under review to draft phase
+// @Summary Return catalog to draft
+// @Description Move a catalog from review back to draft phase for revision; all approvals are discarded (admin only)
+// @Tags Catalogs
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/return-to-draft [post]
+func (h *CatalogHandler) ReturnToDraft(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	if err := h.catalogService.ReturnToDraft(uint(id), userID, userRoles); err != nil {
+		if strings.Contains(err.Error(), "catalog not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	JSONResponse(w, map[string]string{
+		"message": "Catalog returned to draft phase",
+	})
+}
+
+// GetReviewStatus returns approvals and comments of a catalog review
+// @Summary Get catalog review
+// @Description Get the approvals, review comments and required number of approvals of a catalog (admin and catalog approvers)
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} models.CatalogReviewStatus
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /catalogs/{id}/review [get]
+func (h *CatalogHandler) GetReviewStatus(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	status, err := h.catalogService.GetReviewStatus(uint(id), userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "catalog not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, status)
+}
+
+// ApproveCatalog approves a catalog under review
+// @Summary Approve catalog
+// @Description Record the approval of a catalog in review phase; each approver can approve once per review (catalog approvers only)
+// @Tags Catalogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} models.CatalogReviewStatus
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Failure 409 {object} map[string]string "Already approved"
+// @Router /catalogs/{id}/review/approve [post]
+func (h *CatalogHandler) ApproveCatalog(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	status, err := h.catalogService.ApproveCatalog(uint(id), userID, userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "catalog not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else if strings.Contains(err.Error(), "already approved") {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	JSONResponse(w, status)
+}
+
+// ReviewCommentRequest represents the request body for a review comment
+type ReviewCommentRequest struct {
+	Comment string `json:"comment"`
+}
+
+// AddReviewComment adds a comment to a catalog under review
+// @Summary Comment on catalog review
+// @Description Add a comment to a catalog in review phase (admin and catalog approvers)
+// @Tags Catalogs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param request body ReviewCommentRequest true "Comment"
+// @Success 201 {object} models.CatalogReviewComment
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /catalogs/{id}/review/comments [post]
+func (h *CatalogHandler) AddReviewComment(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	var req ReviewCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	comment, err := h.catalogService.AddReviewComment(uint(id), req.Comment, userID, userRoles)
+	if err != nil {
+		if strings.Contains(err.Error(), "catalog not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, comment)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/service"
+)
+
+// ChangeRequestRequest represents the request body for requesting changes to a self-assessment
+type ChangeRequestRequest struct {
+	Message string `json:"message"`
+}
+
+// ChangeRequestHandler handles change requests of reviewers
+type ChangeRequestHandler struct {
+	changeRequestService *service.ChangeRequestService
+}
+
+// NewChangeRequestHandler creates a new change request handler
+func NewChangeRequestHandler(changeRequestService *service.ChangeRequestService) *ChangeRequestHandler {
+	return &ChangeRequestHandler{
+		changeRequestService: changeRequestService,
+	}
+}
+
+// RequestChanges sends a self-assessment back to its owner for rework
+// @Summary Request changes
+// @Description Send a submitted or in-review self-assessment back to the owner (status changes_requested). The message is stored encrypted; reviewer responses are kept. Only reviewers of the panel.
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Param request body ChangeRequestRequest true "Message for the owner"
+// @Success 201 {object} models.ChangeRequest
+// @Failure 400 {object} map[string]string "Invalid request or transition"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /review/assessment/{id}/request-changes [post]
+func (h *ChangeRequestHandler) RequestChanges(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return
+	}
+
+	var req ChangeRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	request, err := h.changeRequestService.RequestChanges(uint(assessmentID), userID, userRoles, req.Message)
+	if err != nil {
+		if strings.Contains(err.Error(), ErrMsgPermissionDenied) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, request)
+}
+
+// GetChangeRequests retrieves the change requests of a self-assessment
+// @Summary Get change requests
+// @Description Retrieve the change requests of a self-assessment with decrypted messages (owner and reviewers of the panel)
+// @Tags Self-Assessments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Success 200 {array} models.ChangeRequest
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /self-assessments/{id}/change-requests [get]
+func (h *ChangeRequestHandler) GetChangeRequests(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	requests, err := h.changeRequestService.GetChangeRequests(uint(assessmentID), userID)
+	if err != nil {
+		if strings.Contains(err.Error(), ErrMsgPermissionDenied) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			slog.Error("Failed to get change requests", "error", err)
+			http.Error(w, "Failed to get change requests", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, requests)
+}
@@ -56,7 +56,7 @@ func (h *ConfigHandler) GetOAuthConfig(w http.ResponseWriter, r *http.Request) {
 
 // GetAppConfig returns the public app configuration for the frontend
 // @Summary Get app configuration
-// @Description Get public app configuration (registration settings)
+// @Description Get public app configuration (registration settings, catalog content locales)
 // @Tags Configuration
 // @Produce json
 // @Success 200 {object} map[string]interface{} "App configuration"
@@ -71,6 +71,7 @@ func (h *ConfigHandler) GetAppConfig(w http.ResponseWriter, r *http.Request) {
 	appConfig := map[string]interface{}{
 		"enable_registration":       h.config.App.EnableRegistration,
 		"enable_oauth_registration": h.config.App.EnableOAuthRegistration,
+		"catalog_locales":           h.config.Catalog.Locales,
 	}
 
 	respondWithJSON(w, http.StatusOK, appConfig)
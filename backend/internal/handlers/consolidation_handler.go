@@ -2,8 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"new-pay/internal/middleware"
 	"new-pay/internal/models"
@@ -13,21 +18,24 @@ import (
 // ConsolidationHandler handles HTTP requests for review consolidation
 type ConsolidationHandler struct {
 	consolidationService *service.ConsolidationService
+	localizer            *service.CatalogLocalizer
 }
 
 // NewConsolidationHandler creates a new consolidation handler
-func NewConsolidationHandler(consolidationService *service.ConsolidationService) *ConsolidationHandler {
+func NewConsolidationHandler(consolidationService *service.ConsolidationService, localizer *service.CatalogLocalizer) *ConsolidationHandler {
 	return &ConsolidationHandler{
 		consolidationService: consolidationService,
+		localizer:            localizer,
 	}
 }
 
 // GetConsolidationData retrieves all data needed for consolidation
 // @Summary Get consolidation data
-// @Description Retrieves user responses, averaged reviewer responses, and overrides for consolidation
+// @Description Retrieves user responses, averaged reviewer responses, and overrides for consolidation. Catalog texts are translated into the locale given by lang or Accept-Language
 // @Tags Consolidation
 // @Security BearerAuth
 // @Param id path int true "Assessment ID"
+// @Param lang query string false "Content locale (overrides Accept-Language)"
 // @Success 200 {object} models.ConsolidationData
 // @Failure 400 {object} map[string]string "Invalid assessment ID"
 // @Failure 403 {object} map[string]string "Permission denied"
@@ -67,19 +75,26 @@ func (h *ConsolidationHandler) GetConsolidationData(w http.ResponseWriter, r *ht
 		return
 	}
 
+	if err := h.localizer.LocalizeConsolidationData(data, requestLocale(w, r, h.localizer)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	JSONResponse(w, data)
 }
 
 // CreateOrUpdateOverride creates or updates a consolidation override
 // @Summary Create or update consolidation override
-// @Description Creates or updates a manually adjusted value during consolidation
+// @Description Creates or updates a manually adjusted value during consolidation. With If-Match the override is only saved if its version is unchanged ("0" for a new override)
 // @Tags Consolidation
 // @Security BearerAuth
 // @Param id path int true "Assessment ID"
+// @Param If-Match header string false "Expected version (ETag)"
 // @Param override body models.ConsolidationOverride true "Override data"
 // @Success 200 {object} models.ConsolidationOverride
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 409 {object} map[string]interface{} "Version conflict with current override"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /review/consolidation/{id}/override [post]
 func (h *ConsolidationHandler) CreateOrUpdateOverride(w http.ResponseWriter, r *http.Request) {
@@ -96,6 +111,12 @@ func (h *ConsolidationHandler) CreateOrUpdateOverride(w http.ResponseWriter, r *
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var override models.ConsolidationOverride
 	if err := json.NewDecoder(r.Body).Decode(&override); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
@@ -105,11 +126,17 @@ func (h *ConsolidationHandler) CreateOrUpdateOverride(w http.ResponseWriter, r *
 	// Ensure assessment ID matches
 	override.AssessmentID = uint(assessmentID)
 
-	if err := h.consolidationService.CreateOrUpdateOverride(&override, userID); err != nil {
+	if err := h.consolidationService.CreateOrUpdateOverride(&override, userID, expectedVersion); err != nil {
+		var conflict *service.VersionConflictError
+		if errors.As(err, &conflict) {
+			writeVersionConflict(w, conflict)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	setETag(w, override.Version)
 	JSONResponse(w, override)
 }
 
@@ -270,10 +297,12 @@ func (h *ConsolidationHandler) DeleteOverride(w http.ResponseWriter, r *http.Req
 // @Tags Consolidation
 // @Security BearerAuth
 // @Param id path int true "Assessment ID"
+// @Param If-Match header string false "Expected version (ETag)"
 // @Param request body object{comment=string} true "Final consolidation comment"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 409 {object} map[string]interface{} "Version conflict with current final consolidation"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /review/consolidation/{id}/final [post]
 func (h *ConsolidationHandler) SaveFinalConsolidation(w http.ResponseWriter, r *http.Request) {
@@ -290,6 +319,12 @@ func (h *ConsolidationHandler) SaveFinalConsolidation(w http.ResponseWriter, r *
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Parse request body
 	var req struct {
 		Comment string `json:"comment"`
@@ -304,9 +339,13 @@ func (h *ConsolidationHandler) SaveFinalConsolidation(w http.ResponseWriter, r *
 		return
 	}
 
-	if err := h.consolidationService.CreateOrUpdateFinalConsolidation(uint(assessmentID), req.Comment, userID); err != nil {
+	finalConsolidation, err := h.consolidationService.CreateOrUpdateFinalConsolidation(uint(assessmentID), req.Comment, userID, expectedVersion)
+	if err != nil {
+		var conflict *service.VersionConflictError
 		errMsg := err.Error()
 		switch {
+		case errors.As(err, &conflict):
+			writeVersionConflict(w, conflict)
 		case errMsg == "user must complete their review before saving final consolidation":
 			http.Error(w, errMsg, http.StatusForbidden)
 		default:
@@ -315,7 +354,11 @@ func (h *ConsolidationHandler) SaveFinalConsolidation(w http.ResponseWriter, r *
 		return
 	}
 
-	JSONResponse(w, map[string]string{"message": "Final consolidation saved successfully"})
+	setETag(w, finalConsolidation.Version)
+	JSONResponse(w, map[string]interface{}{
+		"message": "Final consolidation saved successfully",
+		"version": finalConsolidation.Version,
+	})
 }
 
 // ApproveFinalConsolidation approves the final consolidation
@@ -351,6 +394,8 @@ func (h *ConsolidationHandler) ApproveFinalConsolidation(w http.ResponseWriter,
 			http.Error(w, errMsg, http.StatusForbidden)
 		case errMsg == "final consolidation not found":
 			http.Error(w, errMsg, http.StatusNotFound)
+		case errMsg == "all categories must be approved before approving the final consolidation":
+			http.Error(w, errMsg, http.StatusBadRequest)
 		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
@@ -640,3 +685,106 @@ func (h *ConsolidationHandler) GenerateFinalConsolidationProposal(w http.Respons
 
 	JSONResponse(w, map[string]string{"message": "Final consolidation proposal generated successfully"})
 }
+
+// consolidationHeartbeatInterval keeps idle event streams open through proxies
+const consolidationHeartbeatInterval = 25 * time.Second
+
+// StreamConsolidationEvents streams live changes of the consolidation as Server-Sent Events
+// @Summary Stream consolidation events
+// @Description Server-Sent Events stream of changes to overrides, approvals, category comments and the final consolidation of an assessment. Events contain only type, category, reviewer and time, never decrypted text; clients reload the consolidation data on each event. Same access rules as the consolidation data.
+// @Tags Consolidation
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path int true "Assessment ID"
+// @Success 200 {object} models.ConsolidationEvent "Stream of events"
+// @Failure 400 {object} map[string]string "Invalid assessment ID or status"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Assessment not found"
+// @Router /review/consolidation/{id}/events [get]
+func (h *ConsolidationHandler) StreamConsolidationEvents(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid assessment ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	events, unsubscribe, err := h.consolidationService.SubscribeEvents(uint(assessmentID), userID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), ErrMsgPermissionDenied):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case err.Error() == "assessment not found":
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.HasPrefix(err.Error(), "assessment must be in"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	defer unsubscribe()
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.Warn("Failed to clear write deadline for event stream", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	if err := rc.Flush(); err != nil {
+		slog.Error("Event stream not supported", "error", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(consolidationHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return // Hub closed (server shutdown)
+			}
+			if !h.hasEventAccess(uint(assessmentID), userID) {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				slog.Error("Failed to encode consolidation event", "error", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		case <-heartbeat.C:
+			if !h.hasEventAccess(uint(assessmentID), userID) {
+				return
+			}
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// hasEventAccess re-checks the access of an open event stream; access lost since the stream was opened
+// (e.g. removed from the panel) ends the stream
+func (h *ConsolidationHandler) hasEventAccess(assessmentID, userID uint) bool {
+	if err := h.consolidationService.CheckEventAccess(assessmentID, userID); err != nil {
+		slog.Info("Closing consolidation event stream", "assessment_id", assessmentID, "user_id", userID, "reason", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/service"
+)
+
+// DataErasureRequest represents the request body for an erasure (exactly one of user_id and assessment_id)
+type DataErasureRequest struct {
+	UserID       uint   `json:"user_id,omitempty"`       // Erase all self-assessments and, where possible, the user key
+	AssessmentID uint   `json:"assessment_id,omitempty"` // Erase a single self-assessment
+	Reason       string `json:"reason"`                  // e.g. "GDPR Art. 17 request of 2026-10-01"
+}
+
+// LegalHoldRequest represents the request body for placing a user under legal hold
+type LegalHoldRequest struct {
+	Reason string `json:"reason"`
+}
+
+// DataErasureHandler handles crypto-shredding erasures and legal holds
+type DataErasureHandler struct {
+	dataErasureService *service.DataErasureService
+}
+
+// NewDataErasureHandler creates a new data erasure handler
+func NewDataErasureHandler(dataErasureService *service.DataErasureService) *DataErasureHandler {
+	return &DataErasureHandler{
+		dataErasureService: dataErasureService,
+	}
+}
+
+// checkAvailable reports an error if the data erasure service is not initialized (requires a key provider)
+func (h *DataErasureHandler) checkAvailable(w http.ResponseWriter) bool {
+	if h.dataErasureService == nil {
+		http.Error(w, "Encryption services are not available", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// EraseData erases a user's assessment data by crypto-shredding
+// @Summary Erase data
+// @Description Destroy the process keys of a self-assessment or of all self-assessments of a user, and the user key if no other data depends on it. The encrypted records become permanently unreadable, their hash chains stay verifiable. The erasure is recorded as a hash-chained tombstone. Blocked while the user is under legal hold. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body DataErasureRequest true "Erasure"
+// @Success 201 {object} models.DataErasure
+// @Failure 400 {object} map[string]string "Invalid request or self-assessment not closed"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "User or self-assessment not found"
+// @Failure 409 {object} map[string]string "Legal hold or already erased"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/erasures [post]
+func (h *DataErasureHandler) EraseData(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	var req DataErasureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+	if (req.UserID == 0) == (req.AssessmentID == 0) {
+		http.Error(w, "Exactly one of user_id and assessment_id is required", http.StatusBadRequest)
+		return
+	}
+
+	actorID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var err error
+	var result interface{}
+	if req.UserID != 0 {
+		result, err = h.dataErasureService.EraseUser(req.UserID, req.Reason, actorID)
+	} else {
+		result, err = h.dataErasureService.EraseAssessment(req.AssessmentID, req.Reason, actorID)
+	}
+	if err != nil {
+		writeDataErasureError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, result)
+}
+
+// ListErasures lists erasure tombstones
+// @Summary List erasures
+// @Description List the tombstones of erasures, newest first. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query int false "User ID"
+// @Success 200 {array} models.DataErasure
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/erasures [get]
+func (h *DataErasureHandler) ListErasures(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	var userID uint64
+	if value := r.URL.Query().Get("user_id"); value != "" {
+		var err error
+		userID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	erasures, err := h.dataErasureService.ListErasures(uint(userID))
+	if err != nil {
+		writeDataErasureError(w, err)
+		return
+	}
+
+	JSONResponse(w, erasures)
+}
+
+// GetErasure retrieves an erasure tombstone
+// @Summary Get erasure
+// @Description Retrieve the tombstone of an erasure with its processes. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Erasure ID"
+// @Success 200 {object} models.DataErasure
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Erasure not found"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/erasures/{id} [get]
+func (h *DataErasureHandler) GetErasure(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid erasure ID", http.StatusBadRequest)
+		return
+	}
+
+	erasure, err := h.dataErasureService.GetErasure(uint(id))
+	if err != nil {
+		writeDataErasureError(w, err)
+		return
+	}
+
+	JSONResponse(w, erasure)
+}
+
+// VerifyErasures verifies the tombstone chain
+// @Summary Verify erasures
+// @Description Verify the hash chain of the tombstones and that the erased processes still have no key material and unchanged, intact hash chains. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.DataErasureVerification
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Verification failed to run"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/erasures/verify [get]
+func (h *DataErasureHandler) VerifyErasures(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	verification, err := h.dataErasureService.VerifyErasures()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, verification)
+}
+
+// ListLegalHolds lists all legal holds
+// @Summary List legal holds
+// @Description List the users whose data must not be erased. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.LegalHold
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/legal-holds [get]
+func (h *DataErasureHandler) ListLegalHolds(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	holds, err := h.dataErasureService.ListLegalHolds()
+	if err != nil {
+		writeDataErasureError(w, err)
+		return
+	}
+
+	JSONResponse(w, holds)
+}
+
+// SetLegalHold places a user under legal hold
+// @Summary Set legal hold
+// @Description Place a user under legal hold, which blocks the erasure of their data. Updates the reason of an existing hold. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path int true "User ID"
+// @Param request body LegalHoldRequest true "Reason"
+// @Success 200 {object} models.LegalHold
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/legal-holds/{user_id} [put]
+func (h *DataErasureHandler) SetLegalHold(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	userID, err := strconv.ParseUint(r.PathValue("user_id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req LegalHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	actorID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	hold, err := h.dataErasureService.SetLegalHold(uint(userID), req.Reason, actorID)
+	if err != nil {
+		writeDataErasureError(w, err)
+		return
+	}
+
+	JSONResponse(w, hold)
+}
+
+// ReleaseLegalHold removes the legal hold of a user
+// @Summary Release legal hold
+// @Description Remove the legal hold of a user, which allows the erasure of their data again. Admin only.
+// @Tags Admin
+// @Security BearerAuth
+// @Param user_id path int true "User ID"
+// @Success 204 "Legal hold released"
+// @Failure 400 {object} map[string]string "Invalid user ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Legal hold not found"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/legal-holds/{user_id} [delete]
+func (h *DataErasureHandler) ReleaseLegalHold(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	userID, err := strconv.ParseUint(r.PathValue("user_id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	actorID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.dataErasureService.ReleaseLegalHold(uint(userID), actorID); err != nil {
+		writeDataErasureError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeDataErasureError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), ErrMsgNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if strings.Contains(err.Error(), "legal hold") || strings.Contains(err.Error(), "already been erased") {
+		http.Error(w, err.Error(), http.StatusConflict)
+	} else {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -6,27 +6,32 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"new-pay/internal/middleware"
 	"new-pay/internal/models"
 	"new-pay/internal/repository"
+	"new-pay/internal/service"
 )
 
 type DiscussionConfirmationHandler struct {
 	confirmationRepo *repository.DiscussionConfirmationRepository
 	assessmentRepo   *repository.SelfAssessmentRepository
 	userRepo         *repository.UserRepository
+	meetingService   *service.MeetingService
 }
 
 func NewDiscussionConfirmationHandler(
 	confirmationRepo *repository.DiscussionConfirmationRepository,
 	assessmentRepo *repository.SelfAssessmentRepository,
 	userRepo *repository.UserRepository,
+	meetingService *service.MeetingService,
 ) *DiscussionConfirmationHandler {
 	return &DiscussionConfirmationHandler{
 		confirmationRepo: confirmationRepo,
 		assessmentRepo:   assessmentRepo,
 		userRepo:         userRepo,
+		meetingService:   meetingService,
 	}
 }
 
@@ -86,6 +91,13 @@ func (h *DiscussionConfirmationHandler) CreateConfirmation(w http.ResponseWriter
 		return
 	}
 
+	// The meeting can only be confirmed once it has started
+	meeting, err := h.meetingService.GetConfirmableMeeting(uint(assessmentID), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Determine user type
 	// Get user roles from repository
 	roles, err := h.userRepo.GetUserRoles(user.ID)
@@ -141,6 +153,7 @@ func (h *DiscussionConfirmationHandler) CreateConfirmation(w http.ResponseWriter
 		AssessmentID: uint(assessmentID),
 		UserID:       user.ID,
 		UserType:     userType,
+		MeetingID:    &meeting.ID,
 	}
 
 	if err := h.confirmationRepo.Create(confirmation); err != nil {
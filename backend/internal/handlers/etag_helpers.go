@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"new-pay/internal/service"
+)
+
+// parseIfMatch reads the expected resource version from the If-Match header.
+// Accepts "N" and W/"N"; a missing header or "*" means no expected version (unconditional write),
+// "0" means the resource must not exist yet.
+func parseIfMatch(r *http.Request) (*int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return nil, fmt.Errorf("invalid If-Match header")
+	}
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || version < 0 {
+		return nil, fmt.Errorf("invalid If-Match header")
+	}
+	return &version, nil
+}
+
+// setETag sets the ETag header to the version of a resource
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
+// writeVersionConflict sends 409 Conflict with the current server state so the client can merge
+func writeVersionConflict(w http.ResponseWriter, conflict *service.VersionConflictError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	JSONResponse(w, map[string]interface{}{
+		"error":   conflict.Error(),
+		"current": conflict.Current,
+	})
+}
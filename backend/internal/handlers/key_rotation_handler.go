@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/service"
+)
+
+// KeyRotationRequest represents the request body for rotating a process key
+type KeyRotationRequest struct {
+	ProcessID string `json:"process_id"` // e.g. "assessment-42"
+}
+
+// KeyRotationHandler handles process key rotations
+type KeyRotationHandler struct {
+	keyRotationService *service.KeyRotationService
+}
+
+// NewKeyRotationHandler creates a new key rotation handler
+func NewKeyRotationHandler(keyRotationService *service.KeyRotationService) *KeyRotationHandler {
+	return &KeyRotationHandler{
+		keyRotationService: keyRotationService,
+	}
+}
+
+// checkAvailable reports an error if the key rotation service is not initialized (requires Vault)
+func (h *KeyRotationHandler) checkAvailable(w http.ResponseWriter) bool {
+	if h.keyRotationService == nil {
+		http.Error(w, "Encryption services are not available", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// StartKeyRotation rotates a process key
+// @Summary Rotate process key
+// @Description Create a new version of a process key and re-encrypt all records of the process in the background. Old key versions are retired once all records are re-encrypted. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body KeyRotationRequest true "Process"
+// @Success 202 {object} models.ProcessKeyRotation
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Process key not found"
+// @Failure 409 {object} map[string]string "Rotation already running"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/key-rotations [post]
+func (h *KeyRotationHandler) StartKeyRotation(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	var req KeyRotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	rotation, err := h.keyRotationService.StartRotation(req.ProcessID, userID)
+	if err != nil {
+		writeKeyRotationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	JSONResponse(w, rotation)
+}
+
+// ListKeyRotations lists process key rotations
+// @Summary List key rotations
+// @Description List process key rotations with their re-encryption progress, newest first. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param process_id query string false "Process ID"
+// @Param status query string false "Status (running, completed, failed)"
+// @Success 200 {array} models.ProcessKeyRotation
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/key-rotations [get]
+func (h *KeyRotationHandler) ListKeyRotations(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	rotations, err := h.keyRotationService.ListRotations(r.URL.Query().Get("process_id"), r.URL.Query().Get("status"))
+	if err != nil {
+		writeKeyRotationError(w, err)
+		return
+	}
+
+	JSONResponse(w, rotations)
+}
+
+// GetKeyRotation retrieves a process key rotation
+// @Summary Get key rotation
+// @Description Retrieve a process key rotation with its re-encryption progress. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rotation ID"
+// @Success 200 {object} models.ProcessKeyRotation
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Rotation not found"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/key-rotations/{id} [get]
+func (h *KeyRotationHandler) GetKeyRotation(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid rotation ID", http.StatusBadRequest)
+		return
+	}
+
+	rotation, err := h.keyRotationService.GetRotation(uint(id))
+	if err != nil {
+		writeKeyRotationError(w, err)
+		return
+	}
+
+	JSONResponse(w, rotation)
+}
+
+func writeKeyRotationError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), "already running") {
+		http.Error(w, err.Error(), http.StatusConflict)
+	} else if strings.Contains(err.Error(), ErrMsgNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
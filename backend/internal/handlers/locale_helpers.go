@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"net/http"
+
+	"new-pay/internal/service"
+)
+
+// requestLocale resolves the catalog content locale of a request from the lang query parameter
+// or the Accept-Language header and announces it via the Content-Language response header
+func requestLocale(w http.ResponseWriter, r *http.Request, localizer *service.CatalogLocalizer) string {
+	locale := localizer.ResolveLocale(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+	if locale != "" {
+		w.Header().Set("Content-Language", locale)
+	}
+	return locale
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/service"
+)
+
+// MeetingRequest represents the request body for scheduling or rescheduling a discussion meeting
+type MeetingRequest struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Location string    `json:"location"`
+}
+
+// MeetingHandler handles discussion meeting HTTP requests
+type MeetingHandler struct {
+	meetingService *service.MeetingService
+}
+
+// NewMeetingHandler creates a new meeting handler
+func NewMeetingHandler(meetingService *service.MeetingService) *MeetingHandler {
+	return &MeetingHandler{
+		meetingService: meetingService,
+	}
+}
+
+// GetMeeting retrieves the scheduled discussion meeting of a self-assessment
+// @Summary Get discussion meeting
+// @Description Retrieve the scheduled discussion meeting of a self-assessment (owner and reviewer panel only)
+// @Tags Discussion
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Success 200 {object} models.DiscussionMeeting
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "No meeting scheduled"
+// @Router /discussion/{id}/meeting [get]
+func (h *MeetingHandler) GetMeeting(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	meeting, err := h.meetingService.GetMeeting(uint(assessmentID), userID)
+	if err != nil {
+		writeMeetingError(w, err)
+		return
+	}
+	if meeting == nil {
+		http.Error(w, "meeting not found", http.StatusNotFound)
+		return
+	}
+
+	JSONResponse(w, meeting)
+}
+
+// ScheduleMeeting proposes the discussion meeting of a self-assessment
+// @Summary Schedule discussion meeting
+// @Description Propose date, time and location of the discussion meeting. Owner and reviewer panel receive an iCalendar invitation. Only reviewers of the panel, self-assessment in status discussion.
+// @Tags Discussion
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Param request body MeetingRequest true "Meeting"
+// @Success 201 {object} models.DiscussionMeeting
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /discussion/{id}/meeting [post]
+func (h *MeetingHandler) ScheduleMeeting(w http.ResponseWriter, r *http.Request) {
+	assessmentID, userID, req, ok := parseMeetingRequest(w, r)
+	if !ok {
+		return
+	}
+
+	meeting, err := h.meetingService.ScheduleMeeting(assessmentID, userID, req.StartsAt, req.EndsAt, req.Location)
+	if err != nil {
+		writeMeetingError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, meeting)
+}
+
+// UpdateMeeting reschedules the discussion meeting of a self-assessment
+// @Summary Reschedule discussion meeting
+// @Description Change date, time or location of the discussion meeting. Owner and reviewer panel receive an updated iCalendar invitation. Not possible after the meeting was confirmed.
+// @Tags Discussion
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Param request body MeetingRequest true "Meeting"
+// @Success 200 {object} models.DiscussionMeeting
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "No meeting scheduled"
+// @Router /discussion/{id}/meeting [put]
+func (h *MeetingHandler) UpdateMeeting(w http.ResponseWriter, r *http.Request) {
+	assessmentID, userID, req, ok := parseMeetingRequest(w, r)
+	if !ok {
+		return
+	}
+
+	meeting, err := h.meetingService.UpdateMeeting(assessmentID, userID, req.StartsAt, req.EndsAt, req.Location)
+	if err != nil {
+		writeMeetingError(w, err)
+		return
+	}
+
+	JSONResponse(w, meeting)
+}
+
+// CancelMeeting cancels the discussion meeting of a self-assessment
+// @Summary Cancel discussion meeting
+// @Description Cancel the discussion meeting. Owner and reviewer panel receive an iCalendar cancellation. Not possible after the meeting was confirmed.
+// @Tags Discussion
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "No meeting scheduled"
+// @Router /discussion/{id}/meeting [delete]
+func (h *MeetingHandler) CancelMeeting(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.meetingService.CancelMeeting(uint(assessmentID), userID); err != nil {
+		writeMeetingError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseMeetingRequest reads assessment ID, user and meeting from a schedule or update request
+func parseMeetingRequest(w http.ResponseWriter, r *http.Request) (uint, uint, MeetingRequest, bool) {
+	var req MeetingRequest
+
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, ErrMsgInvalidAssessmentID, http.StatusBadRequest)
+		return 0, 0, req, false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return 0, 0, req, false
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return 0, 0, req, false
+	}
+
+	return uint(assessmentID), userID, req, true
+}
+
+func writeMeetingError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), ErrMsgPermissionDenied) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	} else if strings.Contains(err.Error(), ErrMsgNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
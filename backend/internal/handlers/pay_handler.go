@@ -0,0 +1,449 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/models"
+	"new-pay/internal/service"
+)
+
+// SalaryBandRequest represents the request body for creating or updating a salary band
+type SalaryBandRequest struct {
+	LevelID        uint    `json:"level_id"`
+	Currency       string  `json:"currency"`
+	MinSalary      float64 `json:"min_salary"`
+	MaxSalary      float64 `json:"max_salary"`
+	EffectiveFrom  string  `json:"effective_from"`            // Date string in YYYY-MM-DD format
+	EffectiveUntil *string `json:"effective_until,omitempty"` // Date string in YYYY-MM-DD format, open-ended if omitted
+}
+
+// EmployeeSalaryRequest represents the request body for recording an employee salary
+type EmployeeSalaryRequest struct {
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency"`
+	EffectiveFrom string  `json:"effective_from"` // Date string in YYYY-MM-DD format
+}
+
+// PayHandler handles salary band, salary and pay recommendation HTTP requests
+type PayHandler struct {
+	payService *service.PayService
+}
+
+// NewPayHandler creates a new pay handler
+func NewPayHandler(payService *service.PayService) *PayHandler {
+	return &PayHandler{
+		payService: payService,
+	}
+}
+
+// checkAvailable reports an error if the pay service is not initialized (requires Vault)
+func (h *PayHandler) checkAvailable(w http.ResponseWriter) bool {
+	if h.payService == nil {
+		http.Error(w, "Encryption services are not available", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// GetSalaryBands retrieves all salary bands of a catalog
+// @Summary Get salary bands
+// @Description Retrieve the salary bands of all levels of a catalog (admin only)
+// @Tags Pay
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 200 {array} models.SalaryBand
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/salary-bands [get]
+func (h *PayHandler) GetSalaryBands(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	bands, err := h.payService.GetSalaryBands(uint(catalogID))
+	if err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, bands)
+}
+
+// CreateSalaryBand creates a salary band for a level of a catalog
+// @Summary Create salary band
+// @Description Create a salary band for a catalog level with currency and effective dates (admin only)
+// @Tags Pay
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param band body SalaryBandRequest true "Salary band data"
+// @Success 201 {object} models.SalaryBand
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Band overlaps with existing band"
+// @Router /admin/catalogs/{id}/salary-bands [post]
+func (h *PayHandler) CreateSalaryBand(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	band, ok := decodeSalaryBand(w, r)
+	if !ok {
+		return
+	}
+	band.CatalogID = uint(catalogID)
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.payService.CreateSalaryBand(band, userID); err != nil {
+		writeSalaryBandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, band)
+}
+
+// UpdateSalaryBand updates a salary band of a catalog
+// @Summary Update salary band
+// @Description Update a salary band of a catalog (admin only)
+// @Tags Pay
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param bandId path int true "Salary band ID"
+// @Param band body SalaryBandRequest true "Updated salary band data"
+// @Success 200 {object} models.SalaryBand
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Salary band not found"
+// @Failure 409 {object} map[string]string "Band overlaps with existing band"
+// @Router /admin/catalogs/{id}/salary-bands/{bandId} [put]
+func (h *PayHandler) UpdateSalaryBand(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+	bandID, err := strconv.ParseUint(r.PathValue("bandId"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid salary band ID", http.StatusBadRequest)
+		return
+	}
+
+	band, ok := decodeSalaryBand(w, r)
+	if !ok {
+		return
+	}
+	band.ID = uint(bandID)
+	band.CatalogID = uint(catalogID)
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.payService.UpdateSalaryBand(band, userID); err != nil {
+		writeSalaryBandError(w, err)
+		return
+	}
+
+	JSONResponse(w, band)
+}
+
+// DeleteSalaryBand deletes a salary band of a catalog
+// @Summary Delete salary band
+// @Description Delete a salary band of a catalog (admin only)
+// @Tags Pay
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param bandId path int true "Salary band ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Salary band not found"
+// @Router /admin/catalogs/{id}/salary-bands/{bandId} [delete]
+func (h *PayHandler) DeleteSalaryBand(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+	bandID, err := strconv.ParseUint(r.PathValue("bandId"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid salary band ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.payService.DeleteSalaryBand(uint(catalogID), uint(bandID), userID); err != nil {
+		writeSalaryBandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeSalaryBand decodes and parses a salary band request body
+func decodeSalaryBand(w http.ResponseWriter, r *http.Request) (*models.SalaryBand, bool) {
+	var req SalaryBandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return nil, false
+	}
+
+	effectiveFrom, err := time.Parse(time.DateOnly, req.EffectiveFrom)
+	if err != nil {
+		http.Error(w, "Invalid effective_from date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+		return nil, false
+	}
+
+	band := &models.SalaryBand{
+		LevelID:       req.LevelID,
+		Currency:      req.Currency,
+		MinSalary:     req.MinSalary,
+		MaxSalary:     req.MaxSalary,
+		EffectiveFrom: effectiveFrom,
+	}
+
+	if req.EffectiveUntil != nil && *req.EffectiveUntil != "" {
+		effectiveUntil, err := time.Parse(time.DateOnly, *req.EffectiveUntil)
+		if err != nil {
+			http.Error(w, "Invalid effective_until date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return nil, false
+		}
+		band.EffectiveUntil = &effectiveUntil
+	}
+
+	return band, true
+}
+
+// writeSalaryBandError maps salary band service errors to HTTP status codes
+func writeSalaryBandError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "salary band not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "overlaps"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+// GetSalaryHistory retrieves the decrypted salary history of an employee
+// @Summary Get salary history
+// @Description Retrieve the salary history of an employee, newest first (HR only)
+// @Tags Pay
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {array} models.EmployeeSalary
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /hr/users/{id}/salaries [get]
+func (h *PayHandler) GetSalaryHistory(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	userID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	salaries, err := h.payService.GetSalaryHistory(uint(userID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, salaries)
+}
+
+// SetEmployeeSalary records a new salary of an employee
+// @Summary Record salary
+// @Description Record the salary of an employee from a given date; the amount is stored encrypted (HR only)
+// @Tags Pay
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param salary body EmployeeSalaryRequest true "Salary data"
+// @Success 201 {object} models.EmployeeSalary
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /hr/users/{id}/salaries [post]
+func (h *PayHandler) SetEmployeeSalary(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	employeeID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req EmployeeSalaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	effectiveFrom, err := time.Parse(time.DateOnly, req.EffectiveFrom)
+	if err != nil {
+		http.Error(w, "Invalid effective_from date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	salary := &models.EmployeeSalary{
+		UserID:        uint(employeeID),
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		EffectiveFrom: effectiveFrom,
+	}
+	if err := h.payService.SetEmployeeSalary(salary, userID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, salary)
+}
+
+// GetRecommendations retrieves pay recommendations
+// @Summary Get pay recommendations
+// @Description Retrieve pay recommendations of archived assessments, optionally filtered by status (HR only)
+// @Tags Pay
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (open or exported)"
+// @Success 200 {array} models.PayRecommendation
+// @Failure 400 {object} map[string]string "Invalid status"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /hr/pay-recommendations [get]
+func (h *PayHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	recommendations, err := h.payService.GetRecommendations(r.URL.Query().Get("status"))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid status") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	JSONResponse(w, recommendations)
+}
+
+// ExportRecommendations exports pay recommendations as spreadsheet
+// @Summary Export pay recommendations
+// @Description Export pay recommendations as CSV or XLSX; open recommendations are marked as exported unless mark_exported=false (HR only)
+// @Tags Pay
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Spreadsheet format (xlsx or csv, default xlsx)"
+// @Param status query string false "Filter by status (open or exported, default open)"
+// @Param mark_exported query bool false "Mark exported recommendations (default true)"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string "Invalid format or status"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /hr/pay-recommendations/export [get]
+func (h *PayHandler) ExportRecommendations(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = spreadsheetFormatXLSX
+	}
+	if format != spreadsheetFormatXLSX && format != spreadsheetFormatCSV {
+		http.Error(w, "Invalid format (expected xlsx or csv)", http.StatusBadRequest)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = "open"
+	}
+	markExported := r.URL.Query().Get("mark_exported") != "false"
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	rows, err := h.payService.ExportRecommendations(status, markExported, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid status") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", spreadsheetContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"pay-recommendations-%s.%s\"", time.Now().Format(time.DateOnly), format))
+	if err := writeSpreadsheet(w, format, "Recommendations", rows); err != nil {
+		http.Error(w, "Failed to write spreadsheet", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/models"
+	"new-pay/internal/service"
+)
+
+// QuorumPolicyRequest represents the request body for setting the quorum policy of a catalog
+type QuorumPolicyRequest struct {
+	AveragedCount     int  `json:"averaged_count"`
+	AveragedPercent   int  `json:"averaged_percent"`
+	OverrideCount     int  `json:"override_count"`
+	OverridePercent   int  `json:"override_percent"`
+	FinalCount        int  `json:"final_count"`
+	FinalPercent      int  `json:"final_percent"`
+	AllowSelfApproval bool `json:"allow_self_approval"`
+}
+
+// QuorumHandler handles quorum policy HTTP requests
+type QuorumHandler struct {
+	quorumService *service.QuorumService
+}
+
+// NewQuorumHandler creates a new quorum handler
+func NewQuorumHandler(quorumService *service.QuorumService) *QuorumHandler {
+	return &QuorumHandler{
+		quorumService: quorumService,
+	}
+}
+
+// GetDefaultQuorumPolicy retrieves the global default quorum policy
+// @Summary Get default quorum policy
+// @Description Retrieve the global quorum policy for consolidation approvals used by catalogs without own policy (admin only)
+// @Tags Quorum
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.QuorumPolicy
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/quorum [get]
+func (h *QuorumHandler) GetDefaultQuorumPolicy(w http.ResponseWriter, r *http.Request) {
+	JSONResponse(w, h.quorumService.GetDefaultPolicy())
+}
+
+// GetCatalogQuorumPolicy retrieves the quorum policy used by a catalog
+// @Summary Get catalog quorum policy
+// @Description Retrieve the quorum policy for consolidation approvals of a catalog: its own or the global default (admin only)
+// @Tags Quorum
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} models.QuorumPolicy
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/catalogs/{id}/quorum [get]
+func (h *QuorumHandler) GetCatalogQuorumPolicy(w http.ResponseWriter, r *http.Request) {
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	policy, err := h.quorumService.GetPolicyForCatalog(uint(catalogID))
+	if err != nil {
+		slog.Error("Failed to get quorum policy", "error", err)
+		http.Error(w, "Failed to get quorum policy", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, policy)
+}
+
+// SetCatalogQuorumPolicy sets the quorum policy of a catalog
+// @Summary Set catalog quorum policy
+// @Description Set the quorum policy for consolidation approvals of a catalog. For averaged results, overrides and the final consolidation the higher of count and percentage of the reviewer panel applies (admin only)
+// @Tags Quorum
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param request body QuorumPolicyRequest true "Quorum policy"
+// @Success 200 {object} models.QuorumPolicy
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Catalog not found"
+// @Router /admin/catalogs/{id}/quorum [put]
+func (h *QuorumHandler) SetCatalogQuorumPolicy(w http.ResponseWriter, r *http.Request) {
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	var req QuorumPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	policy := &models.QuorumPolicy{
+		AveragedCount:     req.AveragedCount,
+		AveragedPercent:   req.AveragedPercent,
+		OverrideCount:     req.OverrideCount,
+		OverridePercent:   req.OverridePercent,
+		FinalCount:        req.FinalCount,
+		FinalPercent:      req.FinalPercent,
+		AllowSelfApproval: req.AllowSelfApproval,
+	}
+	if err := h.quorumService.SetCatalogPolicy(uint(catalogID), policy, userID); err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	JSONResponse(w, policy)
+}
+
+// DeleteCatalogQuorumPolicy removes the quorum policy of a catalog
+// @Summary Reset catalog quorum policy
+// @Description Remove the quorum policy of a catalog so the global default applies again (admin only)
+// @Tags Quorum
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/catalogs/{id}/quorum [delete]
+func (h *QuorumHandler) DeleteCatalogQuorumPolicy(w http.ResponseWriter, r *http.Request) {
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.quorumService.DeleteCatalogPolicy(uint(catalogID), userID); err != nil {
+		slog.Error("Failed to delete quorum policy", "error", err)
+		http.Error(w, "Failed to delete quorum policy", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/models"
+	"new-pay/internal/service"
+)
+
+// ReviewerPanelRequest represents the request body for assigning a reviewer panel
+type ReviewerPanelRequest struct {
+	ReviewerIDs []uint `json:"reviewer_ids"`
+}
+
+// ReviewAssignmentHandler handles reviewer panel requests
+type ReviewAssignmentHandler struct {
+	assignmentService *service.ReviewAssignmentService
+}
+
+// NewReviewAssignmentHandler creates a new review assignment handler
+func NewReviewAssignmentHandler(assignmentService *service.ReviewAssignmentService) *ReviewAssignmentHandler {
+	return &ReviewAssignmentHandler{
+		assignmentService: assignmentService,
+	}
+}
+
+// GetPanel retrieves the reviewer panel of a self-assessment
+// @Summary Get reviewer panel
+// @Description Retrieve the reviewers assigned to a self-assessment and the allowed panel size (admins and team leads)
+// @Tags Self-Assessments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Success 200 {object} models.ReviewerPanel
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /self-assessments/{id}/reviewers [get]
+func (h *ReviewAssignmentHandler) GetPanel(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid self-assessment ID", http.StatusBadRequest)
+		return
+	}
+
+	panel, err := h.assignmentService.GetPanel(uint(assessmentID))
+	if err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to get reviewer panel", "error", err)
+		http.Error(w, "Failed to get reviewer panel", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, panel)
+}
+
+// GetIncompletePanels lists self-assessments with a too small reviewer panel
+// @Summary List incomplete reviewer panels
+// @Description List submitted and in-review self-assessments whose reviewer panel is smaller than the minimum panel size, including those without panel (admins and team leads)
+// @Tags Self-Assessments
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.IncompletePanel
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /review/panels/incomplete [get]
+func (h *ReviewAssignmentHandler) GetIncompletePanels(w http.ResponseWriter, r *http.Request) {
+	panels, err := h.assignmentService.GetIncompletePanels()
+	if err != nil {
+		slog.Error("Failed to get incomplete reviewer panels", "error", err)
+		http.Error(w, "Failed to get incomplete reviewer panels", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, panels)
+}
+
+// GetCandidates retrieves the reviewers who can be assigned to a self-assessment
+// @Summary Get reviewer candidates
+// @Description Retrieve all active reviewers except the owner of the self-assessment (admins and team leads)
+// @Tags Self-Assessments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Success 200 {array} models.User
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /self-assessments/{id}/reviewers/candidates [get]
+func (h *ReviewAssignmentHandler) GetCandidates(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid self-assessment ID", http.StatusBadRequest)
+		return
+	}
+
+	candidates, err := h.assignmentService.GetCandidates(uint(assessmentID))
+	if err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to get reviewer candidates", "error", err)
+		http.Error(w, "Failed to get reviewer candidates", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, candidates)
+}
+
+// SetPanel assigns the reviewer panel of a self-assessment
+// @Summary Assign reviewer panel
+// @Description Replace the reviewers assigned to a self-assessment; only assigned reviewers can review it (admins and team leads, not for their own self-assessment)
+// @Tags Self-Assessments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Param request body ReviewerPanelRequest true "User IDs of the reviewers"
+// @Success 200 {object} models.ReviewerPanel
+// @Failure 400 {object} map[string]string "Invalid panel"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /self-assessments/{id}/reviewers [put]
+func (h *ReviewAssignmentHandler) SetPanel(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid self-assessment ID", http.StatusBadRequest)
+		return
+	}
+
+	var req ReviewerPanelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	panel, err := h.assignmentService.SetPanel(uint(assessmentID), req.ReviewerIDs, userID, userRoles)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "permission denied"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), ErrMsgNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	JSONResponse(w, panel)
+}
+
+// GetProposal previews a balanced reviewer panel for a self-assessment
+// @Summary Preview reviewer panel
+// @Description Compute a balanced reviewer panel based on open workload, team and conflicts of interest without assigning it (admins and team leads)
+// @Tags Self-Assessments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Success 200 {object} models.PanelProposal
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /self-assessments/{id}/reviewers/proposal [get]
+func (h *ReviewAssignmentHandler) GetProposal(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid self-assessment ID", http.StatusBadRequest)
+		return
+	}
+
+	proposal, err := h.assignmentService.ProposePanel(uint(assessmentID))
+	if err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to compute reviewer panel proposal", "error", err)
+		http.Error(w, "Failed to compute reviewer panel proposal", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, proposal)
+}
+
+// AutoAssign assigns a balanced reviewer panel to a self-assessment
+// @Summary Assign balanced reviewer panel
+// @Description Assign the proposed balanced reviewer panel; an existing panel is replaced under the same rules as a manual assignment (admins and team leads)
+// @Tags Self-Assessments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Self-assessment ID"
+// @Success 200 {object} models.ReviewerPanel
+// @Failure 400 {object} map[string]string "Not enough eligible reviewers"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Self-assessment not found"
+// @Router /self-assessments/{id}/reviewers/auto-assign [post]
+func (h *ReviewAssignmentHandler) AutoAssign(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid self-assessment ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	panel, err := h.assignmentService.ApplyProposal(uint(assessmentID), userID, userRoles)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "permission denied"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), ErrMsgNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	JSONResponse(w, panel)
+}
+
+// ReviewerConflictRequest represents the request body for declaring a conflict of interest
+type ReviewerConflictRequest struct {
+	ReviewerUserID uint    `json:"reviewer_user_id,omitempty"`
+	UserID         uint    `json:"user_id"`
+	Reason         *string `json:"reason,omitempty"`
+}
+
+// GetConflicts lists declared conflicts of interest
+// @Summary List conflicts of interest
+// @Description List declared conflicts of interest between reviewers and employees; reviewers see their own, admins see all
+// @Tags Review
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.ReviewerConflict
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /review/conflicts [get]
+func (h *ReviewAssignmentHandler) GetConflicts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	conflicts, err := h.assignmentService.GetConflicts(userID, userRoles)
+	if err != nil {
+		slog.Error("Failed to get reviewer conflicts", "error", err)
+		http.Error(w, "Failed to get conflicts of interest", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, conflicts)
+}
+
+// DeclareConflict declares a conflict of interest
+// @Summary Declare conflict of interest
+// @Description Declare a conflict of interest with an employee; the reviewer is never assigned to their self-assessments automatically. Admins may declare conflicts for any reviewer.
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ReviewerConflictRequest true "Conflict of interest"
+// @Success 201 {object} models.ReviewerConflict
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "User not found"
+// @Router /review/conflicts [post]
+func (h *ReviewAssignmentHandler) DeclareConflict(w http.ResponseWriter, r *http.Request) {
+	var req ReviewerConflictRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	conflict := &models.ReviewerConflict{
+		ReviewerUserID: req.ReviewerUserID,
+		UserID:         req.UserID,
+		Reason:         req.Reason,
+	}
+	if err := h.assignmentService.DeclareConflict(conflict, userID, userRoles); err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, conflict)
+}
+
+// DeleteConflict removes a conflict of interest
+// @Summary Delete conflict of interest
+// @Description Remove a declared conflict of interest; reviewers can only remove their own
+// @Tags Review
+// @Security BearerAuth
+// @Param id path int true "Conflict ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Conflict not found"
+// @Router /review/conflicts/{id} [delete]
+func (h *ReviewAssignmentHandler) DeleteConflict(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid conflict ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+	userRoles, ok := middleware.GetUserRoles(r)
+	if !ok {
+		userRoles = []string{}
+	}
+
+	if err := h.assignmentService.DeleteConflict(uint(id), userID, userRoles); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "permission denied"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), ErrMsgNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			slog.Error("Failed to delete reviewer conflict", "error", err)
+			http.Error(w, "Failed to delete conflict of interest", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -16,6 +17,8 @@ import (
 // ReviewerHandler handles reviewer-related HTTP requests
 type ReviewerHandler struct {
 	reviewerService   *service.ReviewerService
+	assignmentService *service.ReviewAssignmentService
+	workflowService   *service.WorkflowService
 	assessmentRepo    *repository.SelfAssessmentRepository
 	discussionService *service.DiscussionService
 }
@@ -23,11 +26,15 @@ type ReviewerHandler struct {
 // NewReviewerHandler creates a new reviewer handler
 func NewReviewerHandler(
 	reviewerService *service.ReviewerService,
+	assignmentService *service.ReviewAssignmentService,
+	workflowService *service.WorkflowService,
 	assessmentRepo *repository.SelfAssessmentRepository,
 	discussionService *service.DiscussionService,
 ) *ReviewerHandler {
 	return &ReviewerHandler{
 		reviewerService:   reviewerService,
+		assignmentService: assignmentService,
+		workflowService:   workflowService,
 		assessmentRepo:    assessmentRepo,
 		discussionService: discussionService,
 	}
@@ -73,6 +80,11 @@ func (h *ReviewerHandler) GetResponses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only reviewers on the panel may review
+	if !h.checkAssigned(w, uint(assessmentID), userID) {
+		return
+	}
+
 	// Get responses (always filtered by reviewer - no admin override)
 	responses, err := h.reviewerService.GetResponsesByAssessment(uint(assessmentID), userID)
 	if err != nil {
@@ -87,6 +99,7 @@ func (h *ReviewerHandler) GetResponses(w http.ResponseWriter, r *http.Request) {
 
 // CreateOrUpdateResponse creates or updates a reviewer response
 // POST /api/v1/review/assessment/:id/responses
+// With If-Match the response is only saved if its version is unchanged (409 with the current response otherwise)
 func (h *ReviewerHandler) CreateOrUpdateResponse(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -125,6 +138,17 @@ func (h *ReviewerHandler) CreateOrUpdateResponse(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Only reviewers on the panel may review
+	if !h.checkAssigned(w, uint(assessmentID), userID) {
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Parse request body
 	var req struct {
 		CategoryID    uint   `json:"category_id"`
@@ -165,7 +189,12 @@ func (h *ReviewerHandler) CreateOrUpdateResponse(w http.ResponseWriter, r *http.
 		Justification: req.Justification,
 	}
 
-	if err := h.reviewerService.CreateOrUpdateResponse(response, userID); err != nil {
+	if err := h.reviewerService.CreateOrUpdateResponse(response, userID, expectedVersion); err != nil {
+		var conflict *service.VersionConflictError
+		if errors.As(err, &conflict) {
+			writeVersionConflict(w, conflict)
+			return
+		}
 		slog.Error("Failed to create/update reviewer response", "error", err)
 		http.Error(w, "Failed to save response", http.StatusInternalServerError)
 		return
@@ -179,6 +208,7 @@ func (h *ReviewerHandler) CreateOrUpdateResponse(w http.ResponseWriter, r *http.
 		return
 	}
 
+	setETag(w, savedResponse.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(savedResponse)
@@ -224,6 +254,11 @@ func (h *ReviewerHandler) DeleteResponse(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Only reviewers on the panel may review
+	if !h.checkAssigned(w, uint(assessmentID), userID) {
+		return
+	}
+
 	// Delete response
 	if err := h.reviewerService.DeleteResponse(uint(assessmentID), uint(categoryID), userID); err != nil {
 		slog.Error("Failed to delete reviewer response", "error", err)
@@ -277,6 +312,11 @@ func (h *ReviewerHandler) CompleteReview(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Only reviewers on the panel may review
+	if !h.checkAssigned(w, uint(assessmentID), userID) {
+		return
+	}
+
 	// Check if review is complete
 	isComplete, err := h.reviewerService.IsReviewComplete(uint(assessmentID), userID)
 	if err != nil {
@@ -295,18 +335,19 @@ func (h *ReviewerHandler) CompleteReview(w http.ResponseWriter, r *http.Request)
 		NewStatus string `json:"new_status"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err == nil && req.NewStatus != "" {
-		// Validate status transition
-		if req.NewStatus == "review_consolidation" {
-			canConsolidate, err := h.reviewerService.CanTransitionToConsolidation(uint(assessmentID))
-			if err != nil {
-				slog.Error("Failed to check consolidation eligibility", "error", err)
-				http.Error(w, "Failed to check consolidation status", http.StatusInternalServerError)
-				return
-			}
-			if !canConsolidate {
-				http.Error(w, "Cannot transition to review_consolidation: at least 3 complete reviews required", http.StatusBadRequest)
-				return
+		// Validate status transition against the workflow (allowed roles and guards,
+		// e.g. all reviewers of the panel must complete their review before consolidation)
+		userRoles, ok := middleware.GetUserRoles(r)
+		if !ok {
+			userRoles = []string{}
+		}
+		if err := h.workflowService.CheckTransition(assessment, req.NewStatus, userID, userRoles); err != nil {
+			if strings.Contains(err.Error(), ErrMsgPermissionDenied) {
+				http.Error(w, err.Error(), http.StatusForbidden)
+			} else {
+				http.Error(w, err.Error(), http.StatusBadRequest)
 			}
+			return
 		}
 
 		// Update assessment status (only reviewers can do this, not admins)
@@ -317,6 +358,7 @@ func (h *ReviewerHandler) CompleteReview(w http.ResponseWriter, r *http.Request)
 			return
 		}
 		assessment.Status = req.NewStatus
+		h.workflowService.OnEnter(assessment)
 
 		// If status changes to 'discussion', create discussion results
 		if req.NewStatus == "discussion" && h.discussionService != nil {
@@ -356,6 +398,15 @@ func (h *ReviewerHandler) GetCompletionStatus(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !h.checkAssigned(w, uint(assessmentID), userID) {
+		return
+	}
+
 	status, err := h.reviewerService.GetCompletionStatus(uint(assessmentID))
 	if err != nil {
 		slog.Error("Failed to get completion status", "error", err)
@@ -369,6 +420,20 @@ func (h *ReviewerHandler) GetCompletionStatus(w http.ResponseWriter, r *http.Req
 
 // Helper functions
 
+// checkAssigned writes 403 if the user is not on the reviewer panel of the assessment
+func (h *ReviewerHandler) checkAssigned(w http.ResponseWriter, assessmentID, userID uint) bool {
+	if err := h.assignmentService.CheckAssigned(assessmentID, userID); err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			slog.Error("Failed to check reviewer assignment", "error", err)
+			http.Error(w, "Failed to check reviewer assignment", http.StatusInternalServerError)
+		}
+		return false
+	}
+	return true
+}
+
 func extractAssessmentID(path string) (int, error) {
 	// Extract ID from path like "/api/v1/review/assessment/123/responses"
 	parts := strings.Split(strings.Trim(path, "/"), "/")
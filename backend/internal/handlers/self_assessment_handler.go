@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"new-pay/internal/middleware"
@@ -20,6 +21,8 @@ type SelfAssessmentHandler struct {
 	confirmationRepo      *repository.DiscussionConfirmationRepository
 	assessmentRepo        *repository.SelfAssessmentRepository
 	consolidationService  *service.ConsolidationService
+	localizer             *service.CatalogLocalizer
+	assignmentService     *service.ReviewAssignmentService
 }
 
 // NewSelfAssessmentHandler creates a new self-assessment handler
@@ -29,6 +32,8 @@ func NewSelfAssessmentHandler(
 	confirmationRepo *repository.DiscussionConfirmationRepository,
 	assessmentRepo *repository.SelfAssessmentRepository,
 	consolidationService *service.ConsolidationService,
+	localizer *service.CatalogLocalizer,
+	assignmentService *service.ReviewAssignmentService,
 ) *SelfAssessmentHandler {
 	return &SelfAssessmentHandler{
 		selfAssessmentService: selfAssessmentService,
@@ -36,6 +41,8 @@ func NewSelfAssessmentHandler(
 		confirmationRepo:      confirmationRepo,
 		assessmentRepo:        assessmentRepo,
 		consolidationService:  consolidationService,
+		localizer:             localizer,
+		assignmentService:     assignmentService,
 	}
 }
 
@@ -255,6 +262,10 @@ func (h *SelfAssessmentHandler) UpdateStatus(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	if req.Status == "submitted" {
+		h.assignPanelOnSubmit(uint(id))
+	}
+
 	JSONResponse(w, map[string]string{
 		"message": "Assessment status updated successfully",
 	})
@@ -536,15 +547,17 @@ func (h *SelfAssessmentHandler) DeleteSelfAssessment(w http.ResponseWriter, r *h
 
 // SaveResponse saves or updates an assessment response
 // @Summary Save assessment response
-// @Description Save or update a response for a category in a self-assessment
+// @Description Save or update a response for a category in a self-assessment. With If-Match the response is only saved if its version is unchanged ("0" for a new response)
 // @Tags Self-Assessments
 // @Security BearerAuth
 // @Param id path int true "Assessment ID"
+// @Param If-Match header string false "Expected version (ETag)"
 // @Param response body models.AssessmentResponse true "Response data"
 // @Success 200 {object} models.AssessmentResponse
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 409 {object} map[string]interface{} "Version conflict with current response"
 // @Router /self-assessments/{id}/responses [post]
 func (h *SelfAssessmentHandler) SaveResponse(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -560,14 +573,25 @@ func (h *SelfAssessmentHandler) SaveResponse(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var response models.AssessmentResponse
 	if err := json.NewDecoder(r.Body).Decode(&response); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	savedResponse, err := h.selfAssessmentService.SaveResponse(userID, uint(assessmentID), &response)
+	savedResponse, err := h.selfAssessmentService.SaveResponse(userID, uint(assessmentID), &response, expectedVersion)
 	if err != nil {
+		var conflict *service.VersionConflictError
+		if errors.As(err, &conflict) {
+			writeVersionConflict(w, conflict)
+			return
+		}
 		slog.Error("Failed to save response", "error", err, "assessment_id", assessmentID, "user_id", userID)
 		if strings.Contains(err.Error(), ErrMsgPermissionDenied) {
 			http.Error(w, err.Error(), http.StatusForbidden)
@@ -579,6 +603,7 @@ func (h *SelfAssessmentHandler) SaveResponse(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	setETag(w, savedResponse.Version)
 	JSONResponse(w, savedResponse)
 }
 
@@ -633,10 +658,11 @@ func (h *SelfAssessmentHandler) DeleteResponse(w http.ResponseWriter, r *http.Re
 
 // GetResponses retrieves all responses for an assessment
 // @Summary Get assessment responses
-// @Description Retrieve all responses for a self-assessment
+// @Description Retrieve all responses for a self-assessment. Catalog texts are translated into the locale given by lang or Accept-Language
 // @Tags Self-Assessments
 // @Security BearerAuth
 // @Param id path int true "Assessment ID"
+// @Param lang query string false "Content locale (overrides Accept-Language)"
 // @Success 200 {array} models.AssessmentResponseWithDetails
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 401 {object} map[string]string "Unauthorized"
@@ -673,6 +699,19 @@ func (h *SelfAssessmentHandler) GetResponses(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Translate catalog texts if another locale than the default was requested
+	if locale := requestLocale(w, r, h.localizer); locale != h.localizer.DefaultLocale() {
+		assessment, err := h.assessmentRepo.GetByID(uint(assessmentID))
+		if err != nil || assessment == nil {
+			http.Error(w, "Failed to load assessment", http.StatusInternalServerError)
+			return
+		}
+		if err := h.localizer.LocalizeResponses(assessment.CatalogID, responses, locale); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	JSONResponse(w, responses)
 }
 
@@ -793,11 +832,24 @@ func (h *SelfAssessmentHandler) SubmitAssessment(w http.ResponseWriter, r *http.
 		return
 	}
 
+	h.assignPanelOnSubmit(uint(assessmentID))
+
 	JSONResponse(w, map[string]string{
 		"message": "Assessment submitted successfully",
 	})
 }
 
+// assignPanelOnSubmit assigns a balanced reviewer panel to a submitted assessment.
+// Failures are only logged; the scheduler retries assessments without panel.
+func (h *SelfAssessmentHandler) assignPanelOnSubmit(assessmentID uint) {
+	if h.assignmentService == nil {
+		return
+	}
+	if err := h.assignmentService.AssignPanelOnSubmit(assessmentID); err != nil {
+		slog.Error("Failed to assign reviewer panel", "assessment_id", assessmentID, "error", err)
+	}
+}
+
 // ArchiveAssessment archives an assessment after confirmations
 func (h *SelfAssessmentHandler) ArchiveAssessment(w http.ResponseWriter, r *http.Request) {
 	// Get assessment ID from URL
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Supported spreadsheet formats and their content types
+const (
+	spreadsheetFormatCSV  = "csv"
+	spreadsheetFormatXLSX = "xlsx"
+
+	contentTypeCSV  = "text/csv"
+	contentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// maxSpreadsheetSize limits the size of uploaded spreadsheets
+const maxSpreadsheetSize = 10 << 20 // 10 MB
+
+// readSpreadsheet reads all rows of a CSV file or of the first sheet of an XLSX workbook
+func readSpreadsheet(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case spreadsheetFormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1 // Rows may have different lengths
+		return reader.ReadAll()
+	case spreadsheetFormatXLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("workbook has no sheets")
+		}
+		return file.GetRows(sheets[0])
+	default:
+		return nil, fmt.Errorf("unsupported spreadsheet format: %s", format)
+	}
+}
+
+// writeSpreadsheet writes rows as CSV file or as single sheet XLSX workbook
+func writeSpreadsheet(w io.Writer, format, sheetName string, rows [][]string) error {
+	switch format {
+	case spreadsheetFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case spreadsheetFormatXLSX:
+		file := excelize.NewFile()
+		defer file.Close()
+		if err := file.SetSheetName(file.GetSheetName(0), sheetName); err != nil {
+			return err
+		}
+		for i, row := range rows {
+			cells := make([]interface{}, len(row))
+			for j, value := range row {
+				cells[j] = value
+			}
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+			if err := file.SetSheetRow(sheetName, cell, &cells); err != nil {
+				return err
+			}
+		}
+		return file.Write(w)
+	default:
+		return fmt.Errorf("unsupported spreadsheet format: %s", format)
+	}
+}
+
+// spreadsheetContentType returns the content type of a spreadsheet format
+func spreadsheetContentType(format string) string {
+	if format == spreadsheetFormatXLSX {
+		return contentTypeXLSX
+	}
+	return contentTypeCSV
+}
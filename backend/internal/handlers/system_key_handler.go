@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/service"
+)
+
+// MinDecryptionVersionRequest represents the request body for the minimum decryption version policy
+type MinDecryptionVersionRequest struct {
+	MinDecryptionVersion int `json:"min_decryption_version"`
+}
+
+// SystemKeyHandler handles rotation of the system master key
+type SystemKeyHandler struct {
+	systemKeyService *service.SystemKeyService
+}
+
+// NewSystemKeyHandler creates a new system key handler
+func NewSystemKeyHandler(systemKeyService *service.SystemKeyService) *SystemKeyHandler {
+	return &SystemKeyHandler{
+		systemKeyService: systemKeyService,
+	}
+}
+
+// checkAvailable reports an error if the system key service is not initialized (requires a key provider)
+func (h *SystemKeyHandler) checkAvailable(w http.ResponseWriter) bool {
+	if h.systemKeyService == nil {
+		http.Error(w, "Encryption services are not available", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// GetSystemKeyStatus reports the system key versions
+// @Summary Get system key status
+// @Description Report the latest and minimum decryption version of the system master key and how many wrapped user and process keys still use older versions. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.SystemKeyStatus
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Key provider not reachable"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/system-key [get]
+func (h *SystemKeyHandler) GetSystemKeyStatus(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	status, err := h.systemKeyService.GetStatus()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, status)
+}
+
+// RotateSystemKey rotates the system master key
+// @Summary Rotate system key
+// @Description Create a new version of the system master key in the key provider and rewrap all user and process keys in the background. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} models.SystemKeyStatus
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Rotation failed"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/system-key/rotate [post]
+func (h *SystemKeyHandler) RotateSystemKey(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	status, err := h.systemKeyService.RotateSystemKey(userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	JSONResponse(w, status)
+}
+
+// RewrapSystemKeys rewraps all keys with the latest system key version
+// @Summary Rewrap keys
+// @Description Rewrap all user and process keys that use an older system key version in the background, e.g. after a failed rewrap. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} models.SystemKeyStatus
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Rewrap already running"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/system-key/rewrap [post]
+func (h *SystemKeyHandler) RewrapSystemKeys(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	status, err := h.systemKeyService.RewrapKeys(userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "already running") {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	JSONResponse(w, status)
+}
+
+// SetMinDecryptionVersion sets the minimum decryption version of the system key
+// @Summary Set minimum decryption version
+// @Description Set the oldest system key version the key provider still decrypts. Refused while wrapped keys use an older version. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body MinDecryptionVersionRequest true "Minimum decryption version"
+// @Success 200 {object} models.SystemKeyStatus
+// @Failure 400 {object} map[string]string "Invalid version or keys not yet rewrapped"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 503 {object} map[string]string "Encryption services not available"
+// @Router /admin/system-key/min-decryption-version [put]
+func (h *SystemKeyHandler) SetMinDecryptionVersion(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAvailable(w) {
+		return
+	}
+
+	var req MinDecryptionVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, ErrMsgUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	status, err := h.systemKeyService.SetMinDecryptionVersion(req.MinDecryptionVersion, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	JSONResponse(w, status)
+}
@@ -79,6 +79,7 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		"email_verified_at":  user.EmailVerifiedAt,
 		"is_active":          user.IsActive,
 		"last_login_at":      user.LastLoginAt,
+		"team":               user.Team,
 		"created_at":         user.CreatedAt,
 		"roles":              roles,
 		"oauth_connections":  oauthConnections,
@@ -151,6 +152,7 @@ func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		"email_verified_at":  user.EmailVerifiedAt,
 		"is_active":          user.IsActive,
 		"last_login_at":      user.LastLoginAt,
+		"team":               user.Team,
 		"created_at":         user.CreatedAt,
 		"updated_at":         user.UpdatedAt,
 		"roles":              roles,
@@ -297,6 +299,7 @@ func (h *UserHandler) buildUserListResponse(users []models.User) []map[string]in
 			"email_verified_at":  user.EmailVerifiedAt,
 			"is_active":          user.IsActive,
 			"last_login_at":      user.LastLoginAt,
+			"team":               user.Team,
 			"created_at":         user.CreatedAt,
 			"updated_at":         user.UpdatedAt,
 			"roles":              roles,
@@ -591,6 +594,7 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 		"email_verified_at":  user.EmailVerifiedAt,
 		"is_active":          user.IsActive,
 		"last_login_at":      user.LastLoginAt,
+		"team":               user.Team,
 		"created_at":         user.CreatedAt,
 		"roles":              roles,
 		"oauth_connections":  oauthConnections,
@@ -802,7 +806,7 @@ func (h *UserHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 
 // UpdateUser updates a user's basic information (admin only)
 // @Summary Update user information
-// @Description Update a user's email, first name, and last name (admin only)
+// @Description Update a user's email, first name, last name and team (admin only)
 // @Tags Admin
 // @Accept json
 // @Produce json
@@ -816,10 +820,11 @@ func (h *UserHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 // @Router /admin/users/update [post]
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		UserID    uint   `json:"user_id"`
-		Email     string `json:"email"`
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
+		UserID    uint    `json:"user_id"`
+		Email     string  `json:"email"`
+		FirstName string  `json:"first_name"`
+		LastName  string  `json:"last_name"`
+		Team      *string `json:"team,omitempty"` // Empty string removes the team
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -846,6 +851,13 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	user.Email = req.Email
 	user.FirstName = req.FirstName
 	user.LastName = req.LastName
+	if req.Team != nil {
+		if team := strings.TrimSpace(*req.Team); team != "" {
+			user.Team = &team
+		} else {
+			user.Team = nil
+		}
+	}
 
 	if err := h.userRepo.Update(user); err != nil {
 		adminID, _ := middleware.GetUserID(r)
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"new-pay/internal/middleware"
+	"new-pay/internal/models"
+	"new-pay/internal/service"
+)
+
+// WorkflowRequest represents the request body for creating, updating or validating a workflow
+type WorkflowRequest struct {
+	Name        string                      `json:"name"`
+	Description *string                     `json:"description,omitempty"`
+	IsDefault   bool                        `json:"is_default"`
+	States      []models.WorkflowState      `json:"states"`
+	Transitions []models.WorkflowTransition `json:"transitions"`
+}
+
+// CatalogWorkflowRequest represents the request body for assigning a workflow to a catalog
+type CatalogWorkflowRequest struct {
+	WorkflowID *uint `json:"workflow_id"` // null = default workflow
+}
+
+// WorkflowHandler handles assessment workflow HTTP requests
+type WorkflowHandler struct {
+	workflowService *service.WorkflowService
+}
+
+// NewWorkflowHandler creates a new workflow handler
+func NewWorkflowHandler(workflowService *service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+func (req *WorkflowRequest) toWorkflow() *models.WorkflowDefinition {
+	return &models.WorkflowDefinition{
+		Name:        req.Name,
+		Description: req.Description,
+		IsDefault:   req.IsDefault,
+		States:      req.States,
+		Transitions: req.Transitions,
+	}
+}
+
+// GetWorkflows retrieves all stored workflows
+// @Summary Get workflows
+// @Description Retrieve all stored assessment workflows with states and transitions (admin only)
+// @Tags Workflows
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.WorkflowDefinition
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/workflows [get]
+func (h *WorkflowHandler) GetWorkflows(w http.ResponseWriter, r *http.Request) {
+	workflows, err := h.workflowService.GetWorkflows()
+	if err != nil {
+		slog.Error("Failed to get workflows", "error", err)
+		http.Error(w, "Failed to get workflows", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, workflows)
+}
+
+// GetBuiltinWorkflow retrieves the built-in default workflow
+// @Summary Get built-in workflow
+// @Description Retrieve the built-in default workflow, used when no default workflow is stored; serves as template for own workflows (admin only)
+// @Tags Workflows
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.WorkflowDefinition
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/workflows/builtin [get]
+func (h *WorkflowHandler) GetBuiltinWorkflow(w http.ResponseWriter, r *http.Request) {
+	JSONResponse(w, h.workflowService.GetBuiltinWorkflow())
+}
+
+// GetWorkflow retrieves a stored workflow
+// @Summary Get workflow
+// @Description Retrieve a stored assessment workflow (admin only)
+// @Tags Workflows
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workflow ID"
+// @Success 200 {object} models.WorkflowDefinition
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Workflow not found"
+// @Router /admin/workflows/{id} [get]
+func (h *WorkflowHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid workflow ID", http.StatusBadRequest)
+		return
+	}
+
+	workflow, err := h.workflowService.GetWorkflow(uint(id))
+	if err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to get workflow", "error", err)
+		http.Error(w, "Failed to get workflow", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, workflow)
+}
+
+// ValidateWorkflow validates a workflow definition without saving it
+// @Summary Validate workflow
+// @Description Check a workflow definition: known states, guards and actions, required states, unreachable and dead-end states (admin only)
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workflow body WorkflowRequest true "Workflow definition"
+// @Success 200 {object} models.WorkflowValidationResult
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/workflows/validate [post]
+func (h *WorkflowHandler) ValidateWorkflow(w http.ResponseWriter, r *http.Request) {
+	var req WorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	JSONResponse(w, h.workflowService.ValidateWorkflow(req.toWorkflow()))
+}
+
+// CreateWorkflow creates a workflow
+// @Summary Create workflow
+// @Description Create an assessment workflow; invalid definitions are rejected (admin only)
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workflow body WorkflowRequest true "Workflow definition"
+// @Success 201 {object} models.WorkflowDefinition
+// @Failure 400 {object} map[string]string "Invalid workflow"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/workflows [post]
+func (h *WorkflowHandler) CreateWorkflow(w http.ResponseWriter, r *http.Request) {
+	var req WorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	workflow := req.toWorkflow()
+	if err := h.workflowService.CreateWorkflow(workflow, userID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	JSONResponse(w, workflow)
+}
+
+// UpdateWorkflow replaces a workflow
+// @Summary Update workflow
+// @Description Replace an assessment workflow; states still used by self-assessments cannot be removed (admin only)
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workflow ID"
+// @Param workflow body WorkflowRequest true "Workflow definition"
+// @Success 200 {object} models.WorkflowDefinition
+// @Failure 400 {object} map[string]string "Invalid workflow"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Workflow not found"
+// @Router /admin/workflows/{id} [put]
+func (h *WorkflowHandler) UpdateWorkflow(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid workflow ID", http.StatusBadRequest)
+		return
+	}
+
+	var req WorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	workflow := req.toWorkflow()
+	workflow.ID = uint(id)
+	if err := h.workflowService.UpdateWorkflow(workflow, userID); err != nil {
+		if strings.Contains(err.Error(), "workflow not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.workflowService.GetWorkflow(uint(id))
+	if err != nil {
+		slog.Error("Failed to get workflow", "error", err)
+		http.Error(w, "Failed to get workflow", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, updated)
+}
+
+// DeleteWorkflow deletes a workflow
+// @Summary Delete workflow
+// @Description Delete an assessment workflow that is not assigned to any catalog (admin only)
+// @Tags Workflows
+// @Security BearerAuth
+// @Param id path int true "Workflow ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Workflow in use"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Workflow not found"
+// @Router /admin/workflows/{id} [delete]
+func (h *WorkflowHandler) DeleteWorkflow(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid workflow ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.workflowService.DeleteWorkflow(uint(id), userID); err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCatalogWorkflow retrieves the workflow used by a catalog
+// @Summary Get catalog workflow
+// @Description Retrieve the workflow used by self-assessments of a catalog: its own, the default or the built-in workflow (admin only)
+// @Tags Workflows
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} models.WorkflowDefinition
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/catalogs/{id}/workflow [get]
+func (h *WorkflowHandler) GetCatalogWorkflow(w http.ResponseWriter, r *http.Request) {
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	workflow, err := h.workflowService.GetWorkflowForCatalog(uint(catalogID))
+	if err != nil {
+		slog.Error("Failed to get catalog workflow", "error", err)
+		http.Error(w, "Failed to get catalog workflow", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, workflow)
+}
+
+// SetCatalogWorkflow assigns a workflow to a catalog
+// @Summary Assign catalog workflow
+// @Description Assign a workflow to a catalog (null = default workflow); self-assessments of the catalog must be in states the workflow defines (admin only)
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Catalog ID"
+// @Param request body CatalogWorkflowRequest true "Workflow ID"
+// @Success 200 {object} models.WorkflowDefinition
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Catalog or workflow not found"
+// @Router /admin/catalogs/{id}/workflow [put]
+func (h *WorkflowHandler) SetCatalogWorkflow(w http.ResponseWriter, r *http.Request) {
+	catalogID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid catalog ID", http.StatusBadRequest)
+		return
+	}
+
+	var req CatalogWorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrMsgInvalidRequestBody, http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "User ID not found in context", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.workflowService.SetCatalogWorkflow(uint(catalogID), req.WorkflowID, userID); err != nil {
+		if strings.Contains(err.Error(), ErrMsgNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	workflow, err := h.workflowService.GetWorkflowForCatalog(uint(catalogID))
+	if err != nil {
+		slog.Error("Failed to get catalog workflow", "error", err)
+		http.Error(w, "Failed to get catalog workflow", http.StatusInternalServerError)
+		return
+	}
+
+	JSONResponse(w, workflow)
+}
@@ -7,27 +7,26 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
-
-	"new-pay/internal/vault"
 )
 
 // KeyManager manages the three-tier key hierarchy
 type KeyManager struct {
 	db          *sql.DB
-	vault       *vault.Client
+	provider    KeyProvider
 	systemKeyID string
 }
 
 // NewKeyManager creates a new KeyManager instance
-func NewKeyManager(db *sql.DB, vaultClient *vault.Client) (*KeyManager, error) {
+func NewKeyManager(db *sql.DB, provider KeyProvider) (*KeyManager, error) {
 	km := &KeyManager{
 		db:          db,
-		vault:       vaultClient,
+		provider:    provider,
 		systemKeyID: "system-master-key",
 	}
 
-	// Initialize system master key in Vault
+	// Initialize system master key in the key provider
 	if err := km.initSystemKey(); err != nil {
 		return nil, fmt.Errorf("failed to initialize system key: %w", err)
 	}
@@ -37,8 +36,8 @@ func NewKeyManager(db *sql.DB, vaultClient *vault.Client) (*KeyManager, error) {
 
 // initSystemKey creates the system master key if it doesn't exist
 func (km *KeyManager) initSystemKey() error {
-	// Create transit key for system-level encryption
-	err := km.vault.CreateKey(km.systemKeyID, "aes256-gcm96")
+	// Create master key for system-level encryption
+	err := km.provider.CreateKey(km.systemKeyID)
 	if err != nil {
 		// Key might already exist, check if it's accessible
 		return nil
@@ -55,8 +54,8 @@ func (km *KeyManager) CreateUserKey(userID int64) (publicKey ed25519.PublicKey,
 		return nil, fmt.Errorf("key generation failed: %w", err)
 	}
 
-	// Encrypt private key using Vault
-	encryptedPrivateKey, err := km.vault.Encrypt(
+	// Encrypt private key with the system master key
+	encryptedPrivateKey, err := km.provider.Encrypt(
 		km.systemKeyID,
 		priv,
 		map[string]string{"user_id": fmt.Sprintf("%d", userID)},
@@ -64,12 +63,23 @@ func (km *KeyManager) CreateUserKey(userID int64) (publicKey ed25519.PublicKey,
 	if err != nil {
 		return nil, fmt.Errorf("private key encryption failed: %w", err)
 	}
+	systemKeyVersion, err := ciphertextVersion(encryptedPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("private key encryption failed: %w", err)
+	}
 
-	// Store in database
+	// Store in database; a key destroyed by an erasure is replaced
 	query := `
-		INSERT INTO user_keys (user_id, public_key, encrypted_private_key, key_version, created_at)
-		VALUES ($1, $2, $3, 1, $4)
-		ON CONFLICT (user_id) DO NOTHING
+		INSERT INTO user_keys (user_id, public_key, encrypted_private_key, key_version, system_key_version, created_at)
+		VALUES ($1, $2, $3, 1, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET public_key = EXCLUDED.public_key,
+		    encrypted_private_key = EXCLUDED.encrypted_private_key,
+		    key_version = user_keys.key_version + 1,
+		    system_key_version = EXCLUDED.system_key_version,
+		    created_at = EXCLUDED.created_at,
+		    destroyed_at = NULL
+		WHERE user_keys.destroyed_at IS NOT NULL
 	`
 
 	_, err = km.db.Exec(
@@ -77,6 +87,7 @@ func (km *KeyManager) CreateUserKey(userID int64) (publicKey ed25519.PublicKey,
 		userID,
 		hex.EncodeToString(pub),
 		encryptedPrivateKey,
+		systemKeyVersion,
 		time.Now(),
 	)
 	if err != nil {
@@ -88,18 +99,21 @@ func (km *KeyManager) CreateUserKey(userID int64) (publicKey ed25519.PublicKey,
 
 // GetUserSigningKey retrieves and decrypts a user's private key for signing
 func (km *KeyManager) GetUserSigningKey(userID int64) (ed25519.PrivateKey, error) {
-	var encryptedPrivateKey string
+	var encryptedPrivateKey sql.NullString
 
 	query := `SELECT encrypted_private_key FROM user_keys WHERE user_id = $1`
 	err := km.db.QueryRow(query, userID).Scan(&encryptedPrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("user key not found: %w", err)
 	}
+	if !encryptedPrivateKey.Valid {
+		return nil, fmt.Errorf("user key destroyed")
+	}
 
-	// Decrypt using Vault
-	privateKeyBytes, err := km.vault.Decrypt(
+	// Decrypt with the system master key
+	privateKeyBytes, err := km.provider.Decrypt(
 		km.systemKeyID,
-		encryptedPrivateKey,
+		encryptedPrivateKey.String,
 		map[string]string{"user_id": fmt.Sprintf("%d", userID)},
 	)
 	if err != nil {
@@ -109,11 +123,11 @@ func (km *KeyManager) GetUserSigningKey(userID int64) (ed25519.PrivateKey, error
 	return privateKeyBytes, nil
 }
 
-// GetUserPublicKey retrieves a user's public key
+// GetUserPublicKey retrieves a user's public key (destroyed keys are not returned)
 func (km *KeyManager) GetUserPublicKey(userID int64) (ed25519.PublicKey, error) {
 	var publicKeyHex string
 
-	query := `SELECT public_key FROM user_keys WHERE user_id = $1`
+	query := `SELECT public_key FROM user_keys WHERE user_id = $1 AND destroyed_at IS NULL`
 	err := km.db.QueryRow(query, userID).Scan(&publicKeyHex)
 	if err != nil {
 		return nil, fmt.Errorf("user key not found: %w", err)
@@ -127,74 +141,114 @@ func (km *KeyManager) GetUserPublicKey(userID int64) (ed25519.PublicKey, error)
 	return publicKey, nil
 }
 
-// CreateProcessKey generates a new 256-bit symmetric key for a process
+// CreateProcessKey generates a new 256-bit symmetric key for a process (key version 1)
 func (km *KeyManager) CreateProcessKey(processID string, expiresAt *time.Time) error {
+	encryptedKey, keyHash, systemKeyVersion, err := km.generateProcessKey(processID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := km.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Store in database
+	result, err := tx.Exec(`
+		INSERT INTO process_keys (process_id, current_version, created_at, expires_at)
+		VALUES ($1, 1, $2, $3)
+		ON CONFLICT (process_id) DO NOTHING
+	`, processID, time.Now(), expiresAt)
+	if err != nil {
+		return fmt.Errorf("database insert failed: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// Process key already exists
+		return nil
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO process_key_versions (process_id, version, encrypted_key_material, key_hash, system_key_version, created_at)
+		VALUES ($1, 1, $2, $3, $4, $5)
+	`, processID, encryptedKey, keyHash, systemKeyVersion, time.Now()); err != nil {
+		return fmt.Errorf("database insert failed: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// generateProcessKey generates random key material and encrypts it with the system master key
+func (km *KeyManager) generateProcessKey(processID string) (encryptedKey string, keyHash string, systemKeyVersion int, err error) {
 	// Generate random 256-bit process key
 	processKey := make([]byte, 32)
 	if _, err := rand.Read(processKey); err != nil {
-		return fmt.Errorf("key generation failed: %w", err)
+		return "", "", 0, fmt.Errorf("key generation failed: %w", err)
 	}
 
 	// Hash for verification
 	hashBytes := sha256.Sum256(processKey)
-	keyHash := hex.EncodeToString(hashBytes[:])
+	keyHash = hex.EncodeToString(hashBytes[:])
 
-	// Encrypt with Vault
-	encryptedKey, err := km.vault.Encrypt(
+	// Encrypt with the system master key
+	encryptedKey, err = km.provider.Encrypt(
 		km.systemKeyID,
 		processKey,
 		map[string]string{"process_id": processID},
 	)
 	if err != nil {
-		return fmt.Errorf("key encryption failed: %w", err)
+		return "", "", 0, fmt.Errorf("key encryption failed: %w", err)
+	}
+	systemKeyVersion, err = ciphertextVersion(encryptedKey)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("key encryption failed: %w", err)
 	}
 
-	// Store in database
-	query := `
-		INSERT INTO process_keys (process_id, encrypted_key_material, key_hash, created_at, expires_at)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (process_id) DO NOTHING
-	`
+	return encryptedKey, keyHash, systemKeyVersion, nil
+}
 
-	_, err = km.db.Exec(
-		query,
-		processID,
-		encryptedKey,
-		keyHash,
-		time.Now(),
-		expiresAt,
-	)
+// GetProcessKey retrieves and decrypts the current version of a process key
+func (km *KeyManager) GetProcessKey(processID string) ([]byte, error) {
+	version, _, err := km.GetCurrentProcessKeyVersion(processID)
 	if err != nil {
-		return fmt.Errorf("database insert failed: %w", err)
+		return nil, err
 	}
 
-	return nil
+	return km.GetProcessKeyVersion(processID, version)
 }
 
-// GetProcessKey retrieves and decrypts a process key
-func (km *KeyManager) GetProcessKey(processID string) ([]byte, error) {
-	var encryptedKey string
-	var expiresAt *time.Time
+// GetProcessKeyVersion retrieves and decrypts a specific version of a process key
+func (km *KeyManager) GetProcessKeyVersion(processID string, version int) ([]byte, error) {
+	var encryptedKey sql.NullString
+	var expiresAt, retiredAt, destroyedAt *time.Time
 
 	query := `
-		SELECT encrypted_key_material, expires_at 
-		FROM process_keys 
-		WHERE process_id = $1
+		SELECT v.encrypted_key_material, k.expires_at, v.retired_at, k.destroyed_at
+		FROM process_key_versions v
+		JOIN process_keys k ON k.process_id = v.process_id
+		WHERE v.process_id = $1 AND v.version = $2
 	`
-	err := km.db.QueryRow(query, processID).Scan(&encryptedKey, &expiresAt)
+	err := km.db.QueryRow(query, processID, version).Scan(&encryptedKey, &expiresAt, &retiredAt, &destroyedAt)
 	if err != nil {
 		return nil, fmt.Errorf("process key not found: %w", err)
 	}
 
+	if destroyedAt != nil || !encryptedKey.Valid {
+		return nil, fmt.Errorf("process key destroyed")
+	}
+
 	// Check expiration
 	if expiresAt != nil && expiresAt.Before(time.Now()) {
 		return nil, fmt.Errorf("process key expired")
 	}
+	if retiredAt != nil {
+		return nil, fmt.Errorf("process key version %d is retired", version)
+	}
 
-	// Decrypt with Vault
-	processKey, err := km.vault.Decrypt(
+	// Decrypt with the system master key
+	processKey, err := km.provider.Decrypt(
 		km.systemKeyID,
-		encryptedKey,
+		encryptedKey.String,
 		map[string]string{"process_id": processID},
 	)
 	if err != nil {
@@ -204,12 +258,32 @@ func (km *KeyManager) GetProcessKey(processID string) ([]byte, error) {
 	return processKey, nil
 }
 
-// GetProcessKeyHash retrieves the hash of a process key
-func (km *KeyManager) GetProcessKeyHash(processID string) (string, error) {
+// GetCurrentProcessKeyVersion returns the version and hash of the process key used for new records
+func (km *KeyManager) GetCurrentProcessKeyVersion(processID string) (version int, keyHash string, err error) {
+	var destroyedAt *time.Time
+	query := `
+		SELECT v.version, v.key_hash, k.destroyed_at
+		FROM process_keys k
+		JOIN process_key_versions v ON v.process_id = k.process_id AND v.version = k.current_version
+		WHERE k.process_id = $1
+	`
+	err = km.db.QueryRow(query, processID).Scan(&version, &keyHash, &destroyedAt)
+	if err != nil {
+		return 0, "", fmt.Errorf("process key not found: %w", err)
+	}
+	if destroyedAt != nil {
+		return 0, "", fmt.Errorf("process key destroyed")
+	}
+
+	return version, keyHash, nil
+}
+
+// GetProcessKeyVersionHash retrieves the hash of a specific version of a process key
+func (km *KeyManager) GetProcessKeyVersionHash(processID string, version int) (string, error) {
 	var keyHash string
 
-	query := `SELECT key_hash FROM process_keys WHERE process_id = $1`
-	err := km.db.QueryRow(query, processID).Scan(&keyHash)
+	query := `SELECT key_hash FROM process_key_versions WHERE process_id = $1 AND version = $2`
+	err := km.db.QueryRow(query, processID, version).Scan(&keyHash)
 	if err != nil {
 		return "", fmt.Errorf("process key not found: %w", err)
 	}
@@ -217,8 +291,15 @@ func (km *KeyManager) GetProcessKeyHash(processID string) (string, error) {
 	return keyHash, nil
 }
 
+// GetProcessKeyHash retrieves the hash of the current version of a process key
+func (km *KeyManager) GetProcessKeyHash(processID string) (string, error) {
+	_, keyHash, err := km.GetCurrentProcessKeyVersion(processID)
+	return keyHash, err
+}
+
 // DeriveDataEncryptionKey combines all three keys to create a data encryption key
-func (km *KeyManager) DeriveDataEncryptionKey(processID string, userID int64) ([]byte, error) {
+// for the given version of the process key
+func (km *KeyManager) DeriveDataEncryptionKey(processID string, userID int64, keyVersion int) ([]byte, error) {
 	// Get user's private key (for key derivation, not for signing)
 	userKey, err := km.GetUserSigningKey(userID)
 	if err != nil {
@@ -226,7 +307,7 @@ func (km *KeyManager) DeriveDataEncryptionKey(processID string, userID int64) ([
 	}
 
 	// Get process key
-	processKey, err := km.GetProcessKey(processID)
+	processKey, err := km.GetProcessKeyVersion(processID, keyVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get process key: %w", err)
 	}
@@ -248,17 +329,77 @@ func (km *KeyManager) DeriveDataEncryptionKey(processID string, userID int64) ([
 	return finalKey[:], nil
 }
 
-// RotateProcessKey creates a new version of a process key
-func (km *KeyManager) RotateProcessKey(processID string) error {
-	// Mark old key as expired
-	query := `UPDATE process_keys SET expires_at = $1 WHERE process_id = $2 AND expires_at IS NULL`
-	_, err := km.db.Exec(query, time.Now(), processID)
+// RotateProcessKey creates a new version of a process key and makes it the current version.
+// Existing records stay encrypted with their version until they are re-encrypted.
+func (km *KeyManager) RotateProcessKey(processID string) (fromVersion, toVersion int, err error) {
+	encryptedKey, keyHash, systemKeyVersion, err := km.generateProcessKey(processID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tx, err := km.db.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`SELECT current_version FROM process_keys WHERE process_id = $1 FOR UPDATE`, processID).Scan(&fromVersion)
+	if err != nil {
+		return 0, 0, fmt.Errorf("process key not found: %w", err)
+	}
+
+	// Versions are never reused, even if the current version is not the newest one
+	err = tx.QueryRow(`SELECT MAX(version) + 1 FROM process_key_versions WHERE process_id = $1`, processID).Scan(&toVersion)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to determine key version: %w", err)
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO process_key_versions (process_id, version, encrypted_key_material, key_hash, system_key_version, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, processID, toVersion, encryptedKey, keyHash, systemKeyVersion, time.Now()); err != nil {
+		return 0, 0, fmt.Errorf("database insert failed: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE process_keys SET current_version = $1 WHERE process_id = $2`, toVersion, processID); err != nil {
+		return 0, 0, fmt.Errorf("failed to activate key version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit key rotation: %w", err)
+	}
+
+	return fromVersion, toVersion, nil
+}
+
+// RetireProcessKeyVersions retires all versions of a process key below the given version.
+// Versions are only retired once no record depends on them anymore; retired reports whether this was the case.
+func (km *KeyManager) RetireProcessKeyVersions(processID string, belowVersion int) (retired bool, err error) {
+	query := `
+		UPDATE process_key_versions
+		SET retired_at = $3
+		WHERE process_id = $1 AND version < $2 AND retired_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM encrypted_records r
+			LEFT JOIN encrypted_record_reencryptions x ON x.record_id = r.id
+			WHERE r.process_id = $1 AND COALESCE(x.key_version, r.key_version) < $2
+		  )
+	`
+	if _, err := km.db.Exec(query, processID, belowVersion, time.Now()); err != nil {
+		return false, fmt.Errorf("failed to retire key versions: %w", err)
+	}
+
+	var remaining int
+	err = km.db.QueryRow(`
+		SELECT COUNT(*) FROM process_key_versions
+		WHERE process_id = $1 AND version < $2 AND retired_at IS NULL
+	`, processID, belowVersion).Scan(&remaining)
 	if err != nil {
-		return fmt.Errorf("failed to expire old key: %w", err)
+		return false, fmt.Errorf("failed to check key versions: %w", err)
 	}
 
-	// Create new key
-	return km.CreateProcessKey(processID, nil)
+	return remaining == 0, nil
 }
 
 // VerifyKeyAccess checks if a user has access to a process
@@ -285,7 +426,397 @@ func (km *KeyManager) VerifyKeyAccess(userID int64, processID string) error {
 	return nil
 }
 
+// DestroyProcessKey destroys the key material of all versions of a process key (crypto-shredding).
+// The key hashes are kept, so the records of the process stay verifiable but can never be decrypted
+// again. It is idempotent and returns the hashes of the destroyed versions ("v<version>:<hash>").
+func (km *KeyManager) DestroyProcessKey(processID string) ([]string, error) {
+	tx, err := km.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE process_keys SET destroyed_at = COALESCE(destroyed_at, $2) WHERE process_id = $1
+	`, processID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to destroy process key: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, fmt.Errorf("process key not found")
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE process_key_versions SET encrypted_key_material = NULL WHERE process_id = $1
+	`, processID); err != nil {
+		return nil, fmt.Errorf("failed to destroy process key: %w", err)
+	}
+
+	rows, err := tx.Query(`
+		SELECT version, key_hash FROM process_key_versions WHERE process_id = $1 ORDER BY version
+	`, processID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process key versions: %w", err)
+	}
+	var keyHashes []string
+	for rows.Next() {
+		var version int
+		var keyHash string
+		if err := rows.Scan(&version, &keyHash); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan process key version: %w", err)
+		}
+		keyHashes = append(keyHashes, fmt.Sprintf("v%d:%s", version, keyHash))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load process key versions: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return keyHashes, nil
+}
+
+// IsProcessKeyDestroyed reports whether a process key is destroyed and no key material is left
+func (km *KeyManager) IsProcessKeyDestroyed(processID string) (bool, error) {
+	var destroyed bool
+	err := km.db.QueryRow(`
+		SELECT k.destroyed_at IS NOT NULL AND NOT EXISTS (
+			SELECT 1 FROM process_key_versions v
+			WHERE v.process_id = k.process_id AND v.encrypted_key_material IS NOT NULL
+		)
+		FROM process_keys k
+		WHERE k.process_id = $1
+	`, processID).Scan(&destroyed)
+	if err != nil {
+		return false, fmt.Errorf("process key not found: %w", err)
+	}
+	return destroyed, nil
+}
+
+// DestroyUserKey destroys a user's private key (crypto-shredding). The public key is kept for the
+// verification of existing signatures; a new key pair is created when the user encrypts data again.
+func (km *KeyManager) DestroyUserKey(userID int64) error {
+	result, err := km.db.Exec(`
+		UPDATE user_keys SET encrypted_private_key = NULL, destroyed_at = COALESCE(destroyed_at, $2)
+		WHERE user_id = $1
+	`, userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to destroy user key: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("user key not found")
+	}
+	return nil
+}
+
 // GetActiveSystemKeyID returns the current system key identifier
 func (km *KeyManager) GetActiveSystemKeyID() string {
 	return km.systemKeyID
 }
+
+// RotateSystemKey creates a new version of the system master key in the key provider and returns it.
+// Wrapped keys keep their version until they are rewrapped.
+func (km *KeyManager) RotateSystemKey() (int, error) {
+	if err := km.provider.RotateKey(km.systemKeyID); err != nil {
+		return 0, err
+	}
+
+	latest, _, err := km.provider.GetKeyVersions(km.systemKeyID)
+	return latest, err
+}
+
+// GetSystemKeyVersions returns the latest and the minimum decryption version of the system master key
+func (km *KeyManager) GetSystemKeyVersions() (latestVersion, minDecryptionVersion int, err error) {
+	return km.provider.GetKeyVersions(km.systemKeyID)
+}
+
+// SetSystemKeyMinDecryptionVersion sets the oldest system key version the key provider still decrypts
+func (km *KeyManager) SetSystemKeyMinDecryptionVersion(version int) error {
+	return km.provider.SetMinDecryptionVersion(km.systemKeyID, version)
+}
+
+// CountWrappedKeys returns the number of wrapped user and process keys per system key version
+func (km *KeyManager) CountWrappedKeys() (userKeys map[int]int, processKeys map[int]int, err error) {
+	userKeys, err = km.countByVersion(`
+		SELECT system_key_version, COUNT(*) FROM user_keys
+		WHERE encrypted_private_key IS NOT NULL
+		GROUP BY system_key_version
+	`)
+	if err != nil {
+		return nil, nil, err
+	}
+	processKeys, err = km.countByVersion(`
+		SELECT system_key_version, COUNT(*) FROM process_key_versions
+		WHERE encrypted_key_material IS NOT NULL
+		GROUP BY system_key_version
+	`)
+	if err != nil {
+		return nil, nil, err
+	}
+	return userKeys, processKeys, nil
+}
+
+func (km *KeyManager) countByVersion(query string) (map[int]int, error) {
+	rows, err := km.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count wrapped keys: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[int]int{}
+	for rows.Next() {
+		var version, count int
+		if err := rows.Scan(&version, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan wrapped key count: %w", err)
+		}
+		counts[version] = count
+	}
+	return counts, rows.Err()
+}
+
+// wrappedKey is a key encrypted with the system master key
+type wrappedKey struct {
+	id         string // user_id or process_id
+	version    int    // process key version (process keys only)
+	ciphertext string
+}
+
+// RewrapKeys rewraps all user and process keys below the latest system key version with the key
+// provider's rewrap (with Vault the key material never leaves Vault). It returns the number of rewrapped keys.
+func (km *KeyManager) RewrapKeys() (int, error) {
+	latest, _, err := km.provider.GetKeyVersions(km.systemKeyID)
+	if err != nil {
+		return 0, err
+	}
+
+	userKeys, err := loadWrappedKeys(km.db, `
+		SELECT user_id::text, 0, encrypted_private_key FROM user_keys
+		WHERE system_key_version < $1 AND encrypted_private_key IS NOT NULL
+	`, latest)
+	if err != nil {
+		return 0, err
+	}
+	processKeys, err := loadWrappedKeys(km.db, `
+		SELECT process_id, version, encrypted_key_material FROM process_key_versions
+		WHERE system_key_version < $1 AND encrypted_key_material IS NOT NULL
+	`, latest)
+	if err != nil {
+		return 0, err
+	}
+
+	rewrapped := 0
+	var failures []error
+	for _, key := range userKeys {
+		ciphertext, version, err := km.rewrap(key.ciphertext, map[string]string{"user_id": key.id})
+		if err == nil {
+			// Skipped if the key was changed in the meantime
+			_, err = km.db.Exec(`
+				UPDATE user_keys SET encrypted_private_key = $1, system_key_version = $2
+				WHERE user_id = $3::bigint AND encrypted_private_key = $4
+			`, ciphertext, version, key.id, key.ciphertext)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Errorf("user key %s: %w", key.id, err))
+			continue
+		}
+		rewrapped++
+	}
+	for _, key := range processKeys {
+		ciphertext, version, err := km.rewrap(key.ciphertext, map[string]string{"process_id": key.id})
+		if err == nil {
+			_, err = km.db.Exec(`
+				UPDATE process_key_versions SET encrypted_key_material = $1, system_key_version = $2
+				WHERE process_id = $3 AND version = $4 AND encrypted_key_material = $5
+			`, ciphertext, version, key.id, key.version, key.ciphertext)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Errorf("process key %s version %d: %w", key.id, key.version, err))
+			continue
+		}
+		rewrapped++
+	}
+
+	if len(failures) > 0 {
+		return rewrapped, fmt.Errorf("failed to rewrap %d keys, first error: %w", len(failures), failures[0])
+	}
+	return rewrapped, nil
+}
+
+// querier is implemented by *sql.DB and *sql.Tx
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func loadWrappedKeys(q querier, query string, args ...interface{}) ([]wrappedKey, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wrapped keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []wrappedKey
+	for rows.Next() {
+		var key wrappedKey
+		if err := rows.Scan(&key.id, &key.version, &key.ciphertext); err != nil {
+			return nil, fmt.Errorf("failed to scan wrapped key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// rewrap rewraps a single ciphertext with the latest system key version
+func (km *KeyManager) rewrap(ciphertext string, ctx map[string]string) (string, int, error) {
+	rewrapped, err := km.provider.Rewrap(km.systemKeyID, ciphertext, ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	version, err := ciphertextVersion(rewrapped)
+	if err != nil {
+		return "", 0, err
+	}
+	return rewrapped, version, nil
+}
+
+// wrap encrypts key material with the latest system key version
+func (km *KeyManager) wrap(plaintext []byte, ctx map[string]string) (string, int, error) {
+	ciphertext, err := km.provider.Encrypt(km.systemKeyID, plaintext, ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	version, err := ciphertextVersion(ciphertext)
+	if err != nil {
+		return "", 0, err
+	}
+	return ciphertext, version, nil
+}
+
+// ProviderName returns the name of the key provider wrapping user and process keys
+func (km *KeyManager) ProviderName() string {
+	return km.provider.Name()
+}
+
+// CountKeysByProvider returns the number of wrapped user and process keys per key provider
+func (km *KeyManager) CountKeysByProvider() (map[string]int, error) {
+	rows, err := km.db.Query(`
+		SELECT provider, SUM(n) FROM (
+			SELECT split_part(encrypted_private_key, ':', 1) AS provider, COUNT(*) AS n FROM user_keys
+			WHERE encrypted_private_key IS NOT NULL GROUP BY 1
+			UNION ALL
+			SELECT split_part(encrypted_key_material, ':', 1), COUNT(*) FROM process_key_versions
+			WHERE encrypted_key_material IS NOT NULL GROUP BY 1
+		) wrapped
+		GROUP BY provider
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count wrapped keys: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var provider string
+		var count int
+		if err := rows.Scan(&provider, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan wrapped key count: %w", err)
+		}
+		counts[provider] = count
+	}
+	return counts, rows.Err()
+}
+
+// CheckProvider verifies that all wrapped keys belong to the configured key provider.
+// Keys of another provider cannot be decrypted until they are migrated with MigrateFrom.
+func (km *KeyManager) CheckProvider() error {
+	counts, err := km.CountKeysByProvider()
+	if err != nil {
+		return err
+	}
+
+	var foreign []string
+	for provider, count := range counts {
+		if provider != km.provider.Name() {
+			foreign = append(foreign, fmt.Sprintf("%d keys of provider %s", count, provider))
+		}
+	}
+	if len(foreign) > 0 {
+		return fmt.Errorf("wrapped keys of another key provider found (%s)", strings.Join(foreign, ", "))
+	}
+	return nil
+}
+
+// MigrateFrom rewraps all user and process keys wrapped by the source provider with the
+// configured provider. The migration runs in one transaction, so it either moves all keys
+// or none. It returns the number of migrated keys.
+func (km *KeyManager) MigrateFrom(source KeyProvider) (int, error) {
+	if source.Name() == km.provider.Name() {
+		return 0, fmt.Errorf("source and target key provider are the same")
+	}
+
+	tx, err := km.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	prefix := source.Name() + ":%"
+	userKeys, err := loadWrappedKeys(tx, `
+		SELECT user_id::text, 0, encrypted_private_key FROM user_keys
+		WHERE encrypted_private_key LIKE $1
+		FOR UPDATE
+	`, prefix)
+	if err != nil {
+		return 0, err
+	}
+	processKeys, err := loadWrappedKeys(tx, `
+		SELECT process_id, version, encrypted_key_material FROM process_key_versions
+		WHERE encrypted_key_material LIKE $1
+		FOR UPDATE
+	`, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range userKeys {
+		ctx := map[string]string{"user_id": key.id}
+		plaintext, err := source.Decrypt(km.systemKeyID, key.ciphertext, ctx)
+		if err != nil {
+			return 0, fmt.Errorf("user key %s: %w", key.id, err)
+		}
+		ciphertext, version, err := km.wrap(plaintext, ctx)
+		if err != nil {
+			return 0, fmt.Errorf("user key %s: %w", key.id, err)
+		}
+		if _, err := tx.Exec(`
+			UPDATE user_keys SET encrypted_private_key = $1, system_key_version = $2
+			WHERE user_id = $3::bigint
+		`, ciphertext, version, key.id); err != nil {
+			return 0, fmt.Errorf("user key %s: %w", key.id, err)
+		}
+	}
+	for _, key := range processKeys {
+		ctx := map[string]string{"process_id": key.id}
+		plaintext, err := source.Decrypt(km.systemKeyID, key.ciphertext, ctx)
+		if err != nil {
+			return 0, fmt.Errorf("process key %s version %d: %w", key.id, key.version, err)
+		}
+		ciphertext, version, err := km.wrap(plaintext, ctx)
+		if err != nil {
+			return 0, fmt.Errorf("process key %s version %d: %w", key.id, key.version, err)
+		}
+		if _, err := tx.Exec(`
+			UPDATE process_key_versions SET encrypted_key_material = $1, system_key_version = $2
+			WHERE process_id = $3 AND version = $4
+		`, ciphertext, version, key.id, key.version); err != nil {
+			return 0, fmt.Errorf("process key %s version %d: %w", key.id, key.version, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit key migration: %w", err)
+	}
+	return len(userKeys) + len(processKeys), nil
+}
@@ -0,0 +1,363 @@
+package keymanager
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"new-pay/internal/vault"
+)
+
+const (
+	// keyringCheckValue is encrypted into the keyring file to detect a wrong passphrase
+	keyringCheckValue = "new-pay-keyring"
+	gcmNonceSize      = 12
+)
+
+// keyringFile is the on-disk format of the local keyring
+type keyringFile struct {
+	Salt  string                 `json:"salt"`  // Salt of the passphrase-derived key (base64)
+	Check string                 `json:"check"` // Encrypted check value (base64 nonce || ciphertext)
+	Keys  map[string]*keyringKey `json:"keys"`
+}
+
+// keyringKey is a versioned master key in the keyring
+type keyringKey struct {
+	LatestVersion        int            `json:"latest_version"`
+	MinDecryptionVersion int            `json:"min_decryption_version"`
+	Versions             map[int]string `json:"versions"` // Key material encrypted with the passphrase-derived key (base64 nonce || ciphertext)
+}
+
+// LocalProvider wraps keys with AES-256-GCM master keys from a local keyring file.
+// The key material in the file is encrypted with a key derived from a passphrase.
+// Without a file the keyring only lives in memory (for tests).
+type LocalProvider struct {
+	name     string
+	path     string
+	kek      []byte
+	mu       sync.Mutex
+	file     keyringFile
+	material map[string]map[int][]byte
+}
+
+// NewLocalProvider opens the keyring file at path or creates it if it does not exist
+func NewLocalProvider(path, passphrase string) (*LocalProvider, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("keyring passphrase is required")
+	}
+
+	p := &LocalProvider{
+		name:     "local",
+		path:     path,
+		material: map[string]map[int][]byte{},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("salt generation failed: %w", err)
+		}
+		if err := p.init(salt, passphrase); err != nil {
+			return nil, err
+		}
+		if err := p.save(); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &p.file); err != nil {
+		return nil, fmt.Errorf("invalid keyring file: %w", err)
+	}
+	salt, err := base64.StdEncoding.DecodeString(p.file.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keyring salt: %w", err)
+	}
+	p.kek = vault.DeriveKey([]byte(passphrase), salt, keyringCheckValue, 32)
+
+	check, err := p.open(p.file.Check, []byte(keyringCheckValue))
+	if err != nil || string(check) != keyringCheckValue {
+		return nil, fmt.Errorf("wrong keyring passphrase")
+	}
+
+	for name, key := range p.file.Keys {
+		p.material[name] = map[int][]byte{}
+		for version, sealed := range key.Versions {
+			material, err := p.open(sealed, materialAAD(name, version))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt version %d of key %s: %w", version, name, err)
+			}
+			p.material[name][version] = material
+		}
+	}
+
+	return p, nil
+}
+
+// NewMemoryProvider creates a provider whose keyring only lives in memory (for tests)
+func NewMemoryProvider() *LocalProvider {
+	p := &LocalProvider{
+		name:     "memory",
+		material: map[string]map[int][]byte{},
+	}
+
+	passphrase := make([]byte, 32)
+	salt := make([]byte, 16)
+	if _, err := rand.Read(passphrase); err != nil {
+		panic(fmt.Sprintf("random generation failed: %v", err))
+	}
+	if _, err := rand.Read(salt); err != nil {
+		panic(fmt.Sprintf("random generation failed: %v", err))
+	}
+	if err := p.init(salt, string(passphrase)); err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// init sets up an empty keyring
+func (p *LocalProvider) init(salt []byte, passphrase string) error {
+	p.kek = vault.DeriveKey([]byte(passphrase), salt, keyringCheckValue, 32)
+
+	check, err := p.seal([]byte(keyringCheckValue), []byte(keyringCheckValue))
+	if err != nil {
+		return err
+	}
+	p.file = keyringFile{
+		Salt:  base64.StdEncoding.EncodeToString(salt),
+		Check: check,
+		Keys:  map[string]*keyringKey{},
+	}
+	return nil
+}
+
+// Name returns the prefix of the provider's ciphertexts ("local" or "memory")
+func (p *LocalProvider) Name() string {
+	return p.name
+}
+
+// CreateKey creates a master key with version 1 if it does not exist yet
+func (p *LocalProvider) CreateKey(keyName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.file.Keys[keyName]; exists {
+		return nil
+	}
+
+	p.file.Keys[keyName] = &keyringKey{MinDecryptionVersion: 1, Versions: map[int]string{}}
+	p.material[keyName] = map[int][]byte{}
+	return p.addVersion(keyName)
+}
+
+// Encrypt wraps plaintext with the latest version of a master key
+func (p *LocalProvider) Encrypt(keyName string, plaintext []byte, ctx map[string]string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.file.Keys[keyName]
+	if !ok {
+		return "", fmt.Errorf("key %s not found", keyName)
+	}
+	version := key.LatestVersion
+
+	ciphertext, nonce, err := vault.EncryptLocal(plaintext, p.material[keyName][version], contextAAD(keyName, version, ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	payload := base64.StdEncoding.EncodeToString(append(nonce, ciphertext...))
+	return fmt.Sprintf("%s:v%d:%s", p.name, version, payload), nil
+}
+
+// Decrypt unwraps a ciphertext of this provider
+func (p *LocalProvider) Decrypt(keyName string, ciphertext string, ctx map[string]string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	provider, version, err := parseCiphertext(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if provider != p.name {
+		return nil, fmt.Errorf("ciphertext of provider %s cannot be decrypted by provider %s", provider, p.name)
+	}
+
+	key, ok := p.file.Keys[keyName]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found", keyName)
+	}
+	if version < key.MinDecryptionVersion {
+		return nil, fmt.Errorf("key version %d is below the minimum decryption version %d", version, key.MinDecryptionVersion)
+	}
+	material, ok := p.material[keyName][version]
+	if !ok {
+		return nil, fmt.Errorf("key version %d not found", version)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(ciphertext[strings.LastIndex(ciphertext, ":")+1:])
+	if err != nil || len(payload) < gcmNonceSize {
+		return nil, fmt.Errorf("invalid ciphertext payload")
+	}
+
+	plaintext, err := vault.DecryptLocal(payload[gcmNonceSize:], material, payload[:gcmNonceSize], contextAAD(keyName, version, ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+// Rewrap re-encrypts a ciphertext with the latest version of a master key
+func (p *LocalProvider) Rewrap(keyName string, ciphertext string, ctx map[string]string) (string, error) {
+	plaintext, err := p.Decrypt(keyName, ciphertext, ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to rewrap: %w", err)
+	}
+	return p.Encrypt(keyName, plaintext, ctx)
+}
+
+// RotateKey creates a new version of a master key
+func (p *LocalProvider) RotateKey(keyName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.file.Keys[keyName]; !ok {
+		return fmt.Errorf("key %s not found", keyName)
+	}
+	return p.addVersion(keyName)
+}
+
+// GetKeyVersions returns the latest and the minimum decryption version of a master key
+func (p *LocalProvider) GetKeyVersions(keyName string) (int, int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.file.Keys[keyName]
+	if !ok {
+		return 0, 0, fmt.Errorf("key %s not found", keyName)
+	}
+	return key.LatestVersion, key.MinDecryptionVersion, nil
+}
+
+// SetMinDecryptionVersion sets the oldest version that may still be used for decryption
+func (p *LocalProvider) SetMinDecryptionVersion(keyName string, version int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.file.Keys[keyName]
+	if !ok {
+		return fmt.Errorf("key %s not found", keyName)
+	}
+	if version < 1 || version > key.LatestVersion {
+		return fmt.Errorf("minimum decryption version must be between 1 and %d", key.LatestVersion)
+	}
+
+	previous := key.MinDecryptionVersion
+	key.MinDecryptionVersion = version
+	if err := p.save(); err != nil {
+		key.MinDecryptionVersion = previous
+		return err
+	}
+	return nil
+}
+
+// addVersion generates new key material and stores it as the latest version (caller holds the lock)
+func (p *LocalProvider) addVersion(keyName string) error {
+	key := p.file.Keys[keyName]
+	version := key.LatestVersion + 1
+
+	material := make([]byte, 32)
+	if _, err := rand.Read(material); err != nil {
+		return fmt.Errorf("key generation failed: %w", err)
+	}
+	sealed, err := p.seal(material, materialAAD(keyName, version))
+	if err != nil {
+		return err
+	}
+
+	key.Versions[version] = sealed
+	key.LatestVersion = version
+	if err := p.save(); err != nil {
+		delete(key.Versions, version)
+		key.LatestVersion = version - 1
+		return err
+	}
+
+	p.material[keyName][version] = material
+	return nil
+}
+
+// save writes the keyring file atomically; without a path the keyring only lives in memory
+func (p *LocalProvider) save() error {
+	if p.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(p.file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal keyring: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create keyring directory: %w", err)
+	}
+
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write keyring: %w", err)
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		return fmt.Errorf("failed to write keyring: %w", err)
+	}
+	return nil
+}
+
+// seal encrypts with the passphrase-derived key
+func (p *LocalProvider) seal(plaintext, aad []byte) (string, error) {
+	ciphertext, nonce, err := vault.EncryptLocal(plaintext, p.kek, aad)
+	if err != nil {
+		return "", fmt.Errorf("failed to seal keyring entry: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(append(nonce, ciphertext...)), nil
+}
+
+// open decrypts with the passphrase-derived key
+func (p *LocalProvider) open(sealed string, aad []byte) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < gcmNonceSize {
+		return nil, fmt.Errorf("invalid keyring entry")
+	}
+	return vault.DecryptLocal(data[gcmNonceSize:], p.kek, data[:gcmNonceSize], aad)
+}
+
+// materialAAD binds sealed key material to its key name and version
+func materialAAD(keyName string, version int) []byte {
+	return []byte(fmt.Sprintf("%s:v%d", keyName, version))
+}
+
+// contextAAD binds a ciphertext to key name, version and context (sorted for a stable encoding)
+func contextAAD(keyName string, version int, ctx map[string]string) []byte {
+	keys := make([]string, 0, len(ctx))
+	for k := range ctx {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:v%d", keyName, version)
+	for _, k := range keys {
+		fmt.Fprintf(&b, ";%s=%s", k, ctx[k])
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,144 @@
+package keymanager
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCiphertext(t *testing.T) {
+	tests := []struct {
+		ciphertext string
+		provider   string
+		version    int
+		wantErr    bool
+	}{
+		{"vault:v1:abc", "vault", 1, false},
+		{"local:v12:abc", "local", 12, false},
+		{"vault:abc", "", 0, true},
+		{"", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		provider, version, err := parseCiphertext(tt.ciphertext)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCiphertext(%q) error = %v, wantErr %v", tt.ciphertext, err, tt.wantErr)
+			continue
+		}
+		if provider != tt.provider || version != tt.version {
+			t.Errorf("parseCiphertext(%q) = %s, %d, want %s, %d", tt.ciphertext, provider, version, tt.provider, tt.version)
+		}
+	}
+}
+
+func TestMemoryProviderRoundtrip(t *testing.T) {
+	p := NewMemoryProvider()
+	if err := p.CreateKey("system"); err != nil {
+		t.Fatalf("CreateKey failed: %v", err)
+	}
+	ctx := map[string]string{"user_id": "1"}
+
+	ciphertext, err := p.Encrypt("system", []byte("secret"), ctx)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !strings.HasPrefix(ciphertext, "memory:v1:") {
+		t.Errorf("unexpected ciphertext prefix: %s", ciphertext)
+	}
+
+	plaintext, err := p.Decrypt("system", ciphertext, ctx)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if string(plaintext) != "secret" {
+		t.Errorf("Decrypt = %q, want %q", plaintext, "secret")
+	}
+
+	if _, err := p.Decrypt("system", ciphertext, map[string]string{"user_id": "2"}); err == nil {
+		t.Error("Decrypt with a different context should fail")
+	}
+}
+
+func TestMemoryProviderRotation(t *testing.T) {
+	p := NewMemoryProvider()
+	if err := p.CreateKey("system"); err != nil {
+		t.Fatalf("CreateKey failed: %v", err)
+	}
+	ctx := map[string]string{"process_id": "assessment-1"}
+
+	old, err := p.Encrypt("system", []byte("key material"), ctx)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if err := p.RotateKey("system"); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+
+	rewrapped, err := p.Rewrap("system", old, ctx)
+	if err != nil {
+		t.Fatalf("Rewrap failed: %v", err)
+	}
+	if version, _ := ciphertextVersion(rewrapped); version != 2 {
+		t.Errorf("rewrapped version = %d, want 2", version)
+	}
+
+	if err := p.SetMinDecryptionVersion("system", 3); err == nil {
+		t.Error("SetMinDecryptionVersion above the latest version should fail")
+	}
+	if err := p.SetMinDecryptionVersion("system", 2); err != nil {
+		t.Fatalf("SetMinDecryptionVersion failed: %v", err)
+	}
+	if _, err := p.Decrypt("system", old, ctx); err == nil {
+		t.Error("Decrypt below the minimum decryption version should fail")
+	}
+	if plaintext, err := p.Decrypt("system", rewrapped, ctx); err != nil || string(plaintext) != "key material" {
+		t.Errorf("Decrypt of rewrapped ciphertext = %q, %v", plaintext, err)
+	}
+}
+
+func TestLocalProviderPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	ctx := map[string]string{"user_id": "7"}
+
+	p, err := NewLocalProvider(path, "passphrase")
+	if err != nil {
+		t.Fatalf("NewLocalProvider failed: %v", err)
+	}
+	if err := p.CreateKey("system"); err != nil {
+		t.Fatalf("CreateKey failed: %v", err)
+	}
+	if err := p.RotateKey("system"); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	ciphertext, err := p.Encrypt("system", []byte("private key"), ctx)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	reopened, err := NewLocalProvider(path, "passphrase")
+	if err != nil {
+		t.Fatalf("reopening keyring failed: %v", err)
+	}
+	latest, minVersion, err := reopened.GetKeyVersions("system")
+	if err != nil || latest != 2 || minVersion != 1 {
+		t.Errorf("GetKeyVersions = %d, %d, %v, want 2, 1", latest, minVersion, err)
+	}
+	plaintext, err := reopened.Decrypt("system", ciphertext, ctx)
+	if err != nil || string(plaintext) != "private key" {
+		t.Errorf("Decrypt after reopening = %q, %v", plaintext, err)
+	}
+
+	if _, err := NewLocalProvider(path, "wrong"); err == nil {
+		t.Error("opening the keyring with a wrong passphrase should fail")
+	}
+}
+
+func TestLocalProviderRejectsForeignCiphertext(t *testing.T) {
+	p := NewMemoryProvider()
+	if err := p.CreateKey("system"); err != nil {
+		t.Fatalf("CreateKey failed: %v", err)
+	}
+	if _, err := p.Decrypt("system", "vault:v1:abc", nil); err == nil {
+		t.Error("Decrypt of another provider's ciphertext should fail")
+	}
+}
@@ -28,13 +28,18 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	if !rw.written {
 		rw.WriteHeader(http.StatusOK)
 	}
-	// Capture response body for DEBUG logging
-	if rw.body != nil {
+	// Capture response body for DEBUG logging (not for long-lived event streams)
+	if rw.body != nil && rw.Header().Get("Content-Type") != "text/event-stream" {
 		rw.body.Write(b)
 	}
 	return rw.ResponseWriter.Write(b)
 }
 
+// Unwrap returns the underlying writer so http.ResponseController can flush streamed responses
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // LoggingMiddleware logs all HTTP requests with level-based detail
 //
 // Log levels:
@@ -19,6 +19,7 @@ type User struct {
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 	OAuthProvider   *string    `json:"oauth_provider,omitempty" db:"oauth_provider"`
 	OAuthProviderID *string    `json:"-" db:"oauth_provider_id"`
+	Team            *string    `json:"team,omitempty" db:"team"` // Used to exclude reviewers of the same team
 }
 
 // Role represents a user role
@@ -125,12 +126,18 @@ type CriteriaCatalog struct {
 	Description *string    `json:"description,omitempty" db:"description"`
 	ValidFrom   time.Time  `json:"valid_from" db:"valid_from"`
 	ValidUntil  time.Time  `json:"valid_until" db:"valid_until"`
-	Phase       string     `json:"phase" db:"phase"` // draft, review, archived
+	Phase       string     `json:"phase" db:"phase"` // draft, review, active, archived
 	CreatedBy   *uint      `json:"created_by,omitempty" db:"created_by"`
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 	PublishedAt *time.Time `json:"published_at,omitempty" db:"published_at"`
 	ArchivedAt  *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+	// SourceCatalogID references the catalog this one was cloned from (lineage)
+	SourceCatalogID *uint `json:"source_catalog_id,omitempty" db:"source_catalog_id"`
+	// ScoringStrategy selects how reviewer ratings are aggregated (mean, median, trimmed_mean, mode)
+	ScoringStrategy string `json:"scoring_strategy" db:"scoring_strategy"`
+	// LevelRounding selects how scores are mapped to levels (nearest, down)
+	LevelRounding string `json:"level_rounding" db:"level_rounding"`
 }
 
 // Category represents a category within a criteria catalog
@@ -163,6 +170,7 @@ type Path struct {
 	Name        string    `json:"name" db:"name"`
 	Description *string   `json:"description,omitempty" db:"description"`
 	SortOrder   int       `json:"sort_order" db:"sort_order"`
+	Weight      *float64  `json:"weight,omitempty" db:"weight"` // Multiplier of the category weight, 1 if nil
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -190,6 +198,19 @@ type CatalogChange struct {
 	ChangedAt  time.Time `json:"changed_at" db:"changed_at"`
 }
 
+// CatalogTranslation holds the text of a catalog entity field in a non-default locale
+type CatalogTranslation struct {
+	ID         uint      `json:"id" db:"id"`
+	CatalogID  uint      `json:"catalog_id" db:"catalog_id"`
+	EntityType string    `json:"entity_type" db:"entity_type"` // category, level, path, description
+	EntityID   uint      `json:"entity_id" db:"entity_id"`
+	Locale     string    `json:"locale" db:"locale"`
+	FieldName  string    `json:"field_name" db:"field_name"` // name, description
+	Value      string    `json:"value" db:"value"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
 // CatalogWithDetails extends CriteriaCatalog with nested structures
 type CatalogWithDetails struct {
 	CriteriaCatalog
@@ -246,6 +267,7 @@ type AssessmentResponse struct {
 	LevelID                  uint      `json:"level_id" db:"level_id"`
 	Justification            string    `json:"justification" db:"justification"`                                     // Decrypted justification (not stored)
 	EncryptedJustificationID *int64    `json:"encrypted_justification_id,omitempty" db:"encrypted_justification_id"` // Reference to encrypted_records
+	Version                  int       `json:"version" db:"version"`                                                 // Incremented on every update (ETag)
 	CreatedAt                time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt                time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -290,6 +312,7 @@ type ReviewerResponse struct {
 	LevelID                  uint      `json:"level_id" db:"level_id"`
 	Justification            string    `json:"justification" db:"-"`                                                 // Decrypted justification (not stored in DB)
 	EncryptedJustificationID *int64    `json:"encrypted_justification_id,omitempty" db:"encrypted_justification_id"` // Reference to encrypted_records
+	Version                  int       `json:"version" db:"version"`                                                 // Incremented on every update (ETag)
 	CreatedAt                time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt                time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -298,6 +321,7 @@ type ReviewerResponse struct {
 type ReviewCompletionStatus struct {
 	TotalReviewers               int                      `json:"total_reviewers"`
 	CompleteReviews              int                      `json:"complete_reviews"`
+	PanelSize                    int                      `json:"panel_size"` // Number of assigned reviewers
 	CanConsolidate               bool                     `json:"can_consolidate"`
 	ReviewersWithCompleteReviews []ReviewerCompletionInfo `json:"reviewers_with_complete_reviews"`
 }
@@ -309,6 +333,82 @@ type ReviewerCompletionInfo struct {
 	CompletedAt  time.Time `json:"completed_at"`
 }
 
+// ReviewerAssignment assigns a reviewer to the panel of a self-assessment
+type ReviewerAssignment struct {
+	ID             uint      `json:"id" db:"id"`
+	AssessmentID   uint      `json:"assessment_id" db:"assessment_id"`
+	ReviewerUserID uint      `json:"reviewer_user_id" db:"reviewer_user_id"`
+	ReviewerName   string    `json:"reviewer_name" db:"-"`
+	AssignedBy     *uint     `json:"assigned_by,omitempty" db:"assigned_by"`
+	AssignedAt     time.Time `json:"assigned_at" db:"assigned_at"`
+}
+
+// ReviewerConflict is a declared conflict of interest between a reviewer and an employee
+type ReviewerConflict struct {
+	ID             uint      `json:"id" db:"id"`
+	ReviewerUserID uint      `json:"reviewer_user_id" db:"reviewer_user_id"`
+	ReviewerName   string    `json:"reviewer_name" db:"-"`
+	UserID         uint      `json:"user_id" db:"user_id"`
+	UserName       string    `json:"user_name" db:"-"`
+	Reason         *string   `json:"reason,omitempty" db:"reason"`
+	CreatedBy      *uint     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// ChangeRequest is a request of a reviewer to rework a submitted self-assessment
+type ChangeRequest struct {
+	ID                 uint       `json:"id" db:"id"`
+	AssessmentID       uint       `json:"assessment_id" db:"assessment_id"`
+	RequestedBy        *uint      `json:"requested_by,omitempty" db:"requested_by"`
+	RequesterName      string     `json:"requester_name" db:"-"`
+	FromStatus         string     `json:"from_status" db:"from_status"`
+	Message            string     `json:"message" db:"-"`                                           // Decrypted message
+	EncryptedMessageID *int64     `json:"encrypted_message_id,omitempty" db:"encrypted_message_id"` // Reference to encrypted_records
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	ResolvedAt         *time.Time `json:"resolved_at,omitempty" db:"resolved_at"` // Set when the owner resubmits
+}
+
+// ReviewerWorkload is the current workload of a reviewer used for automatic panel assignment
+type ReviewerWorkload struct {
+	ReviewerUserID uint       `json:"reviewer_user_id"`
+	ReviewerName   string     `json:"reviewer_name"`
+	OpenReviews    int        `json:"open_reviews"`               // Assigned self-assessments that are still in review
+	LastAssignedAt *time.Time `json:"last_assigned_at,omitempty"` // Used for round-robin between equal workloads
+}
+
+// ReviewerExclusion explains why a reviewer was not considered for a panel
+type ReviewerExclusion struct {
+	ReviewerUserID uint   `json:"reviewer_user_id"`
+	ReviewerName   string `json:"reviewer_name"`
+	Reason         string `json:"reason"` // same_team or conflict_of_interest
+}
+
+// PanelProposal is the result of the automatic panel assignment for a self-assessment
+type PanelProposal struct {
+	AssessmentID uint                `json:"assessment_id"`
+	Size         int                 `json:"size"`
+	Reviewers    []ReviewerWorkload  `json:"reviewers"`
+	Excluded     []ReviewerExclusion `json:"excluded"`
+	Complete     bool                `json:"complete"` // False if there are not enough eligible reviewers
+}
+
+// ReviewerPanel is the reviewer panel of a self-assessment together with the allowed panel size
+type ReviewerPanel struct {
+	AssessmentID uint                 `json:"assessment_id"`
+	Reviewers    []ReviewerAssignment `json:"reviewers"`
+	MinSize      int                  `json:"min_size"`
+	MaxSize      int                  `json:"max_size"`
+}
+
+// IncompletePanel is an open self-assessment whose reviewer panel is smaller than the minimum panel size
+type IncompletePanel struct {
+	AssessmentID uint   `json:"assessment_id"`
+	UserID       uint   `json:"user_id"`
+	Status       string `json:"status"`
+	PanelSize    int    `json:"panel_size"`
+	MinSize      int    `json:"min_size"`
+}
+
 // ConsolidationOverride represents a manually adjusted value during review consolidation
 type ConsolidationOverride struct {
 	ID                       uint                            `json:"id" db:"id"`
@@ -319,11 +419,13 @@ type ConsolidationOverride struct {
 	Justification            string                          `json:"justification" db:"-"`                                                 // Decrypted justification (not stored in DB)
 	EncryptedJustificationID *int64                          `json:"encrypted_justification_id,omitempty" db:"encrypted_justification_id"` // Reference to encrypted_records
 	CreatedByUserID          uint                            `json:"created_by_user_id" db:"created_by_user_id"`
+	Version                  int                             `json:"version" db:"version"` // Incremented on every update (ETag)
 	CreatedAt                time.Time                       `json:"created_at" db:"created_at"`
 	UpdatedAt                time.Time                       `json:"updated_at" db:"updated_at"`
 	Approvals                []ConsolidationOverrideApproval `json:"approvals,omitempty" db:"-"` // Loaded separately
 	ApprovalCount            int                             `json:"approval_count" db:"-"`      // Number of approvals
-	IsApproved               bool                            `json:"is_approved" db:"-"`         // Has at least one approval
+	RequiredApprovals        int                             `json:"required_approvals" db:"-"`  // Approvals needed according to the quorum policy
+	IsApproved               bool                            `json:"is_approved" db:"-"`         // Quorum reached
 }
 
 // ConsolidationOverrideApproval tracks reviewer approvals for overrides
@@ -353,10 +455,18 @@ type AveragedReviewerResponse struct {
 	AverageLevelNumber     float64                         `json:"average_level_number"`
 	AverageLevelName       string                          `json:"average_level_name"` // Computed from average
 	ReviewerCount          int                             `json:"reviewer_count"`
+	PathWeight             float64                         `json:"path_weight"`                       // Mean weight of the paths selected by the reviewers
+	MinLevelNumber         int                             `json:"min_level_number"`                  // Lowest level chosen by a reviewer
+	MaxLevelNumber         int                             `json:"max_level_number"`                  // Highest level chosen by a reviewer
+	LevelSpread            int                             `json:"level_spread"`                      // MaxLevelNumber - MinLevelNumber
+	StdDeviation           float64                         `json:"std_deviation"`                     // Standard deviation of the reviewer levels
+	NeedsDiscussion        bool                            `json:"needs_discussion" db:"-"`           // Spread above the disagreement threshold
+	DiscussionRecorded     bool                            `json:"discussion_recorded" db:"-"`        // A category discussion comment has been written
 	ReviewerJustifications []string                        `json:"reviewer_justifications,omitempty"` // All reviewer justifications for this category
 	Approvals              []ConsolidationAveragedApproval `json:"approvals,omitempty" db:"-"`        // Loaded separately
 	ApprovalCount          int                             `json:"approval_count" db:"-"`             // Number of approvals
-	IsApproved             bool                            `json:"is_approved" db:"-"`                // Has at least 2 approvals
+	RequiredApprovals      int                             `json:"required_approvals" db:"-"`         // Approvals needed according to the quorum policy
+	IsApproved             bool                            `json:"is_approved" db:"-"`                // Quorum reached
 }
 
 // FinalConsolidation represents the final consolidation comment
@@ -366,11 +476,12 @@ type FinalConsolidation struct {
 	Comment            string                       `json:"comment" db:"-"`                                           // Decrypted comment (not stored in DB)
 	EncryptedCommentID *int64                       `json:"encrypted_comment_id,omitempty" db:"encrypted_comment_id"` // Reference to encrypted_records
 	CreatedByUserID    uint                         `json:"created_by_user_id" db:"created_by_user_id"`
+	Version            int                          `json:"version" db:"version"` // Incremented on every update (ETag)
 	CreatedAt          time.Time                    `json:"created_at" db:"created_at"`
 	UpdatedAt          time.Time                    `json:"updated_at" db:"updated_at"`
 	Approvals          []FinalConsolidationApproval `json:"approvals,omitempty" db:"-"` // Loaded separately
 	ApprovalCount      int                          `json:"approval_count" db:"-"`      // Number of approvals
-	RequiredApprovals  int                          `json:"required_approvals" db:"-"`  // How many approvals needed (= reviewer count)
+	RequiredApprovals  int                          `json:"required_approvals" db:"-"`  // Approvals needed according to the quorum policy
 	IsFullyApproved    bool                         `json:"is_fully_approved" db:"-"`   // All required approvals received
 }
 
@@ -400,7 +511,8 @@ type DiscussionConfirmation struct {
 	ID           uint      `json:"id" db:"id"`
 	AssessmentID uint      `json:"assessment_id" db:"assessment_id"`
 	UserID       uint      `json:"user_id" db:"user_id"`
-	UserType     string    `json:"user_type" db:"user_type"` // "reviewer" or "owner"
+	UserType     string    `json:"user_type" db:"user_type"`             // "reviewer" or "owner"
+	MeetingID    *uint     `json:"meeting_id,omitempty" db:"meeting_id"` // Confirmed discussion meeting
 	ConfirmedAt  time.Time `json:"confirmed_at" db:"confirmed_at"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 
@@ -409,6 +521,23 @@ type DiscussionConfirmation struct {
 	UserEmail string `json:"user_email,omitempty" db:"-"`
 }
 
+// DiscussionMeeting is the scheduled discussion meeting of a self-assessment
+type DiscussionMeeting struct {
+	ID              uint       `json:"id" db:"id"`
+	AssessmentID    uint       `json:"assessment_id" db:"assessment_id"`
+	UID             string     `json:"uid" db:"uid"`           // iCalendar UID
+	Sequence        int        `json:"sequence" db:"sequence"` // iCalendar SEQUENCE, incremented on update and cancellation
+	StartsAt        time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt          time.Time  `json:"ends_at" db:"ends_at"`
+	Location        string     `json:"location" db:"location"`
+	Status          string     `json:"status" db:"status"` // "scheduled" or "cancelled"
+	OrganizerUserID *uint      `json:"organizer_user_id,omitempty" db:"organizer_user_id"`
+	OrganizerName   string     `json:"organizer_name,omitempty" db:"-"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+}
+
 // ConsolidationData contains all data needed for the consolidation page
 type ConsolidationData struct {
 	Assessment                 SelfAssessment                  `json:"assessment"`
@@ -420,6 +549,35 @@ type ConsolidationData struct {
 	FinalConsolidation         *FinalConsolidation             `json:"final_consolidation,omitempty"` // Final consolidation if exists
 	CategoryDiscussionComments []CategoryDiscussionComment     `json:"category_discussion_comments"`  // Public category comments
 	AllCategoriesApproved      bool                            `json:"all_categories_approved"`       // True if all categories have required approvals
+	DisagreementThreshold      int                             `json:"disagreement_threshold"`        // Maximum level spread without discussion
+	QuorumPolicy               QuorumPolicy                    `json:"quorum_policy"`                 // Approval rules applied to this assessment
+}
+
+// ConsolidationEvent notifies subscribers of the consolidation page about a change.
+// It carries no decrypted content; clients reload the consolidation data to see the change.
+type ConsolidationEvent struct {
+	Type         string    `json:"type"`
+	AssessmentID uint      `json:"assessment_id"`
+	CategoryID   *uint     `json:"category_id,omitempty"`
+	UserID       uint      `json:"user_id"`          // Reviewer who made the change
+	Status       string    `json:"status,omitempty"` // New assessment status (status_changed only)
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// QuorumPolicy defines how many reviewer approvals consolidation results need.
+// For each kind the higher of the absolute count and the percentage of the reviewer panel applies.
+type QuorumPolicy struct {
+	CatalogID         *uint     `json:"catalog_id,omitempty" db:"catalog_id"` // Nil for the global default
+	AveragedCount     int       `json:"averaged_count" db:"averaged_count"`
+	AveragedPercent   int       `json:"averaged_percent" db:"averaged_percent"`
+	OverrideCount     int       `json:"override_count" db:"override_count"`
+	OverridePercent   int       `json:"override_percent" db:"override_percent"`
+	FinalCount        int       `json:"final_count" db:"final_count"`
+	FinalPercent      int       `json:"final_percent" db:"final_percent"`
+	AllowSelfApproval bool      `json:"allow_self_approval" db:"allow_self_approval"` // Creator may approve own override
+	IsDefault         bool      `json:"is_default" db:"-"`                            // Catalog has no own policy
+	UpdatedBy         *uint     `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty" db:"updated_at"`
 }
 
 // DiscussionResult represents the frozen discussion data
@@ -465,3 +623,338 @@ type DiscussionReviewer struct {
 	ReviewerUserID     uint   `json:"reviewer_user_id" db:"reviewer_user_id"`
 	ReviewerName       string `json:"reviewer_name" db:"reviewer_name"`
 }
+
+// Appeal is an objection of the owner against the discussion result of a self-assessment
+type Appeal struct {
+	ID                  uint                `json:"id" db:"id"`
+	AssessmentID        uint                `json:"assessment_id" db:"assessment_id"`
+	FiledBy             *uint               `json:"filed_by,omitempty" db:"filed_by"`
+	Reason              string              `json:"reason" db:"-"`              // Decrypted reason
+	EncryptedReasonID   *int64              `json:"-" db:"encrypted_reason_id"` // Reference to encrypted_records
+	OriginalResultID    uint                `json:"original_result_id" db:"original_result_id"`
+	Status              string              `json:"status" db:"status"` // "open", "upheld" or "changed"
+	DecidedBy           *uint               `json:"decided_by,omitempty" db:"decided_by"`
+	DeciderName         string              `json:"decider_name,omitempty" db:"-"`
+	Decision            string              `json:"decision,omitempty" db:"-"`          // Decrypted justification of the decision
+	EncryptedDecisionID *int64              `json:"-" db:"encrypted_decision_id"`       // Reference to encrypted_records
+	ResultID            *uint               `json:"result_id,omitempty" db:"result_id"` // Discussion result created by the decision
+	DecidedAt           *time.Time          `json:"decided_at,omitempty" db:"decided_at"`
+	CreatedAt           time.Time           `json:"created_at" db:"created_at"`
+	Panel               []AppealPanelMember `json:"panel" db:"-"`
+}
+
+// AppealPanelMember is a reviewer deciding an appeal
+type AppealPanelMember struct {
+	AppealID       uint      `json:"appeal_id" db:"appeal_id"`
+	ReviewerUserID uint      `json:"reviewer_user_id" db:"reviewer_user_id"`
+	ReviewerName   string    `json:"reviewer_name" db:"-"`
+	AssignedBy     *uint     `json:"assigned_by,omitempty" db:"assigned_by"` // nil for automatic assignment
+	AssignedAt     time.Time `json:"assigned_at" db:"assigned_at"`
+}
+
+// AppealCategoryChange is a category result changed by the appeal panel
+type AppealCategoryChange struct {
+	CategoryID    uint   `json:"category_id"`
+	PathID        uint   `json:"path_id"`
+	LevelID       uint   `json:"level_id"`
+	Justification string `json:"justification"`
+}
+
+// CatalogDocumentSchemaVersion is the current version of the portable catalog document format
+const CatalogDocumentSchemaVersion = 1
+
+// CatalogDocument is the portable, ID-free representation of a catalog used for export and import
+type CatalogDocument struct {
+	SchemaVersion int     `json:"schema_version" yaml:"schema_version"`
+	Name          string  `json:"name" yaml:"name"`
+	Description   *string `json:"description,omitempty" yaml:"description,omitempty"`
+	ValidFrom     string  `json:"valid_from" yaml:"valid_from"`   // YYYY-MM-DD
+	ValidUntil    string  `json:"valid_until" yaml:"valid_until"` // YYYY-MM-DD
+	// Scoring settings (optional, default mean / nearest)
+	ScoringStrategy string                    `json:"scoring_strategy,omitempty" yaml:"scoring_strategy,omitempty"`
+	LevelRounding   string                    `json:"level_rounding,omitempty" yaml:"level_rounding,omitempty"`
+	Levels          []CatalogDocumentLevel    `json:"levels" yaml:"levels"`
+	Categories      []CatalogDocumentCategory `json:"categories" yaml:"categories"`
+}
+
+// CatalogDocumentLevel represents a level (column) in a catalog document
+type CatalogDocumentLevel struct {
+	Name        string  `json:"name" yaml:"name"`
+	LevelNumber int     `json:"level_number" yaml:"level_number"`
+	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// CatalogDocumentCategory represents a category with its paths in a catalog document
+type CatalogDocumentCategory struct {
+	Name        string                `json:"name" yaml:"name"`
+	Description *string               `json:"description,omitempty" yaml:"description,omitempty"`
+	SortOrder   int                   `json:"sort_order" yaml:"sort_order"`
+	Weight      *float64              `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Paths       []CatalogDocumentPath `json:"paths" yaml:"paths"`
+}
+
+// CatalogDocumentPath represents a path (row) with its matrix cells in a catalog document
+type CatalogDocumentPath struct {
+	Name         string                `json:"name" yaml:"name"`
+	Description  *string               `json:"description,omitempty" yaml:"description,omitempty"`
+	SortOrder    int                   `json:"sort_order" yaml:"sort_order"`
+	Weight       *float64              `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Descriptions []CatalogDocumentCell `json:"descriptions" yaml:"descriptions"`
+}
+
+// CatalogDocumentCell represents a matrix cell, referencing its level by name
+type CatalogDocumentCell struct {
+	Level       string `json:"level" yaml:"level"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// CatalogImportResult reports the outcome of a catalog import (or dry run)
+type CatalogImportResult struct {
+	DryRun            bool                `json:"dry_run"`
+	Valid             bool                `json:"valid"`                        // No blocking errors were found
+	Errors            []string            `json:"errors"`                       // Blocking validation errors
+	CompletenessError *string             `json:"completeness_error,omitempty"` // Why the catalog could not be activated yet (non-blocking for drafts)
+	Catalog           *CatalogWithDetails `json:"catalog,omitempty"`            // The created catalog (nil for dry runs and invalid documents)
+}
+
+// CatalogDiffEntry describes one structural or content difference between two catalog versions
+type CatalogDiffEntry struct {
+	EntityType string  `json:"entity_type"`          // catalog, category, level, path, description
+	Change     string  `json:"change"`               // added, removed, renamed, changed
+	Category   string  `json:"category,omitempty"`   // Category name (in the target version if present)
+	Path       string  `json:"path,omitempty"`       // Path name (in the target version if present)
+	Level      string  `json:"level,omitempty"`      // Level name (in the target version if present)
+	FieldName  string  `json:"field_name,omitempty"` // Changed field for "changed" entries
+	OldValue   *string `json:"old_value,omitempty"`
+	NewValue   *string `json:"new_value,omitempty"`
+}
+
+// CatalogDiff is the structured difference between two catalog versions
+type CatalogDiff struct {
+	FromCatalogID uint               `json:"from_catalog_id"`
+	FromAt        *time.Time         `json:"from_at,omitempty"` // Point in time of the source version (nil = current)
+	ToCatalogID   uint               `json:"to_catalog_id"`
+	ToAt          *time.Time         `json:"to_at,omitempty"` // Point in time of the target version (nil = current)
+	Changes       []CatalogDiffEntry `json:"changes"`
+	HasChanges    bool               `json:"has_changes"`
+}
+
+// CatalogSnapshot is a catalog as it looked at a given point in time, reconstructed from the change log
+type CatalogSnapshot struct {
+	CatalogWithDetails
+	At              time.Time `json:"at"`
+	RevertedChanges int       `json:"reverted_changes"` // Number of change log entries rolled back
+	RemovedEntities int       `json:"removed_entities"` // Number of entities created after the point in time
+}
+
+// CatalogRevertResult summarizes a revert of change log entries
+type CatalogRevertResult struct {
+	RevertedChanges int      `json:"reverted_changes"`
+	Skipped         []string `json:"skipped,omitempty"` // Changes that could not be reverted (e.g. entity was deleted)
+}
+
+// CatalogApproval represents an approver's sign-off on a catalog under review
+type CatalogApproval struct {
+	ID               uint      `json:"id" db:"id"`
+	CatalogID        uint      `json:"catalog_id" db:"catalog_id"`
+	ApprovedByUserID uint      `json:"approved_by_user_id" db:"approved_by_user_id"`
+	ApprovedByName   string    `json:"approved_by_name,omitempty" db:"-"` // Loaded separately
+	ApprovedAt       time.Time `json:"approved_at" db:"approved_at"`
+}
+
+// CatalogReviewComment represents a comment on a catalog made during review
+type CatalogReviewComment struct {
+	ID              uint      `json:"id" db:"id"`
+	CatalogID       uint      `json:"catalog_id" db:"catalog_id"`
+	CreatedByUserID *uint     `json:"created_by_user_id,omitempty" db:"created_by_user_id"`
+	CreatedByName   string    `json:"created_by_name,omitempty" db:"-"` // Loaded separately
+	Comment         string    `json:"comment" db:"comment"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// CatalogReviewStatus summarizes the review of a catalog
+type CatalogReviewStatus struct {
+	CatalogID         uint                   `json:"catalog_id"`
+	Phase             string                 `json:"phase"`
+	RequiredApprovals int                    `json:"required_approvals"`
+	Approvals         []CatalogApproval      `json:"approvals"`
+	Comments          []CatalogReviewComment `json:"comments"`
+	CanActivate       bool                   `json:"can_activate"` // In review and enough approvals
+}
+
+// CatalogMatrixImportError describes a problem in a specific row or cell of an imported spreadsheet
+type CatalogMatrixImportError struct {
+	Row     int    `json:"row"`              // 1-based spreadsheet row
+	Column  int    `json:"column,omitempty"` // 1-based spreadsheet column (0 = whole row)
+	Message string `json:"message"`
+}
+
+// CatalogMatrixImportResult is the outcome of a spreadsheet import of the path x level matrix
+type CatalogMatrixImportResult struct {
+	DryRun            bool                       `json:"dry_run"`
+	Valid             bool                       `json:"valid"`
+	Errors            []CatalogMatrixImportError `json:"errors,omitempty"`
+	CreatedLevels     int                        `json:"created_levels"`
+	CreatedCategories int                        `json:"created_categories"`
+	CreatedPaths      int                        `json:"created_paths"`
+	UpdatedCells      int                        `json:"updated_cells"`
+}
+
+// SalaryBand represents the salary range of a catalog level for a period and currency
+type SalaryBand struct {
+	ID             uint       `json:"id" db:"id"`
+	CatalogID      uint       `json:"catalog_id" db:"catalog_id"`
+	LevelID        uint       `json:"level_id" db:"level_id"`
+	LevelName      string     `json:"level_name,omitempty" db:"-"` // Loaded separately
+	Currency       string     `json:"currency" db:"currency"`      // ISO 4217 code, e.g. EUR
+	MinSalary      float64    `json:"min_salary" db:"min_salary"`
+	MaxSalary      float64    `json:"max_salary" db:"max_salary"`
+	EffectiveFrom  time.Time  `json:"effective_from" db:"effective_from"`
+	EffectiveUntil *time.Time `json:"effective_until,omitempty" db:"effective_until"` // Open-ended if nil
+	CreatedBy      *uint      `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// EmployeeSalary represents the salary of an employee from a given date
+type EmployeeSalary struct {
+	ID                uint      `json:"id" db:"id"`
+	UserID            uint      `json:"user_id" db:"user_id"`
+	Amount            float64   `json:"amount" db:"-"`              // Decrypted
+	Currency          string    `json:"currency" db:"-"`            // Decrypted
+	EncryptedSalaryID int64     `json:"-" db:"encrypted_salary_id"` // Reference to encrypted_records
+	EffectiveFrom     time.Time `json:"effective_from" db:"effective_from"`
+	CreatedBy         *uint     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// PayRecommendation maps the archived overall level of an assessment to a salary band
+type PayRecommendation struct {
+	ID                        uint       `json:"id" db:"id"`
+	AssessmentID              uint       `json:"assessment_id" db:"assessment_id"`
+	UserID                    uint       `json:"user_id" db:"user_id"`
+	UserName                  string     `json:"user_name,omitempty" db:"-"`  // Loaded separately
+	UserEmail                 string     `json:"user_email,omitempty" db:"-"` // Loaded separately
+	LevelID                   uint       `json:"level_id" db:"level_id"`
+	LevelName                 string     `json:"level_name,omitempty" db:"-"` // Loaded separately
+	SalaryBandID              *uint      `json:"salary_band_id,omitempty" db:"salary_band_id"`
+	BandMin                   *float64   `json:"band_min,omitempty" db:"-"`          // Loaded from salary band
+	BandMax                   *float64   `json:"band_max,omitempty" db:"-"`          // Loaded from salary band
+	Currency                  string     `json:"currency,omitempty" db:"-"`          // Decrypted
+	CurrentSalary             *float64   `json:"current_salary,omitempty" db:"-"`    // Decrypted, nil if unknown
+	TargetSalary              *float64   `json:"target_salary,omitempty" db:"-"`     // Decrypted, nil if unknown
+	EncryptedRecommendationID int64      `json:"-" db:"encrypted_recommendation_id"` // Reference to encrypted_records
+	BandPosition              string     `json:"band_position" db:"band_position"`   // below, within, above, unknown
+	Status                    string     `json:"status" db:"status"`                 // open, exported
+	CreatedAt                 time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt                 time.Time  `json:"updated_at" db:"updated_at"`
+	ExportedAt                *time.Time `json:"exported_at,omitempty" db:"exported_at"`
+}
+
+// WorkflowDefinition is a data-driven assessment workflow
+type WorkflowDefinition struct {
+	ID          uint                 `json:"id" db:"id"`
+	Name        string               `json:"name" db:"name"`
+	Description *string              `json:"description,omitempty" db:"description"`
+	IsDefault   bool                 `json:"is_default" db:"is_default"` // Used by all catalogs without own workflow
+	States      []WorkflowState      `json:"states" db:"-"`
+	Transitions []WorkflowTransition `json:"transitions" db:"-"`
+	CreatedBy   *uint                `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// WorkflowState is a status a self-assessment can be in
+type WorkflowState struct {
+	Name    string   `json:"name" db:"name"`
+	Final   bool     `json:"final" db:"is_final"`              // End of the workflow (archived, closed)
+	OnEnter []string `json:"on_enter,omitempty" db:"on_enter"` // Actions executed when a self-assessment enters the state
+}
+
+// WorkflowTransition is an allowed status change
+type WorkflowTransition struct {
+	From         string          `json:"from" db:"from_state"`
+	To           string          `json:"to" db:"to_state"`
+	Roles        []string        `json:"roles" db:"roles"`                           // Roles allowed to perform the transition; "owner" is the owner of the self-assessment
+	ExcludeOwner bool            `json:"exclude_owner,omitempty" db:"exclude_owner"` // The owner may not perform the transition even with a matching role
+	Guards       []WorkflowGuard `json:"guards,omitempty" db:"-"`
+}
+
+// WorkflowGuard is a condition that must hold for a transition
+type WorkflowGuard struct {
+	Type  string `json:"type" db:"guard_type"`       // all_categories_complete, min_complete_reviews, panel_reviews_complete, previous_status, max_hours_in_state
+	Value int    `json:"value,omitempty" db:"value"` // Parameter of the guard, e.g. N for min_complete_reviews
+}
+
+// WorkflowValidationResult reports the outcome of a workflow validation
+type WorkflowValidationResult struct {
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors"`
+}
+
+// ProcessKeyRotation is the rotation of a process key and the progress of re-encrypting its records
+type ProcessKeyRotation struct {
+	ID                 uint       `json:"id" db:"id"`
+	ProcessID          string     `json:"process_id" db:"process_id"`
+	FromVersion        int        `json:"from_version" db:"from_version"`
+	ToVersion          int        `json:"to_version" db:"to_version"`
+	Status             string     `json:"status" db:"status"` // "running", "completed" or "failed"
+	TotalRecords       int        `json:"total_records" db:"total_records"`
+	ReencryptedRecords int        `json:"reencrypted_records" db:"reencrypted_records"`
+	Error              *string    `json:"error,omitempty" db:"error"`
+	StartedBy          *uint      `json:"started_by,omitempty" db:"started_by"`
+	StartedAt          time.Time  `json:"started_at" db:"started_at"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// SystemKeyStatus reports the versions of the system master key and the system key versions of the wrapped keys
+type SystemKeyStatus struct {
+	KeyID                string      `json:"key_id"`
+	LatestVersion        int         `json:"latest_version"`
+	MinDecryptionVersion int         `json:"min_decryption_version"`
+	UserKeys             map[int]int `json:"user_keys_by_version"`    // Wrapped user keys per system key version
+	ProcessKeys          map[int]int `json:"process_keys_by_version"` // Wrapped process key versions per system key version
+	OutdatedUserKeys     int         `json:"outdated_user_keys"`      // Wrapped with a version below the latest
+	OutdatedProcessKeys  int         `json:"outdated_process_keys"`   // Wrapped with a version below the latest
+	RewrapRunning        bool        `json:"rewrap_running"`
+}
+
+// LegalHold blocks the erasure of a user's data
+type LegalHold struct {
+	UserID    uint      `json:"user_id" db:"user_id"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedBy *uint     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// DataErasure is the tombstone of a crypto-shredding erasure. Tombstones are append-only and
+// hash-chained: TombstoneHash covers PrevHash and all fields including the processes.
+type DataErasure struct {
+	ID                    uint                 `json:"id" db:"id"`
+	UserID                uint                 `json:"user_id" db:"user_id"` // Owner of the erased data
+	Scope                 string               `json:"scope" db:"scope"`     // "user" or "assessment"
+	Reason                string               `json:"reason" db:"reason"`
+	Processes             []DataErasureProcess `json:"processes"`
+	UserKeyDestroyed      bool                 `json:"user_key_destroyed" db:"user_key_destroyed"`
+	UserKeyRetainedReason *string              `json:"user_key_retained_reason,omitempty" db:"user_key_retained_reason"`
+	ErasedBy              *uint                `json:"erased_by,omitempty" db:"erased_by"`
+	ErasedAt              time.Time            `json:"erased_at" db:"erased_at"`
+	PrevHash              string               `json:"prev_hash" db:"prev_hash"`
+	TombstoneHash         string               `json:"tombstone_hash" db:"tombstone_hash"`
+}
+
+// DataErasureProcess is a secure store process whose key was destroyed by an erasure
+type DataErasureProcess struct {
+	ProcessID   string   `json:"process_id" db:"process_id"`
+	RecordCount int      `json:"record_count" db:"record_count"` // Records made unreadable
+	ChainHead   string   `json:"chain_head" db:"chain_head"`     // Chain hash of the last record at the time of the erasure
+	ChainValid  bool     `json:"chain_valid" db:"chain_valid"`   // Result of the chain verification before the erasure
+	KeyHashes   []string `json:"key_hashes" db:"key_hashes"`     // Hashes of the destroyed key versions
+}
+
+// DataErasureVerification is the result of verifying the tombstone chain
+type DataErasureVerification struct {
+	Valid      bool     `json:"valid"`
+	Tombstones int      `json:"tombstones"`
+	Errors     []string `json:"errors,omitempty"`
+}
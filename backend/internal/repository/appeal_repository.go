@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"new-pay/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// AppealRepository handles appeals against discussion results and their panels
+type AppealRepository struct {
+	db DBTX
+}
+
+// NewAppealRepository creates a new appeal repository
+func NewAppealRepository(db *sql.DB) *AppealRepository {
+	return &AppealRepository{db: db}
+}
+
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *AppealRepository) WithTx(tx *sql.Tx) *AppealRepository {
+	return &AppealRepository{db: tx}
+}
+
+// Create stores a new open appeal
+func (r *AppealRepository) Create(appeal *models.Appeal) error {
+	query := `
+		INSERT INTO assessment_appeals (assessment_id, filed_by, encrypted_reason_id, original_result_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at
+	`
+	return r.db.QueryRow(
+		query,
+		appeal.AssessmentID,
+		appeal.FiledBy,
+		appeal.EncryptedReasonID,
+		appeal.OriginalResultID,
+	).Scan(&appeal.ID, &appeal.Status, &appeal.CreatedAt)
+}
+
+// GetByAssessment retrieves the appeal of a self-assessment (nil if none was filed)
+func (r *AppealRepository) GetByAssessment(assessmentID uint) (*models.Appeal, error) {
+	query := `
+		SELECT a.id, a.assessment_id, a.filed_by, a.encrypted_reason_id, a.original_result_id, a.status,
+			a.decided_by, COALESCE(CONCAT(u.first_name, ' ', u.last_name), ''), a.encrypted_decision_id,
+			a.result_id, a.decided_at, a.created_at
+		FROM assessment_appeals a
+		LEFT JOIN users u ON a.decided_by = u.id
+		WHERE a.assessment_id = $1
+	`
+	var appeal models.Appeal
+	err := r.db.QueryRow(query, assessmentID).Scan(
+		&appeal.ID,
+		&appeal.AssessmentID,
+		&appeal.FiledBy,
+		&appeal.EncryptedReasonID,
+		&appeal.OriginalResultID,
+		&appeal.Status,
+		&appeal.DecidedBy,
+		&appeal.DeciderName,
+		&appeal.EncryptedDecisionID,
+		&appeal.ResultID,
+		&appeal.DecidedAt,
+		&appeal.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get appeal: %w", err)
+	}
+	return &appeal, nil
+}
+
+// Decide records the decision of an open appeal
+func (r *AppealRepository) Decide(appeal *models.Appeal) error {
+	query := `
+		UPDATE assessment_appeals
+		SET status = $1, decided_by = $2, encrypted_decision_id = $3, result_id = $4, decided_at = CURRENT_TIMESTAMP
+		WHERE id = $5 AND status = 'open'
+		RETURNING decided_at
+	`
+	err := r.db.QueryRow(
+		query,
+		appeal.Status,
+		appeal.DecidedBy,
+		appeal.EncryptedDecisionID,
+		appeal.ResultID,
+		appeal.ID,
+	).Scan(&appeal.DecidedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("appeal has already been decided")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decide appeal: %w", err)
+	}
+	return nil
+}
+
+// GetPanel retrieves the panel of an appeal
+func (r *AppealRepository) GetPanel(appealID uint) ([]models.AppealPanelMember, error) {
+	query := `
+		SELECT m.appeal_id, m.reviewer_user_id, CONCAT(u.first_name, ' ', u.last_name), m.assigned_by, m.assigned_at
+		FROM appeal_panel_members m
+		JOIN users u ON u.id = m.reviewer_user_id
+		WHERE m.appeal_id = $1
+		ORDER BY m.assigned_at, m.reviewer_user_id
+	`
+	rows, err := r.db.Query(query, appealID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get appeal panel: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.AppealPanelMember{}
+	for rows.Next() {
+		var m models.AppealPanelMember
+		if err := rows.Scan(&m.AppealID, &m.ReviewerUserID, &m.ReviewerName, &m.AssignedBy, &m.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan appeal panel member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// ReplacePanel replaces the panel of an appeal in a single transaction.
+// Reviewers that stay on the panel keep their original assignment.
+// assignedBy is nil for automatic assignments by the system.
+func (r *AppealRepository) ReplacePanel(appealID uint, reviewerIDs []uint, assignedBy *uint) error {
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, len(reviewerIDs))
+	for i, id := range reviewerIDs {
+		ids[i] = int64(id)
+	}
+
+	if _, err := tx.Exec(`
+		DELETE FROM appeal_panel_members
+		WHERE appeal_id = $1 AND NOT (reviewer_user_id = ANY($2))
+	`, appealID, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to remove appeal panel members: %w", err)
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		if _, err := tx.Exec(`
+			INSERT INTO appeal_panel_members (appeal_id, reviewer_user_id, assigned_by)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (appeal_id, reviewer_user_id) DO NOTHING
+		`, appealID, reviewerID, assignedBy); err != nil {
+			return fmt.Errorf("failed to assign appeal panel member: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// IsPanelMember checks whether a reviewer is on the panel of an appeal
+func (r *AppealRepository) IsPanelMember(appealID, reviewerUserID uint) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM appeal_panel_members WHERE appeal_id = $1 AND reviewer_user_id = $2
+		)
+	`, appealID, reviewerUserID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check appeal panel: %w", err)
+	}
+	return exists, nil
+}
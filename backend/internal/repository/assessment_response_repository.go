@@ -21,7 +21,7 @@ func (r *AssessmentResponseRepository) Create(response *models.AssessmentRespons
 	query := `
 		INSERT INTO assessment_responses (assessment_id, category_id, path_id, level_id, justification)
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at, updated_at
+		RETURNING id, version, created_at, updated_at
 	`
 	err := r.db.QueryRow(
 		query,
@@ -30,7 +30,7 @@ func (r *AssessmentResponseRepository) Create(response *models.AssessmentRespons
 		response.PathID,
 		response.LevelID,
 		response.Justification,
-	).Scan(&response.ID, &response.CreatedAt, &response.UpdatedAt)
+	).Scan(&response.ID, &response.Version, &response.CreatedAt, &response.UpdatedAt)
 
 	return err
 }
@@ -39,9 +39,9 @@ func (r *AssessmentResponseRepository) Create(response *models.AssessmentRespons
 func (r *AssessmentResponseRepository) Update(response *models.AssessmentResponse) error {
 	query := `
 		UPDATE assessment_responses
-		SET path_id = $1, level_id = $2, justification = $3, updated_at = CURRENT_TIMESTAMP
+		SET path_id = $1, level_id = $2, justification = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $4
-		RETURNING updated_at
+		RETURNING version, updated_at
 	`
 	err := r.db.QueryRow(
 		query,
@@ -49,7 +49,7 @@ func (r *AssessmentResponseRepository) Update(response *models.AssessmentRespons
 		response.LevelID,
 		response.Justification,
 		response.ID,
-	).Scan(&response.UpdatedAt)
+	).Scan(&response.Version, &response.UpdatedAt)
 
 	return err
 }
@@ -78,7 +78,7 @@ func (r *AssessmentResponseRepository) Delete(responseID uint) error {
 func (r *AssessmentResponseRepository) GetByID(responseID uint) (*models.AssessmentResponse, error) {
 	var response models.AssessmentResponse
 	query := `
-		SELECT id, assessment_id, category_id, path_id, level_id, encrypted_justification_id, created_at, updated_at
+		SELECT id, assessment_id, category_id, path_id, level_id, encrypted_justification_id, version, created_at, updated_at
 		FROM assessment_responses
 		WHERE id = $1
 	`
@@ -89,6 +89,7 @@ func (r *AssessmentResponseRepository) GetByID(responseID uint) (*models.Assessm
 		&response.PathID,
 		&response.LevelID,
 		&response.EncryptedJustificationID,
+		&response.Version,
 		&response.CreatedAt,
 		&response.UpdatedAt,
 	)
@@ -105,7 +106,7 @@ func (r *AssessmentResponseRepository) GetByID(responseID uint) (*models.Assessm
 func (r *AssessmentResponseRepository) GetByAssessmentAndCategory(assessmentID, categoryID uint) (*models.AssessmentResponse, error) {
 	var response models.AssessmentResponse
 	query := `
-		SELECT id, assessment_id, category_id, path_id, level_id, encrypted_justification_id, created_at, updated_at
+		SELECT id, assessment_id, category_id, path_id, level_id, encrypted_justification_id, version, created_at, updated_at
 		FROM assessment_responses
 		WHERE assessment_id = $1 AND category_id = $2
 	`
@@ -116,6 +117,7 @@ func (r *AssessmentResponseRepository) GetByAssessmentAndCategory(assessmentID,
 		&response.PathID,
 		&response.LevelID,
 		&response.EncryptedJustificationID,
+		&response.Version,
 		&response.CreatedAt,
 		&response.UpdatedAt,
 	)
@@ -133,7 +135,7 @@ func (r *AssessmentResponseRepository) GetAllByAssessment(assessmentID uint) ([]
 	query := `
 		SELECT 
 			ar.id, ar.assessment_id, ar.category_id, ar.path_id, ar.level_id, 
-			ar.encrypted_justification_id, ar.created_at, ar.updated_at,
+			ar.encrypted_justification_id, ar.version, ar.created_at, ar.updated_at,
 			c.name as category_name, c.sort_order as category_sort_order,
 			p.name as path_name, p.description as path_description,
 			l.name as level_name, l.level_number, l.description as level_description,
@@ -163,6 +165,7 @@ func (r *AssessmentResponseRepository) GetAllByAssessment(assessmentID uint) ([]
 			&response.PathID,
 			&response.LevelID,
 			&response.EncryptedJustificationID,
+			&response.Version,
 			&response.CreatedAt,
 			&response.UpdatedAt,
 			&response.CategoryName,
@@ -295,7 +298,7 @@ func (r *AssessmentResponseRepository) ValidateLevelBelongsToCatalog(levelID, ca
 // GetByAssessmentID retrieves all responses for an assessment (without details)
 func (r *AssessmentResponseRepository) GetByAssessmentID(assessmentID uint) ([]*models.AssessmentResponse, error) {
 	query := `
-		SELECT id, assessment_id, category_id, path_id, level_id, encrypted_justification_id, created_at, updated_at
+		SELECT id, assessment_id, category_id, path_id, level_id, encrypted_justification_id, version, created_at, updated_at
 		FROM assessment_responses
 		WHERE assessment_id = $1
 		ORDER BY id
@@ -316,6 +319,7 @@ func (r *AssessmentResponseRepository) GetByAssessmentID(assessmentID uint) ([]*
 			&response.PathID,
 			&response.LevelID,
 			&response.EncryptedJustificationID,
+			&response.Version,
 			&response.CreatedAt,
 			&response.UpdatedAt,
 		)
@@ -337,7 +341,7 @@ func (r *AssessmentResponseRepository) GetWithDetailsByAssessmentID(assessmentID
 	query := `
 		SELECT 
 			ar.id, ar.assessment_id, ar.category_id, ar.path_id, ar.level_id, 
-			ar.encrypted_justification_id, ar.created_at, ar.updated_at,
+			ar.encrypted_justification_id, ar.version, ar.created_at, ar.updated_at,
 			c.name as category_name, c.sort_order as category_sort_order,
 			p.name as path_name, p.description as path_description,
 			l.name as level_name, l.level_number, l.description as level_description,
@@ -366,6 +370,7 @@ func (r *AssessmentResponseRepository) GetWithDetailsByAssessmentID(assessmentID
 			&response.PathID,
 			&response.LevelID,
 			&response.EncryptedJustificationID,
+			&response.Version,
 			&response.CreatedAt,
 			&response.UpdatedAt,
 			&response.CategoryName,
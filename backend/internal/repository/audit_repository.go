@@ -10,7 +10,7 @@ import (
 
 // AuditRepository handles audit log database operations
 type AuditRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewAuditRepository creates a new audit repository
@@ -18,10 +18,15 @@ func NewAuditRepository(db *sql.DB) *AuditRepository {
 	return &AuditRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *AuditRepository) WithTx(tx *sql.Tx) *AuditRepository {
+	return &AuditRepository{db: tx}
+}
+
 // Create creates a new audit log entry
 func (r *AuditRepository) Create(log *models.AuditLog) error {
-	// If user_id is provided, fetch the email
-	var userEmail *string
+	// If user_id is provided, fetch the email (otherwise keep the given actor, e.g. "system")
+	userEmail := log.UserEmail
 	if log.UserID != nil {
 		var email string
 		err := r.db.QueryRow("SELECT email FROM users WHERE id = $1", *log.UserID).Scan(&email)
@@ -10,7 +10,7 @@ import (
 
 // CatalogRepository handles database operations for criteria catalogs
 type CatalogRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewCatalogRepository creates a new catalog repository
@@ -18,11 +18,16 @@ func NewCatalogRepository(db *sql.DB) *CatalogRepository {
 	return &CatalogRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *CatalogRepository) WithTx(tx *sql.Tx) *CatalogRepository {
+	return &CatalogRepository{db: tx}
+}
+
 // CreateCatalog creates a new criteria catalog
 func (r *CatalogRepository) CreateCatalog(catalog *models.CriteriaCatalog) error {
 	query := `
-		INSERT INTO criteria_catalogs (name, description, valid_from, valid_until, phase, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO criteria_catalogs (name, description, valid_from, valid_until, phase, created_by, scoring_strategy, level_rounding)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -34,6 +39,8 @@ func (r *CatalogRepository) CreateCatalog(catalog *models.CriteriaCatalog) error
 		catalog.ValidUntil,
 		catalog.Phase,
 		catalog.CreatedBy,
+		catalog.ScoringStrategy,
+		catalog.LevelRounding,
 	).Scan(&catalog.ID, &catalog.CreatedAt, &catalog.UpdatedAt)
 
 	return err
@@ -43,7 +50,8 @@ func (r *CatalogRepository) CreateCatalog(catalog *models.CriteriaCatalog) error
 func (r *CatalogRepository) GetCatalogByID(id uint) (*models.CriteriaCatalog, error) {
 	query := `
 		SELECT id, name, description, valid_from, valid_until, phase, created_by,
-		       created_at, updated_at, published_at, archived_at
+		       created_at, updated_at, published_at, archived_at, source_catalog_id,
+		       scoring_strategy, level_rounding
 		FROM criteria_catalogs
 		WHERE id = $1
 	`
@@ -61,6 +69,9 @@ func (r *CatalogRepository) GetCatalogByID(id uint) (*models.CriteriaCatalog, er
 		&catalog.UpdatedAt,
 		&catalog.PublishedAt,
 		&catalog.ArchivedAt,
+		&catalog.SourceCatalogID,
+		&catalog.ScoringStrategy,
+		&catalog.LevelRounding,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -74,7 +85,8 @@ func (r *CatalogRepository) GetCatalogByID(id uint) (*models.CriteriaCatalog, er
 func (r *CatalogRepository) GetAllCatalogs() ([]models.CriteriaCatalog, error) {
 	query := `
 		SELECT id, name, description, valid_from, valid_until, phase, created_by,
-		       created_at, updated_at, published_at, archived_at
+		       created_at, updated_at, published_at, archived_at, source_catalog_id,
+		       scoring_strategy, level_rounding
 		FROM criteria_catalogs
 		ORDER BY valid_from DESC, created_at DESC
 	`
@@ -100,6 +112,9 @@ func (r *CatalogRepository) GetAllCatalogs() ([]models.CriteriaCatalog, error) {
 			&catalog.UpdatedAt,
 			&catalog.PublishedAt,
 			&catalog.ArchivedAt,
+			&catalog.SourceCatalogID,
+			&catalog.ScoringStrategy,
+			&catalog.LevelRounding,
 		)
 		if err != nil {
 			return nil, err
@@ -114,7 +129,8 @@ func (r *CatalogRepository) GetAllCatalogs() ([]models.CriteriaCatalog, error) {
 func (r *CatalogRepository) GetCatalogsByPhase(phase string) ([]models.CriteriaCatalog, error) {
 	query := `
 		SELECT id, name, description, valid_from, valid_until, phase, created_by,
-		       created_at, updated_at, published_at, archived_at
+		       created_at, updated_at, published_at, archived_at, source_catalog_id,
+		       scoring_strategy, level_rounding
 		FROM criteria_catalogs
 		WHERE phase = $1
 		ORDER BY valid_from DESC, created_at DESC
@@ -141,6 +157,9 @@ func (r *CatalogRepository) GetCatalogsByPhase(phase string) ([]models.CriteriaC
 			&catalog.UpdatedAt,
 			&catalog.PublishedAt,
 			&catalog.ArchivedAt,
+			&catalog.SourceCatalogID,
+			&catalog.ScoringStrategy,
+			&catalog.LevelRounding,
 		)
 		if err != nil {
 			return nil, err
@@ -183,8 +202,9 @@ func (r *CatalogRepository) CheckOverlappingCatalogs(validFrom, validUntil time.
 func (r *CatalogRepository) UpdateCatalog(catalog *models.CriteriaCatalog) error {
 	query := `
 		UPDATE criteria_catalogs
-		SET name = $1, description = $2, valid_from = $3, valid_until = $4, phase = $5
-		WHERE id = $6
+		SET name = $1, description = $2, valid_from = $3, valid_until = $4, phase = $5,
+		    scoring_strategy = $6, level_rounding = $7
+		WHERE id = $8
 		RETURNING updated_at
 	`
 
@@ -195,19 +215,36 @@ func (r *CatalogRepository) UpdateCatalog(catalog *models.CriteriaCatalog) error
 		catalog.ValidFrom,
 		catalog.ValidUntil,
 		catalog.Phase,
+		catalog.ScoringStrategy,
+		catalog.LevelRounding,
 		catalog.ID,
 	).Scan(&catalog.UpdatedAt)
 
 	return err
 }
 
+// LockCatalogPhase locks the catalog row until the end of the transaction the repository is bound to
+// (WithTx) and returns the current phase, so phase checks and the following writes cannot interleave
+// with other phase changes. It returns "" if the catalog does not exist.
+func (r *CatalogRepository) LockCatalogPhase(id uint) (string, error) {
+	var phase string
+	err := r.db.QueryRow(`SELECT phase FROM criteria_catalogs WHERE id = $1 FOR UPDATE`, id).Scan(&phase)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to lock catalog: %w", err)
+	}
+	return phase, nil
+}
+
 // UpdateCatalogPhase updates the phase of a catalog
 func (r *CatalogRepository) UpdateCatalogPhase(id uint, phase string) error {
 	query := `UPDATE criteria_catalogs SET phase = $1`
 	args := []interface{}{phase, id}
 
 	switch phase {
-	case "review":
+	case "active":
 		query += `, published_at = $3 WHERE id = $2`
 		args = []interface{}{phase, id, time.Now()}
 	case "archived":
@@ -330,11 +367,16 @@ func (r *CatalogRepository) UpdateCategory(category *models.Category) error {
 	return err
 }
 
-// DeleteCategory deletes a category
+// DeleteCategory deletes a category and the translations of it and its children
 func (r *CatalogRepository) DeleteCategory(id uint) error {
-	query := `DELETE FROM categories WHERE id = $1`
-	_, err := r.db.Exec(query, id)
-	return err
+	// The category's paths and descriptions are deleted by ON DELETE CASCADE
+	return r.deleteWithTranslations(`
+		DELETE FROM catalog_translations
+		WHERE (entity_type = 'category' AND entity_id = $1)
+		   OR (entity_type = 'path' AND entity_id IN (SELECT id FROM paths WHERE category_id = $1))
+		   OR (entity_type = 'description' AND entity_id IN (
+		       SELECT d.id FROM path_level_descriptions d JOIN paths p ON p.id = d.path_id WHERE p.category_id = $1))
+	`, `DELETE FROM categories WHERE id = $1`, id)
 }
 
 // CreateLevel creates a new level
@@ -412,18 +454,21 @@ func (r *CatalogRepository) UpdateLevel(level *models.Level) error {
 	return err
 }
 
-// DeleteLevel deletes a level
+// DeleteLevel deletes a level and the translations of it and its children
 func (r *CatalogRepository) DeleteLevel(id uint) error {
-	query := `DELETE FROM levels WHERE id = $1`
-	_, err := r.db.Exec(query, id)
-	return err
+	// The level's descriptions are deleted by ON DELETE CASCADE
+	return r.deleteWithTranslations(`
+		DELETE FROM catalog_translations
+		WHERE (entity_type = 'level' AND entity_id = $1)
+		   OR (entity_type = 'description' AND entity_id IN (SELECT id FROM path_level_descriptions WHERE level_id = $1))
+	`, `DELETE FROM levels WHERE id = $1`, id)
 }
 
 // CreatePath creates a new path
 func (r *CatalogRepository) CreatePath(path *models.Path) error {
 	query := `
-		INSERT INTO paths (category_id, name, description, sort_order)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO paths (category_id, name, description, sort_order, weight)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -433,6 +478,7 @@ func (r *CatalogRepository) CreatePath(path *models.Path) error {
 		path.Name,
 		path.Description,
 		path.SortOrder,
+		path.Weight,
 	).Scan(&path.ID, &path.CreatedAt, &path.UpdatedAt)
 
 	return err
@@ -441,7 +487,7 @@ func (r *CatalogRepository) CreatePath(path *models.Path) error {
 // GetPathsByCategoryID retrieves all paths for a category
 func (r *CatalogRepository) GetPathsByCategoryID(categoryID uint) ([]models.Path, error) {
 	query := `
-		SELECT id, category_id, name, description, sort_order, created_at, updated_at
+		SELECT id, category_id, name, description, sort_order, weight, created_at, updated_at
 		FROM paths
 		WHERE category_id = $1
 		ORDER BY sort_order, name
@@ -462,6 +508,7 @@ func (r *CatalogRepository) GetPathsByCategoryID(categoryID uint) ([]models.Path
 			&path.Name,
 			&path.Description,
 			&path.SortOrder,
+			&path.Weight,
 			&path.CreatedAt,
 			&path.UpdatedAt,
 		)
@@ -478,8 +525,8 @@ func (r *CatalogRepository) GetPathsByCategoryID(categoryID uint) ([]models.Path
 func (r *CatalogRepository) UpdatePath(path *models.Path) error {
 	query := `
 		UPDATE paths
-		SET name = $1, description = $2, sort_order = $3
-		WHERE id = $4
+		SET name = $1, description = $2, sort_order = $3, weight = $4
+		WHERE id = $5
 		RETURNING updated_at
 	`
 
@@ -488,17 +535,21 @@ func (r *CatalogRepository) UpdatePath(path *models.Path) error {
 		path.Name,
 		path.Description,
 		path.SortOrder,
+		path.Weight,
 		path.ID,
 	).Scan(&path.UpdatedAt)
 
 	return err
 }
 
-// DeletePath deletes a path
+// DeletePath deletes a path and the translations of it and its children
 func (r *CatalogRepository) DeletePath(id uint) error {
-	query := `DELETE FROM paths WHERE id = $1`
-	_, err := r.db.Exec(query, id)
-	return err
+	// The path's descriptions are deleted by ON DELETE CASCADE
+	return r.deleteWithTranslations(`
+		DELETE FROM catalog_translations
+		WHERE (entity_type = 'path' AND entity_id = $1)
+		   OR (entity_type = 'description' AND entity_id IN (SELECT id FROM path_level_descriptions WHERE path_id = $1))
+	`, `DELETE FROM paths WHERE id = $1`, id)
 }
 
 // CreatePathLevelDescription creates or updates a description for a path-level combination
@@ -593,11 +644,31 @@ func (r *CatalogRepository) GetDescriptionsByCatalogID(catalogID uint) ([]models
 	return descriptions, rows.Err()
 }
 
-// DeletePathLevelDescription deletes a description
+// DeletePathLevelDescription deletes a description and its translations
 func (r *CatalogRepository) DeletePathLevelDescription(id uint) error {
-	query := `DELETE FROM path_level_descriptions WHERE id = $1`
-	_, err := r.db.Exec(query, id)
-	return err
+	return r.deleteWithTranslations(`
+		DELETE FROM catalog_translations WHERE entity_type = 'description' AND entity_id = $1
+	`, `DELETE FROM path_level_descriptions WHERE id = $1`, id)
+}
+
+// deleteWithTranslations deletes an entity and its translations in one transaction.
+// catalog_translations.entity_id references different tables, so it has no foreign key that could cascade.
+func (r *CatalogRepository) deleteWithTranslations(translationsQuery, entityQuery string, id uint) error {
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Translations first, the query resolves the entity's children before the cascade removes them
+	if _, err := tx.Exec(translationsQuery, id); err != nil {
+		return fmt.Errorf("failed to delete translations: %w", err)
+	}
+	if _, err := tx.Exec(entityQuery, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // LogChange logs a change to the catalog
@@ -660,6 +731,192 @@ func (r *CatalogRepository) GetChangesByCatalogID(catalogID uint) ([]models.Cata
 	return changes, rows.Err()
 }
 
+// GetTranslationsByCatalogID retrieves the translations of a catalog, optionally filtered by locale
+func (r *CatalogRepository) GetTranslationsByCatalogID(catalogID uint, locale string) ([]models.CatalogTranslation, error) {
+	query := `
+		SELECT id, catalog_id, entity_type, entity_id, locale, field_name, value, created_at, updated_at
+		FROM catalog_translations
+		WHERE catalog_id = $1 AND ($2 = '' OR locale = $2)
+		ORDER BY locale, entity_type, entity_id, field_name
+	`
+
+	rows, err := r.db.Query(query, catalogID, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var translations []models.CatalogTranslation
+	for rows.Next() {
+		var translation models.CatalogTranslation
+		err := rows.Scan(
+			&translation.ID,
+			&translation.CatalogID,
+			&translation.EntityType,
+			&translation.EntityID,
+			&translation.Locale,
+			&translation.FieldName,
+			&translation.Value,
+			&translation.CreatedAt,
+			&translation.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+
+	return translations, rows.Err()
+}
+
+// UpsertTranslation creates or updates the translation of an entity field
+func (r *CatalogRepository) UpsertTranslation(translation *models.CatalogTranslation) error {
+	query := `
+		INSERT INTO catalog_translations (catalog_id, entity_type, entity_id, locale, field_name, value)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (entity_type, entity_id, locale, field_name)
+		DO UPDATE SET value = $6
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		translation.CatalogID,
+		translation.EntityType,
+		translation.EntityID,
+		translation.Locale,
+		translation.FieldName,
+		translation.Value,
+	).Scan(&translation.ID, &translation.CreatedAt, &translation.UpdatedAt)
+
+	return err
+}
+
+// DeleteTranslation deletes the translation of an entity field
+func (r *CatalogRepository) DeleteTranslation(entityType string, entityID uint, locale, fieldName string) error {
+	query := `DELETE FROM catalog_translations WHERE entity_type = $1 AND entity_id = $2 AND locale = $3 AND field_name = $4`
+	_, err := r.db.Exec(query, entityType, entityID, locale, fieldName)
+	return err
+}
+
+// CreateApproval records an approval of a catalog; returns false if the user already approved it
+func (r *CatalogRepository) CreateApproval(catalogID, userID uint) (bool, error) {
+	query := `
+		INSERT INTO catalog_approvals (catalog_id, approved_by_user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (catalog_id, approved_by_user_id) DO NOTHING
+	`
+	result, err := r.db.Exec(query, catalogID, userID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// DeleteApprovalsByCatalogID removes all approvals of a catalog
+func (r *CatalogRepository) DeleteApprovalsByCatalogID(catalogID uint) error {
+	query := `DELETE FROM catalog_approvals WHERE catalog_id = $1`
+	_, err := r.db.Exec(query, catalogID)
+	return err
+}
+
+// GetApprovalsByCatalogID retrieves all approvals of a catalog with user names
+func (r *CatalogRepository) GetApprovalsByCatalogID(catalogID uint) ([]models.CatalogApproval, error) {
+	query := `
+		SELECT
+			a.id,
+			a.catalog_id,
+			a.approved_by_user_id,
+			COALESCE(u.first_name || ' ' || u.last_name, u.email) as approved_by_name,
+			a.approved_at
+		FROM catalog_approvals a
+		LEFT JOIN users u ON a.approved_by_user_id = u.id
+		WHERE a.catalog_id = $1
+		ORDER BY a.approved_at ASC
+	`
+
+	rows, err := r.db.Query(query, catalogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	approvals := []models.CatalogApproval{}
+	for rows.Next() {
+		var approval models.CatalogApproval
+		err := rows.Scan(
+			&approval.ID,
+			&approval.CatalogID,
+			&approval.ApprovedByUserID,
+			&approval.ApprovedByName,
+			&approval.ApprovedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, rows.Err()
+}
+
+// CreateReviewComment creates a review comment on a catalog
+func (r *CatalogRepository) CreateReviewComment(comment *models.CatalogReviewComment) error {
+	query := `
+		INSERT INTO catalog_review_comments (catalog_id, created_by_user_id, comment)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	return r.db.QueryRow(query, comment.CatalogID, comment.CreatedByUserID, comment.Comment).Scan(&comment.ID, &comment.CreatedAt)
+}
+
+// GetReviewCommentsByCatalogID retrieves all review comments of a catalog with user names
+func (r *CatalogRepository) GetReviewCommentsByCatalogID(catalogID uint) ([]models.CatalogReviewComment, error) {
+	query := `
+		SELECT
+			c.id,
+			c.catalog_id,
+			c.created_by_user_id,
+			COALESCE(u.first_name || ' ' || u.last_name, u.email, '') as created_by_name,
+			c.comment,
+			c.created_at
+		FROM catalog_review_comments c
+		LEFT JOIN users u ON c.created_by_user_id = u.id
+		WHERE c.catalog_id = $1
+		ORDER BY c.created_at ASC
+	`
+
+	rows, err := r.db.Query(query, catalogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []models.CatalogReviewComment{}
+	for rows.Next() {
+		var comment models.CatalogReviewComment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.CatalogID,
+			&comment.CreatedByUserID,
+			&comment.CreatedByName,
+			&comment.Comment,
+			&comment.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
 // GetCatalogWithDetails retrieves a catalog with all nested entities
 func (r *CatalogRepository) GetCatalogWithDetails(id uint) (*models.CatalogWithDetails, error) {
 	catalog, err := r.GetCatalogByID(id)
@@ -714,3 +971,149 @@ func (r *CatalogRepository) GetCatalogWithDetails(id uint) (*models.CatalogWithD
 
 	return catalogWithDetails, nil
 }
+
+// CreateCatalogWithDetails creates a catalog together with all levels, categories, paths and
+// descriptions in a single transaction. Description level IDs are interpreted as references to
+// the IDs of details.Levels and are remapped to the newly created levels. On success all IDs and
+// timestamps in details are replaced with the values of the new rows.
+func (r *CatalogRepository) CreateCatalogWithDetails(details *models.CatalogWithDetails) error {
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	catalog := &details.CriteriaCatalog
+	err = tx.QueryRow(`
+		INSERT INTO criteria_catalogs (name, description, valid_from, valid_until, phase, created_by, source_catalog_id, scoring_strategy, level_rounding)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`, catalog.Name, catalog.Description, catalog.ValidFrom, catalog.ValidUntil, catalog.Phase, catalog.CreatedBy, catalog.SourceCatalogID,
+		catalog.ScoringStrategy, catalog.LevelRounding,
+	).Scan(&catalog.ID, &catalog.CreatedAt, &catalog.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create catalog: %w", err)
+	}
+
+	levelIDMap := make(map[uint]uint, len(details.Levels)) // [oldID]newID
+	for i := range details.Levels {
+		level := &details.Levels[i]
+		oldID := level.ID
+		level.CatalogID = catalog.ID
+		err = tx.QueryRow(`
+			INSERT INTO levels (catalog_id, name, level_number, description)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at, updated_at
+		`, level.CatalogID, level.Name, level.LevelNumber, level.Description,
+		).Scan(&level.ID, &level.CreatedAt, &level.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create level '%s': %w", level.Name, err)
+		}
+		levelIDMap[oldID] = level.ID
+	}
+
+	for i := range details.Categories {
+		category := &details.Categories[i]
+		category.CatalogID = catalog.ID
+		err = tx.QueryRow(`
+			INSERT INTO categories (catalog_id, name, description, sort_order, weight)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at, updated_at
+		`, category.CatalogID, category.Name, category.Description, category.SortOrder, category.Weight,
+		).Scan(&category.ID, &category.CreatedAt, &category.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create category '%s': %w", category.Name, err)
+		}
+
+		for j := range category.Paths {
+			path := &category.Paths[j]
+			path.CategoryID = category.ID
+			err = tx.QueryRow(`
+				INSERT INTO paths (category_id, name, description, sort_order, weight)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING id, created_at, updated_at
+			`, path.CategoryID, path.Name, path.Description, path.SortOrder, path.Weight,
+			).Scan(&path.ID, &path.CreatedAt, &path.UpdatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to create path '%s': %w", path.Name, err)
+			}
+
+			for k := range path.Descriptions {
+				desc := &path.Descriptions[k]
+				newLevelID, ok := levelIDMap[desc.LevelID]
+				if !ok {
+					return fmt.Errorf("description of path '%s' references unknown level %d", path.Name, desc.LevelID)
+				}
+				desc.PathID = path.ID
+				desc.LevelID = newLevelID
+				err = tx.QueryRow(`
+					INSERT INTO path_level_descriptions (path_id, level_id, description)
+					VALUES ($1, $2, $3)
+					RETURNING id, created_at, updated_at
+				`, desc.PathID, desc.LevelID, desc.Description,
+				).Scan(&desc.ID, &desc.CreatedAt, &desc.UpdatedAt)
+				if err != nil {
+					return fmt.Errorf("failed to create description for path '%s': %w", path.Name, err)
+				}
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetCatalogLineage retrieves a catalog and all catalogs it was (transitively) cloned from,
+// starting with the given catalog and ending with the oldest ancestor
+func (r *CatalogRepository) GetCatalogLineage(id uint) ([]models.CriteriaCatalog, error) {
+	query := `
+		WITH RECURSIVE lineage AS (
+			SELECT id, source_catalog_id, 0 AS depth
+			FROM criteria_catalogs
+			WHERE id = $1
+			UNION ALL
+			SELECT c.id, c.source_catalog_id, l.depth + 1
+			FROM criteria_catalogs c
+			JOIN lineage l ON c.id = l.source_catalog_id
+			WHERE l.depth < 100
+		)
+		SELECT c.id, c.name, c.description, c.valid_from, c.valid_until, c.phase, c.created_by,
+		       c.created_at, c.updated_at, c.published_at, c.archived_at, c.source_catalog_id,
+		       c.scoring_strategy, c.level_rounding
+		FROM lineage l
+		JOIN criteria_catalogs c ON c.id = l.id
+		ORDER BY l.depth
+	`
+
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var catalogs []models.CriteriaCatalog
+	for rows.Next() {
+		var catalog models.CriteriaCatalog
+		err := rows.Scan(
+			&catalog.ID,
+			&catalog.Name,
+			&catalog.Description,
+			&catalog.ValidFrom,
+			&catalog.ValidUntil,
+			&catalog.Phase,
+			&catalog.CreatedBy,
+			&catalog.CreatedAt,
+			&catalog.UpdatedAt,
+			&catalog.PublishedAt,
+			&catalog.ArchivedAt,
+			&catalog.SourceCatalogID,
+			&catalog.ScoringStrategy,
+			&catalog.LevelRounding,
+		)
+		if err != nil {
+			return nil, err
+		}
+		catalogs = append(catalogs, catalog)
+	}
+
+	return catalogs, rows.Err()
+}
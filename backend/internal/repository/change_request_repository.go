@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"new-pay/internal/models"
+)
+
+// ChangeRequestRepository handles reviewer change requests of self-assessments
+type ChangeRequestRepository struct {
+	db DBTX
+}
+
+// NewChangeRequestRepository creates a new change request repository
+func NewChangeRequestRepository(db *sql.DB) *ChangeRequestRepository {
+	return &ChangeRequestRepository{db: db}
+}
+
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *ChangeRequestRepository) WithTx(tx *sql.Tx) *ChangeRequestRepository {
+	return &ChangeRequestRepository{db: tx}
+}
+
+// Create stores a new change request
+func (r *ChangeRequestRepository) Create(request *models.ChangeRequest) error {
+	query := `
+		INSERT INTO assessment_change_requests (assessment_id, requested_by, from_status, encrypted_message_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(
+		query,
+		request.AssessmentID,
+		request.RequestedBy,
+		request.FromStatus,
+		request.EncryptedMessageID,
+	).Scan(&request.ID, &request.CreatedAt)
+}
+
+// GetByAssessment retrieves all change requests of a self-assessment, newest first
+func (r *ChangeRequestRepository) GetByAssessment(assessmentID uint) ([]models.ChangeRequest, error) {
+	query := `
+		SELECT cr.id, cr.assessment_id, cr.requested_by, COALESCE(CONCAT(u.first_name, ' ', u.last_name), ''),
+			cr.from_status, cr.encrypted_message_id, cr.created_at, cr.resolved_at
+		FROM assessment_change_requests cr
+		LEFT JOIN users u ON cr.requested_by = u.id
+		WHERE cr.assessment_id = $1
+		ORDER BY cr.created_at DESC, cr.id DESC
+	`
+	rows, err := r.db.Query(query, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []models.ChangeRequest{}
+	for rows.Next() {
+		var request models.ChangeRequest
+		if err := rows.Scan(
+			&request.ID,
+			&request.AssessmentID,
+			&request.RequestedBy,
+			&request.RequesterName,
+			&request.FromStatus,
+			&request.EncryptedMessageID,
+			&request.CreatedAt,
+			&request.ResolvedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan change request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, rows.Err()
+}
+
+// ResolveOpen marks all open change requests of a self-assessment as resolved
+func (r *ChangeRequestRepository) ResolveOpen(assessmentID uint) error {
+	query := `
+		UPDATE assessment_change_requests
+		SET resolved_at = CURRENT_TIMESTAMP
+		WHERE assessment_id = $1 AND resolved_at IS NULL
+	`
+	if _, err := r.db.Exec(query, assessmentID); err != nil {
+		return fmt.Errorf("failed to resolve change requests: %w", err)
+	}
+	return nil
+}
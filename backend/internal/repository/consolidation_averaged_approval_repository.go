@@ -6,13 +6,18 @@ import (
 )
 
 type ConsolidationAveragedApprovalRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 func NewConsolidationAveragedApprovalRepository(db *sql.DB) *ConsolidationAveragedApprovalRepository {
 	return &ConsolidationAveragedApprovalRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *ConsolidationAveragedApprovalRepository) WithTx(tx *sql.Tx) *ConsolidationAveragedApprovalRepository {
+	return &ConsolidationAveragedApprovalRepository{db: tx}
+}
+
 // CreateApproval creates a new approval for an averaged response
 func (r *ConsolidationAveragedApprovalRepository) CreateApproval(assessmentID, categoryID, userID uint) error {
 	query := `
@@ -6,13 +6,18 @@ import (
 )
 
 type ConsolidationOverrideApprovalRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 func NewConsolidationOverrideApprovalRepository(db *sql.DB) *ConsolidationOverrideApprovalRepository {
 	return &ConsolidationOverrideApprovalRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *ConsolidationOverrideApprovalRepository) WithTx(tx *sql.Tx) *ConsolidationOverrideApprovalRepository {
+	return &ConsolidationOverrideApprovalRepository{db: tx}
+}
+
 // CreateApproval creates a new approval for an override
 func (r *ConsolidationOverrideApprovalRepository) CreateApproval(overrideID, userID uint) error {
 	query := `
@@ -2,12 +2,13 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"new-pay/internal/models"
 )
 
 // ConsolidationOverrideRepository handles database operations for consolidation overrides
 type ConsolidationOverrideRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewConsolidationOverrideRepository creates a new consolidation override repository
@@ -15,8 +16,14 @@ func NewConsolidationOverrideRepository(db *sql.DB) *ConsolidationOverrideReposi
 	return &ConsolidationOverrideRepository{db: db}
 }
 
-// CreateOrUpdate creates or updates a consolidation override
-func (r *ConsolidationOverrideRepository) CreateOrUpdate(override *models.ConsolidationOverride) error {
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *ConsolidationOverrideRepository) WithTx(tx *sql.Tx) *ConsolidationOverrideRepository {
+	return &ConsolidationOverrideRepository{db: tx}
+}
+
+// CreateOrUpdate creates or updates a consolidation override. With expectedVersion set, an existing
+// override is only updated if it still has that version (ErrVersionConflict otherwise).
+func (r *ConsolidationOverrideRepository) CreateOrUpdate(override *models.ConsolidationOverride, expectedVersion *int) error {
 	query := `
 		INSERT INTO consolidation_overrides (
 			assessment_id, category_id, path_id, level_id, encrypted_justification_id, 
@@ -28,11 +35,13 @@ func (r *ConsolidationOverrideRepository) CreateOrUpdate(override *models.Consol
 			level_id = EXCLUDED.level_id,
 			encrypted_justification_id = EXCLUDED.encrypted_justification_id,
 			created_by_user_id = EXCLUDED.created_by_user_id,
+			version = consolidation_overrides.version + 1,
 			updated_at = NOW()
-		RETURNING id, created_at, updated_at
+		WHERE $7::INTEGER IS NULL OR consolidation_overrides.version = $7
+		RETURNING id, version, created_at, updated_at
 	`
 
-	return r.db.QueryRow(
+	err := r.db.QueryRow(
 		query,
 		override.AssessmentID,
 		override.CategoryID,
@@ -40,14 +49,19 @@ func (r *ConsolidationOverrideRepository) CreateOrUpdate(override *models.Consol
 		override.LevelID,
 		override.EncryptedJustificationID,
 		override.CreatedByUserID,
-	).Scan(&override.ID, &override.CreatedAt, &override.UpdatedAt)
+		expectedVersion,
+	).Scan(&override.ID, &override.Version, &override.CreatedAt, &override.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrVersionConflict
+	}
+	return err
 }
 
 // GetByAssessment retrieves all consolidation overrides for an assessment
 func (r *ConsolidationOverrideRepository) GetByAssessment(assessmentID uint) ([]models.ConsolidationOverride, error) {
 	query := `
 		SELECT id, assessment_id, category_id, path_id, level_id, 
-		       encrypted_justification_id, created_by_user_id, created_at, updated_at
+		       encrypted_justification_id, created_by_user_id, version, created_at, updated_at
 		FROM consolidation_overrides
 		WHERE assessment_id = $1
 		ORDER BY category_id
@@ -70,6 +84,7 @@ func (r *ConsolidationOverrideRepository) GetByAssessment(assessmentID uint) ([]
 			&override.LevelID,
 			&override.EncryptedJustificationID,
 			&override.CreatedByUserID,
+			&override.Version,
 			&override.CreatedAt,
 			&override.UpdatedAt,
 		)
@@ -86,7 +101,7 @@ func (r *ConsolidationOverrideRepository) GetByAssessment(assessmentID uint) ([]
 func (r *ConsolidationOverrideRepository) GetByAssessmentAndCategory(assessmentID, categoryID uint) (*models.ConsolidationOverride, error) {
 	query := `
 		SELECT id, assessment_id, category_id, path_id, level_id, 
-		       encrypted_justification_id, created_by_user_id, created_at, updated_at
+		       encrypted_justification_id, created_by_user_id, version, created_at, updated_at
 		FROM consolidation_overrides
 		WHERE assessment_id = $1 AND category_id = $2
 	`
@@ -100,6 +115,7 @@ func (r *ConsolidationOverrideRepository) GetByAssessmentAndCategory(assessmentI
 		&override.LevelID,
 		&override.EncryptedJustificationID,
 		&override.CreatedByUserID,
+		&override.Version,
 		&override.CreatedAt,
 		&override.UpdatedAt,
 	)
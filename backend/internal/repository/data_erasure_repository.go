@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"new-pay/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// DataErasureGenesisHash is the previous hash of the first tombstone
+const DataErasureGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// DataErasureRepository handles the append-only tombstones of crypto-shredding erasures
+type DataErasureRepository struct {
+	db *sql.DB
+}
+
+// NewDataErasureRepository creates a new data erasure repository
+func NewDataErasureRepository(db *sql.DB) *DataErasureRepository {
+	return &DataErasureRepository{db: db}
+}
+
+const dataErasureColumns = `
+	id, user_id, scope, reason, user_key_destroyed, user_key_retained_reason,
+	erased_by, erased_at, prev_hash, tombstone_hash
+`
+
+// Create stores a tombstone with its processes. The previous tombstone hash is read under a
+// table lock so the chain cannot fork; computeHash calculates the tombstone hash from it.
+func (r *DataErasureRepository) Create(erasure *models.DataErasure, computeHash func(prevHash string) string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`LOCK TABLE data_erasures IN EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("failed to lock tombstones: %w", err)
+	}
+
+	prevHash := DataErasureGenesisHash
+	err = tx.QueryRow(`SELECT tombstone_hash FROM data_erasures ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get previous tombstone: %w", err)
+	}
+	erasure.PrevHash = prevHash
+	erasure.TombstoneHash = computeHash(prevHash)
+
+	err = tx.QueryRow(`
+		INSERT INTO data_erasures (
+			user_id, scope, reason, user_key_destroyed, user_key_retained_reason,
+			erased_by, erased_at, prev_hash, tombstone_hash
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`,
+		erasure.UserID,
+		erasure.Scope,
+		erasure.Reason,
+		erasure.UserKeyDestroyed,
+		erasure.UserKeyRetainedReason,
+		erasure.ErasedBy,
+		erasure.ErasedAt,
+		erasure.PrevHash,
+		erasure.TombstoneHash,
+	).Scan(&erasure.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create tombstone: %w", err)
+	}
+
+	for _, process := range erasure.Processes {
+		if _, err := tx.Exec(`
+			INSERT INTO data_erasure_processes (erasure_id, process_id, record_count, chain_head, chain_valid, key_hashes)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, erasure.ID, process.ProcessID, process.RecordCount, process.ChainHead, process.ChainValid, pq.Array(process.KeyHashes)); err != nil {
+			return fmt.Errorf("failed to create tombstone process %s: %w", process.ProcessID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a tombstone with its processes
+func (r *DataErasureRepository) GetByID(id uint) (*models.DataErasure, error) {
+	erasures, err := r.list(`SELECT `+dataErasureColumns+` FROM data_erasures WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(erasures) == 0 {
+		return nil, fmt.Errorf("erasure not found")
+	}
+	return &erasures[0], nil
+}
+
+// List retrieves tombstones, newest first, optionally filtered by user (0 = all)
+func (r *DataErasureRepository) List(userID uint) ([]models.DataErasure, error) {
+	return r.list(`
+		SELECT `+dataErasureColumns+` FROM data_erasures
+		WHERE ($1 = 0 OR user_id = $1)
+		ORDER BY id DESC
+	`, userID)
+}
+
+// ListChain retrieves all tombstones in chain order
+func (r *DataErasureRepository) ListChain() ([]models.DataErasure, error) {
+	return r.list(`SELECT ` + dataErasureColumns + ` FROM data_erasures ORDER BY id ASC`)
+}
+
+// IsProcessErased reports whether the key of a process was destroyed by a recorded erasure
+func (r *DataErasureRepository) IsProcessErased(processID string) (bool, error) {
+	var erased bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM data_erasure_processes WHERE process_id = $1)
+	`, processID).Scan(&erased)
+	if err != nil {
+		return false, fmt.Errorf("failed to check erased process: %w", err)
+	}
+	return erased, nil
+}
+
+func (r *DataErasureRepository) list(query string, args ...interface{}) ([]models.DataErasure, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list erasures: %w", err)
+	}
+	defer rows.Close()
+
+	erasures := []models.DataErasure{}
+	for rows.Next() {
+		var erasure models.DataErasure
+		err := rows.Scan(
+			&erasure.ID,
+			&erasure.UserID,
+			&erasure.Scope,
+			&erasure.Reason,
+			&erasure.UserKeyDestroyed,
+			&erasure.UserKeyRetainedReason,
+			&erasure.ErasedBy,
+			&erasure.ErasedAt,
+			&erasure.PrevHash,
+			&erasure.TombstoneHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan erasure: %w", err)
+		}
+		erasures = append(erasures, erasure)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range erasures {
+		processes, err := r.listProcesses(erasures[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		erasures[i].Processes = processes
+	}
+	return erasures, nil
+}
+
+func (r *DataErasureRepository) listProcesses(erasureID uint) ([]models.DataErasureProcess, error) {
+	rows, err := r.db.Query(`
+		SELECT process_id, record_count, chain_head, chain_valid, key_hashes
+		FROM data_erasure_processes
+		WHERE erasure_id = $1
+		ORDER BY process_id
+	`, erasureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list erasure processes: %w", err)
+	}
+	defer rows.Close()
+
+	processes := []models.DataErasureProcess{}
+	for rows.Next() {
+		var process models.DataErasureProcess
+		if err := rows.Scan(&process.ProcessID, &process.RecordCount, &process.ChainHead, &process.ChainValid, pq.Array(&process.KeyHashes)); err != nil {
+			return nil, fmt.Errorf("failed to scan erasure process: %w", err)
+		}
+		processes = append(processes, process)
+	}
+	return processes, rows.Err()
+}
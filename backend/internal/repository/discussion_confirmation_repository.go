@@ -6,18 +6,23 @@ import (
 )
 
 type DiscussionConfirmationRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 func NewDiscussionConfirmationRepository(db *sql.DB) *DiscussionConfirmationRepository {
 	return &DiscussionConfirmationRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *DiscussionConfirmationRepository) WithTx(tx *sql.Tx) *DiscussionConfirmationRepository {
+	return &DiscussionConfirmationRepository{db: tx}
+}
+
 // Create creates a new discussion confirmation
 func (r *DiscussionConfirmationRepository) Create(confirmation *models.DiscussionConfirmation) error {
 	query := `
-		INSERT INTO discussion_confirmations (assessment_id, user_id, user_type, confirmed_at)
-		VALUES ($1, $2, $3, NOW())
+		INSERT INTO discussion_confirmations (assessment_id, user_id, user_type, meeting_id, confirmed_at)
+		VALUES ($1, $2, $3, $4, NOW())
 		RETURNING id, confirmed_at, created_at
 	`
 	return r.db.QueryRow(
@@ -25,6 +30,7 @@ func (r *DiscussionConfirmationRepository) Create(confirmation *models.Discussio
 		confirmation.AssessmentID,
 		confirmation.UserID,
 		confirmation.UserType,
+		confirmation.MeetingID,
 	).Scan(&confirmation.ID, &confirmation.ConfirmedAt, &confirmation.CreatedAt)
 }
 
@@ -32,7 +38,7 @@ func (r *DiscussionConfirmationRepository) Create(confirmation *models.Discussio
 func (r *DiscussionConfirmationRepository) GetByAssessment(assessmentID uint) ([]models.DiscussionConfirmation, error) {
 	query := `
 		SELECT 
-			dc.id, dc.assessment_id, dc.user_id, dc.user_type, dc.confirmed_at, dc.created_at,
+			dc.id, dc.assessment_id, dc.user_id, dc.user_type, dc.meeting_id, dc.confirmed_at, dc.created_at,
 			u.first_name || ' ' || u.last_name as user_name, u.email as user_email
 		FROM discussion_confirmations dc
 		JOIN users u ON dc.user_id = u.id
@@ -53,6 +59,7 @@ func (r *DiscussionConfirmationRepository) GetByAssessment(assessmentID uint) ([
 			&c.AssessmentID,
 			&c.UserID,
 			&c.UserType,
+			&c.MeetingID,
 			&c.ConfirmedAt,
 			&c.CreatedAt,
 			&c.UserName,
@@ -71,7 +78,7 @@ func (r *DiscussionConfirmationRepository) GetByAssessment(assessmentID uint) ([
 func (r *DiscussionConfirmationRepository) GetByAssessmentAndUser(assessmentID, userID uint) (*models.DiscussionConfirmation, error) {
 	query := `
 		SELECT 
-			dc.id, dc.assessment_id, dc.user_id, dc.user_type, dc.confirmed_at, dc.created_at,
+			dc.id, dc.assessment_id, dc.user_id, dc.user_type, dc.meeting_id, dc.confirmed_at, dc.created_at,
 			u.first_name || ' ' || u.last_name as user_name, u.email as user_email
 		FROM discussion_confirmations dc
 		JOIN users u ON dc.user_id = u.id
@@ -83,6 +90,7 @@ func (r *DiscussionConfirmationRepository) GetByAssessmentAndUser(assessmentID,
 		&c.AssessmentID,
 		&c.UserID,
 		&c.UserType,
+		&c.MeetingID,
 		&c.ConfirmedAt,
 		&c.CreatedAt,
 		&c.UserName,
@@ -123,6 +131,14 @@ func (r *DiscussionConfirmationRepository) HasOwnerConfirmation(assessmentID uin
 	return exists, err
 }
 
+// DeleteReviewerConfirmations removes the reviewer confirmations of an assessment,
+// e.g. when the discussion result they confirmed has been superseded
+func (r *DiscussionConfirmationRepository) DeleteReviewerConfirmations(assessmentID uint) error {
+	query := `DELETE FROM discussion_confirmations WHERE assessment_id = $1 AND user_type = 'reviewer'`
+	_, err := r.db.Exec(query, assessmentID)
+	return err
+}
+
 // Delete removes a confirmation (for testing/admin purposes)
 func (r *DiscussionConfirmationRepository) Delete(id uint) error {
 	query := `DELETE FROM discussion_confirmations WHERE id = $1`
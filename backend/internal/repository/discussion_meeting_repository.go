@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"new-pay/internal/models"
+)
+
+// DiscussionMeetingRepository handles discussion meetings of self-assessments
+type DiscussionMeetingRepository struct {
+	db *sql.DB
+}
+
+// NewDiscussionMeetingRepository creates a new discussion meeting repository
+func NewDiscussionMeetingRepository(db *sql.DB) *DiscussionMeetingRepository {
+	return &DiscussionMeetingRepository{db: db}
+}
+
+// Create stores a new scheduled meeting
+func (r *DiscussionMeetingRepository) Create(meeting *models.DiscussionMeeting) error {
+	query := `
+		INSERT INTO discussion_meetings (assessment_id, uid, starts_at, ends_at, location, organizer_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, sequence, status, created_at, updated_at
+	`
+	return r.db.QueryRow(
+		query,
+		meeting.AssessmentID,
+		meeting.UID,
+		meeting.StartsAt,
+		meeting.EndsAt,
+		meeting.Location,
+		meeting.OrganizerUserID,
+	).Scan(&meeting.ID, &meeting.Sequence, &meeting.Status, &meeting.CreatedAt, &meeting.UpdatedAt)
+}
+
+// Update changes time and location of a scheduled meeting and increments its sequence
+func (r *DiscussionMeetingRepository) Update(meeting *models.DiscussionMeeting) error {
+	query := `
+		UPDATE discussion_meetings
+		SET starts_at = $1, ends_at = $2, location = $3, organizer_user_id = $4,
+			sequence = sequence + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5 AND status = 'scheduled'
+		RETURNING sequence, updated_at
+	`
+	err := r.db.QueryRow(
+		query,
+		meeting.StartsAt,
+		meeting.EndsAt,
+		meeting.Location,
+		meeting.OrganizerUserID,
+		meeting.ID,
+	).Scan(&meeting.Sequence, &meeting.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("meeting not found")
+	}
+	return err
+}
+
+// Cancel marks a scheduled meeting as cancelled and increments its sequence
+func (r *DiscussionMeetingRepository) Cancel(meeting *models.DiscussionMeeting) error {
+	query := `
+		UPDATE discussion_meetings
+		SET status = 'cancelled', sequence = sequence + 1,
+			cancelled_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'scheduled'
+		RETURNING status, sequence, cancelled_at, updated_at
+	`
+	err := r.db.QueryRow(query, meeting.ID).Scan(&meeting.Status, &meeting.Sequence, &meeting.CancelledAt, &meeting.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("meeting not found")
+	}
+	return err
+}
+
+// GetScheduledByAssessment retrieves the scheduled meeting of a self-assessment (nil if none)
+func (r *DiscussionMeetingRepository) GetScheduledByAssessment(assessmentID uint) (*models.DiscussionMeeting, error) {
+	query := `
+		SELECT m.id, m.assessment_id, m.uid, m.sequence, m.starts_at, m.ends_at, m.location, m.status,
+			m.organizer_user_id, COALESCE(CONCAT(u.first_name, ' ', u.last_name), ''),
+			m.created_at, m.updated_at, m.cancelled_at
+		FROM discussion_meetings m
+		LEFT JOIN users u ON m.organizer_user_id = u.id
+		WHERE m.assessment_id = $1 AND m.status = 'scheduled'
+	`
+	var meeting models.DiscussionMeeting
+	err := r.db.QueryRow(query, assessmentID).Scan(
+		&meeting.ID,
+		&meeting.AssessmentID,
+		&meeting.UID,
+		&meeting.Sequence,
+		&meeting.StartsAt,
+		&meeting.EndsAt,
+		&meeting.Location,
+		&meeting.Status,
+		&meeting.OrganizerUserID,
+		&meeting.OrganizerName,
+		&meeting.CreatedAt,
+		&meeting.UpdatedAt,
+		&meeting.CancelledAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discussion meeting: %w", err)
+	}
+	return &meeting, nil
+}
@@ -8,13 +8,18 @@ import (
 )
 
 type DiscussionRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 func NewDiscussionRepository(db *sql.DB) *DiscussionRepository {
 	return &DiscussionRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *DiscussionRepository) WithTx(tx *sql.Tx) *DiscussionRepository {
+	return &DiscussionRepository{db: tx}
+}
+
 // Create creates a new discussion result
 func (r *DiscussionRepository) Create(result *models.DiscussionResult) error {
 	query := `
@@ -68,14 +73,14 @@ func (r *DiscussionRepository) CreateReviewer(reviewer *models.DiscussionReviewe
 	).Scan(&reviewer.ID)
 }
 
-// GetByAssessmentID retrieves discussion result by assessment ID
+// GetByAssessmentID retrieves the active discussion result by assessment ID (superseded results are skipped)
 func (r *DiscussionRepository) GetByAssessmentID(assessmentID uint) (*models.DiscussionResult, error) {
 	var result models.DiscussionResult
 	query := `
 		SELECT id, assessment_id, weighted_overall_level_number, weighted_overall_level_id,
 			encrypted_final_comment_id, encrypted_discussion_note_id, user_approved_at, created_at, updated_at
 		FROM discussion_results
-		WHERE assessment_id = $1
+		WHERE assessment_id = $1 AND superseded_at IS NULL
 	`
 	err := r.db.QueryRow(query, assessmentID).Scan(
 		&result.ID,
@@ -187,3 +192,87 @@ func (r *DiscussionRepository) UpdateDiscussionNote(discussionResultID uint, enc
 	}
 	return nil
 }
+
+// Supersede replaces the active discussion result of an assessment with a new result in a single transaction.
+// The old result is kept and refers to its successor; category results and reviewers of the new result are stored with it.
+func (r *DiscussionRepository) Supersede(oldResultID uint, result *models.DiscussionResult, categoryResults []models.DiscussionCategoryResult, reviewers []models.DiscussionReviewer) error {
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE discussion_results
+		SET superseded_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND superseded_at IS NULL
+	`, oldResultID)
+	if err != nil {
+		return fmt.Errorf("failed to supersede discussion result: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return fmt.Errorf("discussion result has already been superseded")
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO discussion_results (
+			assessment_id, weighted_overall_level_number, weighted_overall_level_id,
+			encrypted_final_comment_id, encrypted_discussion_note_id, user_approved_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`,
+		result.AssessmentID,
+		result.WeightedOverallLevelNum,
+		result.WeightedOverallLevelID,
+		result.EncryptedFinalCommentID,
+		result.EncryptedDiscussionNoteID,
+		result.UserApprovedAt,
+	).Scan(&result.ID, &result.CreatedAt, &result.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create discussion result: %w", err)
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE discussion_results SET superseded_by_id = $1 WHERE id = $2
+	`, result.ID, oldResultID); err != nil {
+		return fmt.Errorf("failed to link superseded discussion result: %w", err)
+	}
+
+	for i := range categoryResults {
+		categoryResults[i].DiscussionResultID = result.ID
+		err := tx.QueryRow(`
+			INSERT INTO discussion_category_results (
+				discussion_result_id, category_id, user_level_id, reviewer_level_id,
+				reviewer_level_number, encrypted_justification_id, is_override
+			) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`,
+			categoryResults[i].DiscussionResultID,
+			categoryResults[i].CategoryID,
+			categoryResults[i].UserLevelID,
+			categoryResults[i].ReviewerLevelID,
+			categoryResults[i].ReviewerLevelNumber,
+			categoryResults[i].EncryptedJustificationID,
+			categoryResults[i].IsOverride,
+		).Scan(&categoryResults[i].ID)
+		if err != nil {
+			return fmt.Errorf("failed to create category result: %w", err)
+		}
+	}
+
+	for i := range reviewers {
+		reviewers[i].DiscussionResultID = result.ID
+		err := tx.QueryRow(`
+			INSERT INTO discussion_reviewers (discussion_result_id, reviewer_user_id, reviewer_name)
+			VALUES ($1, $2, $3)
+			RETURNING id
+		`, reviewers[i].DiscussionResultID, reviewers[i].ReviewerUserID, reviewers[i].ReviewerName).Scan(&reviewers[i].ID)
+		if err != nil {
+			return fmt.Errorf("failed to create reviewer record: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
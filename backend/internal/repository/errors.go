@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrVersionConflict is returned when a conditional write finds a different version than expected
+// (optimistic concurrency control)
+var ErrVersionConflict = errors.New("version conflict")
@@ -2,19 +2,26 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"new-pay/internal/models"
 )
 
 type FinalConsolidationRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 func NewFinalConsolidationRepository(db *sql.DB) *FinalConsolidationRepository {
 	return &FinalConsolidationRepository{db: db}
 }
 
-// CreateOrUpdate creates or updates a final consolidation
-func (r *FinalConsolidationRepository) CreateOrUpdate(fc *models.FinalConsolidation) error {
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *FinalConsolidationRepository) WithTx(tx *sql.Tx) *FinalConsolidationRepository {
+	return &FinalConsolidationRepository{db: tx}
+}
+
+// CreateOrUpdate creates or updates a final consolidation. With expectedVersion set, an existing
+// final consolidation is only updated if it still has that version (ErrVersionConflict otherwise).
+func (r *FinalConsolidationRepository) CreateOrUpdate(fc *models.FinalConsolidation, expectedVersion *int) error {
 	query := `
 		INSERT INTO final_consolidations (
 			assessment_id, encrypted_comment_id, created_by_user_id, updated_at
@@ -23,22 +30,29 @@ func (r *FinalConsolidationRepository) CreateOrUpdate(fc *models.FinalConsolidat
 		DO UPDATE SET
 			encrypted_comment_id = EXCLUDED.encrypted_comment_id,
 			created_by_user_id = EXCLUDED.created_by_user_id,
+			version = final_consolidations.version + 1,
 			updated_at = NOW()
-		RETURNING id, created_at, updated_at
+		WHERE $4::INTEGER IS NULL OR final_consolidations.version = $4
+		RETURNING id, version, created_at, updated_at
 	`
 
-	return r.db.QueryRow(
+	err := r.db.QueryRow(
 		query,
 		fc.AssessmentID,
 		fc.EncryptedCommentID,
 		fc.CreatedByUserID,
-	).Scan(&fc.ID, &fc.CreatedAt, &fc.UpdatedAt)
+		expectedVersion,
+	).Scan(&fc.ID, &fc.Version, &fc.CreatedAt, &fc.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrVersionConflict
+	}
+	return err
 }
 
 // GetByAssessment retrieves final consolidation by assessment ID
 func (r *FinalConsolidationRepository) GetByAssessment(assessmentID uint) (*models.FinalConsolidation, error) {
 	query := `
-		SELECT id, assessment_id, encrypted_comment_id, created_by_user_id, created_at, updated_at
+		SELECT id, assessment_id, encrypted_comment_id, created_by_user_id, version, created_at, updated_at
 		FROM final_consolidations
 		WHERE assessment_id = $1
 	`
@@ -49,6 +63,7 @@ func (r *FinalConsolidationRepository) GetByAssessment(assessmentID uint) (*mode
 		&fc.AssessmentID,
 		&fc.EncryptedCommentID,
 		&fc.CreatedByUserID,
+		&fc.Version,
 		&fc.CreatedAt,
 		&fc.UpdatedAt,
 	)
@@ -64,13 +79,18 @@ func (r *FinalConsolidationRepository) GetByAssessment(assessmentID uint) (*mode
 }
 
 type FinalConsolidationApprovalRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 func NewFinalConsolidationApprovalRepository(db *sql.DB) *FinalConsolidationApprovalRepository {
 	return &FinalConsolidationApprovalRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *FinalConsolidationApprovalRepository) WithTx(tx *sql.Tx) *FinalConsolidationApprovalRepository {
+	return &FinalConsolidationApprovalRepository{db: tx}
+}
+
 // CreateApproval creates a new approval for final consolidation
 func (r *FinalConsolidationApprovalRepository) CreateApproval(assessmentID, userID uint) error {
 	query := `
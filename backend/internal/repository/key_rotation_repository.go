@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"new-pay/internal/models"
+)
+
+// KeyRotationRepository handles process key rotations and their re-encryption progress
+type KeyRotationRepository struct {
+	db *sql.DB
+}
+
+// NewKeyRotationRepository creates a new key rotation repository
+func NewKeyRotationRepository(db *sql.DB) *KeyRotationRepository {
+	return &KeyRotationRepository{db: db}
+}
+
+const keyRotationColumns = `
+	id, process_id, from_version, to_version, status, total_records,
+	reencrypted_records, error, started_by, started_at, completed_at
+`
+
+// Create stores a new running rotation
+func (r *KeyRotationRepository) Create(rotation *models.ProcessKeyRotation) error {
+	query := `
+		INSERT INTO process_key_rotations (process_id, from_version, to_version, total_records, started_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, started_at
+	`
+	return r.db.QueryRow(
+		query,
+		rotation.ProcessID,
+		rotation.FromVersion,
+		rotation.ToVersion,
+		rotation.TotalRecords,
+		rotation.StartedBy,
+	).Scan(&rotation.ID, &rotation.Status, &rotation.StartedAt)
+}
+
+// GetByID retrieves a rotation
+func (r *KeyRotationRepository) GetByID(id uint) (*models.ProcessKeyRotation, error) {
+	query := `SELECT ` + keyRotationColumns + ` FROM process_key_rotations WHERE id = $1`
+	rotation, err := scanKeyRotation(r.db.QueryRow(query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("key rotation not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key rotation: %w", err)
+	}
+	return rotation, nil
+}
+
+// GetRunningByProcess retrieves the running rotation of a process (nil if none)
+func (r *KeyRotationRepository) GetRunningByProcess(processID string) (*models.ProcessKeyRotation, error) {
+	query := `SELECT ` + keyRotationColumns + ` FROM process_key_rotations WHERE process_id = $1 AND status = 'running'`
+	rotation, err := scanKeyRotation(r.db.QueryRow(query, processID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running key rotation: %w", err)
+	}
+	return rotation, nil
+}
+
+// List retrieves rotations, newest first, optionally filtered by process and status
+func (r *KeyRotationRepository) List(processID, status string) ([]models.ProcessKeyRotation, error) {
+	query := `
+		SELECT ` + keyRotationColumns + `
+		FROM process_key_rotations
+		WHERE ($1 = '' OR process_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY started_at DESC, id DESC
+	`
+	rows, err := r.db.Query(query, processID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list key rotations: %w", err)
+	}
+	defer rows.Close()
+
+	rotations := []models.ProcessKeyRotation{}
+	for rows.Next() {
+		rotation, err := scanKeyRotation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan key rotation: %w", err)
+		}
+		rotations = append(rotations, *rotation)
+	}
+	return rotations, rows.Err()
+}
+
+// UpdateProgress records the number of records re-encrypted so far
+func (r *KeyRotationRepository) UpdateProgress(id uint, totalRecords, reencryptedRecords int) error {
+	_, err := r.db.Exec(`
+		UPDATE process_key_rotations
+		SET total_records = $1, reencrypted_records = $2
+		WHERE id = $3 AND status = 'running'
+	`, totalRecords, reencryptedRecords, id)
+	if err != nil {
+		return fmt.Errorf("failed to update key rotation progress: %w", err)
+	}
+	return nil
+}
+
+// Complete marks a running rotation as completed
+func (r *KeyRotationRepository) Complete(id uint) error {
+	_, err := r.db.Exec(`
+		UPDATE process_key_rotations
+		SET status = 'completed', completed_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'running'
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to complete key rotation: %w", err)
+	}
+	return nil
+}
+
+// Fail marks a running rotation as failed
+func (r *KeyRotationRepository) Fail(id uint, reason string) error {
+	_, err := r.db.Exec(`
+		UPDATE process_key_rotations
+		SET status = 'failed', error = $1, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = 'running'
+	`, reason, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark key rotation as failed: %w", err)
+	}
+	return nil
+}
+
+func scanKeyRotation(row rowScanner) (*models.ProcessKeyRotation, error) {
+	var rotation models.ProcessKeyRotation
+	err := row.Scan(
+		&rotation.ID,
+		&rotation.ProcessID,
+		&rotation.FromVersion,
+		&rotation.ToVersion,
+		&rotation.Status,
+		&rotation.TotalRecords,
+		&rotation.ReencryptedRecords,
+		&rotation.Error,
+		&rotation.StartedBy,
+		&rotation.StartedAt,
+		&rotation.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rotation, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"new-pay/internal/models"
+)
+
+// LegalHoldRepository handles legal holds that block the erasure of a user's data
+type LegalHoldRepository struct {
+	db *sql.DB
+}
+
+// NewLegalHoldRepository creates a new legal hold repository
+func NewLegalHoldRepository(db *sql.DB) *LegalHoldRepository {
+	return &LegalHoldRepository{db: db}
+}
+
+// Get retrieves the legal hold of a user (nil if none)
+func (r *LegalHoldRepository) Get(userID uint) (*models.LegalHold, error) {
+	var hold models.LegalHold
+	err := r.db.QueryRow(`
+		SELECT user_id, reason, created_by, created_at FROM legal_holds WHERE user_id = $1
+	`, userID).Scan(&hold.UserID, &hold.Reason, &hold.CreatedBy, &hold.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legal hold: %w", err)
+	}
+	return &hold, nil
+}
+
+// Set places a user under legal hold or updates the reason of an existing hold
+func (r *LegalHoldRepository) Set(hold *models.LegalHold) error {
+	err := r.db.QueryRow(`
+		INSERT INTO legal_holds (user_id, reason, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET reason = EXCLUDED.reason
+		RETURNING created_by, created_at
+	`, hold.UserID, hold.Reason, hold.CreatedBy).Scan(&hold.CreatedBy, &hold.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set legal hold: %w", err)
+	}
+	return nil
+}
+
+// Release removes the legal hold of a user
+func (r *LegalHoldRepository) Release(userID uint) error {
+	result, err := r.db.Exec(`DELETE FROM legal_holds WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to release legal hold: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("legal hold not found")
+	}
+	return nil
+}
+
+// List retrieves all legal holds, newest first
+func (r *LegalHoldRepository) List() ([]models.LegalHold, error) {
+	rows, err := r.db.Query(`
+		SELECT user_id, reason, created_by, created_at FROM legal_holds ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legal holds: %w", err)
+	}
+	defer rows.Close()
+
+	holds := []models.LegalHold{}
+	for rows.Next() {
+		var hold models.LegalHold
+		if err := rows.Scan(&hold.UserID, &hold.Reason, &hold.CreatedBy, &hold.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan legal hold: %w", err)
+		}
+		holds = append(holds, hold)
+	}
+	return holds, rows.Err()
+}
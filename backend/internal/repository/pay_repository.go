@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"new-pay/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// PayRepository handles salary bands, employee salaries and pay recommendations
+type PayRepository struct {
+	db *sql.DB
+}
+
+// NewPayRepository creates a new pay repository
+func NewPayRepository(db *sql.DB) *PayRepository {
+	return &PayRepository{db: db}
+}
+
+// CreateSalaryBand creates a new salary band
+func (r *PayRepository) CreateSalaryBand(band *models.SalaryBand) error {
+	query := `
+		INSERT INTO salary_bands (catalog_id, level_id, currency, min_salary, max_salary, effective_from, effective_until, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		band.CatalogID,
+		band.LevelID,
+		band.Currency,
+		band.MinSalary,
+		band.MaxSalary,
+		band.EffectiveFrom,
+		band.EffectiveUntil,
+		band.CreatedBy,
+	).Scan(&band.ID, &band.CreatedAt, &band.UpdatedAt)
+}
+
+// UpdateSalaryBand updates a salary band
+func (r *PayRepository) UpdateSalaryBand(band *models.SalaryBand) error {
+	query := `
+		UPDATE salary_bands
+		SET level_id = $1, currency = $2, min_salary = $3, max_salary = $4, effective_from = $5, effective_until = $6
+		WHERE id = $7
+		RETURNING updated_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		band.LevelID,
+		band.Currency,
+		band.MinSalary,
+		band.MaxSalary,
+		band.EffectiveFrom,
+		band.EffectiveUntil,
+		band.ID,
+	).Scan(&band.UpdatedAt)
+}
+
+// DeleteSalaryBand deletes a salary band
+func (r *PayRepository) DeleteSalaryBand(id uint) error {
+	query := `DELETE FROM salary_bands WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
+
+const salaryBandColumns = `
+	b.id, b.catalog_id, b.level_id, l.name, b.currency, b.min_salary, b.max_salary,
+	b.effective_from, b.effective_until, b.created_by, b.created_at, b.updated_at
+`
+
+// GetSalaryBandByID retrieves a salary band by ID
+func (r *PayRepository) GetSalaryBandByID(id uint) (*models.SalaryBand, error) {
+	query := `
+		SELECT ` + salaryBandColumns + `
+		FROM salary_bands b
+		JOIN levels l ON b.level_id = l.id
+		WHERE b.id = $1
+	`
+
+	band, err := scanSalaryBand(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return band, err
+}
+
+// GetSalaryBandsByCatalogID retrieves all salary bands of a catalog ordered by level and start date
+func (r *PayRepository) GetSalaryBandsByCatalogID(catalogID uint) ([]models.SalaryBand, error) {
+	query := `
+		SELECT ` + salaryBandColumns + `
+		FROM salary_bands b
+		JOIN levels l ON b.level_id = l.id
+		WHERE b.catalog_id = $1
+		ORDER BY l.level_number, b.currency, b.effective_from
+	`
+
+	rows, err := r.db.Query(query, catalogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bands := []models.SalaryBand{}
+	for rows.Next() {
+		band, err := scanSalaryBand(rows)
+		if err != nil {
+			return nil, err
+		}
+		bands = append(bands, *band)
+	}
+
+	return bands, rows.Err()
+}
+
+// GetEffectiveSalaryBand retrieves the salary band of a level in effect at the given date.
+// If currency is empty, a band in any currency is returned.
+func (r *PayRepository) GetEffectiveSalaryBand(levelID uint, currency string, at time.Time) (*models.SalaryBand, error) {
+	query := `
+		SELECT ` + salaryBandColumns + `
+		FROM salary_bands b
+		JOIN levels l ON b.level_id = l.id
+		WHERE b.level_id = $1
+		AND ($2 = '' OR b.currency = $2)
+		AND b.effective_from <= $3
+		AND (b.effective_until IS NULL OR b.effective_until >= $3)
+		ORDER BY b.effective_from DESC
+		LIMIT 1
+	`
+
+	band, err := scanSalaryBand(r.db.QueryRow(query, levelID, currency, at))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return band, err
+}
+
+// HasOverlappingSalaryBand checks if another band of the same level and currency overlaps the given period
+func (r *PayRepository) HasOverlappingSalaryBand(band *models.SalaryBand) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM salary_bands
+			WHERE level_id = $1
+			AND currency = $2
+			AND id != $3
+			AND ($5::date IS NULL OR effective_from <= $5)
+			AND (effective_until IS NULL OR effective_until >= $4)
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, band.LevelID, band.Currency, band.ID, band.EffectiveFrom, band.EffectiveUntil).Scan(&exists)
+	return exists, err
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSalaryBand(row rowScanner) (*models.SalaryBand, error) {
+	var band models.SalaryBand
+	err := row.Scan(
+		&band.ID,
+		&band.CatalogID,
+		&band.LevelID,
+		&band.LevelName,
+		&band.Currency,
+		&band.MinSalary,
+		&band.MaxSalary,
+		&band.EffectiveFrom,
+		&band.EffectiveUntil,
+		&band.CreatedBy,
+		&band.CreatedAt,
+		&band.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &band, nil
+}
+
+// CreateEmployeeSalary records a salary of an employee (the amount is stored in encrypted_records)
+func (r *PayRepository) CreateEmployeeSalary(salary *models.EmployeeSalary) error {
+	query := `
+		INSERT INTO employee_salaries (user_id, encrypted_salary_id, effective_from, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		salary.UserID,
+		salary.EncryptedSalaryID,
+		salary.EffectiveFrom,
+		salary.CreatedBy,
+	).Scan(&salary.ID, &salary.CreatedAt)
+}
+
+// GetSalariesByUserID retrieves the salary history of an employee, newest first
+func (r *PayRepository) GetSalariesByUserID(userID uint) ([]models.EmployeeSalary, error) {
+	query := `
+		SELECT id, user_id, encrypted_salary_id, effective_from, created_by, created_at
+		FROM employee_salaries
+		WHERE user_id = $1
+		ORDER BY effective_from DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	salaries := []models.EmployeeSalary{}
+	for rows.Next() {
+		var salary models.EmployeeSalary
+		err := rows.Scan(
+			&salary.ID,
+			&salary.UserID,
+			&salary.EncryptedSalaryID,
+			&salary.EffectiveFrom,
+			&salary.CreatedBy,
+			&salary.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		salaries = append(salaries, salary)
+	}
+
+	return salaries, rows.Err()
+}
+
+// GetCurrentSalary retrieves the salary of an employee in effect at the given date
+func (r *PayRepository) GetCurrentSalary(userID uint, at time.Time) (*models.EmployeeSalary, error) {
+	query := `
+		SELECT id, user_id, encrypted_salary_id, effective_from, created_by, created_at
+		FROM employee_salaries
+		WHERE user_id = $1 AND effective_from <= $2
+		ORDER BY effective_from DESC, id DESC
+		LIMIT 1
+	`
+
+	var salary models.EmployeeSalary
+	err := r.db.QueryRow(query, userID, at).Scan(
+		&salary.ID,
+		&salary.UserID,
+		&salary.EncryptedSalaryID,
+		&salary.EffectiveFrom,
+		&salary.CreatedBy,
+		&salary.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &salary, nil
+}
+
+// UpsertRecommendation creates or replaces the pay recommendation of an assessment
+func (r *PayRepository) UpsertRecommendation(rec *models.PayRecommendation) error {
+	query := `
+		INSERT INTO pay_recommendations (assessment_id, user_id, level_id, salary_band_id, encrypted_recommendation_id, band_position)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (assessment_id) DO UPDATE
+		SET level_id = EXCLUDED.level_id,
+		    salary_band_id = EXCLUDED.salary_band_id,
+		    encrypted_recommendation_id = EXCLUDED.encrypted_recommendation_id,
+		    band_position = EXCLUDED.band_position,
+		    status = 'open',
+		    exported_at = NULL
+		RETURNING id, status, created_at, updated_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		rec.AssessmentID,
+		rec.UserID,
+		rec.LevelID,
+		rec.SalaryBandID,
+		rec.EncryptedRecommendationID,
+		rec.BandPosition,
+	).Scan(&rec.ID, &rec.Status, &rec.CreatedAt, &rec.UpdatedAt)
+}
+
+// GetRecommendations retrieves pay recommendations with user, level and band details, optionally filtered by status
+func (r *PayRepository) GetRecommendations(status string) ([]models.PayRecommendation, error) {
+	query := `
+		SELECT
+			p.id, p.assessment_id, p.user_id,
+			CONCAT(u.first_name, ' ', u.last_name) as user_name, u.email as user_email,
+			p.level_id, l.name as level_name,
+			p.salary_band_id, b.min_salary, b.max_salary,
+			p.encrypted_recommendation_id, p.band_position, p.status,
+			p.created_at, p.updated_at, p.exported_at
+		FROM pay_recommendations p
+		JOIN users u ON p.user_id = u.id
+		JOIN levels l ON p.level_id = l.id
+		LEFT JOIN salary_bands b ON p.salary_band_id = b.id
+		WHERE ($1 = '' OR p.status = $1)
+		ORDER BY p.created_at DESC
+	`
+
+	rows, err := r.db.Query(query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recommendations := []models.PayRecommendation{}
+	for rows.Next() {
+		var rec models.PayRecommendation
+		err := rows.Scan(
+			&rec.ID,
+			&rec.AssessmentID,
+			&rec.UserID,
+			&rec.UserName,
+			&rec.UserEmail,
+			&rec.LevelID,
+			&rec.LevelName,
+			&rec.SalaryBandID,
+			&rec.BandMin,
+			&rec.BandMax,
+			&rec.EncryptedRecommendationID,
+			&rec.BandPosition,
+			&rec.Status,
+			&rec.CreatedAt,
+			&rec.UpdatedAt,
+			&rec.ExportedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, rec)
+	}
+
+	return recommendations, rows.Err()
+}
+
+// MarkRecommendationsExported sets the status of the given recommendations to exported
+func (r *PayRepository) MarkRecommendationsExported(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	intIDs := make([]int64, len(ids))
+	for i, id := range ids {
+		intIDs[i] = int64(id)
+	}
+
+	query := `UPDATE pay_recommendations SET status = 'exported', exported_at = NOW() WHERE id = ANY($1)`
+	_, err := r.db.Exec(query, pq.Array(intIDs))
+	return err
+}
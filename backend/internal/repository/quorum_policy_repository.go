@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"new-pay/internal/models"
+)
+
+// QuorumPolicyRepository handles catalog-specific quorum policies for consolidation approvals
+type QuorumPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewQuorumPolicyRepository creates a new quorum policy repository
+func NewQuorumPolicyRepository(db *sql.DB) *QuorumPolicyRepository {
+	return &QuorumPolicyRepository{db: db}
+}
+
+// GetByCatalogID retrieves the quorum policy of a catalog (nil if the catalog has none)
+func (r *QuorumPolicyRepository) GetByCatalogID(catalogID uint) (*models.QuorumPolicy, error) {
+	var policy models.QuorumPolicy
+	err := r.db.QueryRow(`
+		SELECT catalog_id, averaged_count, averaged_percent, override_count, override_percent,
+		       final_count, final_percent, allow_self_approval, updated_by, updated_at
+		FROM catalog_quorum_policies
+		WHERE catalog_id = $1
+	`, catalogID).Scan(
+		&policy.CatalogID, &policy.AveragedCount, &policy.AveragedPercent, &policy.OverrideCount, &policy.OverridePercent,
+		&policy.FinalCount, &policy.FinalPercent, &policy.AllowSelfApproval, &policy.UpdatedBy, &policy.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// Upsert creates or replaces the quorum policy of a catalog
+func (r *QuorumPolicyRepository) Upsert(policy *models.QuorumPolicy) error {
+	err := r.db.QueryRow(`
+		INSERT INTO catalog_quorum_policies (catalog_id, averaged_count, averaged_percent, override_count, override_percent,
+		                                     final_count, final_percent, allow_self_approval, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (catalog_id) DO UPDATE SET
+			averaged_count = EXCLUDED.averaged_count,
+			averaged_percent = EXCLUDED.averaged_percent,
+			override_count = EXCLUDED.override_count,
+			override_percent = EXCLUDED.override_percent,
+			final_count = EXCLUDED.final_count,
+			final_percent = EXCLUDED.final_percent,
+			allow_self_approval = EXCLUDED.allow_self_approval,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at
+	`, policy.CatalogID, policy.AveragedCount, policy.AveragedPercent, policy.OverrideCount, policy.OverridePercent,
+		policy.FinalCount, policy.FinalPercent, policy.AllowSelfApproval, policy.UpdatedBy).Scan(&policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save quorum policy: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the quorum policy of a catalog so the global default applies again
+func (r *QuorumPolicyRepository) Delete(catalogID uint) error {
+	if _, err := r.db.Exec(`DELETE FROM catalog_quorum_policies WHERE catalog_id = $1`, catalogID); err != nil {
+		return fmt.Errorf("failed to delete quorum policy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"new-pay/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ReviewerAssignmentRepository handles database operations for reviewer panels
+type ReviewerAssignmentRepository struct {
+	db *sql.DB
+}
+
+// NewReviewerAssignmentRepository creates a new reviewer assignment repository
+func NewReviewerAssignmentRepository(db *sql.DB) *ReviewerAssignmentRepository {
+	return &ReviewerAssignmentRepository{db: db}
+}
+
+// GetByAssessmentID retrieves the reviewer panel of an assessment
+func (r *ReviewerAssignmentRepository) GetByAssessmentID(assessmentID uint) ([]models.ReviewerAssignment, error) {
+	query := `
+		SELECT a.id, a.assessment_id, a.reviewer_user_id, CONCAT(u.first_name, ' ', u.last_name),
+		       a.assigned_by, a.assigned_at
+		FROM assessment_reviewer_assignments a
+		JOIN users u ON u.id = a.reviewer_user_id
+		WHERE a.assessment_id = $1
+		ORDER BY a.assigned_at, a.id
+	`
+
+	rows, err := r.db.Query(query, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []models.ReviewerAssignment{}
+	for rows.Next() {
+		var a models.ReviewerAssignment
+		if err := rows.Scan(&a.ID, &a.AssessmentID, &a.ReviewerUserID, &a.ReviewerName, &a.AssignedBy, &a.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+
+	return assignments, rows.Err()
+}
+
+// ReplacePanel replaces the reviewer panel of an assessment in a single transaction.
+// Reviewers that stay on the panel keep their original assignment.
+// assignedBy is nil for automatic assignments by the system.
+func (r *ReviewerAssignmentRepository) ReplacePanel(assessmentID uint, reviewerIDs []uint, assignedBy *uint) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, len(reviewerIDs))
+	for i, id := range reviewerIDs {
+		ids[i] = int64(id)
+	}
+
+	if _, err := tx.Exec(`
+		DELETE FROM assessment_reviewer_assignments
+		WHERE assessment_id = $1 AND NOT (reviewer_user_id = ANY($2))
+	`, assessmentID, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to remove reviewer assignments: %w", err)
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		if _, err := tx.Exec(`
+			INSERT INTO assessment_reviewer_assignments (assessment_id, reviewer_user_id, assigned_by)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (assessment_id, reviewer_user_id) DO NOTHING
+		`, assessmentID, reviewerID, assignedBy); err != nil {
+			return fmt.Errorf("failed to assign reviewer: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// IsAssigned checks whether a reviewer is on the panel of an assessment
+func (r *ReviewerAssignmentRepository) IsAssigned(assessmentID, reviewerUserID uint) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM assessment_reviewer_assignments
+			WHERE assessment_id = $1 AND reviewer_user_id = $2
+		)
+	`, assessmentID, reviewerUserID).Scan(&exists)
+	return exists, err
+}
+
+// CountByAssessmentID returns the panel size of an assessment
+func (r *ReviewerAssignmentRepository) CountByAssessmentID(assessmentID uint) (int, error) {
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*) FROM assessment_reviewer_assignments WHERE assessment_id = $1
+	`, assessmentID).Scan(&count)
+	return count, err
+}
+
+// GetAssessmentIDsByReviewer returns the IDs of all assessments a reviewer is assigned to
+func (r *ReviewerAssignmentRepository) GetAssessmentIDsByReviewer(reviewerUserID uint) (map[uint]bool, error) {
+	rows, err := r.db.Query(`
+		SELECT assessment_id FROM assessment_reviewer_assignments WHERE reviewer_user_id = $1
+	`, reviewerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned assessments: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[uint]bool)
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan assessment ID: %w", err)
+		}
+		ids[id] = true
+	}
+
+	return ids, rows.Err()
+}
+
+// GetAssignmentsInReview returns the reviewer assignments of self-assessments that are still in review
+func (r *ReviewerAssignmentRepository) GetAssignmentsInReview() ([]models.ReviewerAssignment, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id, a.assessment_id, a.reviewer_user_id, a.assigned_by, a.assigned_at
+		FROM assessment_reviewer_assignments a
+		JOIN self_assessments sa ON sa.id = a.assessment_id
+		WHERE sa.status IN ('submitted', 'changes_requested', 'in_review', 'review_consolidation', 'reviewed')
+		ORDER BY a.assessment_id, a.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []models.ReviewerAssignment{}
+	for rows.Next() {
+		var a models.ReviewerAssignment
+		if err := rows.Scan(&a.ID, &a.AssessmentID, &a.ReviewerUserID, &a.AssignedBy, &a.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+
+	return assignments, rows.Err()
+}
+
+// GetLastAssignedAt returns the time of the latest assignment of every reviewer with assignments
+func (r *ReviewerAssignmentRepository) GetLastAssignedAt() (map[uint]time.Time, error) {
+	rows, err := r.db.Query(`
+		SELECT reviewer_user_id, MAX(assigned_at)
+		FROM assessment_reviewer_assignments
+		GROUP BY reviewer_user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last assignments: %w", err)
+	}
+	defer rows.Close()
+
+	lastAssigned := make(map[uint]time.Time)
+	for rows.Next() {
+		var reviewerID uint
+		var assignedAt time.Time
+		if err := rows.Scan(&reviewerID, &assignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan last assignment: %w", err)
+		}
+		lastAssigned[reviewerID] = assignedAt
+	}
+
+	return lastAssigned, rows.Err()
+}
+
+// GetSubmittedWithoutPanel returns the IDs of submitted self-assessments without reviewer panel
+func (r *ReviewerAssignmentRepository) GetSubmittedWithoutPanel() ([]uint, error) {
+	rows, err := r.db.Query(`
+		SELECT sa.id
+		FROM self_assessments sa
+		WHERE sa.status = 'submitted'
+		  AND NOT EXISTS (SELECT 1 FROM assessment_reviewer_assignments a WHERE a.assessment_id = sa.id)
+		ORDER BY sa.submitted_at, sa.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get self-assessments without panel: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan assessment ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetOpenWithIncompletePanel returns the submitted and in-review self-assessments whose panel has
+// fewer than minSize reviewers, including those without any panel
+func (r *ReviewerAssignmentRepository) GetOpenWithIncompletePanel(minSize int) ([]models.IncompletePanel, error) {
+	rows, err := r.db.Query(`
+		SELECT sa.id, sa.user_id, sa.status, COUNT(a.id)
+		FROM self_assessments sa
+		LEFT JOIN assessment_reviewer_assignments a ON a.assessment_id = sa.id
+		WHERE sa.status IN ('submitted', 'in_review')
+		GROUP BY sa.id, sa.user_id, sa.status, sa.submitted_at
+		HAVING COUNT(a.id) < $1
+		ORDER BY sa.submitted_at, sa.id
+	`, minSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get self-assessments with incomplete panel: %w", err)
+	}
+	defer rows.Close()
+
+	panels := []models.IncompletePanel{}
+	for rows.Next() {
+		panel := models.IncompletePanel{MinSize: minSize}
+		if err := rows.Scan(&panel.AssessmentID, &panel.UserID, &panel.Status, &panel.PanelSize); err != nil {
+			return nil, fmt.Errorf("failed to scan self-assessment: %w", err)
+		}
+		panels = append(panels, panel)
+	}
+
+	return panels, rows.Err()
+}
+
+// CreateConflict declares a conflict of interest between a reviewer and an employee
+func (r *ReviewerAssignmentRepository) CreateConflict(conflict *models.ReviewerConflict) error {
+	return r.db.QueryRow(`
+		INSERT INTO reviewer_conflicts (reviewer_user_id, user_id, reason, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (reviewer_user_id, user_id) DO UPDATE SET reason = EXCLUDED.reason
+		RETURNING id, created_at
+	`, conflict.ReviewerUserID, conflict.UserID, conflict.Reason, conflict.CreatedBy).Scan(&conflict.ID, &conflict.CreatedAt)
+}
+
+// GetConflictByID retrieves a conflict of interest by ID (nil if not found)
+func (r *ReviewerAssignmentRepository) GetConflictByID(id uint) (*models.ReviewerConflict, error) {
+	conflicts, err := r.queryConflicts(`WHERE c.id = $1`, id)
+	if err != nil || len(conflicts) == 0 {
+		return nil, err
+	}
+	return &conflicts[0], nil
+}
+
+// GetConflicts retrieves all conflicts of interest, optionally only those of one reviewer
+func (r *ReviewerAssignmentRepository) GetConflicts(reviewerUserID *uint) ([]models.ReviewerConflict, error) {
+	if reviewerUserID != nil {
+		return r.queryConflicts(`WHERE c.reviewer_user_id = $1`, *reviewerUserID)
+	}
+	return r.queryConflicts(``)
+}
+
+// GetConflictedReviewerIDs returns the reviewers with a declared conflict of interest with an employee
+func (r *ReviewerAssignmentRepository) GetConflictedReviewerIDs(userID uint) (map[uint]bool, error) {
+	conflicts, err := r.queryConflicts(`WHERE c.user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[uint]bool, len(conflicts))
+	for _, c := range conflicts {
+		ids[c.ReviewerUserID] = true
+	}
+	return ids, nil
+}
+
+// DeleteConflict removes a conflict of interest
+func (r *ReviewerAssignmentRepository) DeleteConflict(id uint) error {
+	_, err := r.db.Exec(`DELETE FROM reviewer_conflicts WHERE id = $1`, id)
+	return err
+}
+
+func (r *ReviewerAssignmentRepository) queryConflicts(where string, args ...interface{}) ([]models.ReviewerConflict, error) {
+	rows, err := r.db.Query(`
+		SELECT c.id, c.reviewer_user_id, CONCAT(rv.first_name, ' ', rv.last_name),
+		       c.user_id, CONCAT(u.first_name, ' ', u.last_name), c.reason, c.created_by, c.created_at
+		FROM reviewer_conflicts c
+		JOIN users rv ON rv.id = c.reviewer_user_id
+		JOIN users u ON u.id = c.user_id
+		`+where+`
+		ORDER BY c.created_at, c.id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts := []models.ReviewerConflict{}
+	for rows.Next() {
+		var c models.ReviewerConflict
+		if err := rows.Scan(&c.ID, &c.ReviewerUserID, &c.ReviewerName, &c.UserID, &c.UserName, &c.Reason, &c.CreatedBy, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer conflict: %w", err)
+		}
+		conflicts = append(conflicts, c)
+	}
+
+	return conflicts, rows.Err()
+}
@@ -10,7 +10,7 @@ import (
 
 // ReviewerResponseRepository handles database operations for reviewer responses
 type ReviewerResponseRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewReviewerResponseRepository creates a new reviewer response repository
@@ -18,6 +18,11 @@ func NewReviewerResponseRepository(db *sql.DB) *ReviewerResponseRepository {
 	return &ReviewerResponseRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *ReviewerResponseRepository) WithTx(tx *sql.Tx) *ReviewerResponseRepository {
+	return &ReviewerResponseRepository{db: tx}
+}
+
 // scanReviewerResponses scans rows into a slice of ReviewerResponse
 func (r *ReviewerResponseRepository) scanReviewerResponses(rows *sql.Rows) ([]models.ReviewerResponse, error) {
 	// Initialize with empty slice instead of nil to avoid JSON null
@@ -33,6 +38,7 @@ func (r *ReviewerResponseRepository) scanReviewerResponses(rows *sql.Rows) ([]mo
 			&resp.PathID,
 			&resp.LevelID,
 			&resp.EncryptedJustificationID,
+			&resp.Version,
 			&resp.CreatedAt,
 			&resp.UpdatedAt,
 		)
@@ -49,8 +55,9 @@ func (r *ReviewerResponseRepository) scanReviewerResponses(rows *sql.Rows) ([]mo
 	return responses, nil
 }
 
-// CreateOrUpdate creates or updates a reviewer response
-func (r *ReviewerResponseRepository) CreateOrUpdate(response *models.ReviewerResponse) error {
+// CreateOrUpdate creates or updates a reviewer response. With expectedVersion set, an existing
+// response is only updated if it still has that version (ErrVersionConflict otherwise).
+func (r *ReviewerResponseRepository) CreateOrUpdate(response *models.ReviewerResponse, expectedVersion *int) error {
 	query := `
 		INSERT INTO reviewer_responses (
 			assessment_id, category_id, reviewer_user_id, path_id, level_id, encrypted_justification_id, updated_at
@@ -60,11 +67,13 @@ func (r *ReviewerResponseRepository) CreateOrUpdate(response *models.ReviewerRes
 			path_id = EXCLUDED.path_id,
 			level_id = EXCLUDED.level_id,
 			encrypted_justification_id = EXCLUDED.encrypted_justification_id,
+			version = reviewer_responses.version + 1,
 			updated_at = NOW()
-		RETURNING id, created_at, updated_at
+		WHERE $7::INTEGER IS NULL OR reviewer_responses.version = $7
+		RETURNING id, version, created_at, updated_at
 	`
 
-	return r.db.QueryRow(
+	err := r.db.QueryRow(
 		query,
 		response.AssessmentID,
 		response.CategoryID,
@@ -72,14 +81,19 @@ func (r *ReviewerResponseRepository) CreateOrUpdate(response *models.ReviewerRes
 		response.PathID,
 		response.LevelID,
 		response.EncryptedJustificationID,
-	).Scan(&response.ID, &response.CreatedAt, &response.UpdatedAt)
+		expectedVersion,
+	).Scan(&response.ID, &response.Version, &response.CreatedAt, &response.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrVersionConflict
+	}
+	return err
 }
 
 // GetByAssessmentAndReviewer retrieves all responses by a specific reviewer for an assessment
 func (r *ReviewerResponseRepository) GetByAssessmentAndReviewer(assessmentID, reviewerUserID uint) ([]models.ReviewerResponse, error) {
 	query := `
 		SELECT id, assessment_id, category_id, reviewer_user_id, path_id, level_id, 
-		       encrypted_justification_id, created_at, updated_at
+		       encrypted_justification_id, version, created_at, updated_at
 		FROM reviewer_responses
 		WHERE assessment_id = $1 AND reviewer_user_id = $2
 		ORDER BY category_id
@@ -104,7 +118,7 @@ func (r *ReviewerResponseRepository) GetByAssessmentAndReviewer(assessmentID, re
 func (r *ReviewerResponseRepository) GetByAssessmentAndCategory(assessmentID, categoryID uint) ([]models.ReviewerResponse, error) {
 	query := `
 		SELECT id, assessment_id, category_id, reviewer_user_id, path_id, level_id, 
-		       encrypted_justification_id, created_at, updated_at
+		       encrypted_justification_id, version, created_at, updated_at
 		FROM reviewer_responses
 		WHERE assessment_id = $1 AND category_id = $2
 	`
@@ -128,7 +142,7 @@ func (r *ReviewerResponseRepository) GetByCategoryAndReviewer(assessmentID, cate
 	var resp models.ReviewerResponse
 	query := `
 		SELECT id, assessment_id, category_id, reviewer_user_id, path_id, level_id, 
-		       encrypted_justification_id, created_at, updated_at
+		       encrypted_justification_id, version, created_at, updated_at
 		FROM reviewer_responses
 		WHERE assessment_id = $1 AND category_id = $2 AND reviewer_user_id = $3
 	`
@@ -141,6 +155,7 @@ func (r *ReviewerResponseRepository) GetByCategoryAndReviewer(assessmentID, cate
 		&resp.PathID,
 		&resp.LevelID,
 		&resp.EncryptedJustificationID,
+		&resp.Version,
 		&resp.CreatedAt,
 		&resp.UpdatedAt,
 	)
@@ -292,7 +307,7 @@ func (r *ReviewerResponseRepository) GetReviewStats(assessmentID uint) (startedC
 func (r *ReviewerResponseRepository) GetAllByAssessment(assessmentID uint) ([]models.ReviewerResponse, error) {
 	query := `
 		SELECT id, assessment_id, category_id, reviewer_user_id, path_id, level_id, 
-		       encrypted_justification_id, created_at, updated_at
+		       encrypted_justification_id, version, created_at, updated_at
 		FROM reviewer_responses
 		WHERE assessment_id = $1
 		ORDER BY reviewer_user_id, category_id
@@ -9,7 +9,7 @@ import (
 
 // SelfAssessmentRepository handles database operations for self-assessments
 type SelfAssessmentRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewSelfAssessmentRepository creates a new self-assessment repository
@@ -17,6 +17,11 @@ func NewSelfAssessmentRepository(db *sql.DB) *SelfAssessmentRepository {
 	return &SelfAssessmentRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs all statements in the given transaction
+func (r *SelfAssessmentRepository) WithTx(tx *sql.Tx) *SelfAssessmentRepository {
+	return &SelfAssessmentRepository{db: tx}
+}
+
 // CountByCatalogID counts self-assessments for a catalog
 func (r *SelfAssessmentRepository) CountByCatalogID(catalogID uint) (int, error) {
 	var count int
@@ -660,6 +665,7 @@ func (r *SelfAssessmentRepository) GetVisibleToReviewers() ([]models.SelfAssessm
 }
 
 // GetOpenAssessmentsForReview retrieves open assessments for reviewers with filters
+// (all submitted states that are not final, including custom workflow states)
 func (r *SelfAssessmentRepository) GetOpenAssessmentsForReview(catalogID *int, username string, status string, fromDate, toDate, fromSubmittedDate, toSubmittedDate *time.Time) ([]models.SelfAssessmentWithDetails, error) {
 	query := `
 SELECT sa.id, sa.catalog_id, sa.user_id, sa.status, 
@@ -671,7 +677,7 @@ SELECT sa.id, sa.catalog_id, sa.user_id, sa.status,
 FROM self_assessments sa
 JOIN users u ON sa.user_id = u.id
 JOIN criteria_catalogs c ON sa.catalog_id = c.id
-WHERE sa.status IN ('submitted', 'in_review', 'review_consolidation', 'reviewed', 'discussion')
+WHERE sa.status NOT IN ('draft', 'archived', 'closed')
 `
 	var args []interface{}
 	argCount := 1
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// DBTX is implemented by *sql.DB and *sql.Tx. Repositories that support WithTx run their
+// statements on a DBTX, so the same methods work inside and outside of a transaction.
+type DBTX interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Transactor runs writes of several repositories in one database transaction
+type Transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor creates a new transactor
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// InTx runs fn in a transaction. The transaction is committed if fn returns nil and rolled back otherwise.
+func (t *Transactor) InTx(fn func(tx *sql.Tx) error) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// txScope is a transaction of a repository method with several statements. If the repository
+// is bound to a transaction (WithTx), the method joins it and leaves commit and rollback to its owner.
+type txScope struct {
+	*sql.Tx
+	owned bool
+}
+
+// beginTx starts a transaction on db or joins the transaction db already is
+func beginTx(db DBTX) (*txScope, error) {
+	if tx, ok := db.(*sql.Tx); ok {
+		return &txScope{Tx: tx}, nil
+	}
+	tx, err := db.(*sql.DB).Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return &txScope{Tx: tx, owned: true}, nil
+}
+
+// Commit commits the transaction if it was started by beginTx
+func (s *txScope) Commit() error {
+	if !s.owned {
+		return nil
+	}
+	return s.Tx.Commit()
+}
+
+// Rollback rolls back the transaction if it was started by beginTx and is still open
+func (s *txScope) Rollback() {
+	if s.owned {
+		_ = s.Tx.Rollback()
+	}
+}
@@ -60,7 +60,7 @@ func (r *UserRepository) Create(user *models.User) error {
 func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, email_verified, email_verified_at,
-		       is_active, last_login_at, oauth_provider, oauth_provider_id, created_at, updated_at
+		       is_active, last_login_at, oauth_provider, oauth_provider_id, team, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -78,6 +78,7 @@ func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 		&user.LastLoginAt,
 		&user.OAuthProvider,
 		&user.OAuthProviderID,
+		&user.Team,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -96,7 +97,7 @@ func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, email_verified, email_verified_at,
-		       is_active, last_login_at, oauth_provider, oauth_provider_id, created_at, updated_at
+		       is_active, last_login_at, oauth_provider, oauth_provider_id, team, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -114,6 +115,7 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 		&user.LastLoginAt,
 		&user.OAuthProvider,
 		&user.OAuthProviderID,
+		&user.Team,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -132,7 +134,7 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 func (r *UserRepository) GetByOAuth(provider, providerID string) (*models.User, error) {
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, email_verified, email_verified_at,
-		       is_active, last_login_at, oauth_provider, oauth_provider_id, created_at, updated_at
+		       is_active, last_login_at, oauth_provider, oauth_provider_id, team, created_at, updated_at
 		FROM users
 		WHERE oauth_provider = $1 AND oauth_provider_id = $2
 	`
@@ -150,6 +152,7 @@ func (r *UserRepository) GetByOAuth(provider, providerID string) (*models.User,
 		&user.LastLoginAt,
 		&user.OAuthProvider,
 		&user.OAuthProviderID,
+		&user.Team,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -169,8 +172,8 @@ func (r *UserRepository) Update(user *models.User) error {
 	query := `
 		UPDATE users
 		SET email = $1, first_name = $2, last_name = $3, email_verified = $4,
-		    email_verified_at = $5, is_active = $6, last_login_at = $7, updated_at = $8
-		WHERE id = $9
+		    email_verified_at = $5, is_active = $6, last_login_at = $7, updated_at = $8, team = $9
+		WHERE id = $10
 	`
 
 	user.UpdatedAt = time.Now()
@@ -184,6 +187,7 @@ func (r *UserRepository) Update(user *models.User) error {
 		user.IsActive,
 		user.LastLoginAt,
 		user.UpdatedAt,
+		user.Team,
 		user.ID,
 	)
 
@@ -328,7 +332,7 @@ func (r *UserRepository) GetUsersByRole(roleID uint) ([]models.User, error) {
 	query := `
 		SELECT u.id, u.email, u.password_hash, u.first_name, u.last_name, 
 		       u.email_verified, u.email_verified_at, u.is_active, u.last_login_at,
-		       u.oauth_provider, u.oauth_provider_id, u.created_at, u.updated_at
+		       u.oauth_provider, u.oauth_provider_id, u.team, u.created_at, u.updated_at
 		FROM users u
 		INNER JOIN user_roles ur ON u.id = ur.user_id
 		WHERE ur.role_id = $1
@@ -355,6 +359,7 @@ func (r *UserRepository) GetUsersByRole(roleID uint) ([]models.User, error) {
 			&user.LastLoginAt,
 			&user.OAuthProvider,
 			&user.OAuthProviderID,
+			&user.Team,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		); err != nil {
@@ -371,7 +376,7 @@ func (r *UserRepository) GetUsersByRoleName(roleName string) ([]models.User, err
 	query := `
 		SELECT u.id, u.email, u.password_hash, u.first_name, u.last_name, 
 		       u.email_verified, u.email_verified_at, u.is_active, u.last_login_at,
-		       u.oauth_provider, u.oauth_provider_id, u.created_at, u.updated_at
+		       u.oauth_provider, u.oauth_provider_id, u.team, u.created_at, u.updated_at
 		FROM users u
 		INNER JOIN user_roles ur ON u.id = ur.user_id
 		INNER JOIN roles r ON ur.role_id = r.id
@@ -399,6 +404,7 @@ func (r *UserRepository) GetUsersByRoleName(roleName string) ([]models.User, err
 			&user.LastLoginAt,
 			&user.OAuthProvider,
 			&user.OAuthProviderID,
+			&user.Team,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		); err != nil {
@@ -440,7 +446,7 @@ type UserFilters struct {
 func (r *UserRepository) GetAll(limit, offset int) ([]models.User, error) {
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, email_verified, email_verified_at,
-		       is_active, last_login_at, oauth_provider, oauth_provider_id, created_at, updated_at
+		       is_active, last_login_at, oauth_provider, oauth_provider_id, team, created_at, updated_at
 		FROM users
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -467,6 +473,7 @@ func (r *UserRepository) GetAll(limit, offset int) ([]models.User, error) {
 			&user.LastLoginAt,
 			&user.OAuthProvider,
 			&user.OAuthProviderID,
+			&user.Team,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		); err != nil {
@@ -483,7 +490,7 @@ func (r *UserRepository) GetAllWithFilters(filters UserFilters, limit, offset in
 	query := `
 		SELECT DISTINCT u.id, u.email, u.password_hash, u.first_name, u.last_name, u.email_verified, 
 		       u.email_verified_at, u.is_active, u.last_login_at, u.oauth_provider, u.oauth_provider_id, 
-		       u.created_at, u.updated_at
+		       u.team, u.created_at, u.updated_at
 		FROM users u
 		LEFT JOIN user_roles ur ON u.id = ur.user_id
 		WHERE 1=1
@@ -545,7 +552,7 @@ func (r *UserRepository) GetAllWithFilters(filters UserFilters, limit, offset in
 
 	// Group by user and filter by role count if roles are specified
 	if len(filters.RoleIDs) > 0 {
-		query += ` GROUP BY u.id, u.email, u.password_hash, u.first_name, u.last_name, u.email_verified, u.email_verified_at, u.is_active, u.last_login_at, u.oauth_provider, u.oauth_provider_id, u.created_at, u.updated_at`
+		query += ` GROUP BY u.id, u.email, u.password_hash, u.first_name, u.last_name, u.email_verified, u.email_verified_at, u.is_active, u.last_login_at, u.oauth_provider, u.oauth_provider_id, u.team, u.created_at, u.updated_at`
 		query += fmt.Sprintf(` HAVING COUNT(DISTINCT ur.role_id) = %d`, len(filters.RoleIDs))
 	}
 
@@ -573,6 +580,7 @@ func (r *UserRepository) GetAllWithFilters(filters UserFilters, limit, offset in
 			&user.LastLoginAt,
 			&user.OAuthProvider,
 			&user.OAuthProviderID,
+			&user.Team,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		); err != nil {
@@ -0,0 +1,343 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"new-pay/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// WorkflowRepository handles assessment workflow definitions
+type WorkflowRepository struct {
+	db *sql.DB
+}
+
+// NewWorkflowRepository creates a new workflow repository
+func NewWorkflowRepository(db *sql.DB) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// Create creates a workflow definition with its states and transitions
+func (r *WorkflowRepository) Create(workflow *models.WorkflowDefinition) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if workflow.IsDefault {
+		if _, err := tx.Exec(`UPDATE workflow_definitions SET is_default = FALSE WHERE is_default`); err != nil {
+			return fmt.Errorf("failed to reset default workflow: %w", err)
+		}
+	}
+
+	if err := tx.QueryRow(`
+		INSERT INTO workflow_definitions (name, description, is_default, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`, workflow.Name, workflow.Description, workflow.IsDefault, workflow.CreatedBy).Scan(&workflow.ID, &workflow.CreatedAt, &workflow.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	if err := r.insertStatesAndTransitions(tx, workflow); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update replaces a workflow definition including its states and transitions
+func (r *WorkflowRepository) Update(workflow *models.WorkflowDefinition) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if workflow.IsDefault {
+		if _, err := tx.Exec(`UPDATE workflow_definitions SET is_default = FALSE WHERE is_default AND id <> $1`, workflow.ID); err != nil {
+			return fmt.Errorf("failed to reset default workflow: %w", err)
+		}
+	}
+
+	if err := tx.QueryRow(`
+		UPDATE workflow_definitions
+		SET name = $1, description = $2, is_default = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING updated_at
+	`, workflow.Name, workflow.Description, workflow.IsDefault, workflow.ID).Scan(&workflow.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to update workflow: %w", err)
+	}
+
+	// Guards are removed by cascade
+	if _, err := tx.Exec(`DELETE FROM workflow_transitions WHERE workflow_id = $1`, workflow.ID); err != nil {
+		return fmt.Errorf("failed to remove workflow transitions: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM workflow_states WHERE workflow_id = $1`, workflow.ID); err != nil {
+		return fmt.Errorf("failed to remove workflow states: %w", err)
+	}
+
+	if err := r.insertStatesAndTransitions(tx, workflow); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *WorkflowRepository) insertStatesAndTransitions(tx *sql.Tx, workflow *models.WorkflowDefinition) error {
+	for i, state := range workflow.States {
+		onEnter := state.OnEnter
+		if onEnter == nil {
+			onEnter = []string{}
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO workflow_states (workflow_id, name, is_final, on_enter, sort_order)
+			VALUES ($1, $2, $3, $4, $5)
+		`, workflow.ID, state.Name, state.Final, pq.Array(onEnter), i); err != nil {
+			return fmt.Errorf("failed to create workflow state %s: %w", state.Name, err)
+		}
+	}
+
+	for i, transition := range workflow.Transitions {
+		var transitionID uint
+		if err := tx.QueryRow(`
+			INSERT INTO workflow_transitions (workflow_id, from_state, to_state, roles, exclude_owner, sort_order)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`, workflow.ID, transition.From, transition.To, pq.Array(transition.Roles), transition.ExcludeOwner, i).Scan(&transitionID); err != nil {
+			return fmt.Errorf("failed to create workflow transition %s -> %s: %w", transition.From, transition.To, err)
+		}
+
+		for _, guard := range transition.Guards {
+			if _, err := tx.Exec(`
+				INSERT INTO workflow_transition_guards (transition_id, guard_type, value)
+				VALUES ($1, $2, $3)
+			`, transitionID, guard.Type, guard.Value); err != nil {
+				return fmt.Errorf("failed to create workflow guard %s: %w", guard.Type, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes a workflow definition
+func (r *WorkflowRepository) Delete(id uint) error {
+	_, err := r.db.Exec(`DELETE FROM workflow_definitions WHERE id = $1`, id)
+	return err
+}
+
+const workflowColumns = `id, name, description, is_default, created_by, created_at, updated_at`
+
+// GetByID retrieves a workflow definition with states and transitions (nil if not found)
+func (r *WorkflowRepository) GetByID(id uint) (*models.WorkflowDefinition, error) {
+	return r.getOne(`SELECT `+workflowColumns+` FROM workflow_definitions WHERE id = $1`, id)
+}
+
+// GetDefault retrieves the stored default workflow (nil if the built-in workflow is used)
+func (r *WorkflowRepository) GetDefault() (*models.WorkflowDefinition, error) {
+	return r.getOne(`SELECT ` + workflowColumns + ` FROM workflow_definitions WHERE is_default`)
+}
+
+// GetByCatalogID retrieves the workflow assigned to a catalog (nil if the catalog uses the default workflow)
+func (r *WorkflowRepository) GetByCatalogID(catalogID uint) (*models.WorkflowDefinition, error) {
+	return r.getOne(`
+		SELECT w.id, w.name, w.description, w.is_default, w.created_by, w.created_at, w.updated_at
+		FROM workflow_definitions w
+		JOIN criteria_catalogs c ON c.workflow_id = w.id
+		WHERE c.id = $1
+	`, catalogID)
+}
+
+// GetAll retrieves all workflow definitions with states and transitions
+func (r *WorkflowRepository) GetAll() ([]models.WorkflowDefinition, error) {
+	rows, err := r.db.Query(`SELECT ` + workflowColumns + ` FROM workflow_definitions ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflows: %w", err)
+	}
+	defer rows.Close()
+
+	workflows := []models.WorkflowDefinition{}
+	for rows.Next() {
+		var w models.WorkflowDefinition
+		if err := rows.Scan(&w.ID, &w.Name, &w.Description, &w.IsDefault, &w.CreatedBy, &w.CreatedAt, &w.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workflow: %w", err)
+		}
+		workflows = append(workflows, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range workflows {
+		if err := r.loadStatesAndTransitions(&workflows[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return workflows, nil
+}
+
+func (r *WorkflowRepository) getOne(query string, args ...interface{}) (*models.WorkflowDefinition, error) {
+	var w models.WorkflowDefinition
+	err := r.db.QueryRow(query, args...).Scan(&w.ID, &w.Name, &w.Description, &w.IsDefault, &w.CreatedBy, &w.CreatedAt, &w.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	if err := r.loadStatesAndTransitions(&w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *WorkflowRepository) loadStatesAndTransitions(workflow *models.WorkflowDefinition) error {
+	stateRows, err := r.db.Query(`
+		SELECT name, is_final, on_enter
+		FROM workflow_states
+		WHERE workflow_id = $1
+		ORDER BY sort_order, id
+	`, workflow.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow states: %w", err)
+	}
+	defer stateRows.Close()
+
+	workflow.States = []models.WorkflowState{}
+	for stateRows.Next() {
+		var state models.WorkflowState
+		if err := stateRows.Scan(&state.Name, &state.Final, pq.Array(&state.OnEnter)); err != nil {
+			return fmt.Errorf("failed to scan workflow state: %w", err)
+		}
+		workflow.States = append(workflow.States, state)
+	}
+	if err := stateRows.Err(); err != nil {
+		return err
+	}
+
+	transitionRows, err := r.db.Query(`
+		SELECT id, from_state, to_state, roles, exclude_owner
+		FROM workflow_transitions
+		WHERE workflow_id = $1
+		ORDER BY sort_order, id
+	`, workflow.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow transitions: %w", err)
+	}
+	defer transitionRows.Close()
+
+	workflow.Transitions = []models.WorkflowTransition{}
+	var transitionIDs []uint
+	for transitionRows.Next() {
+		var id uint
+		var transition models.WorkflowTransition
+		if err := transitionRows.Scan(&id, &transition.From, &transition.To, pq.Array(&transition.Roles), &transition.ExcludeOwner); err != nil {
+			return fmt.Errorf("failed to scan workflow transition: %w", err)
+		}
+		transitionIDs = append(transitionIDs, id)
+		workflow.Transitions = append(workflow.Transitions, transition)
+	}
+	if err := transitionRows.Err(); err != nil {
+		return err
+	}
+
+	for i, id := range transitionIDs {
+		guards, err := r.getGuards(id)
+		if err != nil {
+			return err
+		}
+		workflow.Transitions[i].Guards = guards
+	}
+
+	return nil
+}
+
+func (r *WorkflowRepository) getGuards(transitionID uint) ([]models.WorkflowGuard, error) {
+	rows, err := r.db.Query(`
+		SELECT guard_type, COALESCE(value, 0)
+		FROM workflow_transition_guards
+		WHERE transition_id = $1
+		ORDER BY id
+	`, transitionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow guards: %w", err)
+	}
+	defer rows.Close()
+
+	var guards []models.WorkflowGuard
+	for rows.Next() {
+		var guard models.WorkflowGuard
+		if err := rows.Scan(&guard.Type, &guard.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan workflow guard: %w", err)
+		}
+		guards = append(guards, guard)
+	}
+
+	return guards, rows.Err()
+}
+
+// SetCatalogWorkflow assigns a workflow to a catalog (nil = default workflow)
+func (r *WorkflowRepository) SetCatalogWorkflow(catalogID uint, workflowID *uint) error {
+	result, err := r.db.Exec(`UPDATE criteria_catalogs SET workflow_id = $1 WHERE id = $2`, workflowID, catalogID)
+	if err != nil {
+		return fmt.Errorf("failed to assign workflow: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("catalog not found")
+	}
+	return nil
+}
+
+// CountCatalogsByWorkflow returns the number of catalogs that use a workflow
+func (r *WorkflowRepository) CountCatalogsByWorkflow(workflowID uint) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM criteria_catalogs WHERE workflow_id = $1`, workflowID).Scan(&count)
+	return count, err
+}
+
+// GetStatusesInUse returns the distinct statuses of self-assessments whose catalog uses the workflow;
+// includeUnassigned also covers catalogs without own workflow (for the default workflow)
+func (r *WorkflowRepository) GetStatusesInUse(workflowID uint, includeUnassigned bool) ([]string, error) {
+	return r.queryStatuses(`
+		SELECT DISTINCT sa.status
+		FROM self_assessments sa
+		JOIN criteria_catalogs c ON c.id = sa.catalog_id
+		WHERE c.workflow_id = $1 OR ($2 AND c.workflow_id IS NULL)
+		ORDER BY sa.status
+	`, workflowID, includeUnassigned)
+}
+
+// GetStatusesByCatalog returns the distinct statuses of the self-assessments of a catalog
+func (r *WorkflowRepository) GetStatusesByCatalog(catalogID uint) ([]string, error) {
+	return r.queryStatuses(`
+		SELECT DISTINCT status FROM self_assessments WHERE catalog_id = $1 ORDER BY status
+	`, catalogID)
+}
+
+func (r *WorkflowRepository) queryStatuses(query string, args ...interface{}) ([]string, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []string
+	for rows.Next() {
+		var status string
+		if err := rows.Scan(&status); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, rows.Err()
+}
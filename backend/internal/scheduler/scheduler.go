@@ -9,6 +9,7 @@ import (
 	"new-pay/internal/models"
 	"new-pay/internal/repository"
 	"new-pay/internal/securestore"
+	"new-pay/internal/service"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +20,11 @@ type Scheduler struct {
 	selfAssessmentRepo *repository.SelfAssessmentRepository
 	userRepo           *repository.UserRepository
 	roleRepo           *repository.RoleRepository
+	assignmentRepo     *repository.ReviewerAssignmentRepository
+	catalogService     *service.CatalogService
+	assignmentService  *service.ReviewAssignmentService
+	workflowService    *service.WorkflowService
+	auditService       *service.AuditService
 	emailService       *email.Service
 	secureStore        *securestore.SecureStore
 	db                 *sql.DB
@@ -31,6 +37,11 @@ func NewScheduler(
 	selfAssessmentRepo *repository.SelfAssessmentRepository,
 	userRepo *repository.UserRepository,
 	roleRepo *repository.RoleRepository,
+	assignmentRepo *repository.ReviewerAssignmentRepository,
+	catalogService *service.CatalogService,
+	assignmentService *service.ReviewAssignmentService,
+	workflowService *service.WorkflowService,
+	auditService *service.AuditService,
 	emailService *email.Service,
 	secureStore *securestore.SecureStore,
 	db *sql.DB,
@@ -40,6 +51,11 @@ func NewScheduler(
 		selfAssessmentRepo: selfAssessmentRepo,
 		userRepo:           userRepo,
 		roleRepo:           roleRepo,
+		assignmentRepo:     assignmentRepo,
+		catalogService:     catalogService,
+		assignmentService:  assignmentService,
+		workflowService:    workflowService,
+		auditService:       auditService,
 		emailService:       emailService,
 		secureStore:        secureStore,
 		db:                 db,
@@ -53,7 +69,9 @@ func (s *Scheduler) Start() {
 	slog.Info("Starting scheduler",
 		"draft_reminders_enabled", s.config.EnableDraftReminders,
 		"reviewer_summary_enabled", s.config.EnableReviewerSummary,
-		"hash_chain_validation_enabled", s.config.EnableHashChainValidation)
+		"hash_chain_validation_enabled", s.config.EnableHashChainValidation,
+		"catalog_lifecycle_enabled", s.config.EnableCatalogLifecycle,
+		"panel_assignment_enabled", s.config.EnablePanelAssignment)
 
 	if s.config.EnableDraftReminders {
 		// Parse cron and start draft reminders
@@ -76,6 +94,20 @@ func (s *Scheduler) Start() {
 		}
 	}
 
+	if s.config.EnableCatalogLifecycle {
+		// Parse cron and start automatic catalog activation and archiving
+		if err := s.startCronTask(s.config.CatalogLifecycleCron, "catalog_lifecycle", s.runCatalogLifecycle); err != nil {
+			slog.Error("Failed to start catalog lifecycle", "error", err)
+		}
+	}
+
+	if s.config.EnablePanelAssignment {
+		// Parse cron and start automatic reviewer panel assignment
+		if err := s.startCronTask(s.config.PanelAssignmentCron, "panel_assignment", s.assignReviewerPanels); err != nil {
+			slog.Error("Failed to start panel assignment", "error", err)
+		}
+	}
+
 	slog.Info("Scheduler started")
 }
 
@@ -414,14 +446,25 @@ func (s *Scheduler) sendReviewerSummaries() {
 		}
 	}
 
-	// Send summary to each reviewer
+	// Send summary to each reviewer (only assessments of their reviewer panels)
 	summariesSent := 0
 	for _, reviewer := range reviewers {
-		if len(allItems) == 0 {
+		assigned, err := s.assignmentRepo.GetAssessmentIDsByReviewer(reviewer.ID)
+		if err != nil {
+			slog.Error("Failed to get assigned assessments", "reviewer_email", reviewer.Email, "error", err)
+			continue
+		}
+		var items []email.ReviewSummaryItem
+		for _, item := range allItems {
+			if assigned[item.ID] {
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
 			continue // Don't send empty summaries
 		}
 
-		err := s.emailService.SendReviewerDailySummary(reviewer.Email, allItems)
+		err = s.emailService.SendReviewerDailySummary(reviewer.Email, items)
 		if err != nil {
 			slog.Error("Failed to send reviewer summary",
 				"reviewer_email", reviewer.Email,
@@ -433,7 +476,7 @@ func (s *Scheduler) sendReviewerSummaries() {
 		summariesSent++
 		slog.Info("Reviewer summary sent",
 			"reviewer_email", reviewer.Email,
-			"items_count", len(allItems),
+			"items_count", len(items),
 		)
 	}
 
@@ -443,11 +486,184 @@ func (s *Scheduler) sendReviewerSummaries() {
 	)
 }
 
+// runCatalogLifecycle archives expired catalogs and activates catalogs whose validity period has started
+func (s *Scheduler) runCatalogLifecycle() {
+	slog.Info("Running catalog lifecycle transitions")
+
+	now := time.Now()
+
+	// Archive first so a successor catalog starting today does not overlap an expired one
+	archived, err := s.catalogService.ArchiveExpiredCatalogs(now)
+	if err != nil {
+		slog.Error("Failed to archive expired catalogs", "error", err)
+	}
+	for _, catalog := range archived {
+		slog.Info("Catalog archived automatically", "catalog_id", catalog.ID, "valid_until", catalog.ValidUntil)
+		s.handleOpenAssessmentsOfExpiredCatalog(catalog)
+	}
+
+	activated, err := s.catalogService.ActivateDueCatalogs(now)
+	if err != nil {
+		slog.Error("Failed to activate due catalogs", "error", err)
+	}
+	for _, catalog := range activated {
+		slog.Info("Catalog activated automatically", "catalog_id", catalog.ID, "valid_from", catalog.ValidFrom)
+	}
+
+	slog.Info("Catalog lifecycle transitions completed",
+		"archived", len(archived),
+		"activated", len(activated),
+	)
+}
+
+// assignReviewerPanels assigns balanced reviewer panels to submitted self-assessments without panel
+func (s *Scheduler) assignReviewerPanels() {
+	slog.Info("Assigning reviewer panels")
+
+	assigned, err := s.assignmentService.AssignPendingPanels()
+	if err != nil {
+		slog.Error("Failed to assign reviewer panels", "error", err)
+		return
+	}
+
+	slog.Info("Reviewer panel assignment completed", "assigned", assigned)
+
+	// Panels that are too small are not changed automatically, they need an admin or team lead
+	incomplete, err := s.assignmentService.GetIncompletePanels()
+	if err != nil {
+		slog.Error("Failed to get incomplete reviewer panels", "error", err)
+		return
+	}
+	if len(incomplete) > 0 {
+		slog.Warn("Self-assessments with incomplete reviewer panel, see GET /api/v1/review/panels/incomplete", "count", len(incomplete))
+	}
+}
+
+// handleOpenAssessmentsOfExpiredCatalog applies the configured expiry policy to self-assessments
+// that are still open: drafts are closed, owners and reviewers are notified
+func (s *Scheduler) handleOpenAssessmentsOfExpiredCatalog(catalog models.CriteriaCatalog) {
+	assessments, err := s.selfAssessmentRepo.GetByCatalogID(catalog.ID)
+	if err != nil {
+		slog.Error("Failed to get assessments of expired catalog", "catalog_id", catalog.ID, "error", err)
+		return
+	}
+
+	var reviewItems []email.ReviewSummaryItem
+	draftsClosed := 0
+	for _, assessment := range assessments {
+		if assessment.Status == "archived" || assessment.Status == "closed" {
+			continue
+		}
+
+		closed := false
+		if assessment.Status == "draft" && s.config.CloseDraftsOnExpiry {
+			var err error
+			closed, err = s.closeExpiredDraft(&assessment.SelfAssessment, catalog)
+			if err != nil {
+				slog.Error("Failed to close draft assessment", "assessment_id", assessment.ID, "error", err)
+				continue
+			}
+			if closed {
+				draftsClosed++
+			}
+		} else if assessment.Status != "draft" {
+			reviewItems = append(reviewItems, email.ReviewSummaryItem{
+				ID:          assessment.ID,
+				UserName:    assessment.UserName,
+				UserEmail:   assessment.UserEmail,
+				CatalogName: catalog.Name,
+				Status:      assessment.Status,
+			})
+		}
+
+		if s.config.NotifyOnExpiry {
+			if err := s.emailService.SendCatalogExpiredNotification(assessment.UserEmail, assessment.UserName, catalog.Name, assessment.ID, closed); err != nil {
+				slog.Error("Failed to send catalog expiry notification",
+					"assessment_id", assessment.ID,
+					"user_email", assessment.UserEmail,
+					"error", err,
+				)
+			}
+		}
+	}
+
+	if s.config.NotifyOnExpiry && len(reviewItems) > 0 {
+		// Reviewers only hear about the assessments of their reviewer panels
+		itemsByReviewer, err := s.expiryItemsByReviewer(reviewItems)
+		if err != nil {
+			slog.Error("Failed to get reviewer panels", "catalog_id", catalog.ID, "error", err)
+		}
+		for reviewerEmail, items := range itemsByReviewer {
+			if err := s.emailService.SendCatalogExpiredReviewerNotification(reviewerEmail, catalog.Name, items); err != nil {
+				slog.Error("Failed to send catalog expiry notification to reviewer",
+					"reviewer_email", reviewerEmail,
+					"error", err,
+				)
+			}
+		}
+	}
+
+	slog.Info("Expiry policy applied",
+		"catalog_id", catalog.ID,
+		"drafts_closed", draftsClosed,
+		"open_in_review", len(reviewItems),
+	)
+}
+
+// closeExpiredDraft closes a draft of an expired catalog if the workflow of the catalog allows
+// draft -> closed and runs the on-enter actions. Without that transition the draft stays open.
+func (s *Scheduler) closeExpiredDraft(assessment *models.SelfAssessment, catalog models.CriteriaCatalog) (bool, error) {
+	allowed, err := s.workflowService.HasTransition(assessment, "closed")
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		slog.Warn("Workflow does not allow closing the draft of an expired catalog", "assessment_id", assessment.ID, "catalog_id", catalog.ID)
+		return false, nil
+	}
+
+	if err := s.selfAssessmentRepo.UpdateStatus(assessment.ID, "closed"); err != nil {
+		return false, err
+	}
+	s.auditService.LogSystem("update_status", "self_assessment",
+		fmt.Sprintf("Self-assessment %d status changed: draft -> closed (catalog %d expired)", assessment.ID, catalog.ID))
+
+	assessment.Status = "closed"
+	s.workflowService.OnEnter(assessment)
+	return true, nil
+}
+
+// expiryItemsByReviewer groups the open assessments of an expired catalog by the email address
+// of the reviewers on their panels. Assessments without panel are not reported to any reviewer.
+func (s *Scheduler) expiryItemsByReviewer(items []email.ReviewSummaryItem) (map[string][]email.ReviewSummaryItem, error) {
+	reviewerEmails := make(map[uint]string)
+	itemsByReviewer := make(map[string][]email.ReviewSummaryItem)
+	for _, item := range items {
+		panel, err := s.assignmentRepo.GetByAssessmentID(item.ID)
+		if err != nil {
+			return itemsByReviewer, err
+		}
+		for _, assignment := range panel {
+			reviewerEmail, ok := reviewerEmails[assignment.ReviewerUserID]
+			if !ok {
+				reviewer, err := s.userRepo.GetByID(assignment.ReviewerUserID)
+				if err != nil {
+					return itemsByReviewer, err
+				}
+				reviewerEmail = reviewer.Email
+				reviewerEmails[assignment.ReviewerUserID] = reviewerEmail
+			}
+			itemsByReviewer[reviewerEmail] = append(itemsByReviewer[reviewerEmail], item)
+		}
+	}
+	return itemsByReviewer, nil
+}
+
 // validateHashChains validates all hash chains and alerts admins on errors
 func (s *Scheduler) validateHashChains() {
-	// Skip if secure store is not available (Vault disabled)
+	// Skip if secure store is not available (encryption disabled)
 	if s.secureStore == nil {
-		slog.Warn("Hash chain validation skipped - Vault is disabled")
+		slog.Warn("Hash chain validation skipped - encryption is disabled")
 		return
 	}
 
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"testing"
+
+	"new-pay/internal/config"
+	"new-pay/internal/email"
+	"new-pay/internal/repository"
+	"new-pay/internal/service"
+	"new-pay/internal/testutil"
+)
+
+// TestHandleOpenAssessmentsOfExpiredCatalog verifies the expiry policy and the reviewers it notifies
+func TestHandleOpenAssessmentsOfExpiredCatalog(t *testing.T) {
+	containers := testutil.SetupTestContainers(t)
+	defer containers.Cleanup(t)
+
+	db := containers.DB
+	fixtures := testutil.SetupFixtures(t, db)
+
+	draft := fixtures.CreateSelfAssessment(t, fixtures.RegularUser.ID, "draft")
+	submitted := fixtures.CreateSelfAssessment(t, fixtures.ReviewerUser.ID, "submitted")
+	if _, err := db.Exec(`
+		INSERT INTO assessment_reviewer_assignments (assessment_id, reviewer_user_id)
+		VALUES ($1, $2)
+	`, submitted.ID, fixtures.AdminUser.ID); err != nil {
+		t.Fatalf("Failed to assign reviewer: %v", err)
+	}
+
+	selfAssessmentRepo := repository.NewSelfAssessmentRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	assignmentRepo := repository.NewReviewerAssignmentRepository(db)
+	auditService := service.NewAuditService(repository.NewAuditRepository(db))
+	emailService := email.NewService(&config.EmailConfig{})
+	workflowService := service.NewWorkflowService(
+		repository.NewWorkflowRepository(db),
+		selfAssessmentRepo,
+		repository.NewAssessmentResponseRepository(db),
+		repository.NewReviewerResponseRepository(db),
+		assignmentRepo,
+		repository.NewAppealRepository(db),
+		userRepo,
+		auditService,
+		emailService,
+	)
+	s := NewScheduler(
+		selfAssessmentRepo,
+		userRepo,
+		repository.NewRoleRepository(db),
+		assignmentRepo,
+		nil,
+		nil,
+		workflowService,
+		auditService,
+		emailService,
+		nil,
+		db,
+		&config.SchedulerConfig{CloseDraftsOnExpiry: true},
+	)
+
+	s.handleOpenAssessmentsOfExpiredCatalog(*fixtures.Catalog)
+
+	for id, want := range map[uint]string{draft.ID: "closed", submitted.ID: "submitted"} {
+		assessment, err := selfAssessmentRepo.GetByID(id)
+		if err != nil {
+			t.Fatalf("Failed to load assessment %d: %v", id, err)
+		}
+		if assessment.Status != want {
+			t.Errorf("assessment %d has status %s, want %s", id, assessment.Status, want)
+		}
+	}
+
+	// Only the panel reviewer of the open assessment is notified, not every reviewer
+	items := []email.ReviewSummaryItem{{ID: submitted.ID, CatalogName: fixtures.Catalog.Name, Status: "submitted"}}
+	itemsByReviewer, err := s.expiryItemsByReviewer(items)
+	if err != nil {
+		t.Fatalf("expiryItemsByReviewer() failed: %v", err)
+	}
+	if len(itemsByReviewer) != 1 || len(itemsByReviewer[fixtures.AdminUser.Email]) != 1 {
+		t.Errorf("expiryItemsByReviewer() = %v, want only %s", itemsByReviewer, fixtures.AdminUser.Email)
+	}
+}
@@ -0,0 +1,122 @@
+package securestore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// ChainVersionV1 hashes prev_hash:signature:user_id:process_id:unix_time only
+	ChainVersionV1 = 1
+	// ChainVersionV2 commits to all fields of the record and a digest of the ciphertext
+	ChainVersionV2 = 2
+
+	// CurrentChainVersion is the chain version of new records
+	CurrentChainVersion = ChainVersionV2
+)
+
+// genesisHash is the previous hash of the first record of a process
+const genesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// chainEntryV2 is the hashed content of a version 2 chain entry. The JSON encoding of a struct
+// has a fixed field order, so the hash input is canonical and field boundaries are unambiguous.
+type chainEntryV2 struct {
+	Version            int    `json:"version"`
+	PrevHash           string `json:"prev_hash"`
+	ProcessID          string `json:"process_id"`
+	UserID             int64  `json:"user_id"`
+	CreatedAt          int64  `json:"created_at"` // Unix microseconds, the precision of the database
+	RecordType         string `json:"record_type"`
+	Status             string `json:"status"`
+	KeyVersion         int    `json:"key_version"`
+	SystemKeyID        string `json:"system_key_id"`
+	ProcessKeyHash     string `json:"process_key_hash"`
+	DataSignature      string `json:"data_signature"`
+	SignaturePublicKey string `json:"signature_public_key"`
+	CiphertextDigest   string `json:"ciphertext_digest"`
+}
+
+// computeChainHash calculates the chain hash of a record under the rules of its chain version.
+// The original payload of the record is hashed; re-encrypted payloads are bound to the chain
+// hash by their signature instead.
+func computeChainHash(record *SecureRecord) (string, error) {
+	var input []byte
+	switch record.ChainVersion {
+	case ChainVersionV1:
+		input = []byte(fmt.Sprintf("%s:%s:%d:%s:%d",
+			record.PrevRecordHash,
+			record.DataSignature,
+			record.UserID,
+			record.ProcessID,
+			record.CreatedAt.Unix(),
+		))
+	case ChainVersionV2:
+		var err error
+		input, err = json.Marshal(chainEntryV2{
+			Version:            ChainVersionV2,
+			PrevHash:           record.PrevRecordHash,
+			ProcessID:          record.ProcessID,
+			UserID:             record.UserID,
+			CreatedAt:          record.CreatedAt.UnixMicro(),
+			RecordType:         record.RecordType,
+			Status:             record.Status,
+			KeyVersion:         record.KeyVersion,
+			SystemKeyID:        record.SystemKeyID,
+			ProcessKeyHash:     record.ProcessKeyHash,
+			DataSignature:      record.DataSignature,
+			SignaturePublicKey: record.SignaturePublicKey,
+			CiphertextDigest:   ciphertextDigest(record.EncryptedData, record.EncryptionNonce, record.EncryptionTag),
+		})
+		if err != nil {
+			return "", fmt.Errorf("marshal chain entry failed: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("unsupported chain version %d", record.ChainVersion)
+	}
+
+	hash := sha256.Sum256(input)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// ciphertextDigest returns the SHA-256 digest of encrypted data + nonce + tag.
+// Nonce and tag have a fixed size, so the concatenation is unambiguous.
+func ciphertextDigest(encryptedData, nonce, tag []byte) string {
+	digest := sha256.Sum256(signatureInput(encryptedData, nonce, tag, ""))
+	return hex.EncodeToString(digest[:])
+}
+
+// verifyChainEntry checks the linkage and the chain hash of a record. lastVersion is the highest chain
+// version of the previous records; a chain must not fall back to an older version.
+func verifyChainEntry(record *SecureRecord, prevHash string, lastVersion int) []string {
+	var errors []string
+
+	if record.PrevRecordHash != prevHash {
+		errors = append(errors, fmt.Sprintf("chain broken at record %d: expected prev_hash=%s, got=%s",
+			record.ID, prevHash, record.PrevRecordHash))
+	}
+
+	if record.ChainVersion < lastVersion {
+		errors = append(errors, fmt.Sprintf("record %d: chain version %d follows version %d",
+			record.ID, record.ChainVersion, lastVersion))
+	}
+
+	hash, err := computeChainHash(record)
+	if err == nil && hash != record.ChainHash && record.ChainVersion == ChainVersionV1 && record.CreatedAt.Nanosecond() == 0 {
+		// Version 1 records hashed the unrounded time; the database rounds to microseconds,
+		// which can carry a time just below a full second into the next one
+		rounded := *record
+		rounded.CreatedAt = record.CreatedAt.Add(-time.Microsecond)
+		hash, err = computeChainHash(&rounded)
+	}
+	if err != nil {
+		errors = append(errors, fmt.Sprintf("record %d: %v", record.ID, err))
+	} else if hash != record.ChainHash {
+		errors = append(errors, fmt.Sprintf("record %d: chain hash mismatch (version %d): expected %s, got=%s",
+			record.ID, record.ChainVersion, hash, record.ChainHash))
+	}
+
+	return errors
+}
@@ -0,0 +1,116 @@
+package securestore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func testRecord(id int64, prevHash string, version int) *SecureRecord {
+	record := &SecureRecord{
+		ID:                 id,
+		ProcessID:          "assessment-7",
+		UserID:             42,
+		CreatedAt:          time.Date(2026, 10, 1, 12, 0, 0, 123456000, time.UTC),
+		EncryptedData:      []byte("ciphertext"),
+		EncryptionNonce:    make([]byte, 12),
+		EncryptionTag:      make([]byte, 16),
+		KeyVersion:         1,
+		SystemKeyID:        "system",
+		ProcessKeyHash:     "aa",
+		DataSignature:      fmt.Sprintf("%02x", id),
+		SignaturePublicKey: "bb",
+		RecordType:         "self_assessment",
+		Status:             "closed",
+		PrevRecordHash:     prevHash,
+		ChainVersion:       version,
+	}
+	record.ChainHash, _ = computeChainHash(record)
+	return record
+}
+
+func TestComputeChainHashV1(t *testing.T) {
+	record := testRecord(1, genesisHash, ChainVersionV1)
+
+	// The version 1 format of existing records must not change
+	input := fmt.Sprintf("%s:%s:%d:%s:%d", genesisHash, "01", 42, "assessment-7", record.CreatedAt.Unix())
+	want := sha256.Sum256([]byte(input))
+	if record.ChainHash != hex.EncodeToString(want[:]) {
+		t.Errorf("computeChainHash() = %s, want %s", record.ChainHash, hex.EncodeToString(want[:]))
+	}
+
+	// Version 1 does not cover the record type
+	changed := *record
+	changed.RecordType = "manipulated"
+	if hash, _ := computeChainHash(&changed); hash != record.ChainHash {
+		t.Error("version 1 hash should not depend on the record type")
+	}
+}
+
+func TestComputeChainHashV2(t *testing.T) {
+	record := testRecord(1, genesisHash, ChainVersionV2)
+
+	changes := map[string]func(r *SecureRecord){
+		"record type":      func(r *SecureRecord) { r.RecordType = "manipulated" },
+		"status":           func(r *SecureRecord) { r.Status = "draft" },
+		"key version":      func(r *SecureRecord) { r.KeyVersion = 2 },
+		"system key":       func(r *SecureRecord) { r.SystemKeyID = "other" },
+		"process key hash": func(r *SecureRecord) { r.ProcessKeyHash = "cc" },
+		"public key":       func(r *SecureRecord) { r.SignaturePublicKey = "dd" },
+		"ciphertext":       func(r *SecureRecord) { r.EncryptedData = []byte("Ciphertext") },
+		"nonce":            func(r *SecureRecord) { r.EncryptionNonce = make([]byte, 12); r.EncryptionNonce[0] = 1 },
+		"created at":       func(r *SecureRecord) { r.CreatedAt = r.CreatedAt.Add(time.Microsecond) },
+	}
+
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			changed := *record
+			change(&changed)
+			if hash, _ := computeChainHash(&changed); hash == record.ChainHash {
+				t.Errorf("version 2 hash should change with the %s", name)
+			}
+		})
+	}
+
+	if _, err := computeChainHash(&SecureRecord{ChainVersion: 3}); err == nil {
+		t.Error("expected error for unsupported chain version")
+	}
+}
+
+func TestVerifyChainEntry(t *testing.T) {
+	v1 := testRecord(1, genesisHash, ChainVersionV1)
+	v2 := testRecord(2, v1.ChainHash, ChainVersionV2)
+
+	if errs := verifyChainEntry(v1, genesisHash, ChainVersionV1); len(errs) != 0 {
+		t.Errorf("valid v1 record reported errors: %v", errs)
+	}
+	if errs := verifyChainEntry(v2, v1.ChainHash, ChainVersionV1); len(errs) != 0 {
+		t.Errorf("valid v2 record after v1 reported errors: %v", errs)
+	}
+
+	tampered := *v2
+	tampered.Status = "draft"
+	if errs := verifyChainEntry(&tampered, v1.ChainHash, ChainVersionV1); len(errs) != 1 {
+		t.Errorf("tampered status: got %d errors, want 1", len(errs))
+	}
+
+	if errs := verifyChainEntry(v2, genesisHash, ChainVersionV1); len(errs) != 1 {
+		t.Errorf("broken linkage: got %d errors, want 1", len(errs))
+	}
+
+	// Stored time rounded up to the next second by the database
+	rounded := *testRecord(1, genesisHash, ChainVersionV1)
+	rounded.CreatedAt = time.Date(2026, 10, 1, 12, 0, 0, 999999800, time.UTC)
+	rounded.ChainHash, _ = computeChainHash(&rounded)
+	rounded.CreatedAt = rounded.CreatedAt.Round(time.Microsecond)
+	if errs := verifyChainEntry(&rounded, genesisHash, ChainVersionV1); len(errs) != 0 {
+		t.Errorf("v1 record with rounded time reported errors: %v", errs)
+	}
+
+	downgraded := testRecord(3, v2.ChainHash, ChainVersionV1)
+	if errs := verifyChainEntry(downgraded, v2.ChainHash, ChainVersionV2); len(errs) != 1 {
+		t.Errorf("downgraded version: got %d errors, want 1", len(errs))
+	}
+}
@@ -2,7 +2,6 @@ package securestore
 
 import (
 	"crypto/ed25519"
-	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
@@ -15,22 +14,24 @@ import (
 
 // SecureRecord represents an encrypted and signed record
 type SecureRecord struct {
-	ID                 int64     `json:"id"`
-	ProcessID          string    `json:"process_id"`
-	UserID             int64     `json:"user_id"`
-	CreatedAt          time.Time `json:"created_at"`
-	EncryptedData      []byte    `json:"-"`
-	EncryptionNonce    []byte    `json:"-"`
-	EncryptionTag      []byte    `json:"-"`
-	KeyVersion         int       `json:"key_version"`
-	SystemKeyID        string    `json:"system_key_id"`
-	ProcessKeyHash     string    `json:"process_key_hash"`
-	DataSignature      string    `json:"data_signature"`
-	SignaturePublicKey string    `json:"signature_public_key"`
-	RecordType         string    `json:"record_type"`
-	Status             string    `json:"status,omitempty"`
-	PrevRecordHash     string    `json:"prev_record_hash"`
-	ChainHash          string    `json:"chain_hash"`
+	ID                 int64      `json:"id"`
+	ProcessID          string     `json:"process_id"`
+	UserID             int64      `json:"user_id"`
+	CreatedAt          time.Time  `json:"created_at"`
+	EncryptedData      []byte     `json:"-"`
+	EncryptionNonce    []byte     `json:"-"`
+	EncryptionTag      []byte     `json:"-"`
+	KeyVersion         int        `json:"key_version"`
+	SystemKeyID        string     `json:"system_key_id"`
+	ProcessKeyHash     string     `json:"process_key_hash"`
+	DataSignature      string     `json:"data_signature"`
+	SignaturePublicKey string     `json:"signature_public_key"`
+	RecordType         string     `json:"record_type"`
+	Status             string     `json:"status,omitempty"`
+	PrevRecordHash     string     `json:"prev_record_hash"`
+	ChainHash          string     `json:"chain_hash"`
+	ChainVersion       int        `json:"chain_version"`
+	ReencryptedAt      *time.Time `json:"reencrypted_at,omitempty"` // Set when the payload was re-encrypted after a key rotation
 }
 
 // PlainData represents unencrypted data structure
@@ -39,9 +40,16 @@ type PlainData struct {
 	Metadata map[string]string      `json:"metadata,omitempty"`
 }
 
+// dbtx is implemented by *sql.DB and *sql.Tx
+type dbtx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
 // SecureStore manages encrypted records with hash chain audit trail
 type SecureStore struct {
-	db         *sql.DB
+	db         dbtx
 	keyManager *keymanager.KeyManager
 }
 
@@ -53,6 +61,12 @@ func NewSecureStore(db *sql.DB, keyManager *keymanager.KeyManager) *SecureStore
 	}
 }
 
+// WithTx returns a copy of the store that writes and reads records in the given transaction,
+// so a record is only kept if the transaction commits
+func (ss *SecureStore) WithTx(tx *sql.Tx) *SecureStore {
+	return &SecureStore{db: tx, keyManager: ss.keyManager}
+}
+
 // CreateRecord encrypts, signs, and stores data
 func (ss *SecureStore) CreateRecord(
 	processID string,
@@ -72,8 +86,14 @@ func (ss *SecureStore) CreateRecord(
 		return nil, fmt.Errorf("marshal failed: %w", err)
 	}
 
+	// Get current process key version and its hash for verification
+	keyVersion, processKeyHash, err := ss.keyManager.GetCurrentProcessKeyVersion(processID)
+	if err != nil {
+		return nil, fmt.Errorf("process key version retrieval failed: %w", err)
+	}
+
 	// Get data encryption key (derived from all three keys)
-	dek, err := ss.keyManager.DeriveDataEncryptionKey(processID, userID)
+	dek, err := ss.keyManager.DeriveDataEncryptionKey(processID, userID, keyVersion)
 	if err != nil {
 		return nil, fmt.Errorf("key derivation failed: %w", err)
 	}
@@ -85,23 +105,13 @@ func (ss *SecureStore) CreateRecord(
 	}
 
 	// Encrypt with AES-256-GCM
-	additionalData := []byte(fmt.Sprintf("process:%s:user:%d:type:%s", processID, userID, recordType))
-	ciphertext, nonce, err := vault.EncryptLocal(plainBytes, dek, additionalData)
+	encryptedData, nonce, tag, err := encryptPayload(plainBytes, dek, additionalData(processID, userID, recordType))
 	if err != nil {
-		return nil, fmt.Errorf("encryption failed: %w", err)
+		return nil, err
 	}
 
-	// Extract GCM tag (last 16 bytes)
-	tagSize := 16
-	encryptedData := make([]byte, len(ciphertext)-tagSize)
-	copy(encryptedData, ciphertext[:len(ciphertext)-tagSize])
-	tag := make([]byte, tagSize)
-	copy(tag, ciphertext[len(ciphertext)-tagSize:])
-
 	// Sign: signature covers encrypted data + nonce + tag
-	signatureInput := append(encryptedData, nonce...)
-	signatureInput = append(signatureInput, tag...)
-	signature := ed25519.Sign(signingKey, signatureInput)
+	signature := ed25519.Sign(signingKey, signatureInput(encryptedData, nonce, tag, ""))
 
 	// Get previous hash for chain
 	prevHash, err := ss.getLatestHash(processID)
@@ -109,34 +119,16 @@ func (ss *SecureStore) CreateRecord(
 		return nil, fmt.Errorf("prev hash retrieval failed: %w", err)
 	}
 
-	// Calculate chain hash
-	now := time.Now().UTC()
-	chainInput := fmt.Sprintf("%s:%s:%d:%s:%d",
-		prevHash,
-		hex.EncodeToString(signature),
-		userID,
-		processID,
-		now.Unix(),
-	)
-	chainHashBytes := sha256.Sum256([]byte(chainInput))
-	chainHash := hex.EncodeToString(chainHashBytes[:])
-
-	// Get process key hash for verification
-	processKeyHash, err := ss.keyManager.GetProcessKeyHash(processID)
-	if err != nil {
-		return nil, fmt.Errorf("process key hash retrieval failed: %w", err)
-	}
-
-	// Create record
+	// Create record (created_at is stored with microsecond precision and part of the chain hash)
 	publicKey := ed25519.PublicKey(signingKey[32:])
 	record := &SecureRecord{
 		ProcessID:          processID,
 		UserID:             userID,
-		CreatedAt:          now,
+		CreatedAt:          time.Now().UTC().Truncate(time.Microsecond),
 		EncryptedData:      encryptedData,
 		EncryptionNonce:    nonce,
 		EncryptionTag:      tag,
-		KeyVersion:         1,
+		KeyVersion:         keyVersion,
 		SystemKeyID:        ss.keyManager.GetActiveSystemKeyID(),
 		ProcessKeyHash:     processKeyHash,
 		DataSignature:      hex.EncodeToString(signature),
@@ -144,7 +136,13 @@ func (ss *SecureStore) CreateRecord(
 		RecordType:         recordType,
 		Status:             status,
 		PrevRecordHash:     prevHash,
-		ChainHash:          chainHash,
+		ChainVersion:       CurrentChainVersion,
+	}
+
+	// Calculate chain hash over all fields of the record
+	record.ChainHash, err = computeChainHash(record)
+	if err != nil {
+		return nil, err
 	}
 
 	// Store in database
@@ -168,6 +166,22 @@ func (ss *SecureStore) DecryptRecord(recordID int64) (*PlainData, error) {
 
 // DecryptRecordData decrypts the data from a SecureRecord
 func (ss *SecureStore) DecryptRecordData(record *SecureRecord) (*PlainData, error) {
+	plainBytes, err := ss.decryptPayload(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deserialize
+	var data PlainData
+	if err := json.Unmarshal(plainBytes, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal failed: %w", err)
+	}
+
+	return &data, nil
+}
+
+// decryptPayload verifies the signature of a record and returns the decrypted payload
+func (ss *SecureStore) decryptPayload(record *SecureRecord) ([]byte, error) {
 	// Verify signature first
 	publicKey, err := hex.DecodeString(record.SignaturePublicKey)
 	if err != nil {
@@ -179,47 +193,47 @@ func (ss *SecureStore) DecryptRecordData(record *SecureRecord) (*PlainData, erro
 		return nil, fmt.Errorf("invalid signature: %w", err)
 	}
 
-	signatureInput := append(record.EncryptedData, record.EncryptionNonce...)
-	signatureInput = append(signatureInput, record.EncryptionTag...)
-
-	if !ed25519.Verify(publicKey, signatureInput, signature) {
+	// Re-encrypted payloads are additionally bound to the chain entry of the original record
+	boundChainHash := ""
+	if record.ReencryptedAt != nil {
+		boundChainHash = record.ChainHash
+	}
+	input := signatureInput(record.EncryptedData, record.EncryptionNonce, record.EncryptionTag, boundChainHash)
+	if !ed25519.Verify(publicKey, input, signature) {
 		return nil, fmt.Errorf("signature verification failed - data may be tampered")
 	}
 
-	// Get data encryption key
-	dek, err := ss.keyManager.DeriveDataEncryptionKey(record.ProcessID, record.UserID)
+	// Get data encryption key for the key version of the record
+	dek, err := ss.keyManager.DeriveDataEncryptionKey(record.ProcessID, record.UserID, record.KeyVersion)
 	if err != nil {
 		return nil, fmt.Errorf("key derivation failed: %w", err)
 	}
 
 	// Decrypt
-	additionalData := []byte(fmt.Sprintf("process:%s:user:%d:type:%s", record.ProcessID, record.UserID, record.RecordType))
-	ciphertext := append(record.EncryptedData, record.EncryptionTag...)
-	plainBytes, err := vault.DecryptLocal(ciphertext, dek, record.EncryptionNonce, additionalData)
+	ciphertext := make([]byte, 0, len(record.EncryptedData)+len(record.EncryptionTag))
+	ciphertext = append(ciphertext, record.EncryptedData...)
+	ciphertext = append(ciphertext, record.EncryptionTag...)
+	plainBytes, err := vault.DecryptLocal(ciphertext, dek, record.EncryptionNonce, additionalData(record.ProcessID, record.UserID, record.RecordType))
 	if err != nil {
 		return nil, fmt.Errorf("decryption failed - data may be corrupted: %w", err)
 	}
 
-	// Deserialize
-	var data PlainData
-	if err := json.Unmarshal(plainBytes, &data); err != nil {
-		return nil, fmt.Errorf("unmarshal failed: %w", err)
-	}
-
-	return &data, nil
+	return plainBytes, nil
 }
 
 // VerifyChain verifies the integrity of the entire hash chain for a process
 func (ss *SecureStore) VerifyChain(processID string) (bool, []string, error) {
 	query := `
-		SELECT id, process_id, user_id, created_at, encrypted_data,
-		       encryption_nonce, encryption_tag, key_version,
-		       system_key_id, process_key_hash, data_signature,
-		       signature_public_key, record_type, status,
-		       prev_record_hash, chain_hash
-		FROM encrypted_records
-		WHERE process_id = $1
-		ORDER BY id ASC
+		SELECT r.id, r.process_id, r.user_id, r.created_at, r.encrypted_data,
+		       r.encryption_nonce, r.encryption_tag, r.key_version,
+		       r.system_key_id, r.process_key_hash, r.data_signature,
+		       r.signature_public_key, r.record_type, r.status,
+		       r.prev_record_hash, r.chain_hash, r.chain_version,
+		       x.encrypted_data, x.encryption_nonce, x.encryption_tag, x.data_signature
+		FROM encrypted_records r
+		LEFT JOIN encrypted_record_reencryptions x ON x.record_id = r.id
+		WHERE r.process_id = $1
+		ORDER BY r.id ASC
 	`
 
 	rows, err := ss.db.Query(query, processID)
@@ -228,13 +242,16 @@ func (ss *SecureStore) VerifyChain(processID string) (bool, []string, error) {
 	}
 	defer rows.Close()
 
-	var prevHash = "0000000000000000000000000000000000000000000000000000000000000000"
+	prevHash := genesisHash
+	lastVersion := ChainVersionV1
 	recordCount := 0
 	var errors []string
 
 	for rows.Next() {
 		var record SecureRecord
 		var status sql.NullString
+		var reencryptedData, reencryptedNonce, reencryptedTag []byte
+		var reencryptedSignature sql.NullString
 
 		err := rows.Scan(
 			&record.ID,
@@ -253,6 +270,11 @@ func (ss *SecureStore) VerifyChain(processID string) (bool, []string, error) {
 			&status,
 			&record.PrevRecordHash,
 			&record.ChainHash,
+			&record.ChainVersion,
+			&reencryptedData,
+			&reencryptedNonce,
+			&reencryptedTag,
+			&reencryptedSignature,
 		)
 		if err != nil {
 			return false, nil, fmt.Errorf("scan failed: %w", err)
@@ -262,11 +284,8 @@ func (ss *SecureStore) VerifyChain(processID string) (bool, []string, error) {
 			record.Status = status.String
 		}
 
-		// Check chain integrity
-		if record.PrevRecordHash != prevHash {
-			errors = append(errors, fmt.Sprintf("chain broken at record %d: expected prev_hash=%s, got=%s",
-				record.ID, prevHash, record.PrevRecordHash))
-		}
+		// Check chain integrity: linkage and the recomputed chain hash
+		errors = append(errors, verifyChainEntry(&record, prevHash, lastVersion)...)
 
 		// Verify signature
 		publicKey, err := hex.DecodeString(record.SignaturePublicKey)
@@ -281,14 +300,24 @@ func (ss *SecureStore) VerifyChain(processID string) (bool, []string, error) {
 			continue
 		}
 
-		signatureInput := append(record.EncryptedData, record.EncryptionNonce...)
-		signatureInput = append(signatureInput, record.EncryptionTag...)
-
-		if !ed25519.Verify(publicKey, signatureInput, signature) {
+		input := signatureInput(record.EncryptedData, record.EncryptionNonce, record.EncryptionTag, "")
+		if !ed25519.Verify(publicKey, input, signature) {
 			errors = append(errors, fmt.Sprintf("record %d: signature verification failed", record.ID))
 		}
 
+		// Verify the re-encrypted payload, signed by the same user and bound to this chain entry
+		if reencryptedSignature.Valid {
+			reencrypted, err := hex.DecodeString(reencryptedSignature.String)
+			input := signatureInput(reencryptedData, reencryptedNonce, reencryptedTag, record.ChainHash)
+			if err != nil || !ed25519.Verify(publicKey, input, reencrypted) {
+				errors = append(errors, fmt.Sprintf("record %d: signature verification of re-encrypted data failed", record.ID))
+			}
+		}
+
 		prevHash = record.ChainHash
+		if record.ChainVersion > lastVersion {
+			lastVersion = record.ChainVersion
+		}
 		recordCount++
 	}
 
@@ -302,11 +331,13 @@ func (ss *SecureStore) VerifyChain(processID string) (bool, []string, error) {
 // GetRecordsByProcess retrieves all records for a process (metadata only, not decrypted)
 func (ss *SecureStore) GetRecordsByProcess(processID string) ([]*SecureRecord, error) {
 	query := `
-		SELECT id, process_id, user_id, created_at, key_version,
-		       system_key_id, process_key_hash, record_type, status, chain_hash
-		FROM encrypted_records
-		WHERE process_id = $1
-		ORDER BY created_at ASC
+		SELECT r.id, r.process_id, r.user_id, r.created_at, COALESCE(x.key_version, r.key_version),
+		       r.system_key_id, COALESCE(x.process_key_hash, r.process_key_hash), r.record_type, r.status,
+		       r.chain_hash, r.chain_version, x.reencrypted_at
+		FROM encrypted_records r
+		LEFT JOIN encrypted_record_reencryptions x ON x.record_id = r.id
+		WHERE r.process_id = $1
+		ORDER BY r.created_at ASC
 	`
 
 	rows, err := ss.db.Query(query, processID)
@@ -331,6 +362,8 @@ func (ss *SecureStore) GetRecordsByProcess(processID string) ([]*SecureRecord, e
 			&record.RecordType,
 			&status,
 			&record.ChainHash,
+			&record.ChainVersion,
+			&record.ReencryptedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan failed: %w", err)
@@ -346,6 +379,157 @@ func (ss *SecureStore) GetRecordsByProcess(processID string) ([]*SecureRecord, e
 	return records, nil
 }
 
+// GetChainHead returns the chain hash of the last record of a process and the number of records
+func (ss *SecureStore) GetChainHead(processID string) (string, int, error) {
+	head, err := ss.getLatestHash(processID)
+	if err != nil {
+		return "", 0, fmt.Errorf("chain head retrieval failed: %w", err)
+	}
+
+	var count int
+	if err := ss.db.QueryRow(`SELECT COUNT(*) FROM encrypted_records WHERE process_id = $1`, processID).Scan(&count); err != nil {
+		return "", 0, fmt.Errorf("record count failed: %w", err)
+	}
+	return head, count, nil
+}
+
+// CountReadableRecordsByUser counts the records of a user in processes whose key was not destroyed.
+// Their data encryption keys are derived from the user's key, so it must be kept while this is not 0.
+func (ss *SecureStore) CountReadableRecordsByUser(userID int64) (int, error) {
+	var count int
+	err := ss.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM encrypted_records r
+		JOIN process_keys k ON k.process_id = r.process_id
+		WHERE r.user_id = $1 AND k.destroyed_at IS NULL
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("record count failed: %w", err)
+	}
+	return count, nil
+}
+
+// ListRecordIDsBelowKeyVersion returns the records of a process whose payload is
+// encrypted with a process key version below the given version
+func (ss *SecureStore) ListRecordIDsBelowKeyVersion(processID string, version int) ([]int64, error) {
+	rows, err := ss.db.Query(`
+		SELECT r.id
+		FROM encrypted_records r
+		LEFT JOIN encrypted_record_reencryptions x ON x.record_id = r.id
+		WHERE r.process_id = $1 AND COALESCE(x.key_version, r.key_version) < $2
+		ORDER BY r.id ASC
+	`, processID, version)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ReencryptRecord re-encrypts the payload of a record with the given process key version.
+// The original row stays unchanged so its signature and the hash chain remain verifiable;
+// the new payload is signed by the record owner and bound to the chain hash of the record.
+func (ss *SecureStore) ReencryptRecord(recordID int64, keyVersion int, rotationID uint) error {
+	record, err := ss.loadRecord(recordID)
+	if err != nil {
+		return fmt.Errorf("record load failed: %w", err)
+	}
+	if record.KeyVersion >= keyVersion {
+		// Already encrypted with this or a newer version
+		return nil
+	}
+
+	// The payload is re-encrypted byte for byte
+	plainBytes, err := ss.decryptPayload(record)
+	if err != nil {
+		return err
+	}
+
+	dek, err := ss.keyManager.DeriveDataEncryptionKey(record.ProcessID, record.UserID, keyVersion)
+	if err != nil {
+		return fmt.Errorf("key derivation failed: %w", err)
+	}
+	signingKey, err := ss.keyManager.GetUserSigningKey(record.UserID)
+	if err != nil {
+		return fmt.Errorf("signing key retrieval failed: %w", err)
+	}
+	if hex.EncodeToString(signingKey[32:]) != record.SignaturePublicKey {
+		return fmt.Errorf("signing key of user %d does not match the record signature", record.UserID)
+	}
+	processKeyHash, err := ss.keyManager.GetProcessKeyVersionHash(record.ProcessID, keyVersion)
+	if err != nil {
+		return fmt.Errorf("process key hash retrieval failed: %w", err)
+	}
+
+	encryptedData, nonce, tag, err := encryptPayload(plainBytes, dek, additionalData(record.ProcessID, record.UserID, record.RecordType))
+	if err != nil {
+		return err
+	}
+	signature := ed25519.Sign(signingKey, signatureInput(encryptedData, nonce, tag, record.ChainHash))
+
+	_, err = ss.db.Exec(`
+		INSERT INTO encrypted_record_reencryptions (
+			record_id, key_version, process_key_hash, encrypted_data,
+			encryption_nonce, encryption_tag, data_signature, rotation_id, reencrypted_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (record_id) DO UPDATE SET
+			key_version = EXCLUDED.key_version,
+			process_key_hash = EXCLUDED.process_key_hash,
+			encrypted_data = EXCLUDED.encrypted_data,
+			encryption_nonce = EXCLUDED.encryption_nonce,
+			encryption_tag = EXCLUDED.encryption_tag,
+			data_signature = EXCLUDED.data_signature,
+			rotation_id = EXCLUDED.rotation_id,
+			reencrypted_at = EXCLUDED.reencrypted_at
+		WHERE encrypted_record_reencryptions.key_version < EXCLUDED.key_version
+	`, recordID, keyVersion, processKeyHash, encryptedData, nonce, tag, hex.EncodeToString(signature), rotationID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to store re-encrypted record: %w", err)
+	}
+
+	return nil
+}
+
+// additionalData returns the GCM additional authenticated data of a record
+func additionalData(processID string, userID int64, recordType string) []byte {
+	return []byte(fmt.Sprintf("process:%s:user:%d:type:%s", processID, userID, recordType))
+}
+
+// encryptPayload encrypts with AES-256-GCM and splits off the GCM tag (last 16 bytes)
+func encryptPayload(plainBytes, dek, aad []byte) (encryptedData, nonce, tag []byte, err error) {
+	ciphertext, nonce, err := vault.EncryptLocal(plainBytes, dek, aad)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("encryption failed: %w", err)
+	}
+
+	tagSize := 16
+	encryptedData = make([]byte, len(ciphertext)-tagSize)
+	copy(encryptedData, ciphertext[:len(ciphertext)-tagSize])
+	tag = make([]byte, tagSize)
+	copy(tag, ciphertext[len(ciphertext)-tagSize:])
+
+	return encryptedData, nonce, tag, nil
+}
+
+// signatureInput returns the signed bytes: encrypted data + nonce + tag, followed by the
+// chain hash of the original record for re-encrypted payloads (empty for original payloads)
+func signatureInput(encryptedData, nonce, tag []byte, chainHash string) []byte {
+	input := make([]byte, 0, len(encryptedData)+len(nonce)+len(tag)+len(chainHash))
+	input = append(input, encryptedData...)
+	input = append(input, nonce...)
+	input = append(input, tag...)
+	return append(input, chainHash...)
+}
+
 // insertRecord stores a record in the database
 func (ss *SecureStore) insertRecord(record *SecureRecord) error {
 	query := `
@@ -354,8 +538,8 @@ func (ss *SecureStore) insertRecord(record *SecureRecord) error {
 			encryption_nonce, encryption_tag, key_version,
 			system_key_id, process_key_hash, data_signature,
 			signature_public_key, record_type, status,
-			prev_record_hash, chain_hash
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			prev_record_hash, chain_hash, chain_version
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id
 	`
 
@@ -381,6 +565,7 @@ func (ss *SecureStore) insertRecord(record *SecureRecord) error {
 		status,
 		record.PrevRecordHash,
 		record.ChainHash,
+		record.ChainVersion,
 	).Scan(&record.ID)
 }
 
@@ -389,14 +574,21 @@ func (ss *SecureStore) loadRecord(recordID int64) (*SecureRecord, error) {
 	record := &SecureRecord{}
 	var status sql.NullString
 
+	// The re-encrypted payload replaces the original payload, key version and signature
 	query := `
-		SELECT id, process_id, user_id, created_at, encrypted_data,
-		       encryption_nonce, encryption_tag, key_version,
-		       system_key_id, process_key_hash, data_signature,
-		       signature_public_key, record_type, status,
-		       prev_record_hash, chain_hash
-		FROM encrypted_records
-		WHERE id = $1
+		SELECT r.id, r.process_id, r.user_id, r.created_at,
+		       COALESCE(x.encrypted_data, r.encrypted_data),
+		       COALESCE(x.encryption_nonce, r.encryption_nonce),
+		       COALESCE(x.encryption_tag, r.encryption_tag),
+		       COALESCE(x.key_version, r.key_version),
+		       r.system_key_id,
+		       COALESCE(x.process_key_hash, r.process_key_hash),
+		       COALESCE(x.data_signature, r.data_signature),
+		       r.signature_public_key, r.record_type, r.status,
+		       r.prev_record_hash, r.chain_hash, r.chain_version, x.reencrypted_at
+		FROM encrypted_records r
+		LEFT JOIN encrypted_record_reencryptions x ON x.record_id = r.id
+		WHERE r.id = $1
 	`
 
 	err := ss.db.QueryRow(query, recordID).Scan(
@@ -416,6 +608,8 @@ func (ss *SecureStore) loadRecord(recordID int64) (*SecureRecord, error) {
 		&status,
 		&record.PrevRecordHash,
 		&record.ChainHash,
+		&record.ChainVersion,
+		&record.ReencryptedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -441,7 +635,7 @@ func (ss *SecureStore) getLatestHash(processID string) (string, error) {
 
 	if err == sql.ErrNoRows {
 		// Genesis block: no previous hash
-		return "0000000000000000000000000000000000000000000000000000000000000000", nil
+		return genesisHash, nil
 	}
 
 	return hash, err
@@ -0,0 +1,673 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"new-pay/internal/email"
+	"new-pay/internal/keymanager"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+	"new-pay/internal/securestore"
+)
+
+// statusAppeal is the status of a self-assessment while an appeal against its discussion result is open
+const statusAppeal = "appeal"
+
+// Appeal states; a decided appeal carries its outcome as status
+const (
+	appealStatusOpen     = "open"
+	appealOutcomeUpheld  = "upheld"  // The discussion result stays unchanged
+	appealOutcomeChanged = "changed" // The appeal panel changed category results
+)
+
+// AppealService handles appeals of owners against the discussion result
+type AppealService struct {
+	appealRepo       *repository.AppealRepository
+	assessmentRepo   *repository.SelfAssessmentRepository
+	assignmentRepo   *repository.ReviewerAssignmentRepository
+	reviewerRepo     *repository.ReviewerResponseRepository
+	discussionRepo   *repository.DiscussionRepository
+	meetingRepo      *repository.DiscussionMeetingRepository
+	confirmationRepo *repository.DiscussionConfirmationRepository
+	userRepo         *repository.UserRepository
+	discussionSvc    *DiscussionService
+	workflowSvc      *WorkflowService
+	keyManager       *keymanager.KeyManager
+	secureStore      *securestore.SecureStore
+	transactor       *repository.Transactor
+	auditSvc         *AuditService
+	emailService     *email.Service
+	windowDays       int // Days after the start of the discussion meeting in which an appeal can be filed
+	panelSize        int // Number of reviewers assigned automatically
+}
+
+// NewAppealService creates a new appeal service
+func NewAppealService(
+	appealRepo *repository.AppealRepository,
+	assessmentRepo *repository.SelfAssessmentRepository,
+	assignmentRepo *repository.ReviewerAssignmentRepository,
+	reviewerRepo *repository.ReviewerResponseRepository,
+	discussionRepo *repository.DiscussionRepository,
+	meetingRepo *repository.DiscussionMeetingRepository,
+	confirmationRepo *repository.DiscussionConfirmationRepository,
+	userRepo *repository.UserRepository,
+	discussionSvc *DiscussionService,
+	workflowSvc *WorkflowService,
+	keyManager *keymanager.KeyManager,
+	secureStore *securestore.SecureStore,
+	transactor *repository.Transactor,
+	auditSvc *AuditService,
+	emailService *email.Service,
+	windowDays, panelSize int,
+) *AppealService {
+	return &AppealService{
+		appealRepo:       appealRepo,
+		assessmentRepo:   assessmentRepo,
+		assignmentRepo:   assignmentRepo,
+		reviewerRepo:     reviewerRepo,
+		discussionRepo:   discussionRepo,
+		meetingRepo:      meetingRepo,
+		confirmationRepo: confirmationRepo,
+		userRepo:         userRepo,
+		discussionSvc:    discussionSvc,
+		workflowSvc:      workflowSvc,
+		keyManager:       keyManager,
+		secureStore:      secureStore,
+		transactor:       transactor,
+		auditSvc:         auditSvc,
+		emailService:     emailService,
+		windowDays:       windowDays,
+		panelSize:        panelSize,
+	}
+}
+
+// withTx returns a copy of the service whose repository, secure store and audit log writes run in the given transaction
+func (s *AppealService) withTx(tx *sql.Tx) *AppealService {
+	txSvc := *s
+	txSvc.appealRepo = s.appealRepo.WithTx(tx)
+	txSvc.assessmentRepo = s.assessmentRepo.WithTx(tx)
+	txSvc.confirmationRepo = s.confirmationRepo.WithTx(tx)
+	txSvc.secureStore = s.secureStore.WithTx(tx)
+	txSvc.auditSvc = s.auditSvc.WithTx(tx)
+	return &txSvc
+}
+
+// GetAppeal retrieves the appeal of a self-assessment with decrypted reason and decision
+// (owner, reviewers of the original panel, appeal panel and admins). Returns nil if no appeal was filed.
+func (s *AppealService) GetAppeal(assessmentID, userID uint, userRoles []string) (*models.Appeal, error) {
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	appeal, err := s.appealRepo.GetByAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if assessment.UserID != userID && !contains(userRoles, "admin") {
+		allowed, err := s.assignmentRepo.IsAssigned(assessmentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed && appeal != nil {
+			if allowed, err = s.appealRepo.IsPanelMember(appeal.ID, userID); err != nil {
+				return nil, err
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("permission denied: cannot view the appeal of this self-assessment")
+		}
+	}
+
+	if appeal == nil {
+		return nil, nil
+	}
+	if err := s.loadDetails(appeal); err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// FileAppeal files the owner's appeal against the discussion result. An appeal is possible once per
+// self-assessment, from the start of the discussion meeting (or the creation of the discussion result if
+// no meeting is scheduled) until the end of the appeal window and only before the owner confirmed the
+// discussion. The reason is encrypted in the secure store and an appeal panel of reviewers outside the
+// original panel is assigned.
+func (s *AppealService) FileAppeal(assessmentID, userID uint, userRoles []string, reason string) (*models.Appeal, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment.UserID != userID {
+		return nil, fmt.Errorf("permission denied: only the owner can appeal the discussion result")
+	}
+
+	existing, err := s.appealRepo.GetByAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("an appeal has already been filed for this self-assessment")
+	}
+
+	result, err := s.discussionRepo.GetByAssessmentID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("discussion result not found")
+	}
+
+	confirmation, err := s.confirmationRepo.GetByAssessmentAndUser(assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if confirmation != nil {
+		return nil, fmt.Errorf("the discussion has already been confirmed and can no longer be appealed")
+	}
+
+	meeting, err := s.meetingRepo.GetScheduledByAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAppealWindow(appealWindowStart(result, meeting), s.windowDays, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// The workflow decides whether appeals are possible at all
+	if err := s.workflowSvc.CheckTransition(assessment, statusAppeal, userID, userRoles); err != nil {
+		return nil, err
+	}
+
+	// The encrypted reason, the appeal and the status change are kept only together
+	appeal := &models.Appeal{
+		AssessmentID:     assessmentID,
+		FiledBy:          &userID,
+		OriginalResultID: result.ID,
+	}
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		return s.withTx(tx).writeAppeal(appeal, userID, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assessment.Status = statusAppeal
+	s.workflowSvc.OnEnter(assessment)
+
+	if err := s.assignPanel(appeal, assessment); err != nil {
+		// The appeal stays open; an admin assigns the panel manually
+		slog.Error("Failed to assign appeal panel", "assessment_id", assessmentID, "appeal_id", appeal.ID, "error", err)
+	}
+
+	appeal.Reason = reason
+	if appeal.Panel, err = s.appealRepo.GetPanel(appeal.ID); err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// writeAppeal encrypts the reason, stores the appeal and moves the self-assessment to appeal status
+func (s *AppealService) writeAppeal(appeal *models.Appeal, userID uint, reason string) error {
+	record, err := s.encrypt(appeal.AssessmentID, userID, "APPEAL_REASON", "reason", reason)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt reason: %w", err)
+	}
+
+	appeal.EncryptedReasonID = &record.ID
+	if err := s.appealRepo.Create(appeal); err != nil {
+		return err
+	}
+
+	if err := s.assessmentRepo.UpdateStatus(appeal.AssessmentID, statusAppeal); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "file_appeal", "self_assessment",
+		fmt.Sprintf("Filed appeal %d against discussion result %d of self-assessment %d", appeal.ID, appeal.OriginalResultID, appeal.AssessmentID))
+	return nil
+}
+
+// SetPanel replaces the panel of an open appeal (admins). Panel members must be reviewers outside the
+// original panel without a conflict of interest with the owner.
+func (s *AppealService) SetPanel(assessmentID uint, reviewerIDs []uint, adminID uint) (*models.Appeal, error) {
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	appeal, err := s.getOpenAppeal(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible, err := s.eligibleReviewers(assessment, appeal)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAppealPanel(reviewerIDs, eligible); err != nil {
+		return nil, err
+	}
+
+	if err := s.appealRepo.ReplacePanel(appeal.ID, reviewerIDs, &adminID); err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Log(adminID, "assign_appeal_panel", "self_assessment",
+		fmt.Sprintf("Assigned appeal panel %v to appeal %d of self-assessment %d", reviewerIDs, appeal.ID, assessmentID))
+
+	s.notifyPanel(assessmentID, reviewerIDs)
+
+	if err := s.loadDetails(appeal); err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// DecideAppeal records the decision of the appeal panel. The active discussion result is superseded by a
+// new result (a copy if upheld, with the changed category results otherwise) and the self-assessment
+// returns to discussion so that the result can be confirmed. Reviewer confirmations of the old result
+// are removed; all writes run in one transaction.
+func (s *AppealService) DecideAppeal(assessmentID, reviewerID uint, outcome, reason string, changes []models.AppealCategoryChange) (*models.Appeal, error) {
+	reason = strings.TrimSpace(reason)
+	if err := validateAppealOutcome(outcome, reason, changes); err != nil {
+		return nil, err
+	}
+
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment.Status != statusAppeal {
+		return nil, fmt.Errorf("appeals can only be decided in appeal status")
+	}
+
+	appeal, err := s.getOpenAppeal(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.appealRepo.IsPanelMember(appeal.ID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, fmt.Errorf("permission denied: you are not on the appeal panel of this self-assessment")
+	}
+
+	// The status change after the decision is automatic, so the workflow must provide it up front
+	allowed, err := s.workflowSvc.HasTransition(assessment, statusDiscussion)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, fmt.Errorf("cannot transition from %s to %s status", statusAppeal, statusDiscussion)
+	}
+
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		return s.withTx(tx).writeDecision(tx, appeal, reviewerID, outcome, reason, changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assessment.Status = statusDiscussion
+	s.workflowSvc.OnEnter(assessment)
+	s.notifyOwner(assessmentID)
+
+	if err := s.loadDetails(appeal); err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// writeDecision encrypts the decision, supersedes the discussion result, clears the reviewer confirmations
+// of the old result and returns the self-assessment to discussion
+func (s *AppealService) writeDecision(tx *sql.Tx, appeal *models.Appeal, reviewerID uint, outcome, reason string, changes []models.AppealCategoryChange) error {
+	assessmentID := appeal.AssessmentID
+	record, err := s.encrypt(assessmentID, reviewerID, "APPEAL_DECISION", "decision", reason)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt decision: %w", err)
+	}
+
+	result, err := s.discussionSvc.SupersedeResult(tx, assessmentID, changes)
+	if err != nil {
+		return err
+	}
+
+	appeal.Status = outcome
+	appeal.DecidedBy = &reviewerID
+	appeal.EncryptedDecisionID = &record.ID
+	appeal.ResultID = &result.ID
+	if err := s.appealRepo.Decide(appeal); err != nil {
+		return err
+	}
+
+	// Reviewers confirmed the old result; the new result has to be confirmed again
+	if err := s.confirmationRepo.DeleteReviewerConfirmations(assessmentID); err != nil {
+		return fmt.Errorf("failed to reset reviewer confirmations: %w", err)
+	}
+
+	if err := s.assessmentRepo.UpdateStatus(assessmentID, statusDiscussion); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(reviewerID, "decide_appeal", "self_assessment",
+		fmt.Sprintf("Decided appeal %d of self-assessment %d (%s, %d categories changed): discussion result %d superseded by %d",
+			appeal.ID, assessmentID, outcome, len(changes), appeal.OriginalResultID, result.ID))
+	return nil
+}
+
+// assignPanel assigns a balanced appeal panel (workload, team, conflicts of interest) as system assignment
+func (s *AppealService) assignPanel(appeal *models.Appeal, assessment *models.SelfAssessment) error {
+	eligible, err := s.eligibleReviewers(assessment, appeal)
+	if err != nil {
+		return err
+	}
+	if len(eligible) == 0 {
+		return fmt.Errorf("no eligible reviewers for the appeal panel")
+	}
+
+	workloads, err := reviewerWorkloads(s.assignmentRepo, s.reviewerRepo)
+	if err != nil {
+		return err
+	}
+	candidates := make([]models.ReviewerWorkload, 0, len(eligible))
+	for _, reviewer := range eligible {
+		workload := workloads[reviewer.ID]
+		workload.ReviewerUserID = reviewer.ID
+		workload.ReviewerName = reviewer.FirstName + " " + reviewer.LastName
+		candidates = append(candidates, workload)
+	}
+
+	ids := reviewerIDs(selectPanel(candidates, s.panelSize))
+	if err := s.appealRepo.ReplacePanel(appeal.ID, ids, nil); err != nil {
+		return err
+	}
+
+	s.auditSvc.LogSystem("assign_appeal_panel", "self_assessment",
+		fmt.Sprintf("Automatically assigned appeal panel %v to appeal %d of self-assessment %d", ids, appeal.ID, assessment.ID))
+
+	s.notifyPanel(assessment.ID, ids)
+	return nil
+}
+
+// eligibleReviewers returns the reviewers that may decide an appeal: not the owner, not of the owner's team,
+// without a conflict of interest and neither on the original panel nor among the reviewers of the original result
+func (s *AppealService) eligibleReviewers(assessment *models.SelfAssessment, appeal *models.Appeal) ([]models.User, error) {
+	owner, err := s.userRepo.GetByID(assessment.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner: %w", err)
+	}
+
+	reviewers, err := s.userRepo.GetUsersByRoleName("reviewer")
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.assignmentRepo.GetConflictedReviewerIDs(owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	original := make(map[uint]bool)
+	assignments, err := s.assignmentRepo.GetByAssessmentID(assessment.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		original[assignment.ReviewerUserID] = true
+	}
+	resultReviewers, err := s.discussionRepo.GetReviewers(appeal.OriginalResultID)
+	if err != nil {
+		return nil, err
+	}
+	for _, reviewer := range resultReviewers {
+		original[reviewer.ReviewerUserID] = true
+	}
+
+	candidates := make([]models.User, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		if !original[reviewer.ID] {
+			candidates = append(candidates, reviewer)
+		}
+	}
+
+	eligible, _ := filterPanelCandidates(candidates, owner, conflicts)
+	return eligible, nil
+}
+
+// loadDetails decrypts reason and decision of an appeal and loads its panel
+func (s *AppealService) loadDetails(appeal *models.Appeal) error {
+	if appeal.EncryptedReasonID != nil {
+		appeal.Reason = s.decrypt(*appeal.EncryptedReasonID, "reason", appeal.ID)
+	}
+	if appeal.EncryptedDecisionID != nil {
+		appeal.Decision = s.decrypt(*appeal.EncryptedDecisionID, "decision", appeal.ID)
+	}
+
+	panel, err := s.appealRepo.GetPanel(appeal.ID)
+	if err != nil {
+		return err
+	}
+	appeal.Panel = panel
+	return nil
+}
+
+func (s *AppealService) decrypt(recordID int64, field string, appealID uint) string {
+	plainData, err := s.secureStore.DecryptRecord(recordID)
+	if err != nil {
+		slog.Error("Failed to decrypt appeal", "error", err, "appeal_id", appealID, "field", field)
+		return "[Decryption failed]"
+	}
+	value, _ := plainData.Fields[field].(string)
+	return value
+}
+
+// encrypt stores a text of the appeal in the secure store, signed by the given user
+func (s *AppealService) encrypt(assessmentID, userID uint, recordType, field, text string) (*securestore.SecureRecord, error) {
+	if err := s.ensureUserKey(int64(userID)); err != nil {
+		return nil, fmt.Errorf("failed to ensure user key: %w", err)
+	}
+	processID := fmt.Sprintf("assessment-%d", assessmentID)
+	if err := s.ensureProcessKey(processID); err != nil {
+		return nil, fmt.Errorf("failed to ensure process key: %w", err)
+	}
+
+	data := &securestore.PlainData{
+		Fields: map[string]interface{}{
+			field: text,
+		},
+		Metadata: map[string]string{
+			"assessment_id": fmt.Sprintf("%d", assessmentID),
+			"user_id":       fmt.Sprintf("%d", userID),
+			"type":          strings.ToLower(recordType),
+		},
+	}
+	return s.secureStore.CreateRecord(processID, int64(userID), recordType, data, "")
+}
+
+func (s *AppealService) getAssessment(assessmentID uint) (*models.SelfAssessment, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment not found")
+	}
+	return assessment, nil
+}
+
+func (s *AppealService) getOpenAppeal(assessmentID uint) (*models.Appeal, error) {
+	appeal, err := s.appealRepo.GetByAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if appeal == nil {
+		return nil, fmt.Errorf("appeal not found")
+	}
+	if appeal.Status != appealStatusOpen {
+		return nil, fmt.Errorf("appeal has already been decided")
+	}
+	return appeal, nil
+}
+
+// notifyPanel informs the reviewers of the appeal panel about their assignment
+func (s *AppealService) notifyPanel(assessmentID uint, reviewerIDs []uint) {
+	if s.emailService == nil {
+		return
+	}
+	details, err := s.assessmentRepo.GetByIDWithDetails(assessmentID)
+	if err != nil || details == nil {
+		slog.Error("Failed to get assessment details for appeal notification", "assessment_id", assessmentID, "error", err)
+		return
+	}
+	for _, id := range reviewerIDs {
+		reviewer, err := s.userRepo.GetByID(id)
+		if err != nil {
+			slog.Error("Failed to get reviewer for appeal notification", "reviewer_id", id, "error", err)
+			continue
+		}
+		if err := s.emailService.SendAssessmentStatusNotification(reviewer.Email, reviewer.FirstName+" "+reviewer.LastName,
+			details.CatalogName, details.UserName, statusAppeal, assessmentID); err != nil {
+			slog.Error("Failed to send appeal notification", "assessment_id", assessmentID, "reviewer_id", id, "error", err)
+		}
+	}
+}
+
+// notifyOwner informs the owner that the appeal has been decided
+func (s *AppealService) notifyOwner(assessmentID uint) {
+	if s.emailService == nil {
+		return
+	}
+	details, err := s.assessmentRepo.GetByIDWithDetails(assessmentID)
+	if err != nil || details == nil {
+		slog.Error("Failed to get assessment details for appeal notification", "assessment_id", assessmentID, "error", err)
+		return
+	}
+	if err := s.emailService.SendAssessmentStatusNotification(details.UserEmail, details.UserName,
+		details.CatalogName, details.UserName, details.Status, assessmentID); err != nil {
+		slog.Error("Failed to send appeal decision notification", "assessment_id", assessmentID, "error", err)
+	}
+}
+
+// ensureUserKey ensures the user has a signing key
+func (s *AppealService) ensureUserKey(userID int64) error {
+	if _, err := s.keyManager.GetUserPublicKey(userID); err == nil {
+		return nil
+	}
+	_, err := s.keyManager.CreateUserKey(userID)
+	return err
+}
+
+// ensureProcessKey ensures a process encryption key exists
+func (s *AppealService) ensureProcessKey(processID string) error {
+	if _, err := s.keyManager.GetProcessKey(processID); err == nil {
+		return nil
+	}
+	return s.keyManager.CreateProcessKey(processID, nil)
+}
+
+// appealWindowStart returns the start of the appeal window: the start of the scheduled discussion meeting,
+// or the creation of the discussion result if no meeting is scheduled
+func appealWindowStart(result *models.DiscussionResult, meeting *models.DiscussionMeeting) time.Time {
+	if meeting != nil {
+		return meeting.StartsAt
+	}
+	return result.CreatedAt
+}
+
+// checkAppealWindow checks that an appeal is filed after the window started and within the appeal window
+func checkAppealWindow(windowStart time.Time, windowDays int, now time.Time) error {
+	if now.Before(windowStart) {
+		return fmt.Errorf("the appeal window has not started yet (starts at %s)", windowStart.UTC().Format(time.RFC3339))
+	}
+	deadline := windowStart.AddDate(0, 0, windowDays)
+	if now.After(deadline) {
+		return fmt.Errorf("the appeal window of %d days ended at %s", windowDays, deadline.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validateAppealOutcome checks the outcome of an appeal decision against the category changes
+func validateAppealOutcome(outcome, reason string, changes []models.AppealCategoryChange) error {
+	if reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	switch outcome {
+	case appealOutcomeUpheld:
+		if len(changes) > 0 {
+			return fmt.Errorf("an upheld result cannot change categories")
+		}
+	case appealOutcomeChanged:
+		if len(changes) == 0 {
+			return fmt.Errorf("at least one category change is required")
+		}
+	default:
+		return fmt.Errorf("outcome must be %s or %s", appealOutcomeUpheld, appealOutcomeChanged)
+	}
+	return nil
+}
+
+// validateAppealChanges checks the category changes of an appeal decision against the catalog
+// and the category results of the discussion result
+func validateAppealChanges(changes []models.AppealCategoryChange, catalog *models.CatalogWithDetails, results []models.DiscussionCategoryResult) error {
+	inResult := make(map[uint]bool, len(results))
+	for _, result := range results {
+		inResult[result.CategoryID] = true
+	}
+
+	seen := make(map[uint]bool, len(changes))
+	for _, change := range changes {
+		if !inResult[change.CategoryID] {
+			return fmt.Errorf("category %d is not part of the discussion result", change.CategoryID)
+		}
+		if seen[change.CategoryID] {
+			return fmt.Errorf("category %d is changed more than once", change.CategoryID)
+		}
+		seen[change.CategoryID] = true
+
+		if path := findPathByID(catalog, change.PathID); path == nil || path.CategoryID != change.CategoryID {
+			return fmt.Errorf("path %d does not belong to category %d", change.PathID, change.CategoryID)
+		}
+		if findLevelByID(catalog, change.LevelID) == nil {
+			return fmt.Errorf("level %d is not part of the catalog", change.LevelID)
+		}
+		if strings.TrimSpace(change.Justification) == "" {
+			return fmt.Errorf("justification is required for category %d", change.CategoryID)
+		}
+	}
+	return nil
+}
+
+// validateAppealPanel checks a manually assigned appeal panel against the eligible reviewers
+func validateAppealPanel(reviewerIDs []uint, eligible []models.User) error {
+	if len(reviewerIDs) == 0 {
+		return fmt.Errorf("at least one reviewer is required")
+	}
+
+	allowed := make(map[uint]bool, len(eligible))
+	for _, reviewer := range eligible {
+		allowed[reviewer.ID] = true
+	}
+
+	seen := make(map[uint]bool, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		if seen[id] {
+			return fmt.Errorf("reviewer %d is assigned more than once", id)
+		}
+		seen[id] = true
+		if !allowed[id] {
+			return fmt.Errorf("reviewer %d is not eligible for the appeal panel", id)
+		}
+	}
+	return nil
+}
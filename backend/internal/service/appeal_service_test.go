@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+func TestCheckAppealWindow(t *testing.T) {
+	start := time.Date(2026, 10, 20, 14, 0, 0, 0, time.UTC)
+
+	if err := checkAppealWindow(start, 14, start.Add(-time.Minute)); err == nil {
+		t.Error("appeal allowed before the discussion meeting started")
+	}
+	if err := checkAppealWindow(start, 14, start); err != nil {
+		t.Errorf("appeal at meeting start rejected: %v", err)
+	}
+	if err := checkAppealWindow(start, 14, start.AddDate(0, 0, 14)); err != nil {
+		t.Errorf("appeal at the end of the window rejected: %v", err)
+	}
+	if err := checkAppealWindow(start, 14, start.AddDate(0, 0, 14).Add(time.Second)); err == nil {
+		t.Error("appeal allowed after the window ended")
+	}
+}
+
+func TestAppealWindowStart(t *testing.T) {
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	result := &models.DiscussionResult{CreatedAt: created}
+
+	if got := appealWindowStart(result, nil); !got.Equal(created) {
+		t.Errorf("appealWindowStart() without meeting = %v, want result creation %v", got, created)
+	}
+
+	meeting := &models.DiscussionMeeting{StartsAt: created.AddDate(0, 0, 4)}
+	if got := appealWindowStart(result, meeting); !got.Equal(meeting.StartsAt) {
+		t.Errorf("appealWindowStart() with meeting = %v, want meeting start %v", got, meeting.StartsAt)
+	}
+}
+
+func TestValidateAppealOutcome(t *testing.T) {
+	change := []models.AppealCategoryChange{{CategoryID: 1, PathID: 1, LevelID: 1, Justification: "x"}}
+
+	tests := []struct {
+		name    string
+		outcome string
+		reason  string
+		changes []models.AppealCategoryChange
+		wantErr string
+	}{
+		{name: "upheld", outcome: appealOutcomeUpheld, reason: "Ergebnis bestätigt"},
+		{name: "changed", outcome: appealOutcomeChanged, reason: "Kategorie neu bewertet", changes: change},
+		{name: "missing reason", outcome: appealOutcomeUpheld, wantErr: "reason is required"},
+		{name: "upheld with changes", outcome: appealOutcomeUpheld, reason: "x", changes: change, wantErr: "an upheld result cannot change categories"},
+		{name: "changed without changes", outcome: appealOutcomeChanged, reason: "x", wantErr: "at least one category change is required"},
+		{name: "unknown outcome", outcome: "rejected", reason: "x", wantErr: "outcome must be upheld or changed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAppealOutcome(tt.outcome, tt.reason, tt.changes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAppealChanges(t *testing.T) {
+	catalog := &models.CatalogWithDetails{
+		Categories: []models.CategoryWithPaths{
+			{Category: models.Category{ID: 1}, Paths: []models.PathWithDescriptions{{Path: models.Path{ID: 10, CategoryID: 1}}}},
+			{Category: models.Category{ID: 2}, Paths: []models.PathWithDescriptions{{Path: models.Path{ID: 20, CategoryID: 2}}}},
+		},
+		Levels: []models.Level{{ID: 100, LevelNumber: 1}, {ID: 101, LevelNumber: 2}},
+	}
+	results := []models.DiscussionCategoryResult{{CategoryID: 1}, {CategoryID: 2}}
+
+	tests := []struct {
+		name    string
+		changes []models.AppealCategoryChange
+		wantErr string
+	}{
+		{name: "valid", changes: []models.AppealCategoryChange{{CategoryID: 1, PathID: 10, LevelID: 101, Justification: "Projektleitung"}}},
+		{name: "unknown category", changes: []models.AppealCategoryChange{{CategoryID: 3, PathID: 10, LevelID: 101, Justification: "x"}}, wantErr: "category 3 is not part of the discussion result"},
+		{name: "duplicate category", changes: []models.AppealCategoryChange{
+			{CategoryID: 1, PathID: 10, LevelID: 101, Justification: "x"},
+			{CategoryID: 1, PathID: 10, LevelID: 100, Justification: "y"},
+		}, wantErr: "category 1 is changed more than once"},
+		{name: "path of other category", changes: []models.AppealCategoryChange{{CategoryID: 1, PathID: 20, LevelID: 101, Justification: "x"}}, wantErr: "path 20 does not belong to category 1"},
+		{name: "unknown level", changes: []models.AppealCategoryChange{{CategoryID: 2, PathID: 20, LevelID: 999, Justification: "x"}}, wantErr: "level 999 is not part of the catalog"},
+		{name: "missing justification", changes: []models.AppealCategoryChange{{CategoryID: 2, PathID: 20, LevelID: 100, Justification: "  "}}, wantErr: "justification is required for category 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAppealChanges(tt.changes, catalog, results)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAppealPanel(t *testing.T) {
+	eligible := []models.User{{ID: 4}, {ID: 5}}
+
+	if err := validateAppealPanel([]uint{4, 5}, eligible); err != nil {
+		t.Errorf("valid panel rejected: %v", err)
+	}
+	if err := validateAppealPanel(nil, eligible); err == nil {
+		t.Error("empty panel accepted")
+	}
+	if err := validateAppealPanel([]uint{4, 4}, eligible); err == nil {
+		t.Error("duplicate reviewer accepted")
+	}
+	if err := validateAppealPanel([]uint{4, 2}, eligible); err == nil {
+		t.Error("reviewer of the original panel accepted")
+	}
+}
+
+func TestResolveCategoryScore(t *testing.T) {
+	weight := 2.0
+	catalog := &models.CatalogWithDetails{
+		Categories: []models.CategoryWithPaths{
+			{Category: models.Category{ID: 1}, Paths: []models.PathWithDescriptions{{Path: models.Path{ID: 10, CategoryID: 1, Weight: &weight}}}},
+		},
+		Levels: []models.Level{{ID: 100, LevelNumber: 1}, {ID: 101, LevelNumber: 2}, {ID: 102, LevelNumber: 3}},
+	}
+	strategy := NewScoringStrategy(&catalog.CriteriaCatalog)
+	averaged := []models.AveragedReviewerResponse{{CategoryID: 1, AverageLevelNumber: 2.2, PathWeight: 1.5}}
+
+	score := resolveCategoryScore(catalog, strategy, 1, nil, averaged)
+	if score.levelID != 101 || score.levelNumber != 2.2 || score.pathWeight != 1.5 || score.isOverride {
+		t.Errorf("averaged score = %+v", score)
+	}
+
+	overrides := []models.ConsolidationOverride{{CategoryID: 1, PathID: 10, LevelID: 102}}
+	score = resolveCategoryScore(catalog, strategy, 1, overrides, averaged)
+	if score.levelID != 102 || score.levelNumber != 3 || score.pathWeight != 2 || !score.isOverride {
+		t.Errorf("override score = %+v", score)
+	}
+
+	score = resolveCategoryScore(catalog, strategy, 2, overrides, averaged)
+	if score.levelID != 0 || score.pathWeight != 1 {
+		t.Errorf("score of category without result = %+v", score)
+	}
+}
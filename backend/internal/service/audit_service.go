@@ -1,10 +1,15 @@
 package service
 
 import (
+	"database/sql"
+
 	"new-pay/internal/models"
 	"new-pay/internal/repository"
 )
 
+// SystemActor is recorded as actor of audit log entries written by automatic processes (e.g. the scheduler)
+const SystemActor = "system"
+
 // AuditService handles audit logging
 type AuditService struct {
 	auditRepo *repository.AuditRepository
@@ -17,6 +22,12 @@ func NewAuditService(auditRepo *repository.AuditRepository) *AuditService {
 	}
 }
 
+// WithTx returns a copy of the service that writes its entries in the given transaction,
+// so they are only kept if the audited change is committed
+func (s *AuditService) WithTx(tx *sql.Tx) *AuditService {
+	return &AuditService{auditRepo: s.auditRepo.WithTx(tx)}
+}
+
 // Log creates an audit log entry, ignoring errors
 // This is the recommended way to log audit events as it won't fail the main operation
 func (s *AuditService) Log(userID uint, action, resource, details string) {
@@ -28,6 +39,17 @@ func (s *AuditService) Log(userID uint, action, resource, details string) {
 	})
 }
 
+// LogSystem creates an audit log entry for an action performed automatically by the system, ignoring errors
+func (s *AuditService) LogSystem(action, resource, details string) {
+	actor := SystemActor
+	_ = s.auditRepo.Create(&models.AuditLog{
+		UserEmail: &actor,
+		Action:    action,
+		Resource:  resource,
+		Details:   details,
+	})
+}
+
 // LogError creates an audit log entry and returns any error
 // Use this when you need to handle audit logging errors explicitly
 func (s *AuditService) LogError(userID uint, action, resource, details string) error {
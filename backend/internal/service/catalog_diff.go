@@ -0,0 +1,826 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"new-pay/internal/models"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// catalogDeletedField is the field name of change log entries that record the deletion of a
+// category, level, path or description. The old value holds the deleted entity with its children as JSON.
+const catalogDeletedField = "deleted"
+
+// deletedLevel is the logged state of a deleted level; its descriptions are deleted with it
+type deletedLevel struct {
+	models.Level
+	Descriptions []models.PathLevelDescription `json:"descriptions,omitempty"`
+}
+
+// DiffCatalogs computes a structured diff between two catalog versions.
+// Each side is either the current state of a catalog (at == nil) or its state at a point in
+// time reconstructed from the change log, so the same catalog can be compared with itself.
+func (s *CatalogService) DiffCatalogs(fromID uint, fromAt *time.Time, toID uint, toAt *time.Time, userRoles []string) (*models.CatalogDiff, error) {
+	if !canReviewCatalogChanges(userRoles) {
+		return nil, fmt.Errorf("permission denied: only admins and reviewers can compare catalogs")
+	}
+
+	from, err := s.loadCatalogVersion(fromID, fromAt)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.loadCatalogVersion(toID, toAt)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := diffCatalogs(from, to)
+	return &models.CatalogDiff{
+		FromCatalogID: fromID,
+		FromAt:        fromAt,
+		ToCatalogID:   toID,
+		ToAt:          toAt,
+		Changes:       changes,
+		HasChanges:    len(changes) > 0,
+	}, nil
+}
+
+// GetCatalogAt reconstructs what a catalog looked like at the given point in time by rolling
+// back all logged changes made after it. Deleted entities are restored from the change log,
+// entities created after the point in time are removed.
+func (s *CatalogService) GetCatalogAt(catalogID uint, at time.Time, userRoles []string) (*models.CatalogSnapshot, error) {
+	if !canReviewCatalogChanges(userRoles) {
+		return nil, fmt.Errorf("permission denied: only admins and reviewers can view catalog history")
+	}
+
+	current, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	changes, err := s.catalogRepo.GetChangesByCatalogID(catalogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load change log: %w", err)
+	}
+
+	return reconstructCatalogAt(current, changes, at), nil
+}
+
+// loadCatalogVersion loads the current state of a catalog or its reconstruction at a point in time
+func (s *CatalogService) loadCatalogVersion(catalogID uint, at *time.Time) (*models.CatalogWithDetails, error) {
+	if at == nil {
+		details, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+		if err != nil {
+			return nil, err
+		}
+		if details == nil {
+			return nil, fmt.Errorf("catalog not found")
+		}
+		return details, nil
+	}
+
+	current, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+	changes, err := s.catalogRepo.GetChangesByCatalogID(catalogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load change log: %w", err)
+	}
+	return &reconstructCatalogAt(current, changes, *at).CatalogWithDetails, nil
+}
+
+// canReviewCatalogChanges checks whether a user may view catalog diffs and history
+func canReviewCatalogChanges(userRoles []string) bool {
+	return contains(userRoles, "admin") || contains(userRoles, "reviewer")
+}
+
+// reconstructCatalogAt rolls back all changes made after the given time on a copy of the catalog.
+// Changes must be ordered newest first (as returned by GetChangesByCatalogID). Deletions are
+// replayed from the logged entities; additions are recognized by the creation time of the entity,
+// which also covers entities created before deletions were logged.
+func reconstructCatalogAt(current *models.CatalogWithDetails, changes []models.CatalogChange, at time.Time) *models.CatalogSnapshot {
+	snapshot := &models.CatalogSnapshot{
+		CatalogWithDetails: *copyCatalogDetails(current),
+		At:                 at,
+	}
+	details := &snapshot.CatalogWithDetails
+
+	for _, change := range changes {
+		if !change.ChangedAt.After(at) {
+			continue
+		}
+		if change.FieldName == catalogDeletedField {
+			if restored, err := restoreDeletedEntity(details, change.EntityType, change.OldValue); err == nil && restored {
+				snapshot.RevertedChanges++
+			}
+			continue
+		}
+		if applied, err := applyCatalogFieldValue(details, change.EntityType, change.EntityID, change.FieldName, change.OldValue); err == nil && applied {
+			snapshot.RevertedChanges++
+		}
+	}
+
+	snapshot.RemovedEntities = removeEntitiesCreatedAfter(details, at)
+	sortCatalogDetails(details)
+
+	return snapshot
+}
+
+// deletedEntitySnapshot returns the JSON of a catalog entity with its children before it is deleted,
+// or nil if the entity is not part of the catalog
+func (s *CatalogService) deletedEntitySnapshot(catalogID uint, entityType string, entityID uint) ([]byte, error) {
+	details, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, nil
+	}
+	entity := findCatalogEntity(details, entityType, entityID)
+	if entity == nil {
+		return nil, nil
+	}
+	return json.Marshal(entity)
+}
+
+// findCatalogEntity returns an entity with its children in the logged form of a deletion, or nil
+func findCatalogEntity(details *models.CatalogWithDetails, entityType string, entityID uint) interface{} {
+	switch entityType {
+	case "category":
+		for _, category := range details.Categories {
+			if category.ID == entityID {
+				return category
+			}
+		}
+	case "level":
+		for _, level := range details.Levels {
+			if level.ID != entityID {
+				continue
+			}
+			deleted := deletedLevel{Level: level}
+			for _, category := range details.Categories {
+				for _, path := range category.Paths {
+					for _, desc := range path.Descriptions {
+						if desc.LevelID == entityID {
+							deleted.Descriptions = append(deleted.Descriptions, desc)
+						}
+					}
+				}
+			}
+			return deleted
+		}
+	case "path":
+		for _, category := range details.Categories {
+			for _, path := range category.Paths {
+				if path.ID == entityID {
+					return path
+				}
+			}
+		}
+	case "description":
+		for _, category := range details.Categories {
+			for _, path := range category.Paths {
+				for _, desc := range path.Descriptions {
+					if desc.ID == entityID {
+						return desc
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// restoreDeletedEntity adds a logged deleted entity back to an in-memory catalog.
+// Returns false if its parent is not part of the catalog or the entity already exists.
+func restoreDeletedEntity(details *models.CatalogWithDetails, entityType string, value *string) (bool, error) {
+	if value == nil {
+		return false, fmt.Errorf("deleted %s has no logged state", entityType)
+	}
+
+	switch entityType {
+	case "category":
+		var category models.CategoryWithPaths
+		if err := json.Unmarshal([]byte(*value), &category); err != nil {
+			return false, fmt.Errorf("invalid deleted category: %w", err)
+		}
+		if findCatalogEntity(details, "category", category.ID) != nil {
+			return false, nil
+		}
+		details.Categories = append(details.Categories, category)
+		return true, nil
+
+	case "level":
+		var level deletedLevel
+		if err := json.Unmarshal([]byte(*value), &level); err != nil {
+			return false, fmt.Errorf("invalid deleted level: %w", err)
+		}
+		if findCatalogEntity(details, "level", level.ID) != nil {
+			return false, nil
+		}
+		details.Levels = append(details.Levels, level.Level)
+		for _, desc := range level.Descriptions {
+			restoreDescription(details, desc)
+		}
+		return true, nil
+
+	case "path":
+		var path models.PathWithDescriptions
+		if err := json.Unmarshal([]byte(*value), &path); err != nil {
+			return false, fmt.Errorf("invalid deleted path: %w", err)
+		}
+		if findCatalogEntity(details, "path", path.ID) != nil {
+			return false, nil
+		}
+		for i := range details.Categories {
+			if details.Categories[i].ID == path.CategoryID {
+				details.Categories[i].Paths = append(details.Categories[i].Paths, path)
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case "description":
+		var desc models.PathLevelDescription
+		if err := json.Unmarshal([]byte(*value), &desc); err != nil {
+			return false, fmt.Errorf("invalid deleted description: %w", err)
+		}
+		return restoreDescription(details, desc), nil
+	}
+
+	return false, fmt.Errorf("unsupported entity type: %s", entityType)
+}
+
+// restoreDescription adds a description to its path unless the path is missing or already has it
+func restoreDescription(details *models.CatalogWithDetails, desc models.PathLevelDescription) bool {
+	for i := range details.Categories {
+		for j := range details.Categories[i].Paths {
+			path := &details.Categories[i].Paths[j]
+			if path.ID != desc.PathID {
+				continue
+			}
+			for _, existing := range path.Descriptions {
+				if existing.ID == desc.ID || existing.LevelID == desc.LevelID {
+					return false
+				}
+			}
+			path.Descriptions = append(path.Descriptions, desc)
+			return true
+		}
+	}
+	return false
+}
+
+// removeEntitiesCreatedAfter removes all levels, categories, paths and descriptions created after
+// the given time (including descriptions of removed levels) and returns the number of removed entities
+func removeEntitiesCreatedAfter(details *models.CatalogWithDetails, at time.Time) int {
+	removed := 0
+
+	removedLevels := make(map[uint]bool)
+	levels := details.Levels[:0]
+	for _, level := range details.Levels {
+		if level.CreatedAt.After(at) {
+			removedLevels[level.ID] = true
+			removed++
+			continue
+		}
+		levels = append(levels, level)
+	}
+	details.Levels = levels
+
+	categories := details.Categories[:0]
+	for _, category := range details.Categories {
+		if category.CreatedAt.After(at) {
+			removed++
+			continue
+		}
+		paths := category.Paths[:0]
+		for _, path := range category.Paths {
+			if path.CreatedAt.After(at) {
+				removed++
+				continue
+			}
+			descriptions := path.Descriptions[:0]
+			for _, desc := range path.Descriptions {
+				if removedLevels[desc.LevelID] {
+					continue
+				}
+				if desc.CreatedAt.After(at) {
+					removed++
+					continue
+				}
+				descriptions = append(descriptions, desc)
+			}
+			path.Descriptions = descriptions
+			paths = append(paths, path)
+		}
+		category.Paths = paths
+		categories = append(categories, category)
+	}
+	details.Categories = categories
+
+	return removed
+}
+
+// sortCatalogDetails restores the order of the repository after entities were restored
+func sortCatalogDetails(details *models.CatalogWithDetails) {
+	sort.SliceStable(details.Levels, func(i, j int) bool {
+		return details.Levels[i].LevelNumber < details.Levels[j].LevelNumber
+	})
+	sort.SliceStable(details.Categories, func(i, j int) bool {
+		return details.Categories[i].SortOrder < details.Categories[j].SortOrder
+	})
+	for i := range details.Categories {
+		paths := details.Categories[i].Paths
+		sort.SliceStable(paths, func(a, b int) bool { return paths[a].SortOrder < paths[b].SortOrder })
+		for j := range paths {
+			descriptions := paths[j].Descriptions
+			sort.SliceStable(descriptions, func(a, b int) bool { return descriptions[a].LevelID < descriptions[b].LevelID })
+		}
+	}
+}
+
+// applyCatalogFieldValue sets a single logged field of an entity in an in-memory catalog.
+// A nil value for a description removes the cell (it did not exist before).
+// Returns false if the entity is not part of the catalog (e.g. it was deleted).
+func applyCatalogFieldValue(details *models.CatalogWithDetails, entityType string, entityID uint, fieldName string, value *string) (bool, error) {
+	switch entityType {
+	case "catalog":
+		if details.ID != entityID {
+			return false, nil
+		}
+		switch fieldName {
+		case "name":
+			details.Name = derefString(value)
+		case "description":
+			details.Description = value
+		case "valid_from", "valid_until":
+			t, err := time.Parse(time.RFC3339, derefString(value))
+			if err != nil {
+				return false, fmt.Errorf("invalid %s value: %w", fieldName, err)
+			}
+			if fieldName == "valid_from" {
+				details.ValidFrom = t
+			} else {
+				details.ValidUntil = t
+			}
+		case "scoring_strategy":
+			details.ScoringStrategy = derefString(value)
+		case "level_rounding":
+			details.LevelRounding = derefString(value)
+		default:
+			return false, fmt.Errorf("unsupported catalog field: %s", fieldName)
+		}
+		return true, nil
+
+	case "category":
+		for i := range details.Categories {
+			category := &details.Categories[i]
+			if category.ID != entityID {
+				continue
+			}
+			switch fieldName {
+			case "name":
+				category.Name = derefString(value)
+			case "description":
+				category.Description = value
+			case "weight":
+				if value == nil {
+					category.Weight = nil
+					break
+				}
+				weight, err := strconv.ParseFloat(*value, 64)
+				if err != nil {
+					return false, fmt.Errorf("invalid weight value: %w", err)
+				}
+				category.Weight = &weight
+			case "sort_order":
+				sortOrder, err := strconv.Atoi(derefString(value))
+				if err != nil {
+					return false, fmt.Errorf("invalid sort_order value: %w", err)
+				}
+				category.SortOrder = sortOrder
+			default:
+				return false, fmt.Errorf("unsupported category field: %s", fieldName)
+			}
+			return true, nil
+		}
+		return false, nil
+
+	case "level":
+		for i := range details.Levels {
+			level := &details.Levels[i]
+			if level.ID != entityID {
+				continue
+			}
+			switch fieldName {
+			case "name":
+				level.Name = derefString(value)
+			case "description":
+				level.Description = value
+			case "level_number":
+				levelNumber, err := strconv.Atoi(derefString(value))
+				if err != nil {
+					return false, fmt.Errorf("invalid level_number value: %w", err)
+				}
+				level.LevelNumber = levelNumber
+			default:
+				return false, fmt.Errorf("unsupported level field: %s", fieldName)
+			}
+			return true, nil
+		}
+		return false, nil
+
+	case "path":
+		for i := range details.Categories {
+			for j := range details.Categories[i].Paths {
+				path := &details.Categories[i].Paths[j]
+				if path.ID != entityID {
+					continue
+				}
+				switch fieldName {
+				case "name":
+					path.Name = derefString(value)
+				case "description":
+					path.Description = value
+				case "sort_order":
+					sortOrder, err := strconv.Atoi(derefString(value))
+					if err != nil {
+						return false, fmt.Errorf("invalid sort_order value: %w", err)
+					}
+					path.SortOrder = sortOrder
+				case "weight":
+					if value == nil {
+						path.Weight = nil
+						break
+					}
+					weight, err := strconv.ParseFloat(*value, 64)
+					if err != nil {
+						return false, fmt.Errorf("invalid weight value: %w", err)
+					}
+					path.Weight = &weight
+				default:
+					return false, fmt.Errorf("unsupported path field: %s", fieldName)
+				}
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case "description":
+		if fieldName != "description" {
+			return false, fmt.Errorf("unsupported description field: %s", fieldName)
+		}
+		for i := range details.Categories {
+			for j := range details.Categories[i].Paths {
+				path := &details.Categories[i].Paths[j]
+				for k := range path.Descriptions {
+					if path.Descriptions[k].ID != entityID {
+						continue
+					}
+					if value == nil {
+						path.Descriptions = append(path.Descriptions[:k], path.Descriptions[k+1:]...)
+					} else {
+						path.Descriptions[k].Description = *value
+					}
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	return false, fmt.Errorf("unsupported entity type: %s", entityType)
+}
+
+// copyCatalogDetails creates a deep copy of a catalog with all nested slices
+func copyCatalogDetails(details *models.CatalogWithDetails) *models.CatalogWithDetails {
+	result := &models.CatalogWithDetails{
+		CriteriaCatalog: details.CriteriaCatalog,
+		Levels:          append([]models.Level(nil), details.Levels...),
+	}
+	for _, category := range details.Categories {
+		categoryCopy := models.CategoryWithPaths{Category: category.Category}
+		for _, path := range category.Paths {
+			categoryCopy.Paths = append(categoryCopy.Paths, models.PathWithDescriptions{
+				Path:         path.Path,
+				Descriptions: append([]models.PathLevelDescription(nil), path.Descriptions...),
+			})
+		}
+		result.Categories = append(result.Categories, categoryCopy)
+	}
+	return result
+}
+
+// diffCatalogs compares two catalog versions. Levels are matched by level number, categories
+// and paths by name; unmatched categories or paths with the same sort order are reported as renamed.
+func diffCatalogs(from, to *models.CatalogWithDetails) []models.CatalogDiffEntry {
+	var changes []models.CatalogDiffEntry
+
+	changed := func(entry models.CatalogDiffEntry, oldValue, newValue *string) {
+		if compareStringPointers(oldValue, newValue) {
+			return
+		}
+		entry.Change = "changed"
+		entry.OldValue = oldValue
+		entry.NewValue = newValue
+		changes = append(changes, entry)
+	}
+
+	// Catalog fields
+	catalogEntry := models.CatalogDiffEntry{EntityType: "catalog"}
+	catalogEntry.FieldName = "name"
+	changed(catalogEntry, &from.Name, &to.Name)
+	catalogEntry.FieldName = "description"
+	changed(catalogEntry, from.Description, to.Description)
+	catalogEntry.FieldName = "valid_from"
+	changed(catalogEntry, formatDate(from.ValidFrom), formatDate(to.ValidFrom))
+	catalogEntry.FieldName = "valid_until"
+	changed(catalogEntry, formatDate(from.ValidUntil), formatDate(to.ValidUntil))
+	catalogEntry.FieldName = "scoring_strategy"
+	changed(catalogEntry, stringPtr(from.ScoringStrategy), stringPtr(to.ScoringStrategy))
+	catalogEntry.FieldName = "level_rounding"
+	changed(catalogEntry, stringPtr(from.LevelRounding), stringPtr(to.LevelRounding))
+
+	// Levels, matched by level number
+	fromLevels := make(map[int]models.Level, len(from.Levels))
+	for _, level := range from.Levels {
+		fromLevels[level.LevelNumber] = level
+	}
+	toLevels := make(map[int]models.Level, len(to.Levels))
+	for _, level := range to.Levels {
+		toLevels[level.LevelNumber] = level
+		oldLevel, ok := fromLevels[level.LevelNumber]
+		if !ok {
+			changes = append(changes, models.CatalogDiffEntry{EntityType: "level", Change: "added", Level: level.Name})
+			continue
+		}
+		if oldLevel.Name != level.Name {
+			changes = append(changes, models.CatalogDiffEntry{
+				EntityType: "level", Change: "renamed", Level: level.Name,
+				OldValue: stringPtr(oldLevel.Name), NewValue: stringPtr(level.Name),
+			})
+		}
+		changed(models.CatalogDiffEntry{EntityType: "level", Level: level.Name, FieldName: "description"}, oldLevel.Description, level.Description)
+	}
+	for _, level := range from.Levels {
+		if _, ok := toLevels[level.LevelNumber]; !ok {
+			changes = append(changes, models.CatalogDiffEntry{EntityType: "level", Change: "removed", Level: level.Name})
+		}
+	}
+
+	// Level IDs differ between catalogs, so cells are compared via level numbers
+	fromLevelNumbers := levelNumbersByID(from.Levels)
+	toLevelNumbers := levelNumbersByID(to.Levels)
+	levelName := func(number int) string {
+		if level, ok := toLevels[number]; ok {
+			return level.Name
+		}
+		return fromLevels[number].Name
+	}
+
+	// Categories, matched by name and then by sort order
+	categoryPairs, addedCategories, removedCategories := matchCategories(from.Categories, to.Categories)
+	for _, pair := range categoryPairs {
+		oldCategory, newCategory := pair.from, pair.to
+		entry := models.CatalogDiffEntry{EntityType: "category", Category: newCategory.Name}
+		if oldCategory.Name != newCategory.Name {
+			renamed := entry
+			renamed.Change = "renamed"
+			renamed.OldValue = stringPtr(oldCategory.Name)
+			renamed.NewValue = stringPtr(newCategory.Name)
+			changes = append(changes, renamed)
+		}
+		entry.FieldName = "description"
+		changed(entry, oldCategory.Description, newCategory.Description)
+		entry.FieldName = "weight"
+		changed(entry, formatWeight(oldCategory.Weight), formatWeight(newCategory.Weight))
+		entry.FieldName = "sort_order"
+		changed(entry, formatInt(oldCategory.SortOrder), formatInt(newCategory.SortOrder))
+
+		// Paths, matched by name and then by sort order
+		pathPairs, addedPaths, removedPaths := matchPaths(oldCategory.Paths, newCategory.Paths)
+		for _, pathPair := range pathPairs {
+			oldPath, newPath := pathPair.from, pathPair.to
+			pathEntry := models.CatalogDiffEntry{EntityType: "path", Category: newCategory.Name, Path: newPath.Name}
+			if oldPath.Name != newPath.Name {
+				renamed := pathEntry
+				renamed.Change = "renamed"
+				renamed.OldValue = stringPtr(oldPath.Name)
+				renamed.NewValue = stringPtr(newPath.Name)
+				changes = append(changes, renamed)
+			}
+			pathEntry.FieldName = "description"
+			changed(pathEntry, oldPath.Description, newPath.Description)
+			pathEntry.FieldName = "sort_order"
+			changed(pathEntry, formatInt(oldPath.SortOrder), formatInt(newPath.SortOrder))
+			pathEntry.FieldName = "weight"
+			changed(pathEntry, formatWeight(oldPath.Weight), formatWeight(newPath.Weight))
+
+			// Cells, matched by level number
+			oldCells := cellsByLevelNumber(oldPath.Descriptions, fromLevelNumbers)
+			newCells := cellsByLevelNumber(newPath.Descriptions, toLevelNumbers)
+			for _, level := range to.Levels {
+				cellEntry := models.CatalogDiffEntry{EntityType: "description", Category: newCategory.Name, Path: newPath.Name, Level: level.Name}
+				oldText, hadOld := oldCells[level.LevelNumber]
+				newText, hasNew := newCells[level.LevelNumber]
+				switch {
+				case hasNew && !hadOld:
+					cellEntry.Change = "added"
+					cellEntry.NewValue = stringPtr(newText)
+					changes = append(changes, cellEntry)
+				case hasNew && hadOld:
+					cellEntry.FieldName = "description"
+					changed(cellEntry, stringPtr(oldText), stringPtr(newText))
+				}
+			}
+			for _, level := range from.Levels {
+				oldText, hadOld := oldCells[level.LevelNumber]
+				if _, hasNew := newCells[level.LevelNumber]; hadOld && !hasNew {
+					changes = append(changes, models.CatalogDiffEntry{
+						EntityType: "description", Change: "removed",
+						Category: newCategory.Name, Path: newPath.Name, Level: levelName(level.LevelNumber),
+						OldValue: stringPtr(oldText),
+					})
+				}
+			}
+		}
+		for _, path := range addedPaths {
+			changes = append(changes, models.CatalogDiffEntry{EntityType: "path", Change: "added", Category: newCategory.Name, Path: path.Name})
+		}
+		for _, path := range removedPaths {
+			changes = append(changes, models.CatalogDiffEntry{EntityType: "path", Change: "removed", Category: newCategory.Name, Path: path.Name})
+		}
+	}
+	for _, category := range addedCategories {
+		changes = append(changes, models.CatalogDiffEntry{EntityType: "category", Change: "added", Category: category.Name})
+	}
+	for _, category := range removedCategories {
+		changes = append(changes, models.CatalogDiffEntry{EntityType: "category", Change: "removed", Category: category.Name})
+	}
+
+	return changes
+}
+
+type categoryPair struct {
+	from, to models.CategoryWithPaths
+}
+
+type pathPair struct {
+	from, to models.PathWithDescriptions
+}
+
+// matchCategories pairs categories by name, then remaining ones by sort order (renames)
+func matchCategories(from, to []models.CategoryWithPaths) ([]categoryPair, []models.CategoryWithPaths, []models.CategoryWithPaths) {
+	fromNames := make([]string, len(from))
+	fromSortOrders := make([]int, len(from))
+	for i, category := range from {
+		fromNames[i] = category.Name
+		fromSortOrders[i] = category.SortOrder
+	}
+	toNames := make([]string, len(to))
+	toSortOrders := make([]int, len(to))
+	for i, category := range to {
+		toNames[i] = category.Name
+		toSortOrders[i] = category.SortOrder
+	}
+
+	pairs, added, removed := matchByNameThenSortOrder(fromNames, fromSortOrders, toNames, toSortOrders)
+
+	var result []categoryPair
+	for _, p := range pairs {
+		result = append(result, categoryPair{from: from[p[0]], to: to[p[1]]})
+	}
+	var addedCategories, removedCategories []models.CategoryWithPaths
+	for _, i := range added {
+		addedCategories = append(addedCategories, to[i])
+	}
+	for _, i := range removed {
+		removedCategories = append(removedCategories, from[i])
+	}
+	return result, addedCategories, removedCategories
+}
+
+// matchPaths pairs paths by name, then remaining ones by sort order (renames)
+func matchPaths(from, to []models.PathWithDescriptions) ([]pathPair, []models.PathWithDescriptions, []models.PathWithDescriptions) {
+	fromNames := make([]string, len(from))
+	fromSortOrders := make([]int, len(from))
+	for i, path := range from {
+		fromNames[i] = path.Name
+		fromSortOrders[i] = path.SortOrder
+	}
+	toNames := make([]string, len(to))
+	toSortOrders := make([]int, len(to))
+	for i, path := range to {
+		toNames[i] = path.Name
+		toSortOrders[i] = path.SortOrder
+	}
+
+	pairs, added, removed := matchByNameThenSortOrder(fromNames, fromSortOrders, toNames, toSortOrders)
+
+	var result []pathPair
+	for _, p := range pairs {
+		result = append(result, pathPair{from: from[p[0]], to: to[p[1]]})
+	}
+	var addedPaths, removedPaths []models.PathWithDescriptions
+	for _, i := range added {
+		addedPaths = append(addedPaths, to[i])
+	}
+	for _, i := range removed {
+		removedPaths = append(removedPaths, from[i])
+	}
+	return result, addedPaths, removedPaths
+}
+
+// matchByNameThenSortOrder returns index pairs [fromIndex, toIndex] of matching entries (in target
+// order) as well as the indexes of added (target only) and removed (source only) entries
+func matchByNameThenSortOrder(fromNames []string, fromSortOrders []int, toNames []string, toSortOrders []int) ([][2]int, []int, []int) {
+	matchedFrom := make(map[int]bool)
+	toMatch := make(map[int]int) // [toIndex]fromIndex
+
+	for j, name := range toNames {
+		for i, fromName := range fromNames {
+			if !matchedFrom[i] && fromName == name {
+				matchedFrom[i] = true
+				toMatch[j] = i
+				break
+			}
+		}
+	}
+	for j := range toNames {
+		if _, ok := toMatch[j]; ok {
+			continue
+		}
+		for i := range fromNames {
+			if !matchedFrom[i] && fromSortOrders[i] == toSortOrders[j] {
+				matchedFrom[i] = true
+				toMatch[j] = i
+				break
+			}
+		}
+	}
+
+	var pairs [][2]int
+	var added, removed []int
+	for j := range toNames {
+		if i, ok := toMatch[j]; ok {
+			pairs = append(pairs, [2]int{i, j})
+		} else {
+			added = append(added, j)
+		}
+	}
+	for i := range fromNames {
+		if !matchedFrom[i] {
+			removed = append(removed, i)
+		}
+	}
+	return pairs, added, removed
+}
+
+// levelNumbersByID maps level IDs to level numbers
+func levelNumbersByID(levels []models.Level) map[uint]int {
+	result := make(map[uint]int, len(levels))
+	for _, level := range levels {
+		result[level.ID] = level.LevelNumber
+	}
+	return result
+}
+
+// cellsByLevelNumber maps the descriptions of a path to their level numbers
+func cellsByLevelNumber(descriptions []models.PathLevelDescription, levelNumbers map[uint]int) map[int]string {
+	result := make(map[int]string, len(descriptions))
+	for _, desc := range descriptions {
+		if number, ok := levelNumbers[desc.LevelID]; ok {
+			result[number] = desc.Description
+		}
+	}
+	return result
+}
+
+// formatDate formats a date for diff output
+func formatDate(t time.Time) *string {
+	formatted := t.Format(catalogDocumentDateFormat)
+	return &formatted
+}
+
+// stringPtr returns a pointer to a copy of the string
+func stringPtr(value string) *string {
+	return &value
+}
+
+// derefString returns the string value or an empty string for nil
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+func testCatalogDetails(t *testing.T) *models.CatalogWithDetails {
+	t.Helper()
+	details, errs := documentToCatalog(testCatalogDocument())
+	if len(errs) != 0 {
+		t.Fatalf("documentToCatalog() errors = %v", errs)
+	}
+	details.ID = 1
+	details.Categories[0].ID = 10
+	details.Categories[0].Paths[0].ID = 100
+	for i := range details.Categories[0].Paths[0].Descriptions {
+		details.Categories[0].Paths[0].Descriptions[i].ID = uint(1000 + i)
+	}
+	return details
+}
+
+func TestDiffCatalogs(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(details *models.CatalogWithDetails)
+		want   []models.CatalogDiffEntry
+	}{
+		{
+			name:   "identical catalogs",
+			modify: func(details *models.CatalogWithDetails) {},
+			want:   nil,
+		},
+		{
+			name: "renamed category",
+			modify: func(details *models.CatalogWithDetails) {
+				details.Categories[0].Name = "Engineering"
+			},
+			want: []models.CatalogDiffEntry{
+				{EntityType: "category", Change: "renamed", Category: "Engineering"},
+			},
+		},
+		{
+			name: "changed weight and cell",
+			modify: func(details *models.CatalogWithDetails) {
+				weight := 0.5
+				details.Categories[0].Weight = &weight
+				details.Categories[0].Paths[0].Descriptions[0].Description = "Fundamentals"
+			},
+			want: []models.CatalogDiffEntry{
+				{EntityType: "category", Change: "changed", Category: "Technology", FieldName: "weight"},
+				{EntityType: "description", Change: "changed", Category: "Technology", Path: "Backend", Level: "A", FieldName: "description"},
+			},
+		},
+		{
+			name: "added path and removed cell",
+			modify: func(details *models.CatalogWithDetails) {
+				details.Categories[0].Paths[0].Descriptions = details.Categories[0].Paths[0].Descriptions[:1]
+				details.Categories[0].Paths = append(details.Categories[0].Paths, models.PathWithDescriptions{
+					Path: models.Path{Name: "Frontend", SortOrder: 1},
+				})
+			},
+			want: []models.CatalogDiffEntry{
+				{EntityType: "description", Change: "removed", Category: "Technology", Path: "Backend", Level: "B"},
+				{EntityType: "path", Change: "added", Category: "Technology", Path: "Frontend"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := testCatalogDetails(t)
+			to := copyCatalogDetails(from)
+			tt.modify(to)
+
+			got := diffCatalogs(from, to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("diffCatalogs() = %+v, want %d entries", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				g := got[i]
+				if g.EntityType != want.EntityType || g.Change != want.Change || g.Category != want.Category ||
+					g.Path != want.Path || g.Level != want.Level || g.FieldName != want.FieldName {
+					t.Errorf("entry %d = %+v, want %+v", i, g, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReconstructCatalogAt(t *testing.T) {
+	current := testCatalogDetails(t)
+	current.Categories[0].Paths[0].Descriptions[0].Description = "Fundamentals"
+
+	before := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	changedAt := before.Add(24 * time.Hour)
+	added := current.Categories[0].Paths[0].Descriptions[1]
+
+	changes := []models.CatalogChange{
+		{CatalogID: 1, EntityType: "description", EntityID: added.ID, FieldName: "description", NewValue: &added.Description, ChangedAt: changedAt},
+		{CatalogID: 1, EntityType: "description", EntityID: 1000, FieldName: "description", OldValue: stringPtr("Basics"), NewValue: stringPtr("Fundamentals"), ChangedAt: changedAt},
+		{CatalogID: 1, EntityType: "catalog", EntityID: 1, FieldName: "name", OldValue: stringPtr("Old name"), NewValue: stringPtr("Catalog 2026"), ChangedAt: before.Add(-time.Hour)},
+	}
+
+	snapshot := reconstructCatalogAt(current, changes, before)
+
+	if snapshot.RevertedChanges != 2 {
+		t.Errorf("RevertedChanges = %d, want 2", snapshot.RevertedChanges)
+	}
+	descriptions := snapshot.Categories[0].Paths[0].Descriptions
+	if len(descriptions) != 1 || descriptions[0].Description != "Basics" {
+		t.Errorf("descriptions = %+v, want only 'Basics'", descriptions)
+	}
+	if snapshot.Name != "Catalog 2026" {
+		t.Errorf("Name = %q, change before the point in time must not be reverted", snapshot.Name)
+	}
+	if current.Categories[0].Paths[0].Descriptions[0].Description != "Fundamentals" || len(current.Categories[0].Paths[0].Descriptions) != 2 {
+		t.Error("reconstructCatalogAt() modified the current catalog")
+	}
+}
+
+func TestReconstructCatalogAtStructuralChanges(t *testing.T) {
+	before := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	changedAt := before.Add(24 * time.Hour)
+
+	original := testCatalogDetails(t)
+	for i := range original.Levels {
+		original.Levels[i].ID = uint(10 + i)
+	}
+	original.Categories[0].Paths[0].CategoryID = 10
+	for i := range original.Categories[0].Paths[0].Descriptions {
+		original.Categories[0].Paths[0].Descriptions[i].PathID = 100
+		original.Categories[0].Paths[0].Descriptions[i].LevelID = original.Levels[i].ID
+	}
+
+	// After the point in time: level B and the path were deleted, a category was added
+	current := copyCatalogDetails(original)
+	levelSnapshot, err := json.Marshal(findCatalogEntity(original, "level", original.Levels[1].ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	current.Levels = current.Levels[:1]
+	pathSnapshot, err := json.Marshal(findCatalogEntity(current, "path", 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	current.Categories[0].Paths = nil
+	current.Categories = append(current.Categories, models.CategoryWithPaths{
+		Category: models.Category{ID: 11, Name: "Leadership", SortOrder: 2, CreatedAt: changedAt},
+	})
+
+	// Newest first: the path was deleted after the level
+	changes := []models.CatalogChange{
+		{CatalogID: 1, EntityType: "path", EntityID: 100, FieldName: catalogDeletedField, OldValue: stringPtr(string(pathSnapshot)), ChangedAt: changedAt.Add(time.Hour)},
+		{CatalogID: 1, EntityType: "level", EntityID: original.Levels[1].ID, FieldName: catalogDeletedField, OldValue: stringPtr(string(levelSnapshot)), ChangedAt: changedAt},
+	}
+
+	snapshot := reconstructCatalogAt(current, changes, before)
+
+	if snapshot.RevertedChanges != 2 || snapshot.RemovedEntities != 1 {
+		t.Errorf("RevertedChanges = %d, RemovedEntities = %d, want 2 and 1", snapshot.RevertedChanges, snapshot.RemovedEntities)
+	}
+	if diff := diffCatalogs(original, &snapshot.CatalogWithDetails); len(diff) != 0 {
+		t.Errorf("reconstructed catalog differs from the original: %+v", diff)
+	}
+	if len(current.Levels) != 1 || len(current.Categories) != 2 {
+		t.Error("reconstructCatalogAt() modified the current catalog")
+	}
+}
+
+func TestPlanCatalogRevert(t *testing.T) {
+	current := testCatalogDetails(t)
+	current.Categories[0].Paths[0].Descriptions[0].Description = "Fundamentals"
+	added := current.Categories[0].Paths[0].Descriptions[1]
+
+	changes := []models.CatalogChange{
+		{ID: 4, CatalogID: 1, EntityType: "description", EntityID: added.ID, FieldName: "description", NewValue: &added.Description},
+		{ID: 3, CatalogID: 1, EntityType: "description", EntityID: 1000, FieldName: "description", OldValue: stringPtr("Basics"), NewValue: stringPtr("Fundamentals")},
+		{ID: 2, CatalogID: 1, EntityType: "path", EntityID: 999, FieldName: "name", OldValue: stringPtr("Deleted"), NewValue: stringPtr("Removed")},
+		{ID: 1, CatalogID: 1, EntityType: "catalog", EntityID: 1, FieldName: "name", OldValue: stringPtr("Old name"), NewValue: stringPtr("Catalog 2026")},
+	}
+
+	plan, err := planCatalogRevert(current, changes, []string{"admin"})
+	if err != nil {
+		t.Fatalf("planCatalogRevert() error = %v", err)
+	}
+	if plan.reverted != 3 || len(plan.skipped) != 1 {
+		t.Errorf("reverted = %d, skipped = %v, want 3 reverted and change 2 skipped", plan.reverted, plan.skipped)
+	}
+	if plan.catalog.Name != "Old name" || !plan.touched[revertEntityKey("catalog", 1)] || !plan.touched["description"] {
+		t.Errorf("catalog = %q, touched = %v", plan.catalog.Name, plan.touched)
+	}
+	descriptions := plan.catalog.Categories[0].Paths[0].Descriptions
+	if len(descriptions) != 1 || descriptions[0].Description != "Basics" {
+		t.Errorf("descriptions = %+v, want only 'Basics'", descriptions)
+	}
+	if current.Name == "Old name" || len(current.Categories[0].Paths[0].Descriptions) != 2 {
+		t.Error("planCatalogRevert() modified the current catalog")
+	}
+
+	// A change that cannot be reverted fails the whole plan before anything is written
+	changes = append(changes, models.CatalogChange{ID: 5, CatalogID: 1, EntityType: "level", EntityID: 7, FieldName: catalogDeletedField, OldValue: stringPtr("{}")})
+	if _, err := planCatalogRevert(current, changes, []string{"admin"}); err == nil {
+		t.Error("expected error for the deletion of a level")
+	}
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"fmt"
+	"new-pay/internal/models"
+	"strings"
+	"time"
+)
+
+// catalogDocumentDateFormat is the date format used for validity dates in catalog documents
+const catalogDocumentDateFormat = "2006-01-02"
+
+// ExportCatalog serializes a catalog with all categories, levels, paths and descriptions
+// into a portable document that can be re-imported with ImportCatalog
+func (s *CatalogService) ExportCatalog(catalogID uint, userRoles []string) (*models.CatalogDocument, error) {
+	if !contains(userRoles, "admin") {
+		return nil, fmt.Errorf("permission denied: only admins can export catalogs")
+	}
+
+	details, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	return catalogToDocument(details), nil
+}
+
+// ImportCatalog creates a new draft catalog from a catalog document in one transaction.
+// In dry-run mode the document is only validated and nothing is written.
+// Blocking validation errors are reported in the result instead of being returned as error.
+func (s *CatalogService) ImportCatalog(doc *models.CatalogDocument, userID uint, userRoles []string, dryRun bool) (*models.CatalogImportResult, error) {
+	if !contains(userRoles, "admin") {
+		return nil, fmt.Errorf("permission denied: only admins can import catalogs")
+	}
+
+	result := &models.CatalogImportResult{DryRun: dryRun}
+
+	details, errs := documentToCatalog(doc)
+	result.Errors = errs
+
+	if details != nil {
+		overlaps, err := s.catalogRepo.CheckOverlappingCatalogs(details.ValidFrom, details.ValidUntil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check overlapping catalogs: %w", err)
+		}
+		if overlaps {
+			result.Errors = append(result.Errors, "catalog validity period overlaps with existing non-archived catalog")
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	if !result.Valid {
+		return result, nil
+	}
+
+	// Completeness is only required for activation, so a draft may still be imported
+	if err := checkCatalogCompleteness(details); err != nil {
+		msg := err.Error()
+		result.CompletenessError = &msg
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	details.Phase = "draft"
+	details.CreatedBy = &userID
+	if err := s.catalogRepo.CreateCatalogWithDetails(details); err != nil {
+		return nil, fmt.Errorf("failed to import catalog: %w", err)
+	}
+	result.Catalog = details
+
+	// Audit log
+	s.auditSvc.Log(userID, "import", "catalog", fmt.Sprintf("Imported catalog: %s (ID: %d)", details.Name, details.ID))
+
+	return result, nil
+}
+
+// catalogToDocument converts a catalog with details into its portable document form
+func catalogToDocument(details *models.CatalogWithDetails) *models.CatalogDocument {
+	doc := &models.CatalogDocument{
+		SchemaVersion:   models.CatalogDocumentSchemaVersion,
+		Name:            details.Name,
+		Description:     details.Description,
+		ValidFrom:       details.ValidFrom.Format(catalogDocumentDateFormat),
+		ValidUntil:      details.ValidUntil.Format(catalogDocumentDateFormat),
+		ScoringStrategy: details.ScoringStrategy,
+		LevelRounding:   details.LevelRounding,
+		Levels:          []models.CatalogDocumentLevel{},
+		Categories:      []models.CatalogDocumentCategory{},
+	}
+
+	levelNames := make(map[uint]string, len(details.Levels))
+	for _, level := range details.Levels {
+		levelNames[level.ID] = level.Name
+		doc.Levels = append(doc.Levels, models.CatalogDocumentLevel{
+			Name:        level.Name,
+			LevelNumber: level.LevelNumber,
+			Description: level.Description,
+		})
+	}
+
+	for _, category := range details.Categories {
+		docCategory := models.CatalogDocumentCategory{
+			Name:        category.Name,
+			Description: category.Description,
+			SortOrder:   category.SortOrder,
+			Weight:      category.Weight,
+			Paths:       []models.CatalogDocumentPath{},
+		}
+
+		for _, path := range category.Paths {
+			docPath := models.CatalogDocumentPath{
+				Name:         path.Name,
+				Description:  path.Description,
+				SortOrder:    path.SortOrder,
+				Weight:       path.Weight,
+				Descriptions: []models.CatalogDocumentCell{},
+			}
+			for _, desc := range path.Descriptions {
+				docPath.Descriptions = append(docPath.Descriptions, models.CatalogDocumentCell{
+					Level:       levelNames[desc.LevelID],
+					Description: desc.Description,
+				})
+			}
+			docCategory.Paths = append(docCategory.Paths, docPath)
+		}
+
+		doc.Categories = append(doc.Categories, docCategory)
+	}
+
+	return doc
+}
+
+// documentToCatalog validates a catalog document against the schema and converts it into an
+// unsaved catalog. Levels get temporary IDs (1..n) which descriptions reference via LevelID.
+// Returns all validation errors found; the catalog is nil if the dates could not be parsed.
+func documentToCatalog(doc *models.CatalogDocument) (*models.CatalogWithDetails, []string) {
+	var errs []string
+	addErr := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if doc.SchemaVersion != models.CatalogDocumentSchemaVersion {
+		addErr("unsupported schema_version %d (expected %d)", doc.SchemaVersion, models.CatalogDocumentSchemaVersion)
+	}
+	if strings.TrimSpace(doc.Name) == "" {
+		addErr("name is required")
+	}
+
+	validFrom, errFrom := time.Parse(catalogDocumentDateFormat, doc.ValidFrom)
+	if errFrom != nil {
+		addErr("invalid valid_from date format (expected YYYY-MM-DD)")
+	}
+	validUntil, errUntil := time.Parse(catalogDocumentDateFormat, doc.ValidUntil)
+	if errUntil != nil {
+		addErr("invalid valid_until date format (expected YYYY-MM-DD)")
+	}
+	if errFrom == nil && errUntil == nil && !validFrom.Before(validUntil) {
+		addErr("valid_from must be before valid_until")
+	}
+
+	details := &models.CatalogWithDetails{
+		CriteriaCatalog: models.CriteriaCatalog{
+			Name:            doc.Name,
+			Description:     doc.Description,
+			ValidFrom:       validFrom,
+			ValidUntil:      validUntil,
+			ScoringStrategy: doc.ScoringStrategy,
+			LevelRounding:   doc.LevelRounding,
+		},
+	}
+	if err := validateScoringConfig(&details.CriteriaCatalog); err != nil {
+		addErr("%s", err.Error())
+	}
+
+	levelIDs := make(map[string]uint, len(doc.Levels)) // [name]temporary ID
+	levelNumbers := make(map[int]bool, len(doc.Levels))
+	for i, level := range doc.Levels {
+		if strings.TrimSpace(level.Name) == "" {
+			addErr("levels[%d]: name is required", i)
+			continue
+		}
+		if _, exists := levelIDs[level.Name]; exists {
+			addErr("levels[%d]: duplicate level name '%s'", i, level.Name)
+			continue
+		}
+		if levelNumbers[level.LevelNumber] {
+			addErr("levels[%d]: duplicate level_number %d", i, level.LevelNumber)
+			continue
+		}
+		id := uint(i + 1)
+		levelIDs[level.Name] = id
+		levelNumbers[level.LevelNumber] = true
+		details.Levels = append(details.Levels, models.Level{
+			ID:          id,
+			Name:        level.Name,
+			LevelNumber: level.LevelNumber,
+			Description: level.Description,
+		})
+	}
+
+	categoryNames := make(map[string]bool, len(doc.Categories))
+	for i, category := range doc.Categories {
+		if strings.TrimSpace(category.Name) == "" {
+			addErr("categories[%d]: name is required", i)
+			continue
+		}
+		if categoryNames[category.Name] {
+			addErr("categories[%d]: duplicate category name '%s'", i, category.Name)
+			continue
+		}
+		categoryNames[category.Name] = true
+		if category.Weight != nil && (*category.Weight < 0 || *category.Weight > 1) {
+			addErr("category '%s': weight must be between 0.00 and 1.00", category.Name)
+		}
+
+		categoryWithPaths := models.CategoryWithPaths{
+			Category: models.Category{
+				Name:        category.Name,
+				Description: category.Description,
+				SortOrder:   category.SortOrder,
+				Weight:      category.Weight,
+			},
+		}
+
+		pathNames := make(map[string]bool, len(category.Paths))
+		for j, path := range category.Paths {
+			if strings.TrimSpace(path.Name) == "" {
+				addErr("category '%s': paths[%d]: name is required", category.Name, j)
+				continue
+			}
+			if pathNames[path.Name] {
+				addErr("category '%s': duplicate path name '%s'", category.Name, path.Name)
+				continue
+			}
+			pathNames[path.Name] = true
+			if path.Weight != nil && *path.Weight <= 0 {
+				addErr("category '%s', path '%s': weight must be greater than 0", category.Name, path.Name)
+			}
+
+			pathWithDescriptions := models.PathWithDescriptions{
+				Path: models.Path{
+					Name:        path.Name,
+					Description: path.Description,
+					SortOrder:   path.SortOrder,
+					Weight:      path.Weight,
+				},
+			}
+
+			cellLevels := make(map[string]bool, len(path.Descriptions))
+			for _, cell := range path.Descriptions {
+				levelID, ok := levelIDs[cell.Level]
+				if !ok {
+					addErr("category '%s', path '%s': unknown level '%s'", category.Name, path.Name, cell.Level)
+					continue
+				}
+				if cellLevels[cell.Level] {
+					addErr("category '%s', path '%s': duplicate description for level '%s'", category.Name, path.Name, cell.Level)
+					continue
+				}
+				cellLevels[cell.Level] = true
+				pathWithDescriptions.Descriptions = append(pathWithDescriptions.Descriptions, models.PathLevelDescription{
+					LevelID:     levelID,
+					Description: cell.Description,
+				})
+			}
+
+			categoryWithPaths.Paths = append(categoryWithPaths.Paths, pathWithDescriptions)
+		}
+
+		details.Categories = append(details.Categories, categoryWithPaths)
+	}
+
+	if errFrom != nil || errUntil != nil {
+		return nil, errs
+	}
+
+	return details, errs
+}
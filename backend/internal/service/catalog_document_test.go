@@ -0,0 +1,107 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+func testCatalogDocument() *models.CatalogDocument {
+	weight := 1.0
+	return &models.CatalogDocument{
+		SchemaVersion: models.CatalogDocumentSchemaVersion,
+		Name:          "Catalog 2026",
+		ValidFrom:     "2026-01-01",
+		ValidUntil:    "2026-12-31",
+		Levels: []models.CatalogDocumentLevel{
+			{Name: "A", LevelNumber: 1},
+			{Name: "B", LevelNumber: 2},
+		},
+		Categories: []models.CatalogDocumentCategory{
+			{
+				Name:   "Technology",
+				Weight: &weight,
+				Paths: []models.CatalogDocumentPath{
+					{
+						Name: "Backend",
+						Descriptions: []models.CatalogDocumentCell{
+							{Level: "A", Description: "Basics"},
+							{Level: "B", Description: "Advanced"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDocumentToCatalog(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(doc *models.CatalogDocument)
+		wantErrors int
+	}{
+		{
+			name:       "valid document",
+			modify:     func(doc *models.CatalogDocument) {},
+			wantErrors: 0,
+		},
+		{
+			name:       "unsupported schema version",
+			modify:     func(doc *models.CatalogDocument) { doc.SchemaVersion = 99 },
+			wantErrors: 1,
+		},
+		{
+			name:       "invalid date",
+			modify:     func(doc *models.CatalogDocument) { doc.ValidFrom = "01.01.2026" },
+			wantErrors: 1,
+		},
+		{
+			name: "duplicate level number",
+			modify: func(doc *models.CatalogDocument) {
+				doc.Levels = append(doc.Levels, models.CatalogDocumentLevel{Name: "C", LevelNumber: 2})
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "unknown level in cell",
+			modify: func(doc *models.CatalogDocument) {
+				doc.Categories[0].Paths[0].Descriptions[1].Level = "Z"
+			},
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := testCatalogDocument()
+			tt.modify(doc)
+			_, errs := documentToCatalog(doc)
+			if len(errs) != tt.wantErrors {
+				t.Errorf("documentToCatalog() errors = %v, want %d errors", errs, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestCatalogDocumentRoundTrip(t *testing.T) {
+	details, errs := documentToCatalog(testCatalogDocument())
+	if len(errs) != 0 {
+		t.Fatalf("documentToCatalog() errors = %v", errs)
+	}
+	if err := checkCatalogCompleteness(details); err != nil {
+		t.Errorf("checkCatalogCompleteness() error = %v", err)
+	}
+	if !details.ValidFrom.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ValidFrom = %v", details.ValidFrom)
+	}
+
+	doc := catalogToDocument(details)
+	if len(doc.Categories) != 1 || len(doc.Categories[0].Paths[0].Descriptions) != 2 {
+		t.Fatalf("catalogToDocument() lost structure: %+v", doc)
+	}
+	if got := doc.Categories[0].Paths[0].Descriptions[1]; got.Level != "B" || got.Description != "Advanced" {
+		t.Errorf("cell = %+v, want level B with description Advanced", got)
+	}
+}
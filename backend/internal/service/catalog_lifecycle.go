@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"new-pay/internal/models"
+	"time"
+)
+
+// ActivateDueCatalogs activates catalogs under review whose validity period has started.
+// Catalogs that are incomplete or lack the required approvals stay in review.
+// Used by the scheduler; transitions are audited under the system actor.
+func (s *CatalogService) ActivateDueCatalogs(now time.Time) ([]models.CriteriaCatalog, error) {
+	catalogs, err := s.catalogRepo.GetCatalogsByPhase("review")
+	if err != nil {
+		return nil, err
+	}
+
+	var activated []models.CriteriaCatalog
+	for _, catalog := range catalogs {
+		if !isCatalogDue(&catalog, now) || isCatalogExpired(&catalog, now) {
+			continue
+		}
+
+		if err := s.validateCatalogCompleteness(catalog.ID); err != nil {
+			slog.Warn("Catalog not activated automatically", "catalog_id", catalog.ID, "reason", err)
+			continue
+		}
+		if err := s.validateApprovals(catalog.ID); err != nil {
+			slog.Warn("Catalog not activated automatically", "catalog_id", catalog.ID, "reason", err)
+			continue
+		}
+
+		if err := s.catalogRepo.UpdateCatalogPhase(catalog.ID, "active"); err != nil {
+			return activated, fmt.Errorf("failed to activate catalog %d: %w", catalog.ID, err)
+		}
+		catalog.Phase = "active"
+		activated = append(activated, catalog)
+
+		// Audit log
+		s.auditSvc.LogSystem("transition", "catalog", fmt.Sprintf("Automatically activated catalog %s (ID: %d), valid from %s",
+			catalog.Name, catalog.ID, catalog.ValidFrom.Format(time.DateOnly)))
+	}
+
+	return activated, nil
+}
+
+// ArchiveExpiredCatalogs archives active catalogs whose validity period has ended.
+// Used by the scheduler; transitions are audited under the system actor.
+func (s *CatalogService) ArchiveExpiredCatalogs(now time.Time) ([]models.CriteriaCatalog, error) {
+	catalogs, err := s.catalogRepo.GetCatalogsByPhase("active")
+	if err != nil {
+		return nil, err
+	}
+
+	var archived []models.CriteriaCatalog
+	for _, catalog := range catalogs {
+		if !isCatalogExpired(&catalog, now) {
+			continue
+		}
+
+		if err := s.catalogRepo.UpdateCatalogPhase(catalog.ID, "archived"); err != nil {
+			return archived, fmt.Errorf("failed to archive catalog %d: %w", catalog.ID, err)
+		}
+		catalog.Phase = "archived"
+		archived = append(archived, catalog)
+
+		// Audit log
+		s.auditSvc.LogSystem("transition", "catalog", fmt.Sprintf("Automatically archived catalog %s (ID: %d), valid until %s",
+			catalog.Name, catalog.ID, catalog.ValidUntil.Format(time.DateOnly)))
+	}
+
+	return archived, nil
+}
+
+// isCatalogDue checks whether the first day of the validity period has been reached
+func isCatalogDue(catalog *models.CriteriaCatalog, now time.Time) bool {
+	return catalog.ValidFrom.Format(time.DateOnly) <= now.Format(time.DateOnly)
+}
+
+// isCatalogExpired checks whether the last day of the validity period has passed
+func isCatalogExpired(catalog *models.CriteriaCatalog, now time.Time) bool {
+	return catalog.ValidUntil.Format(time.DateOnly) < now.Format(time.DateOnly)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+func TestCatalogLifecycleDates(t *testing.T) {
+	catalog := &models.CriteriaCatalog{
+		ValidFrom:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		ValidUntil: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name        string
+		now         time.Time
+		wantDue     bool
+		wantExpired bool
+	}{
+		{name: "before validity", now: time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC)},
+		{name: "first day", now: time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC), wantDue: true},
+		{name: "last day", now: time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC), wantDue: true},
+		{name: "after validity", now: time.Date(2027, 1, 1, 0, 5, 0, 0, time.UTC), wantDue: true, wantExpired: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCatalogDue(catalog, tt.now); got != tt.wantDue {
+				t.Errorf("isCatalogDue() = %v, want %v", got, tt.wantDue)
+			}
+			if got := isCatalogExpired(catalog, tt.now); got != tt.wantExpired {
+				t.Errorf("isCatalogExpired() = %v, want %v", got, tt.wantExpired)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"new-pay/internal/models"
+	"strings"
+)
+
+// Header cells of the first two spreadsheet columns; all further columns are levels
+const (
+	matrixCategoryHeader = "Category"
+	matrixPathHeader     = "Path"
+)
+
+// matrixImportPlan is a validated spreadsheet matrix ready to be applied to a catalog
+type matrixImportPlan struct {
+	levels []string // Level name per level column
+	rows   []matrixImportRow
+}
+
+// matrixImportRow is one category/path row of the matrix
+type matrixImportRow struct {
+	category string
+	path     string
+	cells    map[string]string // [level name]description, empty cells are omitted
+}
+
+// ExportCatalogMatrix returns the path x level matrix of a catalog as spreadsheet rows.
+// The first row holds the headers: Category, Path and one column per level ordered by level number.
+func (s *CatalogService) ExportCatalogMatrix(catalogID uint, userRoles []string) ([][]string, error) {
+	if !contains(userRoles, "admin") {
+		return nil, fmt.Errorf("permission denied: only admins can export catalogs")
+	}
+
+	details, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	return catalogToMatrix(details), nil
+}
+
+// ImportCatalogMatrix upserts levels, categories, paths and descriptions of a catalog from
+// spreadsheet rows laid out as produced by ExportCatalogMatrix. Levels, categories and paths are
+// matched by name and created if missing; empty cells leave existing descriptions untouched.
+// The whole matrix is validated before anything is written; errors are reported per row and cell.
+func (s *CatalogService) ImportCatalogMatrix(catalogID uint, rows [][]string, userID uint, userRoles []string, dryRun bool) (*models.CatalogMatrixImportResult, error) {
+	if !contains(userRoles, "admin") {
+		return nil, fmt.Errorf("permission denied: only admins can import catalogs")
+	}
+
+	details, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	plan, result := planMatrixImport(details, rows)
+	result.DryRun = dryRun
+	if !result.Valid || dryRun {
+		return result, nil
+	}
+
+	// Creating levels, categories or paths is a structural change
+	if (result.CreatedLevels > 0 || result.CreatedCategories > 0 || result.CreatedPaths > 0) && !canEditStructure(details.Phase, userRoles) {
+		return nil, fmt.Errorf("permission denied: cannot change structure in %s phase", details.Phase)
+	}
+	if !canEditCatalog(details.Phase, userRoles) {
+		return nil, fmt.Errorf("permission denied: cannot edit catalog in %s phase", details.Phase)
+	}
+
+	// A failing row rolls back the whole import
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		return s.withTx(tx).applyMatrixImportPlan(details, plan, userID, userRoles)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import matrix: %w", err)
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "import", "catalog", fmt.Sprintf("Imported matrix into catalog %d: %d levels, %d categories, %d paths created, %d cells updated",
+		catalogID, result.CreatedLevels, result.CreatedCategories, result.CreatedPaths, result.UpdatedCells))
+
+	return result, nil
+}
+
+// applyMatrixImportPlan writes a validated matrix through the regular create and update methods.
+// It must run on a service bound to a transaction (withTx).
+func (s *CatalogService) applyMatrixImportPlan(details *models.CatalogWithDetails, plan *matrixImportPlan, userID uint, userRoles []string) error {
+	levelIDs := make(map[string]uint, len(details.Levels))
+	nextLevelNumber := 1
+	for _, level := range details.Levels {
+		levelIDs[level.Name] = level.ID
+		if level.LevelNumber >= nextLevelNumber {
+			nextLevelNumber = level.LevelNumber + 1
+		}
+	}
+	for _, name := range plan.levels {
+		if _, ok := levelIDs[name]; ok {
+			continue
+		}
+		level := &models.Level{CatalogID: details.ID, Name: name, LevelNumber: nextLevelNumber}
+		if err := s.CreateLevel(level, userID, userRoles); err != nil {
+			return err
+		}
+		levelIDs[name] = level.ID
+		nextLevelNumber++
+	}
+
+	categoryIDs := make(map[string]uint, len(details.Categories))
+	pathIDs := make(map[uint]map[string]uint) // [category ID][path name]path ID
+	cells := make(map[uint]map[uint]string)   // [path ID][level ID]description
+	nextCategorySortOrder := 0
+	for _, category := range details.Categories {
+		categoryIDs[category.Name] = category.ID
+		pathIDs[category.ID] = make(map[string]uint, len(category.Paths))
+		if category.SortOrder >= nextCategorySortOrder {
+			nextCategorySortOrder = category.SortOrder + 1
+		}
+		for _, path := range category.Paths {
+			pathIDs[category.ID][path.Name] = path.ID
+			cells[path.ID] = make(map[uint]string, len(path.Descriptions))
+			for _, desc := range path.Descriptions {
+				cells[path.ID][desc.LevelID] = desc.Description
+			}
+		}
+	}
+
+	for _, row := range plan.rows {
+		categoryID, ok := categoryIDs[row.category]
+		if !ok {
+			category := &models.Category{CatalogID: details.ID, Name: row.category, SortOrder: nextCategorySortOrder}
+			if err := s.CreateCategory(category, userID, userRoles); err != nil {
+				return err
+			}
+			categoryID = category.ID
+			categoryIDs[row.category] = categoryID
+			pathIDs[categoryID] = make(map[string]uint)
+			nextCategorySortOrder++
+		}
+
+		pathID, ok := pathIDs[categoryID][row.path]
+		if !ok {
+			path := &models.Path{CategoryID: categoryID, Name: row.path, SortOrder: len(pathIDs[categoryID])}
+			if err := s.CreatePath(path, userID, userRoles, details.ID); err != nil {
+				return err
+			}
+			pathID = path.ID
+			pathIDs[categoryID][row.path] = pathID
+			cells[pathID] = make(map[uint]string)
+		}
+
+		for _, levelName := range plan.levels {
+			text, ok := row.cells[levelName]
+			if !ok {
+				continue
+			}
+			levelID := levelIDs[levelName]
+			if existing, ok := cells[pathID][levelID]; ok && existing == text {
+				continue
+			}
+			desc := &models.PathLevelDescription{PathID: pathID, LevelID: levelID, Description: text}
+			if err := s.CreateOrUpdateDescription(desc, userID, userRoles, details.ID); err != nil {
+				return err
+			}
+			cells[pathID][levelID] = text
+		}
+	}
+
+	return nil
+}
+
+// catalogToMatrix converts a catalog into spreadsheet rows (header row first)
+func catalogToMatrix(details *models.CatalogWithDetails) [][]string {
+	header := []string{matrixCategoryHeader, matrixPathHeader}
+	levelColumns := make(map[uint]int, len(details.Levels)) // [level ID]column index
+	for i, level := range details.Levels {
+		header = append(header, level.Name)
+		levelColumns[level.ID] = i + 2
+	}
+
+	rows := [][]string{header}
+	for _, category := range details.Categories {
+		for _, path := range category.Paths {
+			row := make([]string, len(header))
+			row[0] = category.Name
+			row[1] = path.Name
+			for _, desc := range path.Descriptions {
+				if column, ok := levelColumns[desc.LevelID]; ok {
+					row[column] = desc.Description
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// planMatrixImport validates spreadsheet rows against a catalog and computes what an import
+// would create and update. A row with an empty category cell continues the previous category.
+func planMatrixImport(details *models.CatalogWithDetails, rows [][]string) (*matrixImportPlan, *models.CatalogMatrixImportResult) {
+	result := &models.CatalogMatrixImportResult{}
+	addErr := func(row, column int, format string, args ...interface{}) {
+		result.Errors = append(result.Errors, models.CatalogMatrixImportError{Row: row, Column: column, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(rows) == 0 {
+		addErr(1, 0, "spreadsheet is empty")
+		return nil, result
+	}
+
+	// Header row
+	header := rows[0]
+	if len(header) < 3 {
+		addErr(1, 0, "header must contain '%s', '%s' and at least one level column", matrixCategoryHeader, matrixPathHeader)
+		return nil, result
+	}
+	if !strings.EqualFold(strings.TrimSpace(header[0]), matrixCategoryHeader) {
+		addErr(1, 1, "expected header '%s'", matrixCategoryHeader)
+	}
+	if !strings.EqualFold(strings.TrimSpace(header[1]), matrixPathHeader) {
+		addErr(1, 2, "expected header '%s'", matrixPathHeader)
+	}
+
+	existingLevels := make(map[string]uint, len(details.Levels))
+	for _, level := range details.Levels {
+		existingLevels[level.Name] = level.ID
+	}
+
+	plan := &matrixImportPlan{}
+	seenLevels := make(map[string]bool)
+	for i, cell := range header[2:] {
+		name := strings.TrimSpace(cell)
+		column := i + 3
+		if name == "" {
+			addErr(1, column, "level name is required")
+			continue
+		}
+		if seenLevels[name] {
+			addErr(1, column, "duplicate level '%s'", name)
+			continue
+		}
+		seenLevels[name] = true
+		plan.levels = append(plan.levels, name)
+		if _, ok := existingLevels[name]; !ok {
+			result.CreatedLevels++
+		}
+	}
+
+	// Existing categories, paths and cells by name
+	existingPaths := make(map[string]map[string]map[uint]string) // [category][path][level ID]description
+	for _, category := range details.Categories {
+		existingPaths[category.Name] = make(map[string]map[uint]string, len(category.Paths))
+		for _, path := range category.Paths {
+			cells := make(map[uint]string, len(path.Descriptions))
+			for _, desc := range path.Descriptions {
+				cells[desc.LevelID] = desc.Description
+			}
+			existingPaths[category.Name][path.Name] = cells
+		}
+	}
+
+	// Data rows
+	newCategories := make(map[string]bool)
+	seenRows := make(map[string]bool)
+	currentCategory := ""
+	for i, cells := range rows[1:] {
+		rowNumber := i + 2
+		if isEmptyRow(cells) {
+			continue
+		}
+
+		if category := strings.TrimSpace(cellAt(cells, 0)); category != "" {
+			currentCategory = category
+		}
+		path := strings.TrimSpace(cellAt(cells, 1))
+		if currentCategory == "" {
+			addErr(rowNumber, 1, "category is required")
+			continue
+		}
+		if path == "" {
+			addErr(rowNumber, 2, "path is required")
+			continue
+		}
+		key := currentCategory + "\x00" + path
+		if seenRows[key] {
+			addErr(rowNumber, 2, "duplicate path '%s' in category '%s'", path, currentCategory)
+			continue
+		}
+		seenRows[key] = true
+
+		row := matrixImportRow{category: currentCategory, path: path, cells: make(map[string]string)}
+		for column := len(header); column < len(cells); column++ {
+			if strings.TrimSpace(cells[column]) != "" {
+				addErr(rowNumber, column+1, "cell outside of the level columns")
+			}
+		}
+		for j, levelName := range header[2:] {
+			text := strings.TrimSpace(cellAt(cells, j+2))
+			if text == "" || !seenLevels[strings.TrimSpace(levelName)] {
+				continue
+			}
+			row.cells[strings.TrimSpace(levelName)] = text
+		}
+
+		existingCategory, categoryExists := existingPaths[currentCategory]
+		if !categoryExists && !newCategories[currentCategory] {
+			newCategories[currentCategory] = true
+			result.CreatedCategories++
+		}
+		existingCells, pathExists := existingCategory[path]
+		if !pathExists {
+			result.CreatedPaths++
+		}
+		for levelName, text := range row.cells {
+			levelID, levelExists := existingLevels[levelName]
+			if !pathExists || !levelExists {
+				result.UpdatedCells++
+				continue
+			}
+			if existing, ok := existingCells[levelID]; !ok || existing != text {
+				result.UpdatedCells++
+			}
+		}
+
+		plan.rows = append(plan.rows, row)
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return plan, result
+}
+
+// cellAt returns the cell at the given index or an empty string if the row is shorter
+func cellAt(cells []string, index int) string {
+	if index < len(cells) {
+		return cells[index]
+	}
+	return ""
+}
+
+// isEmptyRow checks whether all cells of a row are blank
+func isEmptyRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestPlanMatrixImport(t *testing.T) {
+	tests := []struct {
+		name              string
+		rows              [][]string
+		wantErrors        int
+		wantCreatedLevels int
+		wantCreatedPaths  int
+		wantUpdatedCells  int
+	}{
+		{
+			name: "unchanged export",
+			rows: [][]string{
+				{"Category", "Path", "A", "B"},
+				{"Technology", "Backend", "Basics", "Advanced"},
+			},
+		},
+		{
+			name: "new path inherits category and new level",
+			rows: [][]string{
+				{"Category", "Path", "A", "B", "C"},
+				{"Technology", "Backend", "Basics", "Advanced", "Expert"},
+				{"", "Frontend", "HTML", "", ""},
+			},
+			wantCreatedLevels: 1,
+			wantCreatedPaths:  1,
+			wantUpdatedCells:  2,
+		},
+		{
+			name: "row and cell errors",
+			rows: [][]string{
+				{"Category", "Path", "A", "A"},
+				{"Technology", "", "Basics"},
+				{"Technology", "Backend", "Basics", "", "stray"},
+				{"Technology", "Backend", "Basics"},
+			},
+			wantErrors: 4,
+		},
+		{
+			name:       "missing header",
+			rows:       [][]string{{"Technology", "Backend", "Basics"}},
+			wantErrors: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, result := planMatrixImport(testCatalogDetails(t), tt.rows)
+			if len(result.Errors) != tt.wantErrors {
+				t.Fatalf("planMatrixImport() errors = %+v, want %d errors", result.Errors, tt.wantErrors)
+			}
+			if result.Valid != (tt.wantErrors == 0) {
+				t.Errorf("Valid = %v", result.Valid)
+			}
+			if tt.wantErrors > 0 {
+				return
+			}
+			if result.CreatedLevels != tt.wantCreatedLevels || result.CreatedPaths != tt.wantCreatedPaths || result.UpdatedCells != tt.wantUpdatedCells {
+				t.Errorf("result = %+v, want %d levels, %d paths, %d cells", result, tt.wantCreatedLevels, tt.wantCreatedPaths, tt.wantUpdatedCells)
+			}
+		})
+	}
+}
+
+func TestCatalogToMatrix(t *testing.T) {
+	rows := catalogToMatrix(testCatalogDetails(t))
+	if len(rows) != 2 {
+		t.Fatalf("catalogToMatrix() = %v, want header and one path row", rows)
+	}
+	want := []string{"Technology", "Backend", "Basics", "Advanced"}
+	for i, cell := range want {
+		if rows[1][i] != cell {
+			t.Errorf("rows[1][%d] = %q, want %q", i, rows[1][i], cell)
+		}
+	}
+}
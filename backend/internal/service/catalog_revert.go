@@ -0,0 +1,294 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"new-pay/internal/models"
+	"time"
+)
+
+// errRevertTargetNotFound is returned when the entity of a change log entry no longer exists
+var errRevertTargetNotFound = errors.New("changed entity not found")
+
+// catalogRevertPlan is the catalog state after reverting a set of changes, computed before anything is written
+type catalogRevertPlan struct {
+	catalog  *models.CatalogWithDetails
+	touched  map[string]bool // entity type + ID of every entity changed by the plan
+	reverted int
+	skipped  []string
+}
+
+// RevertChange reverts a single change log entry by writing its old value back.
+// The revert itself is recorded in the change log like any other edit.
+func (s *CatalogService) RevertChange(catalogID, changeID uint, userID uint, userRoles []string) (*models.CatalogRevertResult, error) {
+	if err := s.checkRevertAllowed(catalogID, userRoles); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.catalogRepo.GetChangesByCatalogID(catalogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load change log: %w", err)
+	}
+
+	var change *models.CatalogChange
+	for i := range changes {
+		if changes[i].ID == changeID {
+			change = &changes[i]
+			break
+		}
+	}
+	if change == nil {
+		return nil, fmt.Errorf("change not found")
+	}
+	if change.EntityType == reviewEntityType {
+		return nil, fmt.Errorf("cannot revert review events")
+	}
+
+	result, err := s.revertChanges(catalogID, []models.CatalogChange{*change}, userID, userRoles)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Skipped) > 0 {
+		return nil, errRevertTargetNotFound
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "revert", "catalog", fmt.Sprintf("Reverted change %d (%s %d, field %s) in catalog %d", change.ID, change.EntityType, change.EntityID, change.FieldName, catalogID))
+
+	return result, nil
+}
+
+// RevertChangesSince reverts all change log entries made after the given time, newest first.
+// Changes whose entity has been deleted in the meantime are skipped and reported in the result.
+// Either all remaining changes are reverted or, if one of them cannot be reverted, none.
+func (s *CatalogService) RevertChangesSince(catalogID uint, since time.Time, userID uint, userRoles []string) (*models.CatalogRevertResult, error) {
+	if err := s.checkRevertAllowed(catalogID, userRoles); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.catalogRepo.GetChangesByCatalogID(catalogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load change log: %w", err)
+	}
+
+	var selected []models.CatalogChange
+	for _, change := range changes {
+		if change.ChangedAt.After(since) && change.EntityType != reviewEntityType {
+			selected = append(selected, change)
+		}
+	}
+
+	result, err := s.revertChanges(catalogID, selected, userID, userRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "revert", "catalog", fmt.Sprintf("Reverted %d changes since %s in catalog %d", result.RevertedChanges, since.Format(time.RFC3339), catalogID))
+
+	return result, nil
+}
+
+// checkRevertAllowed ensures the catalog exists and may be edited by the user
+func (s *CatalogService) checkRevertAllowed(catalogID uint, userRoles []string) error {
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return err
+	}
+	if catalog == nil {
+		return fmt.Errorf("catalog not found")
+	}
+	if !canEditCatalog(catalog.Phase, userRoles) {
+		return fmt.Errorf("permission denied: cannot edit catalog in %s phase", catalog.Phase)
+	}
+	return nil
+}
+
+// revertChanges plans the revert of the given changes (newest first) on a copy of the catalog
+// and writes the resulting state in one transaction
+func (s *CatalogService) revertChanges(catalogID uint, changes []models.CatalogChange, userID uint, userRoles []string) (*models.CatalogRevertResult, error) {
+	details, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	plan, err := planCatalogRevert(details, changes, userRoles)
+	if err != nil {
+		return nil, err
+	}
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		return s.withTx(tx).writeRevertPlan(details, plan, userID, userRoles)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CatalogRevertResult{RevertedChanges: plan.reverted, Skipped: plan.skipped}, nil
+}
+
+// planCatalogRevert applies the old values of the changes to a copy of the catalog. Changes of
+// entities that no longer exist are skipped; any other change that cannot be reverted fails the plan.
+func planCatalogRevert(details *models.CatalogWithDetails, changes []models.CatalogChange, userRoles []string) (*catalogRevertPlan, error) {
+	plan := &catalogRevertPlan{
+		catalog: copyCatalogDetails(details),
+		touched: make(map[string]bool),
+	}
+
+	for i := range changes {
+		change := &changes[i]
+
+		// Sort order and level numbers are structural properties
+		if (change.FieldName == "sort_order" || change.FieldName == "level_number") && !canEditStructure(details.Phase, userRoles) {
+			return nil, fmt.Errorf("permission denied: cannot change structure in %s phase", details.Phase)
+		}
+
+		applied, err := planChangeRevert(plan.catalog, change)
+		if err != nil {
+			return nil, fmt.Errorf("failed to revert change %d: %w", change.ID, err)
+		}
+		if !applied {
+			plan.skipped = append(plan.skipped, fmt.Sprintf("change %d: %s %d no longer exists", change.ID, change.EntityType, change.EntityID))
+			continue
+		}
+
+		if change.EntityType == "description" {
+			plan.touched["description"] = true
+		} else {
+			plan.touched[revertEntityKey(change.EntityType, change.EntityID)] = true
+		}
+		plan.reverted++
+	}
+
+	return plan, nil
+}
+
+// planChangeRevert reverts a single change in the planned catalog and reports whether its entity was found
+func planChangeRevert(catalog *models.CatalogWithDetails, change *models.CatalogChange) (bool, error) {
+	if change.FieldName == catalogDeletedField {
+		// Deleted categories, levels and paths cannot be restored, references to them
+		// (e.g. from self-assessments) would point to new IDs
+		if change.EntityType != "description" {
+			return false, fmt.Errorf("cannot revert the deletion of %s %d", change.EntityType, change.EntityID)
+		}
+		if change.OldValue == nil {
+			return false, fmt.Errorf("deleted description %d has no logged state", change.EntityID)
+		}
+		return restoreDeletedEntity(catalog, change.EntityType, change.OldValue)
+	}
+
+	// A description without old value was newly created, so reverting means removing it
+	if change.EntityType == "description" && change.OldValue == nil {
+		return removePlannedDescription(catalog, change.EntityID), nil
+	}
+
+	return applyCatalogFieldValue(catalog, change.EntityType, change.EntityID, change.FieldName, change.OldValue)
+}
+
+// removePlannedDescription removes a description from the planned catalog
+func removePlannedDescription(catalog *models.CatalogWithDetails, descriptionID uint) bool {
+	for i := range catalog.Categories {
+		for j := range catalog.Categories[i].Paths {
+			path := &catalog.Categories[i].Paths[j]
+			for k, desc := range path.Descriptions {
+				if desc.ID == descriptionID {
+					path.Descriptions = append(path.Descriptions[:k], path.Descriptions[k+1:]...)
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// writeRevertPlan writes all entities changed by the plan through the regular update methods, which
+// validate and log them like any other edit. It must run on a service bound to a transaction (withTx).
+func (s *CatalogService) writeRevertPlan(current *models.CatalogWithDetails, plan *catalogRevertPlan, userID uint, userRoles []string) error {
+	catalogID := current.ID
+
+	if plan.touched[revertEntityKey("catalog", catalogID)] {
+		catalog := plan.catalog.CriteriaCatalog
+		if err := s.UpdateCatalog(&catalog, userID, userRoles); err != nil {
+			return err
+		}
+	}
+
+	for _, level := range plan.catalog.Levels {
+		if !plan.touched[revertEntityKey("level", level.ID)] {
+			continue
+		}
+		if err := s.UpdateLevel(&level, userID, userRoles); err != nil {
+			return err
+		}
+	}
+
+	currentDescs := make(map[uint]models.PathLevelDescription)
+	for _, category := range current.Categories {
+		for _, path := range category.Paths {
+			for _, desc := range path.Descriptions {
+				currentDescs[desc.ID] = desc
+			}
+		}
+	}
+
+	plannedDescs := make(map[uint]bool)
+	for _, category := range plan.catalog.Categories {
+		if plan.touched[revertEntityKey("category", category.ID)] {
+			if err := s.UpdateCategory(&category.Category, userID, userRoles); err != nil {
+				return err
+			}
+		}
+		for _, path := range category.Paths {
+			if plan.touched[revertEntityKey("path", path.ID)] {
+				if err := s.UpdatePath(&path.Path, userID, userRoles, catalogID); err != nil {
+					return err
+				}
+			}
+			for _, desc := range path.Descriptions {
+				plannedDescs[desc.ID] = true
+			}
+		}
+	}
+
+	if !plan.touched["description"] {
+		return nil
+	}
+
+	// Remove descriptions first, a restored description may take the cell of a removed one
+	for _, desc := range currentDescs {
+		if plannedDescs[desc.ID] {
+			continue
+		}
+		if err := s.DeleteDescription(desc.ID, catalogID, userID, userRoles); err != nil {
+			return err
+		}
+	}
+
+	for _, category := range plan.catalog.Categories {
+		for _, path := range category.Paths {
+			for _, desc := range path.Descriptions {
+				if existing, ok := currentDescs[desc.ID]; ok {
+					if existing.Description == desc.Description {
+						continue
+					}
+				} else {
+					// Restored descriptions get a new ID
+					desc.ID = 0
+				}
+				if err := s.CreateOrUpdateDescription(&desc, userID, userRoles, catalogID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// revertEntityKey identifies an entity in a revert plan
+func revertEntityKey(entityType string, entityID uint) string {
+	return fmt.Sprintf("%s:%d", entityType, entityID)
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"new-pay/internal/models"
+	"strings"
+)
+
+// reviewEntityType marks review events (submission, approvals, return to draft) in the change log.
+// These entries document the review and are not field edits, so they cannot be reverted.
+const reviewEntityType = "review"
+
+// SubmitForReview moves a complete draft catalog into the review phase
+func (s *CatalogService) SubmitForReview(catalogID uint, userID uint, userRoles []string) error {
+	if !contains(userRoles, "admin") {
+		return fmt.Errorf("permission denied: only admins can submit catalogs for review")
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return err
+	}
+	if catalog == nil {
+		return fmt.Errorf("catalog not found")
+	}
+
+	if catalog.Phase != "draft" {
+		return fmt.Errorf("can only submit catalogs in draft phase for review")
+	}
+
+	if err := s.validateCatalogCompleteness(catalogID); err != nil {
+		return fmt.Errorf("catalog validation failed: %w", err)
+	}
+
+	return s.transactor.InTx(func(tx *sql.Tx) error {
+		txSvc := s.withTx(tx)
+		if err := txSvc.lockPhase(catalogID, "draft", "can only submit catalogs in draft phase for review"); err != nil {
+			return err
+		}
+
+		// A new review starts without approvals
+		if err := txSvc.catalogRepo.DeleteApprovalsByCatalogID(catalogID); err != nil {
+			return fmt.Errorf("failed to reset approvals: %w", err)
+		}
+		if err := txSvc.logReviewEvent(catalogID, "submitted", userID); err != nil {
+			return fmt.Errorf("failed to log change: %w", err)
+		}
+		if err := txSvc.catalogRepo.UpdateCatalogPhase(catalogID, "review"); err != nil {
+			return err
+		}
+
+		// Audit log
+		txSvc.auditSvc.Log(userID, "submit_review", "catalog", fmt.Sprintf("Submitted catalog %s (ID: %d) for review", catalog.Name, catalogID))
+		return nil
+	})
+}
+
+// ReturnToDraft moves a catalog under review back to draft so it can be revised.
+// All approvals are discarded; review comments are kept.
+func (s *CatalogService) ReturnToDraft(catalogID uint, userID uint, userRoles []string) error {
+	if !contains(userRoles, "admin") {
+		return fmt.Errorf("permission denied: only admins can return catalogs to draft")
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return err
+	}
+	if catalog == nil {
+		return fmt.Errorf("catalog not found")
+	}
+
+	if catalog.Phase != "review" {
+		return fmt.Errorf("can only return catalogs in review phase to draft")
+	}
+
+	return s.transactor.InTx(func(tx *sql.Tx) error {
+		txSvc := s.withTx(tx)
+		if err := txSvc.lockPhase(catalogID, "review", "can only return catalogs in review phase to draft"); err != nil {
+			return err
+		}
+
+		if err := txSvc.catalogRepo.DeleteApprovalsByCatalogID(catalogID); err != nil {
+			return fmt.Errorf("failed to reset approvals: %w", err)
+		}
+		if err := txSvc.logReviewEvent(catalogID, "returned", userID); err != nil {
+			return fmt.Errorf("failed to log change: %w", err)
+		}
+		if err := txSvc.catalogRepo.UpdateCatalogPhase(catalogID, "draft"); err != nil {
+			return err
+		}
+
+		// Audit log
+		txSvc.auditSvc.Log(userID, "return_to_draft", "catalog", fmt.Sprintf("Returned catalog %s (ID: %d) from review to draft", catalog.Name, catalogID))
+		return nil
+	})
+}
+
+// ApproveCatalog records the approval of a catalog under review by a designated approver
+func (s *CatalogService) ApproveCatalog(catalogID uint, userID uint, userRoles []string) (*models.CatalogReviewStatus, error) {
+	if !contains(userRoles, "catalog_approver") {
+		return nil, fmt.Errorf("permission denied: only catalog approvers can approve catalogs")
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	if catalog.Phase != "review" {
+		return nil, fmt.Errorf("can only approve catalogs in review phase")
+	}
+
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		txSvc := s.withTx(tx)
+		if err := txSvc.lockPhase(catalogID, "review", "can only approve catalogs in review phase"); err != nil {
+			return err
+		}
+
+		created, err := txSvc.catalogRepo.CreateApproval(catalogID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to approve catalog: %w", err)
+		}
+		if !created {
+			return fmt.Errorf("catalog already approved by this user")
+		}
+		if err := txSvc.logReviewEvent(catalogID, "approval", userID); err != nil {
+			return fmt.Errorf("failed to log change: %w", err)
+		}
+
+		// Audit log
+		txSvc.auditSvc.Log(userID, "approve", "catalog", fmt.Sprintf("Approved catalog %s (ID: %d)", catalog.Name, catalogID))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReviewStatus(catalogID, userRoles)
+}
+
+// AddReviewComment adds a comment to a catalog under review
+func (s *CatalogService) AddReviewComment(catalogID uint, comment string, userID uint, userRoles []string) (*models.CatalogReviewComment, error) {
+	if !canParticipateInReview(userRoles) {
+		return nil, fmt.Errorf("permission denied: only admins and catalog approvers can comment on reviews")
+	}
+
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, fmt.Errorf("comment is required")
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	if catalog.Phase != "review" {
+		return nil, fmt.Errorf("can only comment on catalogs in review phase")
+	}
+
+	reviewComment := &models.CatalogReviewComment{
+		CatalogID:       catalogID,
+		CreatedByUserID: &userID,
+		Comment:         comment,
+	}
+	if err := s.catalogRepo.CreateReviewComment(reviewComment); err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "comment", "catalog", fmt.Sprintf("Commented on review of catalog %d", catalogID))
+
+	return reviewComment, nil
+}
+
+// GetReviewStatus returns the approvals and comments of a catalog review
+func (s *CatalogService) GetReviewStatus(catalogID uint, userRoles []string) (*models.CatalogReviewStatus, error) {
+	if !canParticipateInReview(userRoles) {
+		return nil, fmt.Errorf("permission denied: only admins and catalog approvers can view reviews")
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	approvals, err := s.catalogRepo.GetApprovalsByCatalogID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.catalogRepo.GetReviewCommentsByCatalogID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CatalogReviewStatus{
+		CatalogID:         catalogID,
+		Phase:             catalog.Phase,
+		RequiredApprovals: s.requiredApprovals,
+		Approvals:         approvals,
+		Comments:          comments,
+		CanActivate:       catalog.Phase == "review" && len(approvals) >= s.requiredApprovals,
+	}, nil
+}
+
+// validateApprovals ensures a catalog has the configured number of approvals
+func (s *CatalogService) validateApprovals(catalogID uint) error {
+	approvals, err := s.catalogRepo.GetApprovalsByCatalogID(catalogID)
+	if err != nil {
+		return fmt.Errorf("failed to load approvals: %w", err)
+	}
+	if len(approvals) < s.requiredApprovals {
+		return fmt.Errorf("catalog needs %d approvals before activation (currently: %d)", s.requiredApprovals, len(approvals))
+	}
+	return nil
+}
+
+// lockPhase locks the catalog row for the rest of the transaction and checks that the catalog is
+// (still) in the given phase. Review transitions and approvals are serialized this way, so an
+// approval cannot survive a concurrent return to draft.
+func (s *CatalogService) lockPhase(catalogID uint, phase, message string) error {
+	current, err := s.catalogRepo.LockCatalogPhase(catalogID)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return fmt.Errorf("catalog not found")
+	}
+	if current != phase {
+		return fmt.Errorf("%s", message)
+	}
+	return nil
+}
+
+// logReviewEvent records a review event in the change log
+func (s *CatalogService) logReviewEvent(catalogID uint, event string, userID uint) error {
+	change := &models.CatalogChange{
+		CatalogID:  catalogID,
+		EntityType: reviewEntityType,
+		EntityID:   catalogID,
+		FieldName:  event,
+		ChangedBy:  &userID,
+	}
+	return s.catalogRepo.LogChange(change)
+}
+
+func canParticipateInReview(userRoles []string) bool {
+	return contains(userRoles, "admin") || contains(userRoles, "catalog_approver")
+}
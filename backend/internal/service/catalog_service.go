@@ -1,10 +1,12 @@
 package service
 
 import (
+	"database/sql"
 	"fmt"
 	"new-pay/internal/email"
 	"new-pay/internal/models"
 	"new-pay/internal/repository"
+	"strconv"
 	"time"
 )
 
@@ -12,20 +14,34 @@ import (
 type CatalogService struct {
 	catalogRepo        *repository.CatalogRepository
 	selfAssessmentRepo *repository.SelfAssessmentRepository
+	transactor         *repository.Transactor
 	auditSvc           *AuditService
 	emailService       *email.Service
+	localizer          *CatalogLocalizer
+	requiredApprovals  int // Approvals required before a catalog under review can be activated
 }
 
 // NewCatalogService creates a new catalog service
-func NewCatalogService(catalogRepo *repository.CatalogRepository, selfAssessmentRepo *repository.SelfAssessmentRepository, auditSvc *AuditService, emailService *email.Service) *CatalogService {
+func NewCatalogService(catalogRepo *repository.CatalogRepository, selfAssessmentRepo *repository.SelfAssessmentRepository, transactor *repository.Transactor, auditSvc *AuditService, emailService *email.Service, localizer *CatalogLocalizer, requiredApprovals int) *CatalogService {
 	return &CatalogService{
 		catalogRepo:        catalogRepo,
 		selfAssessmentRepo: selfAssessmentRepo,
+		transactor:         transactor,
 		auditSvc:           auditSvc,
 		emailService:       emailService,
+		localizer:          localizer,
+		requiredApprovals:  requiredApprovals,
 	}
 }
 
+// withTx returns a copy of the service whose repository and audit log writes run in the given transaction
+func (s *CatalogService) withTx(tx *sql.Tx) *CatalogService {
+	txSvc := *s
+	txSvc.catalogRepo = s.catalogRepo.WithTx(tx)
+	txSvc.auditSvc = s.auditSvc.WithTx(tx)
+	return &txSvc
+}
+
 // Helper functions
 
 // CreateCatalog creates a new catalog in draft phase
@@ -35,6 +51,10 @@ func (s *CatalogService) CreateCatalog(catalog *models.CriteriaCatalog, userID u
 		return fmt.Errorf("valid_from must be before valid_until")
 	}
 
+	if err := validateScoringConfig(catalog); err != nil {
+		return err
+	}
+
 	// Check for overlapping catalogs
 	overlaps, err := s.catalogRepo.CheckOverlappingCatalogs(catalog.ValidFrom, catalog.ValidUntil, nil)
 	if err != nil {
@@ -86,6 +106,25 @@ func (s *CatalogService) GetVisibleCatalogs(userRoles []string, userID uint) ([]
 		return s.catalogRepo.GetAllCatalogs()
 	}
 
+	if contains(userRoles, "catalog_approver") {
+		// Approvers additionally see catalogs under review
+		reviewCatalogs, err := s.catalogRepo.GetCatalogsByPhase("review")
+		if err != nil {
+			return nil, err
+		}
+		otherRoles := make([]string, 0, len(userRoles))
+		for _, role := range userRoles {
+			if role != "catalog_approver" {
+				otherRoles = append(otherRoles, role)
+			}
+		}
+		catalogs, err := s.GetVisibleCatalogs(otherRoles, userID)
+		if err != nil {
+			return nil, err
+		}
+		return append(catalogs, reviewCatalogs...), nil
+	}
+
 	if isReviewer {
 		// Reviewers see active, archived, and draft catalogs
 		activeCatalogs, err := s.catalogRepo.GetCatalogsByPhase("active")
@@ -146,6 +185,14 @@ func (s *CatalogService) UpdateCatalog(catalog *models.CriteriaCatalog, userID u
 		return fmt.Errorf("catalog not found")
 	}
 
+	// Keep existing scoring settings if not given
+	if catalog.ScoringStrategy == "" {
+		catalog.ScoringStrategy = existing.ScoringStrategy
+	}
+	if catalog.LevelRounding == "" {
+		catalog.LevelRounding = existing.LevelRounding
+	}
+
 	// Check permissions
 	if !canEditCatalog(existing.Phase, userRoles) {
 		// Special case: Admins can update valid_until for active catalogs
@@ -157,6 +204,8 @@ func (s *CatalogService) UpdateCatalog(catalog *models.CriteriaCatalog, userID u
 			if catalog.Name != existing.Name ||
 				!descriptionSame ||
 				!validFromSame ||
+				catalog.ScoringStrategy != existing.ScoringStrategy ||
+				catalog.LevelRounding != existing.LevelRounding ||
 				catalog.Phase != existing.Phase {
 				return fmt.Errorf("permission denied: can only change valid_until for active catalogs")
 			}
@@ -187,6 +236,10 @@ func (s *CatalogService) UpdateCatalog(catalog *models.CriteriaCatalog, userID u
 		return fmt.Errorf("valid_from must be before valid_until")
 	}
 
+	if err := validateScoringConfig(catalog); err != nil {
+		return err
+	}
+
 	// Check for overlapping catalogs (excluding current catalog)
 	overlaps, err := s.catalogRepo.CheckOverlappingCatalogs(catalog.ValidFrom, catalog.ValidUntil, &catalog.ID)
 	if err != nil {
@@ -196,17 +249,22 @@ func (s *CatalogService) UpdateCatalog(catalog *models.CriteriaCatalog, userID u
 		return fmt.Errorf("catalog validity period overlaps with existing non-archived catalog")
 	}
 
-	// Log changes if in active phase
-	if existing.Phase == "active" {
-		if err := s.logCatalogChanges(existing, catalog, userID); err != nil {
-			return fmt.Errorf("failed to log changes: %w", err)
-		}
+	// Log field changes (used for diffs, point-in-time reconstruction and reverts)
+	if err := s.logCatalogChanges(existing, catalog, userID); err != nil {
+		return fmt.Errorf("failed to log changes: %w", err)
 	}
 
 	if err := s.catalogRepo.UpdateCatalog(catalog); err != nil {
 		return err
 	}
 
+	// A new review starts without approvals
+	if catalog.Phase == "review" && existing.Phase != "review" {
+		if err := s.catalogRepo.DeleteApprovalsByCatalogID(catalog.ID); err != nil {
+			return fmt.Errorf("failed to reset approvals: %w", err)
+		}
+	}
+
 	// Audit log
 	details := fmt.Sprintf("Updated catalog: %s (ID: %d)", catalog.Name, catalog.ID)
 	if existing.Phase != catalog.Phase {
@@ -276,7 +334,7 @@ func (s *CatalogService) UpdateCatalogValidUntil(catalogID uint, newValidUntilSt
 	}
 
 	// Send notifications to affected users
-	affectedStatuses := []string{"draft", "submitted", "in_review", "reviewed", "discussion"}
+	affectedStatuses := []string{"draft", "submitted", "changes_requested", "in_review", "reviewed", "discussion"}
 	oldDateStr := oldValidUntil.Format("02.01.2006")
 	newDateStr := newValidUntil.Format("02.01.2006")
 
@@ -294,7 +352,7 @@ func (s *CatalogService) UpdateCatalogValidUntil(catalogID uint, newValidUntilSt
 	return updatedCatalog, nil
 }
 
-// TransitionToActive transitions a catalog from draft to active phase
+// TransitionToActive transitions a catalog from review to active phase once it has enough approvals
 func (s *CatalogService) TransitionToActive(catalogID uint, userRoles []string) error {
 	if !contains(userRoles, "admin") {
 		return fmt.Errorf("permission denied: only admins can transition to active phase")
@@ -308,8 +366,8 @@ func (s *CatalogService) TransitionToActive(catalogID uint, userRoles []string)
 		return fmt.Errorf("catalog not found")
 	}
 
-	if catalog.Phase != "draft" {
-		return fmt.Errorf("can only transition from draft to active phase")
+	if catalog.Phase != "review" {
+		return fmt.Errorf("can only transition from review to active phase")
 	}
 
 	// Validate catalog completeness
@@ -317,6 +375,10 @@ func (s *CatalogService) TransitionToActive(catalogID uint, userRoles []string)
 		return fmt.Errorf("catalog validation failed: %w", err)
 	}
 
+	if err := s.validateApprovals(catalogID); err != nil {
+		return err
+	}
+
 	return s.catalogRepo.UpdateCatalogPhase(catalogID, "active")
 }
 
@@ -443,11 +505,9 @@ func (s *CatalogService) UpdateCategory(category *models.Category, userID uint,
 		return fmt.Errorf("permission denied: cannot edit catalog in %s phase", catalog.Phase)
 	}
 
-	// Log changes if in active phase
-	if catalog.Phase == "active" {
-		if err := s.logCategoryChanges(catalog.ID, oldCategory, category, userID); err != nil {
-			return fmt.Errorf("failed to log changes: %w", err)
-		}
+	// Log field changes
+	if err := s.logCategoryChanges(catalog.ID, oldCategory, category, userID); err != nil {
+		return fmt.Errorf("failed to log changes: %w", err)
 	}
 
 	if err := s.catalogRepo.UpdateCategory(category); err != nil {
@@ -474,10 +534,20 @@ func (s *CatalogService) DeleteCategory(categoryID, catalogID uint, userID uint,
 		return fmt.Errorf("permission denied: cannot delete categories in %s phase", catalog.Phase)
 	}
 
+	// Keep the deleted category with its children, so earlier versions of the catalog can be reconstructed
+	deleted, err := s.deletedEntitySnapshot(catalogID, "category", categoryID)
+	if err != nil {
+		return err
+	}
+
 	if err := s.catalogRepo.DeleteCategory(categoryID); err != nil {
 		return err
 	}
 
+	if err := s.logDeletion(catalogID, "category", categoryID, deleted, userID); err != nil {
+		return fmt.Errorf("failed to log change: %w", err)
+	}
+
 	// Audit log
 	s.auditSvc.Log(userID, "delete", "category", fmt.Sprintf("Deleted category ID: %d from catalog %d", categoryID, catalogID))
 
@@ -526,6 +596,26 @@ func (s *CatalogService) UpdateLevel(level *models.Level, userID uint, userRoles
 	// We can't easily check if sort_order changed without querying, but
 	// the handler should prevent sort operations in active/archived phase
 
+	levels, err := s.catalogRepo.GetLevelsByCatalogID(level.CatalogID)
+	if err != nil {
+		return err
+	}
+	var oldLevel *models.Level
+	for i := range levels {
+		if levels[i].ID == level.ID {
+			oldLevel = &levels[i]
+			break
+		}
+	}
+	if oldLevel == nil {
+		return fmt.Errorf("level not found")
+	}
+
+	// Log field changes
+	if err := s.logLevelChanges(catalog.ID, oldLevel, level, userID); err != nil {
+		return fmt.Errorf("failed to log changes: %w", err)
+	}
+
 	if err := s.catalogRepo.UpdateLevel(level); err != nil {
 		return err
 	}
@@ -550,10 +640,20 @@ func (s *CatalogService) DeleteLevel(levelID, catalogID uint, userID uint, userR
 		return fmt.Errorf("permission denied: cannot delete levels in %s phase", catalog.Phase)
 	}
 
+	// Keep the deleted level with its children, so earlier versions of the catalog can be reconstructed
+	deleted, err := s.deletedEntitySnapshot(catalogID, "level", levelID)
+	if err != nil {
+		return err
+	}
+
 	if err := s.catalogRepo.DeleteLevel(levelID); err != nil {
 		return err
 	}
 
+	if err := s.logDeletion(catalogID, "level", levelID, deleted, userID); err != nil {
+		return fmt.Errorf("failed to log change: %w", err)
+	}
+
 	// Audit log
 	s.auditSvc.Log(userID, "delete", "level", fmt.Sprintf("Deleted level ID: %d from catalog %d", levelID, catalogID))
 
@@ -574,6 +674,10 @@ func (s *CatalogService) CreatePath(path *models.Path, userID uint, userRoles []
 		return fmt.Errorf("permission denied: cannot add paths in %s phase", catalog.Phase)
 	}
 
+	if path.Weight != nil && *path.Weight <= 0 {
+		return fmt.Errorf("path weight must be greater than 0")
+	}
+
 	if err := s.catalogRepo.CreatePath(path); err != nil {
 		return err
 	}
@@ -598,6 +702,30 @@ func (s *CatalogService) UpdatePath(path *models.Path, userID uint, userRoles []
 		return fmt.Errorf("permission denied: cannot edit catalog in %s phase", catalog.Phase)
 	}
 
+	if path.Weight != nil && *path.Weight <= 0 {
+		return fmt.Errorf("path weight must be greater than 0")
+	}
+
+	paths, err := s.catalogRepo.GetPathsByCategoryID(path.CategoryID)
+	if err != nil {
+		return err
+	}
+	var oldPath *models.Path
+	for i := range paths {
+		if paths[i].ID == path.ID {
+			oldPath = &paths[i]
+			break
+		}
+	}
+	if oldPath == nil {
+		return fmt.Errorf("path not found")
+	}
+
+	// Log field changes
+	if err := s.logPathChanges(catalogID, oldPath, path, userID); err != nil {
+		return fmt.Errorf("failed to log changes: %w", err)
+	}
+
 	if err := s.catalogRepo.UpdatePath(path); err != nil {
 		return err
 	}
@@ -622,10 +750,20 @@ func (s *CatalogService) DeletePath(pathID, catalogID uint, userID uint, userRol
 		return fmt.Errorf("permission denied: cannot delete paths in %s phase", catalog.Phase)
 	}
 
+	// Keep the deleted path with its children, so earlier versions of the catalog can be reconstructed
+	deleted, err := s.deletedEntitySnapshot(catalogID, "path", pathID)
+	if err != nil {
+		return err
+	}
+
 	if err := s.catalogRepo.DeletePath(pathID); err != nil {
 		return err
 	}
 
+	if err := s.logDeletion(catalogID, "path", pathID, deleted, userID); err != nil {
+		return fmt.Errorf("failed to log change: %w", err)
+	}
+
 	// Audit log
 	s.auditSvc.Log(userID, "delete", "path", fmt.Sprintf("Deleted path ID: %d from catalog %d", pathID, catalogID))
 
@@ -646,34 +784,168 @@ func (s *CatalogService) CreateOrUpdateDescription(desc *models.PathLevelDescrip
 		return fmt.Errorf("permission denied: cannot edit catalog in %s phase", catalog.Phase)
 	}
 
-	// Get old description if in active phase
-	if catalog.Phase == "active" {
-		oldDescs, err := s.catalogRepo.GetDescriptionsByPathID(desc.PathID)
-		if err != nil {
-			return err
+	// Get old description to log the change
+	oldDescs, err := s.catalogRepo.GetDescriptionsByPathID(desc.PathID)
+	if err != nil {
+		return err
+	}
+	var oldValue *string
+	for _, oldDesc := range oldDescs {
+		if oldDesc.LevelID == desc.LevelID {
+			oldText := oldDesc.Description
+			oldValue = &oldText
+			break
 		}
+	}
 
-		for _, oldDesc := range oldDescs {
-			if oldDesc.LevelID == desc.LevelID {
-				// Log the change
-				change := &models.CatalogChange{
-					CatalogID:  catalogID,
-					EntityType: "description",
-					EntityID:   oldDesc.ID,
-					FieldName:  "description",
-					OldValue:   &oldDesc.Description,
-					NewValue:   &desc.Description,
-					ChangedBy:  &userID,
-				}
-				if err := s.catalogRepo.LogChange(change); err != nil {
-					return fmt.Errorf("failed to log change: %w", err)
-				}
-				break
-			}
+	if err := s.catalogRepo.CreatePathLevelDescription(desc); err != nil {
+		return err
+	}
+
+	// Log the change (a nil old value means the cell was newly created)
+	if oldValue == nil || *oldValue != desc.Description {
+		newValue := desc.Description
+		change := &models.CatalogChange{
+			CatalogID:  catalogID,
+			EntityType: "description",
+			EntityID:   desc.ID,
+			FieldName:  "description",
+			OldValue:   oldValue,
+			NewValue:   &newValue,
+			ChangedBy:  &userID,
+		}
+		if err := s.catalogRepo.LogChange(change); err != nil {
+			return fmt.Errorf("failed to log change: %w", err)
 		}
 	}
 
-	return s.catalogRepo.CreatePathLevelDescription(desc)
+	return nil
+}
+
+// DeleteDescription deletes a path-level description
+func (s *CatalogService) DeleteDescription(descID, catalogID uint, userID uint, userRoles []string) error {
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return err
+	}
+	if catalog == nil {
+		return fmt.Errorf("catalog not found")
+	}
+
+	if !canEditCatalog(catalog.Phase, userRoles) {
+		return fmt.Errorf("permission denied: cannot edit catalog in %s phase", catalog.Phase)
+	}
+
+	// Keep the deleted description, so earlier versions of the catalog can be reconstructed
+	deleted, err := s.deletedEntitySnapshot(catalogID, "description", descID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.catalogRepo.DeletePathLevelDescription(descID); err != nil {
+		return err
+	}
+
+	if err := s.logDeletion(catalogID, "description", descID, deleted, userID); err != nil {
+		return fmt.Errorf("failed to log change: %w", err)
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "delete", "description", fmt.Sprintf("Deleted description ID: %d from catalog %d", descID, catalogID))
+
+	return nil
+}
+
+// CloneCatalog deep-copies a catalog (in any phase) with all categories, levels, paths and
+// descriptions into a new draft catalog with the given validity period.
+// The new catalog records the source catalog ID as lineage.
+func (s *CatalogService) CloneCatalog(sourceID uint, name string, validFrom, validUntil time.Time, userID uint, userRoles []string) (*models.CatalogWithDetails, error) {
+	if !contains(userRoles, "admin") {
+		return nil, fmt.Errorf("permission denied: only admins can clone catalogs")
+	}
+
+	source, err := s.catalogRepo.GetCatalogWithDetails(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	// Validate dates
+	if validFrom.After(validUntil) || validFrom.Equal(validUntil) {
+		return nil, fmt.Errorf("valid_from must be before valid_until")
+	}
+
+	// Check for overlapping catalogs
+	overlaps, err := s.catalogRepo.CheckOverlappingCatalogs(validFrom, validUntil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check overlapping catalogs: %w", err)
+	}
+	if overlaps {
+		return nil, fmt.Errorf("catalog validity period overlaps with existing non-archived catalog")
+	}
+
+	if name == "" {
+		name = source.Name
+	}
+
+	// Remember the source entity IDs, the repository replaces them with the IDs of the clone
+	sourceEntityIDs := catalogEntityIDs(source)
+
+	// The clone reuses the loaded structure, so its catalog ID is overwritten below
+	srcID := source.ID
+
+	// Reuse the loaded structure; level IDs of the descriptions are remapped by the repository
+	clone := source
+	clone.CriteriaCatalog = models.CriteriaCatalog{
+		Name:            name,
+		Description:     source.Description,
+		ValidFrom:       validFrom,
+		ValidUntil:      validUntil,
+		Phase:           "draft",
+		CreatedBy:       &userID,
+		SourceCatalogID: &srcID,
+		ScoringStrategy: source.ScoringStrategy,
+		LevelRounding:   source.LevelRounding,
+	}
+
+	// The clone and its translations are written together, a failed copy leaves no half clone behind
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		txSvc := s.withTx(tx)
+		if err := txSvc.catalogRepo.CreateCatalogWithDetails(clone); err != nil {
+			return fmt.Errorf("failed to clone catalog: %w", err)
+		}
+		if err := txSvc.copyTranslations(sourceID, clone.ID, sourceEntityIDs, catalogEntityIDs(clone)); err != nil {
+			return fmt.Errorf("failed to copy translations: %w", err)
+		}
+
+		// Audit log
+		txSvc.auditSvc.Log(userID, "clone", "catalog", fmt.Sprintf("Cloned catalog %d into: %s (ID: %d)", sourceID, clone.Name, clone.ID))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// GetCatalogLineage retrieves a catalog and the chain of catalogs it was cloned from
+func (s *CatalogService) GetCatalogLineage(catalogID uint, userRoles []string) ([]models.CriteriaCatalog, error) {
+	if !contains(userRoles, "admin") {
+		return nil, fmt.Errorf("permission denied: only admins can view catalog lineage")
+	}
+
+	lineage, err := s.catalogRepo.GetCatalogLineage(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lineage) == 0 {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	return lineage, nil
 }
 
 // GetChangesByCatalogID retrieves all changes for a catalog
@@ -703,6 +975,8 @@ func canViewCatalog(phase string, userRoles []string) bool {
 	switch phase {
 	case "draft":
 		return isAdmin
+	case "review":
+		return isAdmin || contains(userRoles, "catalog_approver") // Approvers review the catalog
 	case "active":
 		return true // Everyone can view active phase
 	case "archived":
@@ -718,8 +992,8 @@ func canEditCatalog(phase string, userRoles []string) bool {
 	switch phase {
 	case "draft":
 		return isAdmin
-	case "active", "archived":
-		return false // Nobody can edit active or archived catalogs directly (use special endpoints)
+	case "review", "active", "archived":
+		return false // Nobody can edit catalogs under review, active or archived directly (use special endpoints)
 	default:
 		return false
 	}
@@ -739,7 +1013,8 @@ func (s *CatalogService) validatePhaseTransition(catalogID uint, fromPhase, toPh
 
 	// Define allowed transitions
 	allowedTransitions := map[string][]string{
-		"draft":    {"active"},
+		"draft":    {"review"},
+		"review":   {"draft", "active"},   // Active requires the configured number of approvals
 		"active":   {"archived", "draft"}, // Can go back to draft if no self-assessments exist
 		"archived": {},                    // No transitions from archived
 	}
@@ -761,9 +1036,14 @@ func (s *CatalogService) validatePhaseTransition(catalogID uint, fromPhase, toPh
 		return fmt.Errorf("cannot transition from %s to %s phase", fromPhase, toPhase)
 	}
 
-	// Additional validation when transitioning to active
-	if toPhase == "active" {
+	// Additional validation when submitting for review or transitioning to active
+	if toPhase == "review" || toPhase == "active" {
 		if err := s.validateCatalogCompleteness(catalogID); err != nil {
+			return fmt.Errorf("cannot transition to %s phase: %w", toPhase, err)
+		}
+	}
+	if toPhase == "active" {
+		if err := s.validateApprovals(catalogID); err != nil {
 			return fmt.Errorf("cannot transition to active phase: %w", err)
 		}
 	}
@@ -792,6 +1072,15 @@ func (s *CatalogService) validateCatalogCompleteness(catalogID uint) error {
 		return fmt.Errorf("catalog not found")
 	}
 
+	if err := checkCatalogCompleteness(catalogDetails); err != nil {
+		return err
+	}
+
+	return s.localizer.checkTranslationCompleteness(catalogDetails)
+}
+
+// checkCatalogCompleteness validates that a catalog structure is complete enough to be activated
+func checkCatalogCompleteness(catalogDetails *models.CatalogWithDetails) error {
 	// Must have at least one category
 	if len(catalogDetails.Categories) == 0 {
 		return fmt.Errorf("catalog must have at least one category")
@@ -897,6 +1186,11 @@ func (s *CatalogService) logCatalogChanges(oldCatalog, newCatalog *models.Criter
 		})
 	}
 
+	changes = appendFieldChange(changes, oldCatalog.ID, "catalog", oldCatalog.ID, "scoring_strategy",
+		&oldCatalog.ScoringStrategy, &newCatalog.ScoringStrategy, userID)
+	changes = appendFieldChange(changes, oldCatalog.ID, "catalog", oldCatalog.ID, "level_rounding",
+		&oldCatalog.LevelRounding, &newCatalog.LevelRounding, userID)
+
 	for _, change := range changes {
 		if err := s.catalogRepo.LogChange(&change); err != nil {
 			return err
@@ -937,6 +1231,27 @@ func (s *CatalogService) logCategoryChanges(catalogID uint, oldCategory, newCate
 		})
 	}
 
+	changes = appendFieldChange(changes, catalogID, "category", oldCategory.ID, "weight",
+		formatWeight(oldCategory.Weight), formatWeight(newCategory.Weight), userID)
+	changes = appendFieldChange(changes, catalogID, "category", oldCategory.ID, "sort_order",
+		formatInt(oldCategory.SortOrder), formatInt(newCategory.SortOrder), userID)
+
+	for _, change := range changes {
+		if err := s.catalogRepo.LogChange(&change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *CatalogService) logLevelChanges(catalogID uint, oldLevel, newLevel *models.Level, userID uint) error {
+	var changes []models.CatalogChange
+	changes = appendFieldChange(changes, catalogID, "level", oldLevel.ID, "name", &oldLevel.Name, &newLevel.Name, userID)
+	changes = appendFieldChange(changes, catalogID, "level", oldLevel.ID, "level_number",
+		formatInt(oldLevel.LevelNumber), formatInt(newLevel.LevelNumber), userID)
+	changes = appendFieldChange(changes, catalogID, "level", oldLevel.ID, "description", oldLevel.Description, newLevel.Description, userID)
+
 	for _, change := range changes {
 		if err := s.catalogRepo.LogChange(&change); err != nil {
 			return err
@@ -946,6 +1261,72 @@ func (s *CatalogService) logCategoryChanges(catalogID uint, oldCategory, newCate
 	return nil
 }
 
+func (s *CatalogService) logPathChanges(catalogID uint, oldPath, newPath *models.Path, userID uint) error {
+	var changes []models.CatalogChange
+	changes = appendFieldChange(changes, catalogID, "path", oldPath.ID, "name", &oldPath.Name, &newPath.Name, userID)
+	changes = appendFieldChange(changes, catalogID, "path", oldPath.ID, "description", oldPath.Description, newPath.Description, userID)
+	changes = appendFieldChange(changes, catalogID, "path", oldPath.ID, "sort_order",
+		formatInt(oldPath.SortOrder), formatInt(newPath.SortOrder), userID)
+	changes = appendFieldChange(changes, catalogID, "path", oldPath.ID, "weight",
+		formatWeight(oldPath.Weight), formatWeight(newPath.Weight), userID)
+
+	for _, change := range changes {
+		if err := s.catalogRepo.LogChange(&change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// logDeletion records the deletion of a catalog entity with the deleted entity as old value.
+// Nothing is logged if the entity was not part of the catalog (deleted is nil).
+func (s *CatalogService) logDeletion(catalogID uint, entityType string, entityID uint, deleted []byte, userID uint) error {
+	if deleted == nil {
+		return nil
+	}
+	oldValue := string(deleted)
+	return s.catalogRepo.LogChange(&models.CatalogChange{
+		CatalogID:  catalogID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		FieldName:  catalogDeletedField,
+		OldValue:   &oldValue,
+		ChangedBy:  &userID,
+	})
+}
+
+// appendFieldChange appends a change log entry if the old and new value differ
+func appendFieldChange(changes []models.CatalogChange, catalogID uint, entityType string, entityID uint, fieldName string, oldValue, newValue *string, userID uint) []models.CatalogChange {
+	if compareStringPointers(oldValue, newValue) {
+		return changes
+	}
+	return append(changes, models.CatalogChange{
+		CatalogID:  catalogID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		FieldName:  fieldName,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		ChangedBy:  &userID,
+	})
+}
+
+// formatWeight formats an optional category weight for the change log
+func formatWeight(weight *float64) *string {
+	if weight == nil {
+		return nil
+	}
+	value := strconv.FormatFloat(*weight, 'f', -1, 64)
+	return &value
+}
+
+// formatInt formats an integer field for the change log
+func formatInt(value int) *string {
+	formatted := strconv.Itoa(value)
+	return &formatted
+}
+
 // compareStringPointers safely compares two string pointers
 // Treats nil and empty string as equivalent
 func compareStringPointers(a, b *string) bool {
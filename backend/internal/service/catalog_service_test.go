@@ -0,0 +1,276 @@
+package service_test
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+	"new-pay/internal/service"
+	"new-pay/internal/testutil"
+)
+
+// TestCloneCatalog verifies that a clone copies the structure and links to its source catalog
+func TestCloneCatalog(t *testing.T) {
+	containers := testutil.SetupTestContainers(t)
+	defer containers.Cleanup(t)
+
+	fixtures := testutil.SetupFixtures(t, containers.DB)
+
+	if _, err := containers.DB.Exec(`
+		INSERT INTO path_level_descriptions (path_id, level_id, description)
+		VALUES ($1, $2, $3)
+	`, fixtures.Paths[0].ID, fixtures.Levels[1].ID, "Designs small components"); err != nil {
+		t.Fatalf("Failed to create description: %v", err)
+	}
+
+	catalogRepo := repository.NewCatalogRepository(containers.DB)
+	catalogSvc := newTestCatalogService(containers.DB, catalogRepo)
+
+	// The fixture catalog is valid for one year, the clone must not overlap
+	validFrom := time.Now().AddDate(2, 0, 0)
+	validUntil := validFrom.AddDate(1, 0, 0)
+	clone, err := catalogSvc.CloneCatalog(fixtures.Catalog.ID, "Cloned Catalog", validFrom, validUntil, fixtures.AdminUser.ID, []string{"admin"})
+	if err != nil {
+		t.Fatalf("CloneCatalog failed: %v", err)
+	}
+
+	stored, err := catalogRepo.GetCatalogWithDetails(clone.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("Failed to load clone: %v", err)
+	}
+
+	if stored.ID == fixtures.Catalog.ID {
+		t.Fatal("clone must be a new catalog")
+	}
+	if stored.SourceCatalogID == nil || *stored.SourceCatalogID != fixtures.Catalog.ID {
+		t.Errorf("source_catalog_id = %v, want %d", stored.SourceCatalogID, fixtures.Catalog.ID)
+	}
+	if stored.Name != "Cloned Catalog" || stored.Phase != "draft" {
+		t.Errorf("clone = %q in phase %s, want %q in phase draft", stored.Name, stored.Phase, "Cloned Catalog")
+	}
+
+	if len(stored.Levels) != len(fixtures.Levels) {
+		t.Errorf("clone has %d levels, want %d", len(stored.Levels), len(fixtures.Levels))
+	}
+	if len(stored.Categories) != len(fixtures.Categories) {
+		t.Fatalf("clone has %d categories, want %d", len(stored.Categories), len(fixtures.Categories))
+	}
+
+	var paths, descriptions int
+	for _, category := range stored.Categories {
+		if category.CatalogID != stored.ID {
+			t.Errorf("category %d belongs to catalog %d, want %d", category.ID, category.CatalogID, stored.ID)
+		}
+		for _, path := range category.Paths {
+			paths++
+			for _, desc := range path.Descriptions {
+				descriptions++
+				if desc.Description != "Designs small components" {
+					t.Errorf("description = %q, want %q", desc.Description, "Designs small components")
+				}
+				if !levelBelongsTo(stored.Levels, desc.LevelID) {
+					t.Errorf("description references level %d outside the clone", desc.LevelID)
+				}
+			}
+		}
+	}
+	if paths != len(fixtures.Paths) {
+		t.Errorf("clone has %d paths, want %d", paths, len(fixtures.Paths))
+	}
+	if descriptions != 1 {
+		t.Errorf("clone has %d descriptions, want 1", descriptions)
+	}
+
+	// The source catalog stays unchanged
+	source, err := catalogRepo.GetCatalogByID(fixtures.Catalog.ID)
+	if err != nil || source == nil {
+		t.Fatalf("Failed to load source: %v", err)
+	}
+	if source.SourceCatalogID != nil {
+		t.Errorf("source catalog must not get a source_catalog_id, got %d", *source.SourceCatalogID)
+	}
+}
+
+// TestDeleteCatalogEntitiesRemovesTranslations verifies that deleting a category or level also
+// deletes the translations of the entity and of the children removed with it
+func TestDeleteCatalogEntitiesRemovesTranslations(t *testing.T) {
+	containers := testutil.SetupTestContainers(t)
+	defer containers.Cleanup(t)
+
+	fixtures := testutil.SetupFixtures(t, containers.DB)
+
+	// Structure may only be deleted in draft
+	if _, err := containers.DB.Exec(`UPDATE criteria_catalogs SET phase = 'draft' WHERE id = $1`, fixtures.Catalog.ID); err != nil {
+		t.Fatalf("Failed to reset catalog phase: %v", err)
+	}
+
+	var descID uint
+	if err := containers.DB.QueryRow(`
+		INSERT INTO path_level_descriptions (path_id, level_id, description)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, fixtures.Paths[0].ID, fixtures.Levels[1].ID, "Designs small components").Scan(&descID); err != nil {
+		t.Fatalf("Failed to create description: %v", err)
+	}
+
+	catalogRepo := repository.NewCatalogRepository(containers.DB)
+	catalogSvc := newTestCatalogService(containers.DB, catalogRepo)
+
+	// The fixture paths belong to the first category
+	translations := []models.CatalogTranslation{
+		{EntityType: "category", EntityID: fixtures.Categories[0].ID, FieldName: "name", Value: "Kategorie A"},
+		{EntityType: "path", EntityID: fixtures.Paths[0].ID, FieldName: "name", Value: "Fachlicher Pfad"},
+		{EntityType: "path", EntityID: fixtures.Paths[1].ID, FieldName: "name", Value: "Führungspfad"},
+		{EntityType: "description", EntityID: descID, FieldName: "description", Value: "Entwirft kleine Komponenten"},
+		{EntityType: "level", EntityID: fixtures.Levels[2].ID, FieldName: "name", Value: "Fortgeschritten"},
+		{EntityType: "category", EntityID: fixtures.Categories[1].ID, FieldName: "name", Value: "Kategorie B"},
+	}
+	for i := range translations {
+		translations[i].CatalogID = fixtures.Catalog.ID
+		translations[i].Locale = "de"
+		if err := catalogRepo.UpsertTranslation(&translations[i]); err != nil {
+			t.Fatalf("Failed to create translation: %v", err)
+		}
+	}
+
+	admin := []string{"admin"}
+	if err := catalogSvc.DeleteCategory(fixtures.Categories[0].ID, fixtures.Catalog.ID, fixtures.AdminUser.ID, admin); err != nil {
+		t.Fatalf("DeleteCategory failed: %v", err)
+	}
+	assertTranslationEntities(t, catalogRepo, fixtures.Catalog.ID, []string{
+		fmt.Sprintf("category:%d", fixtures.Categories[1].ID),
+		fmt.Sprintf("level:%d", fixtures.Levels[2].ID),
+	})
+
+	if err := catalogSvc.DeleteLevel(fixtures.Levels[2].ID, fixtures.Catalog.ID, fixtures.AdminUser.ID, admin); err != nil {
+		t.Fatalf("DeleteLevel failed: %v", err)
+	}
+	assertTranslationEntities(t, catalogRepo, fixtures.Catalog.ID, []string{
+		fmt.Sprintf("category:%d", fixtures.Categories[1].ID),
+	})
+}
+
+// TestCatalogLifecycleTransitions verifies the scheduled activation and archiving of catalogs
+func TestCatalogLifecycleTransitions(t *testing.T) {
+	containers := testutil.SetupTestContainers(t)
+	defer containers.Cleanup(t)
+
+	fixtures := testutil.SetupFixtures(t, containers.DB)
+	catalogRepo := repository.NewCatalogRepository(containers.DB)
+	catalogSvc := newTestCatalogService(containers.DB, catalogRepo)
+
+	// A complete successor catalog and an incomplete one, both under review and starting in two years
+	validFrom := time.Now().AddDate(2, 0, 0)
+	weight := 1.0
+	complete := &models.CatalogWithDetails{
+		CriteriaCatalog: models.CriteriaCatalog{Name: "Successor", Phase: "review", ValidFrom: validFrom, ValidUntil: validFrom.AddDate(1, 0, 0),
+			ScoringStrategy: service.ScoringMean, LevelRounding: service.LevelRoundingNearest},
+		Levels: []models.Level{{ID: 1, Name: "Junior", LevelNumber: 1}},
+		Categories: []models.CategoryWithPaths{{
+			Category: models.Category{Name: "Technology", Weight: &weight},
+			Paths: []models.PathWithDescriptions{{
+				Path:         models.Path{Name: "Backend"},
+				Descriptions: []models.PathLevelDescription{{LevelID: 1, Description: "Builds services"}},
+			}},
+		}},
+	}
+	incomplete := &models.CatalogWithDetails{
+		CriteriaCatalog: models.CriteriaCatalog{Name: "Incomplete", Phase: "review", ValidFrom: validFrom, ValidUntil: validFrom.AddDate(1, 0, 0),
+			ScoringStrategy: service.ScoringMean, LevelRounding: service.LevelRoundingNearest},
+	}
+	for _, details := range []*models.CatalogWithDetails{complete, incomplete} {
+		if err := catalogRepo.CreateCatalogWithDetails(details); err != nil {
+			t.Fatalf("Failed to create catalog: %v", err)
+		}
+	}
+
+	activated, err := catalogSvc.ActivateDueCatalogs(time.Now())
+	if err != nil || len(activated) != 0 {
+		t.Fatalf("ActivateDueCatalogs() before validity = %v, %v, want nothing activated", activated, err)
+	}
+
+	// Due, but without the required approval
+	activated, err = catalogSvc.ActivateDueCatalogs(validFrom)
+	if err != nil || len(activated) != 0 {
+		t.Fatalf("ActivateDueCatalogs() without approval = %v, %v, want nothing activated", activated, err)
+	}
+
+	for _, details := range []*models.CatalogWithDetails{complete, incomplete} {
+		if _, err := catalogRepo.CreateApproval(details.ID, fixtures.AdminUser.ID); err != nil {
+			t.Fatalf("Failed to approve catalog: %v", err)
+		}
+	}
+	activated, err = catalogSvc.ActivateDueCatalogs(validFrom)
+	if err != nil {
+		t.Fatalf("ActivateDueCatalogs() failed: %v", err)
+	}
+	if len(activated) != 1 || activated[0].ID != complete.ID {
+		t.Fatalf("ActivateDueCatalogs() = %v, want only catalog %d", activated, complete.ID)
+	}
+	assertCatalogPhase(t, catalogRepo, complete.ID, "active")
+	assertCatalogPhase(t, catalogRepo, incomplete.ID, "review")
+
+	archived, err := catalogSvc.ArchiveExpiredCatalogs(time.Now())
+	if err != nil || len(archived) != 0 {
+		t.Fatalf("ArchiveExpiredCatalogs() during validity = %v, %v, want nothing archived", archived, err)
+	}
+
+	// The day after the last day of the fixture catalog
+	archived, err = catalogSvc.ArchiveExpiredCatalogs(fixtures.Catalog.ValidUntil.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("ArchiveExpiredCatalogs() failed: %v", err)
+	}
+	if len(archived) != 1 || archived[0].ID != fixtures.Catalog.ID {
+		t.Fatalf("ArchiveExpiredCatalogs() = %v, want only catalog %d", archived, fixtures.Catalog.ID)
+	}
+	assertCatalogPhase(t, catalogRepo, fixtures.Catalog.ID, "archived")
+	assertCatalogPhase(t, catalogRepo, complete.ID, "active")
+}
+
+func newTestCatalogService(db *sql.DB, catalogRepo *repository.CatalogRepository) *service.CatalogService {
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db))
+	localizer := service.NewCatalogLocalizer(catalogRepo, nil, false)
+	return service.NewCatalogService(catalogRepo, repository.NewSelfAssessmentRepository(db), repository.NewTransactor(db), auditSvc, nil, localizer, 1)
+}
+
+func assertCatalogPhase(t *testing.T, catalogRepo *repository.CatalogRepository, catalogID uint, want string) {
+	t.Helper()
+	catalog, err := catalogRepo.GetCatalogByID(catalogID)
+	if err != nil || catalog == nil {
+		t.Fatalf("Failed to load catalog %d: %v", catalogID, err)
+	}
+	if catalog.Phase != want {
+		t.Errorf("catalog %d is in phase %s, want %s", catalogID, catalog.Phase, want)
+	}
+}
+
+func assertTranslationEntities(t *testing.T, catalogRepo *repository.CatalogRepository, catalogID uint, want []string) {
+	t.Helper()
+	translations, err := catalogRepo.GetTranslationsByCatalogID(catalogID, "")
+	if err != nil {
+		t.Fatalf("Failed to load translations: %v", err)
+	}
+	var got []string
+	for _, translation := range translations {
+		got = append(got, fmt.Sprintf("%s:%d", translation.EntityType, translation.EntityID))
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("translated entities = %v, want %v", got, want)
+	}
+}
+
+func levelBelongsTo(levels []models.Level, id uint) bool {
+	for _, level := range levels {
+		if level.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,472 @@
+package service
+
+import (
+	"fmt"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CatalogLocalizer resolves the content locale of a request and applies catalog translations.
+// The name/description columns of catalog entities hold the texts of the default locale
+// (the first configured locale); other locales are stored as translations.
+type CatalogLocalizer struct {
+	catalogRepo         *repository.CatalogRepository
+	locales             []string
+	requireTranslations bool
+}
+
+// NewCatalogLocalizer creates a new catalog localizer
+func NewCatalogLocalizer(catalogRepo *repository.CatalogRepository, locales []string, requireTranslations bool) *CatalogLocalizer {
+	return &CatalogLocalizer{
+		catalogRepo:         catalogRepo,
+		locales:             locales,
+		requireTranslations: requireTranslations,
+	}
+}
+
+// translationKey identifies a translated field of a catalog entity
+type translationKey struct {
+	entityType string
+	entityID   uint
+	fieldName  string
+}
+
+// translationIndex maps translated fields to their values for one locale
+type translationIndex map[translationKey]string
+
+// Locales returns the configured content locales (default locale first)
+func (l *CatalogLocalizer) Locales() []string {
+	return l.locales
+}
+
+// DefaultLocale returns the locale of the base texts
+func (l *CatalogLocalizer) DefaultLocale() string {
+	if len(l.locales) == 0 {
+		return ""
+	}
+	return l.locales[0]
+}
+
+// ResolveLocale selects the content locale from an explicit lang parameter or an
+// Accept-Language header, falling back to the default locale
+func (l *CatalogLocalizer) ResolveLocale(lang, acceptLanguage string) string {
+	return resolveLocale(l.locales, lang, acceptLanguage)
+}
+
+// LocalizeCatalog replaces the texts of a catalog with their translations for the given locale.
+// Fields without translation keep the text of the default locale.
+func (l *CatalogLocalizer) LocalizeCatalog(details *models.CatalogWithDetails, locale string) error {
+	index, err := l.loadTranslations(details.ID, locale)
+	if err != nil || index == nil {
+		return err
+	}
+	applyCatalogTranslations(details, index)
+	return nil
+}
+
+// LocalizeResponses replaces the category, path, level and cell texts of assessment responses
+// with their translations for the given locale
+func (l *CatalogLocalizer) LocalizeResponses(catalogID uint, responses []models.AssessmentResponseWithDetails, locale string) error {
+	index, err := l.loadTranslations(catalogID, locale)
+	if err != nil || index == nil {
+		return err
+	}
+
+	details, err := l.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return err
+	}
+	if details == nil {
+		return fmt.Errorf("catalog not found")
+	}
+
+	applyResponseTranslations(responses, details, index)
+	return nil
+}
+
+// LocalizeConsolidationData translates the catalog, user responses and averaged responses
+// of the consolidation data for the given locale
+func (l *CatalogLocalizer) LocalizeConsolidationData(data *models.ConsolidationData, locale string) error {
+	index, err := l.loadTranslations(data.Catalog.ID, locale)
+	if err != nil || index == nil {
+		return err
+	}
+
+	// Responses are mapped via the untranslated catalog
+	applyResponseTranslations(data.UserResponses, &data.Catalog, index)
+
+	levelNames := make(map[string]string, len(data.Catalog.Levels))
+	for _, level := range data.Catalog.Levels {
+		if name, ok := index[translationKey{"level", level.ID, "name"}]; ok {
+			levelNames[level.Name] = name
+		}
+	}
+	for i := range data.AveragedResponses {
+		averaged := &data.AveragedResponses[i]
+		if name, ok := index[translationKey{"category", averaged.CategoryID, "name"}]; ok {
+			averaged.CategoryName = name
+		}
+		if name, ok := levelNames[averaged.AverageLevelName]; ok {
+			averaged.AverageLevelName = name
+		}
+	}
+
+	applyCatalogTranslations(&data.Catalog, index)
+	return nil
+}
+
+// loadTranslations loads the translations of a catalog for a locale.
+// Returns nil for the default locale, which needs no translation.
+func (l *CatalogLocalizer) loadTranslations(catalogID uint, locale string) (translationIndex, error) {
+	if locale == "" || locale == l.DefaultLocale() {
+		return nil, nil
+	}
+
+	translations, err := l.catalogRepo.GetTranslationsByCatalogID(catalogID, locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load translations: %w", err)
+	}
+
+	index := make(translationIndex, len(translations))
+	for _, translation := range translations {
+		index[translationKey{translation.EntityType, translation.EntityID, translation.FieldName}] = translation.Value
+	}
+	return index, nil
+}
+
+// checkTranslationCompleteness validates that all texts of a catalog are translated into every
+// non-default locale. Does nothing unless translations are required by configuration.
+func (l *CatalogLocalizer) checkTranslationCompleteness(details *models.CatalogWithDetails) error {
+	if !l.requireTranslations || len(l.locales) < 2 {
+		return nil
+	}
+
+	translations, err := l.catalogRepo.GetTranslationsByCatalogID(details.ID, "")
+	if err != nil {
+		return fmt.Errorf("failed to load translations: %w", err)
+	}
+
+	indexes := make(map[string]translationIndex, len(l.locales))
+	for _, translation := range translations {
+		if indexes[translation.Locale] == nil {
+			indexes[translation.Locale] = make(translationIndex)
+		}
+		indexes[translation.Locale][translationKey{translation.EntityType, translation.EntityID, translation.FieldName}] = translation.Value
+	}
+
+	for _, locale := range l.locales[1:] {
+		if err := checkTranslationsComplete(details, indexes[locale], locale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTranslations retrieves the translations of a catalog, optionally filtered by locale
+func (s *CatalogService) GetTranslations(catalogID uint, locale string, userRoles []string) ([]models.CatalogTranslation, error) {
+	if !contains(userRoles, "admin") {
+		return nil, fmt.Errorf("permission denied: only admins can manage translations")
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	return s.catalogRepo.GetTranslationsByCatalogID(catalogID, locale)
+}
+
+// SetTranslations creates, updates or (for empty values) deletes translations of catalog texts.
+// Translations can only be edited where the catalog itself can be edited.
+func (s *CatalogService) SetTranslations(catalogID uint, translations []models.CatalogTranslation, userID uint, userRoles []string) error {
+	details, err := s.catalogRepo.GetCatalogWithDetails(catalogID)
+	if err != nil {
+		return err
+	}
+	if details == nil {
+		return fmt.Errorf("catalog not found")
+	}
+
+	if !canEditCatalog(details.Phase, userRoles) {
+		return fmt.Errorf("permission denied: cannot edit catalog in %s phase", details.Phase)
+	}
+
+	entities := catalogEntityIDs(details)
+	for i := range translations {
+		translation := &translations[i]
+		if err := s.validateTranslation(translation, entities); err != nil {
+			return fmt.Errorf("translations[%d]: %w", i, err)
+		}
+	}
+
+	for i := range translations {
+		translation := &translations[i]
+		translation.CatalogID = catalogID
+		if strings.TrimSpace(translation.Value) == "" {
+			if err := s.catalogRepo.DeleteTranslation(translation.EntityType, translation.EntityID, translation.Locale, translation.FieldName); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.catalogRepo.UpsertTranslation(translation); err != nil {
+			return err
+		}
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "update", "catalog_translation", fmt.Sprintf("Updated %d translations in catalog %d", len(translations), catalogID))
+
+	return nil
+}
+
+// validateTranslation checks locale, field and entity of a translation
+func (s *CatalogService) validateTranslation(translation *models.CatalogTranslation, entities map[string][]uint) error {
+	locales := s.localizer.Locales()
+	if len(locales) < 2 || !contains(locales[1:], translation.Locale) {
+		return fmt.Errorf("locale '%s' is not a configured translation locale", translation.Locale)
+	}
+
+	switch translation.EntityType {
+	case "category", "level", "path":
+		if translation.FieldName != "name" && translation.FieldName != "description" {
+			return fmt.Errorf("invalid field '%s' for %s", translation.FieldName, translation.EntityType)
+		}
+	case "description":
+		if translation.FieldName != "description" {
+			return fmt.Errorf("invalid field '%s' for description", translation.FieldName)
+		}
+	default:
+		return fmt.Errorf("invalid entity type '%s'", translation.EntityType)
+	}
+
+	for _, id := range entities[translation.EntityType] {
+		if id == translation.EntityID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %d not found in catalog", translation.EntityType, translation.EntityID)
+}
+
+// copyTranslations copies all translations of a catalog to its clone. The entity ID lists of
+// source and clone must have been collected with catalogEntityIDs in the same order.
+func (s *CatalogService) copyTranslations(sourceID, cloneID uint, sourceIDs, cloneIDs map[string][]uint) error {
+	translations, err := s.catalogRepo.GetTranslationsByCatalogID(sourceID, "")
+	if err != nil {
+		return err
+	}
+
+	idMap := make(map[translationKey]uint) // [entity type, source ID]clone ID
+	for entityType, ids := range sourceIDs {
+		for i, id := range ids {
+			if i < len(cloneIDs[entityType]) {
+				idMap[translationKey{entityType: entityType, entityID: id}] = cloneIDs[entityType][i]
+			}
+		}
+	}
+
+	for _, translation := range translations {
+		newID, ok := idMap[translationKey{entityType: translation.EntityType, entityID: translation.EntityID}]
+		if !ok {
+			continue // Entity was deleted
+		}
+		translation.CatalogID = cloneID
+		translation.EntityID = newID
+		if err := s.catalogRepo.UpsertTranslation(&translation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// catalogEntityIDs collects the IDs of all translatable entities of a catalog in structure order
+func catalogEntityIDs(details *models.CatalogWithDetails) map[string][]uint {
+	ids := make(map[string][]uint)
+	for _, level := range details.Levels {
+		ids["level"] = append(ids["level"], level.ID)
+	}
+	for _, category := range details.Categories {
+		ids["category"] = append(ids["category"], category.ID)
+		for _, path := range category.Paths {
+			ids["path"] = append(ids["path"], path.ID)
+			for _, desc := range path.Descriptions {
+				ids["description"] = append(ids["description"], desc.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// applyCatalogTranslations replaces catalog texts with their translations
+func applyCatalogTranslations(details *models.CatalogWithDetails, index translationIndex) {
+	for i := range details.Levels {
+		level := &details.Levels[i]
+		translateName(&level.Name, index, "level", level.ID)
+		translateDescription(&level.Description, index, "level", level.ID)
+	}
+	for i := range details.Categories {
+		category := &details.Categories[i]
+		translateName(&category.Name, index, "category", category.ID)
+		translateDescription(&category.Description, index, "category", category.ID)
+		for j := range category.Paths {
+			path := &category.Paths[j]
+			translateName(&path.Name, index, "path", path.ID)
+			translateDescription(&path.Description, index, "path", path.ID)
+			for k := range path.Descriptions {
+				desc := &path.Descriptions[k]
+				if value, ok := index[translationKey{"description", desc.ID, "description"}]; ok {
+					desc.Description = value
+				}
+			}
+		}
+	}
+}
+
+// applyResponseTranslations replaces the catalog texts of assessment responses with their
+// translations. The catalog must be untranslated, it is only used to find the matrix cells.
+func applyResponseTranslations(responses []models.AssessmentResponseWithDetails, details *models.CatalogWithDetails, index translationIndex) {
+	cellIDs := make(map[[2]uint]uint) // [path ID, level ID]description ID
+	for _, category := range details.Categories {
+		for _, path := range category.Paths {
+			for _, desc := range path.Descriptions {
+				cellIDs[[2]uint{desc.PathID, desc.LevelID}] = desc.ID
+			}
+		}
+	}
+
+	for i := range responses {
+		response := &responses[i]
+		translateName(&response.CategoryName, index, "category", response.CategoryID)
+		translateName(&response.PathName, index, "path", response.PathID)
+		translateDescription(&response.PathDescription, index, "path", response.PathID)
+		translateName(&response.LevelName, index, "level", response.LevelID)
+		translateDescription(&response.LevelDescription, index, "level", response.LevelID)
+		if cellID, ok := cellIDs[[2]uint{response.PathID, response.LevelID}]; ok {
+			if value, ok := index[translationKey{"description", cellID, "description"}]; ok {
+				response.PathLevelDescription = value
+			}
+		}
+	}
+}
+
+// translateName replaces a name with its translation if one exists
+func translateName(name *string, index translationIndex, entityType string, entityID uint) {
+	if value, ok := index[translationKey{entityType, entityID, "name"}]; ok {
+		*name = value
+	}
+}
+
+// translateDescription replaces an optional description with its translation if one exists
+func translateDescription(description **string, index translationIndex, entityType string, entityID uint) {
+	if value, ok := index[translationKey{entityType, entityID, "description"}]; ok {
+		*description = &value
+	}
+}
+
+// checkTranslationsComplete validates that all names, non-empty descriptions and matrix cells
+// of a catalog have a translation for the locale
+func checkTranslationsComplete(details *models.CatalogWithDetails, index translationIndex, locale string) error {
+	has := func(entityType string, entityID uint, fieldName string) bool {
+		value, ok := index[translationKey{entityType, entityID, fieldName}]
+		return ok && strings.TrimSpace(value) != ""
+	}
+	needsDescription := func(description *string) bool {
+		return description != nil && strings.TrimSpace(*description) != ""
+	}
+
+	for _, level := range details.Levels {
+		if !has("level", level.ID, "name") || (needsDescription(level.Description) && !has("level", level.ID, "description")) {
+			return fmt.Errorf("level '%s' is missing a '%s' translation", level.Name, locale)
+		}
+	}
+	for _, category := range details.Categories {
+		if !has("category", category.ID, "name") || (needsDescription(category.Description) && !has("category", category.ID, "description")) {
+			return fmt.Errorf("category '%s' is missing a '%s' translation", category.Name, locale)
+		}
+		for _, path := range category.Paths {
+			if !has("path", path.ID, "name") || (needsDescription(path.Description) && !has("path", path.ID, "description")) {
+				return fmt.Errorf("path '%s' is missing a '%s' translation", path.Name, locale)
+			}
+			for _, desc := range path.Descriptions {
+				if !has("description", desc.ID, "description") {
+					return fmt.Errorf("path '%s' has descriptions without '%s' translation", path.Name, locale)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveLocale picks the first configured locale matching the lang parameter or, ordered by
+// quality, the Accept-Language header. Falls back to the default (first) locale.
+func resolveLocale(locales []string, lang, acceptLanguage string) string {
+	if len(locales) == 0 {
+		return ""
+	}
+	if match := matchLocale(locales, lang); match != "" {
+		return match
+	}
+
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+	var tags []weightedTag
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		tags = append(tags, weightedTag{tag: tag, quality: quality})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].quality > tags[j].quality
+	})
+
+	for _, tag := range tags {
+		if tag.quality <= 0 {
+			continue
+		}
+		if match := matchLocale(locales, tag.tag); match != "" {
+			return match
+		}
+	}
+
+	return locales[0]
+}
+
+// matchLocale matches a language tag against the configured locales, first exactly and then
+// by primary language (e.g. "de-AT" matches "de")
+func matchLocale(locales []string, tag string) string {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if tag == "" {
+		return ""
+	}
+	for _, locale := range locales {
+		if strings.EqualFold(locale, tag) {
+			return locale
+		}
+	}
+	primary := strings.SplitN(tag, "-", 2)[0]
+	for _, locale := range locales {
+		if strings.EqualFold(strings.SplitN(locale, "-", 2)[0], primary) {
+			return locale
+		}
+	}
+	return ""
+}
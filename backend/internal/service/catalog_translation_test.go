@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+
+	"new-pay/internal/models"
+)
+
+func TestResolveLocale(t *testing.T) {
+	locales := []string{"de", "en"}
+	tests := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "default", want: "de"},
+		{name: "lang parameter", lang: "en", acceptLanguage: "de", want: "en"},
+		{name: "unknown lang falls back to header", lang: "fr", acceptLanguage: "en-US", want: "en"},
+		{name: "quality order", acceptLanguage: "fr;q=0.9, de;q=0.5, en;q=0.8", want: "en"},
+		{name: "zero quality is ignored", acceptLanguage: "en;q=0", want: "de"},
+		{name: "region matches primary language", acceptLanguage: "en_GB", want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveLocale(locales, tt.lang, tt.acceptLanguage); got != tt.want {
+				t.Errorf("resolveLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTranslations(t *testing.T) {
+	details := testCatalogDetails(t)
+	index := translationIndex{
+		{"category", 10, "name"}:             "Technik",
+		{"description", 1000, "description"}: "Grundlagen",
+	}
+
+	responses := []models.AssessmentResponseWithDetails{{
+		AssessmentResponse:   models.AssessmentResponse{CategoryID: 10, PathID: 100, LevelID: details.Levels[0].ID},
+		CategoryName:         "Technology",
+		PathName:             "Backend",
+		PathLevelDescription: "Basics",
+	}}
+	details.Categories[0].Paths[0].Descriptions[0].PathID = 100
+	applyResponseTranslations(responses, details, index)
+	if responses[0].CategoryName != "Technik" || responses[0].PathLevelDescription != "Grundlagen" || responses[0].PathName != "Backend" {
+		t.Errorf("applyResponseTranslations() = %+v", responses[0])
+	}
+
+	applyCatalogTranslations(details, index)
+	if details.Categories[0].Name != "Technik" {
+		t.Errorf("category name = %q, want Technik", details.Categories[0].Name)
+	}
+	if details.Categories[0].Paths[0].Descriptions[0].Description != "Grundlagen" {
+		t.Errorf("cell = %q, want Grundlagen", details.Categories[0].Paths[0].Descriptions[0].Description)
+	}
+	if details.Levels[0].Name != "A" {
+		t.Errorf("untranslated level name = %q, want A", details.Levels[0].Name)
+	}
+}
+
+func TestCheckTranslationsComplete(t *testing.T) {
+	details := testCatalogDetails(t)
+	index := translationIndex{
+		{"level", details.Levels[0].ID, "name"}: "A",
+		{"level", details.Levels[1].ID, "name"}: "B",
+		{"category", 10, "name"}:                "Technik",
+		{"path", 100, "name"}:                   "Backend",
+		{"description", 1000, "description"}:    "Grundlagen",
+	}
+
+	if err := checkTranslationsComplete(details, index, "en"); err == nil {
+		t.Error("checkTranslationsComplete() expected error for missing cell translation")
+	}
+
+	index[translationKey{"description", 1001, "description"}] = "Fortgeschritten"
+	if err := checkTranslationsComplete(details, index, "en"); err != nil {
+		t.Errorf("checkTranslationsComplete() error = %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"new-pay/internal/email"
+	"new-pay/internal/keymanager"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+	"new-pay/internal/securestore"
+)
+
+// statusChangesRequested is the editable status a self-assessment returns to when reviewers request changes
+const statusChangesRequested = "changes_requested"
+
+// ChangeRequestService handles change requests of reviewers
+type ChangeRequestService struct {
+	changeRequestRepo *repository.ChangeRequestRepository
+	assessmentRepo    *repository.SelfAssessmentRepository
+	assignmentRepo    *repository.ReviewerAssignmentRepository
+	userRepo          *repository.UserRepository
+	keyManager        *keymanager.KeyManager
+	secureStore       *securestore.SecureStore
+	transactor        *repository.Transactor
+	workflowSvc       *WorkflowService
+	auditSvc          *AuditService
+	emailService      *email.Service
+}
+
+// NewChangeRequestService creates a new change request service
+func NewChangeRequestService(
+	changeRequestRepo *repository.ChangeRequestRepository,
+	assessmentRepo *repository.SelfAssessmentRepository,
+	assignmentRepo *repository.ReviewerAssignmentRepository,
+	userRepo *repository.UserRepository,
+	keyManager *keymanager.KeyManager,
+	secureStore *securestore.SecureStore,
+	transactor *repository.Transactor,
+	workflowSvc *WorkflowService,
+	auditSvc *AuditService,
+	emailService *email.Service,
+) *ChangeRequestService {
+	return &ChangeRequestService{
+		changeRequestRepo: changeRequestRepo,
+		assessmentRepo:    assessmentRepo,
+		assignmentRepo:    assignmentRepo,
+		userRepo:          userRepo,
+		keyManager:        keyManager,
+		secureStore:       secureStore,
+		transactor:        transactor,
+		workflowSvc:       workflowSvc,
+		auditSvc:          auditSvc,
+		emailService:      emailService,
+	}
+}
+
+// RequestChanges sends a self-assessment back to its owner for rework.
+// The message is encrypted in the secure store; reviewer responses already entered are kept.
+func (s *ChangeRequestService) RequestChanges(assessmentID, reviewerID uint, userRoles []string, message string) (*models.ChangeRequest, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, fmt.Errorf("message is required")
+	}
+
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment not found")
+	}
+
+	assigned, err := s.assignmentRepo.IsAssigned(assessmentID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		return nil, fmt.Errorf("permission denied: you are not assigned as reviewer of this self-assessment")
+	}
+
+	// The workflow decides from which states changes can be requested and by whom
+	if err := s.workflowSvc.CheckTransition(assessment, statusChangesRequested, reviewerID, userRoles); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureUserKey(int64(reviewerID)); err != nil {
+		return nil, fmt.Errorf("failed to ensure reviewer key: %w", err)
+	}
+	processID := fmt.Sprintf("assessment-%d", assessmentID)
+	if err := s.ensureProcessKey(processID); err != nil {
+		return nil, fmt.Errorf("failed to ensure process key: %w", err)
+	}
+
+	data := &securestore.PlainData{
+		Fields: map[string]interface{}{
+			"message": message,
+		},
+		Metadata: map[string]string{
+			"assessment_id": fmt.Sprintf("%d", assessmentID),
+			"reviewer_id":   fmt.Sprintf("%d", reviewerID),
+			"type":          "change_request",
+		},
+	}
+	// The encrypted message, the change request and the status change are kept only together
+	request := &models.ChangeRequest{
+		AssessmentID: assessmentID,
+		RequestedBy:  &reviewerID,
+		FromStatus:   assessment.Status,
+	}
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		record, err := s.secureStore.WithTx(tx).CreateRecord(processID, int64(reviewerID), "CHANGE_REQUEST", data, "")
+		if err != nil {
+			return fmt.Errorf("failed to encrypt message: %w", err)
+		}
+		request.EncryptedMessageID = &record.ID
+		if err := s.changeRequestRepo.WithTx(tx).Create(request); err != nil {
+			return err
+		}
+		if err := s.assessmentRepo.WithTx(tx).UpdateStatus(assessmentID, statusChangesRequested); err != nil {
+			return err
+		}
+		s.auditSvc.WithTx(tx).Log(reviewerID, "request_changes", "self_assessment",
+			fmt.Sprintf("Requested changes to self-assessment %d (status %s -> %s)", assessmentID, assessment.Status, statusChangesRequested))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assessment.Status = statusChangesRequested
+	s.workflowSvc.OnEnter(assessment)
+
+	reviewer, err := s.userRepo.GetByID(reviewerID)
+	if err != nil {
+		slog.Error("Failed to get reviewer for change request", "reviewer_id", reviewerID, "error", err)
+	} else {
+		request.RequesterName = reviewer.FirstName + " " + reviewer.LastName
+	}
+	request.Message = message
+	s.notifyOwner(assessmentID, request.RequesterName)
+
+	return request, nil
+}
+
+// GetChangeRequests retrieves the change requests of a self-assessment with decrypted messages
+// (owner and assigned reviewers only)
+func (s *ChangeRequestService) GetChangeRequests(assessmentID, userID uint) ([]models.ChangeRequest, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment not found")
+	}
+
+	if assessment.UserID != userID {
+		assigned, err := s.assignmentRepo.IsAssigned(assessmentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !assigned {
+			return nil, fmt.Errorf("permission denied: cannot view change requests of this self-assessment")
+		}
+	}
+
+	requests, err := s.changeRequestRepo.GetByAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range requests {
+		if requests[i].EncryptedMessageID == nil {
+			continue
+		}
+		plainData, err := s.secureStore.DecryptRecord(*requests[i].EncryptedMessageID)
+		if err != nil {
+			slog.Error("Failed to decrypt change request message", "error", err, "change_request_id", requests[i].ID)
+			requests[i].Message = "[Decryption failed]"
+			continue
+		}
+		if message, ok := plainData.Fields["message"].(string); ok {
+			requests[i].Message = message
+		}
+	}
+
+	return requests, nil
+}
+
+func (s *ChangeRequestService) notifyOwner(assessmentID uint, reviewerName string) {
+	if s.emailService == nil {
+		return
+	}
+	details, err := s.assessmentRepo.GetByIDWithDetails(assessmentID)
+	if err != nil || details == nil {
+		slog.Error("Failed to get assessment details for change request notification", "assessment_id", assessmentID, "error", err)
+		return
+	}
+	if err := s.emailService.SendChangesRequestedNotification(details.UserEmail, details.UserName, details.CatalogName, reviewerName, assessmentID); err != nil {
+		slog.Error("Failed to send change request notification", "assessment_id", assessmentID, "error", err)
+	}
+}
+
+// ensureUserKey ensures the user has a signing key
+func (s *ChangeRequestService) ensureUserKey(userID int64) error {
+	if _, err := s.keyManager.GetUserPublicKey(userID); err == nil {
+		return nil
+	}
+	_, err := s.keyManager.CreateUserKey(userID)
+	return err
+}
+
+// ensureProcessKey ensures a process encryption key exists
+func (s *ChangeRequestService) ensureProcessKey(processID string) error {
+	if _, err := s.keyManager.GetProcessKey(processID); err == nil {
+		return nil
+	}
+	return s.keyManager.CreateProcessKey(processID, nil)
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+// Consolidation event types
+const (
+	EventOverrideSaved           = "override_saved"
+	EventOverrideDeleted         = "override_deleted"
+	EventOverrideApproved        = "override_approved"
+	EventOverrideApprovalRevoked = "override_approval_revoked"
+	EventAveragedApproved        = "averaged_approved"
+	EventAveragedApprovalRevoked = "averaged_approval_revoked"
+	EventCategoryCommentSaved    = "category_comment_saved"
+	EventProposalsGenerated      = "proposals_generated"
+	EventFinalSaved              = "final_consolidation_saved"
+	EventFinalApproved           = "final_approved"
+	EventFinalApprovalRevoked    = "final_approval_revoked"
+	EventStatusChanged           = "status_changed"
+)
+
+// eventBufferSize is the number of events buffered per subscriber before further events are dropped
+const eventBufferSize = 32
+
+// ConsolidationEventHub distributes consolidation events to the subscribers of an assessment.
+// Subscriptions are held in memory, so events reach only clients connected to the same instance.
+type ConsolidationEventHub struct {
+	mu          sync.Mutex
+	subscribers map[uint]map[chan models.ConsolidationEvent]struct{}
+	closed      bool
+}
+
+// NewConsolidationEventHub creates a new consolidation event hub
+func NewConsolidationEventHub() *ConsolidationEventHub {
+	return &ConsolidationEventHub{
+		subscribers: make(map[uint]map[chan models.ConsolidationEvent]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber for the events of an assessment. The returned function
+// ends the subscription; the channel is closed when the subscription ends or the hub is closed.
+func (h *ConsolidationEventHub) Subscribe(assessmentID uint) (<-chan models.ConsolidationEvent, func()) {
+	ch := make(chan models.ConsolidationEvent, eventBufferSize)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subscribers[assessmentID] == nil {
+		h.subscribers[assessmentID] = make(map[chan models.ConsolidationEvent]struct{})
+	}
+	h.subscribers[assessmentID][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() { h.unsubscribe(assessmentID, ch) })
+	}
+}
+
+func (h *ConsolidationEventHub) unsubscribe(assessmentID uint, ch chan models.ConsolidationEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscribers := h.subscribers[assessmentID]
+	if _, ok := subscribers[ch]; !ok {
+		return // Already closed by Close
+	}
+	delete(subscribers, ch)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, assessmentID)
+	}
+	close(ch)
+}
+
+// Publish sends an event to all subscribers of its assessment without blocking;
+// subscribers that do not keep up miss the event
+func (h *ConsolidationEventHub) Publish(event models.ConsolidationEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[event.AssessmentID] {
+		select {
+		case ch <- event:
+		default:
+			slog.Warn("Dropped consolidation event for slow subscriber", "assessment_id", event.AssessmentID, "type", event.Type)
+		}
+	}
+}
+
+// Close ends all subscriptions, e.g. on server shutdown
+func (h *ConsolidationEventHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for assessmentID, subscribers := range h.subscribers {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(h.subscribers, assessmentID)
+	}
+	h.closed = true
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+
+	"new-pay/internal/models"
+)
+
+func TestConsolidationEventHubPublish(t *testing.T) {
+	hub := NewConsolidationEventHub()
+
+	events, unsubscribe := hub.Subscribe(1)
+	defer unsubscribe()
+	other, unsubscribeOther := hub.Subscribe(2)
+	defer unsubscribeOther()
+
+	categoryID := uint(5)
+	hub.Publish(models.ConsolidationEvent{Type: EventOverrideSaved, AssessmentID: 1, CategoryID: &categoryID, UserID: 3})
+
+	select {
+	case event := <-events:
+		if event.Type != EventOverrideSaved || *event.CategoryID != 5 || event.OccurredAt.IsZero() {
+			t.Errorf("unexpected event %+v", event)
+		}
+	default:
+		t.Fatal("subscriber did not receive the event")
+	}
+
+	select {
+	case event := <-other:
+		t.Errorf("subscriber of another assessment received %+v", event)
+	default:
+	}
+}
+
+func TestConsolidationEventHubSlowSubscriber(t *testing.T) {
+	hub := NewConsolidationEventHub()
+	events, unsubscribe := hub.Subscribe(1)
+	defer unsubscribe()
+
+	// Publishing must not block when the buffer is full
+	for i := 0; i < eventBufferSize+5; i++ {
+		hub.Publish(models.ConsolidationEvent{Type: EventAveragedApproved, AssessmentID: 1})
+	}
+	if len(events) != eventBufferSize {
+		t.Errorf("buffered %d events, want %d", len(events), eventBufferSize)
+	}
+}
+
+func TestConsolidationEventHubUnsubscribeAndClose(t *testing.T) {
+	hub := NewConsolidationEventHub()
+
+	events, unsubscribe := hub.Subscribe(1)
+	unsubscribe()
+	unsubscribe() // Idempotent
+	if _, ok := <-events; ok {
+		t.Error("channel not closed after unsubscribe")
+	}
+
+	events, unsubscribe = hub.Subscribe(1)
+	hub.Close()
+	unsubscribe() // Safe after Close
+	if _, ok := <-events; ok {
+		t.Error("channel not closed by Close")
+	}
+
+	events, _ = hub.Subscribe(1)
+	if _, ok := <-events; ok {
+		t.Error("subscription after Close not closed")
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -16,6 +17,7 @@ import (
 // ConsolidationService handles business logic for review consolidation
 type ConsolidationService struct {
 	db                     *sql.DB
+	transactor             *repository.Transactor
 	consolidationRepo      *repository.ConsolidationOverrideRepository
 	approvalRepo           *repository.ConsolidationOverrideApprovalRepository
 	averagedApprovalRepo   *repository.ConsolidationAveragedApprovalRepository
@@ -24,6 +26,7 @@ type ConsolidationService struct {
 	assessmentRepo         *repository.SelfAssessmentRepository
 	responseRepo           *repository.AssessmentResponseRepository
 	reviewerRepo           *repository.ReviewerResponseRepository
+	assignmentRepo         *repository.ReviewerAssignmentRepository
 	catalogRepo            *repository.CatalogRepository
 	categoryDiscussionRepo *repository.CategoryDiscussionCommentRepository
 	encryptedResponseSvc   *EncryptedResponseService
@@ -31,6 +34,10 @@ type ConsolidationService struct {
 	secureStore            *securestore.SecureStore
 	emailService           *email.Service
 	llmService             *LLMService
+	workflowSvc            *WorkflowService
+	disagreementThreshold  int // Maximum level spread per category before a discussion comment is required
+	quorumSvc              *QuorumService
+	events                 *ConsolidationEventHub
 }
 
 // NewConsolidationService creates a new consolidation service
@@ -44,6 +51,7 @@ func NewConsolidationService(
 	assessmentRepo *repository.SelfAssessmentRepository,
 	responseRepo *repository.AssessmentResponseRepository,
 	reviewerRepo *repository.ReviewerResponseRepository,
+	assignmentRepo *repository.ReviewerAssignmentRepository,
 	catalogRepo *repository.CatalogRepository,
 	categoryDiscussionRepo *repository.CategoryDiscussionCommentRepository,
 	encryptedResponseSvc *EncryptedResponseService,
@@ -51,9 +59,14 @@ func NewConsolidationService(
 	secureStore *securestore.SecureStore,
 	emailService *email.Service,
 	llmService *LLMService,
+	workflowSvc *WorkflowService,
+	disagreementThreshold int,
+	quorumSvc *QuorumService,
+	events *ConsolidationEventHub,
 ) *ConsolidationService {
 	return &ConsolidationService{
 		db:                     db,
+		transactor:             repository.NewTransactor(db),
 		consolidationRepo:      consolidationRepo,
 		approvalRepo:           approvalRepo,
 		averagedApprovalRepo:   averagedApprovalRepo,
@@ -62,6 +75,7 @@ func NewConsolidationService(
 		assessmentRepo:         assessmentRepo,
 		responseRepo:           responseRepo,
 		reviewerRepo:           reviewerRepo,
+		assignmentRepo:         assignmentRepo,
 		catalogRepo:            catalogRepo,
 		categoryDiscussionRepo: categoryDiscussionRepo,
 		encryptedResponseSvc:   encryptedResponseSvc,
@@ -69,11 +83,99 @@ func NewConsolidationService(
 		secureStore:            secureStore,
 		emailService:           emailService,
 		llmService:             llmService,
+		workflowSvc:            workflowSvc,
+		disagreementThreshold:  disagreementThreshold,
+		quorumSvc:              quorumSvc,
+		events:                 events,
 	}
 }
 
 // Helper functions
 
+// panelSize returns the size of the reviewer panel (reviewers with a complete review if no panel is assigned)
+func (s *ConsolidationService) panelSize(assessmentID uint) (int, error) {
+	panelSize, err := s.assignmentRepo.CountByAssessmentID(assessmentID)
+	if err != nil {
+		return 0, err
+	}
+	if panelSize > 0 {
+		return panelSize, nil
+	}
+
+	reviewers, err := s.reviewerRepo.GetCompleteReviewers(assessmentID)
+	if err != nil {
+		return 0, err
+	}
+	return len(reviewers), nil
+}
+
+// quorumFor returns the quorum policy of the assessment's catalog and the size of its reviewer panel
+func (s *ConsolidationService) quorumFor(assessment *models.SelfAssessment) (*models.QuorumPolicy, int, error) {
+	policy, err := s.quorumSvc.GetPolicyForCatalog(assessment.CatalogID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get quorum policy: %w", err)
+	}
+	panelSize, err := s.panelSize(assessment.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get reviewer panel: %w", err)
+	}
+	return policy, panelSize, nil
+}
+
+// loadCategoryApprovals loads averaged results and overrides with their approvals
+// and evaluates them against the quorum policy
+func (s *ConsolidationService) loadCategoryApprovals(assessmentID uint, catalog *models.CatalogWithDetails, policy *models.QuorumPolicy, panelSize int) ([]models.AveragedReviewerResponse, []models.ConsolidationOverride, error) {
+	// Get all reviewer responses for this assessment
+	reviewerResponses, err := s.reviewerRepo.GetAllByAssessment(assessmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get reviewer responses: %w", err)
+	}
+
+	// Calculate averaged responses per category (without justifications for security)
+	averagedResponses := calculateAveragedResponses(reviewerResponses, catalog, false)
+
+	// Load approvals for averaged responses
+	allAveragedApprovals, err := s.averagedApprovalRepo.GetApprovalsByAssessment(assessmentID)
+	if err != nil {
+		slog.Error("Failed to load averaged approvals", "error", err)
+	} else {
+		// Group approvals by category
+		approvalsByCategory := make(map[uint][]models.ConsolidationAveragedApproval)
+		for _, approval := range allAveragedApprovals {
+			approvalsByCategory[approval.CategoryID] = append(approvalsByCategory[approval.CategoryID], approval)
+		}
+
+		// Attach approvals to averaged responses
+		for i := range averagedResponses {
+			approvals := approvalsByCategory[averagedResponses[i].CategoryID]
+			averagedResponses[i].Approvals = approvals
+			averagedResponses[i].ApprovalCount = len(approvals)
+		}
+	}
+
+	// Get consolidation overrides
+	overrides, err := s.consolidationRepo.GetByAssessment(assessmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get overrides: %w", err)
+	}
+
+	for i := range overrides {
+		// Load approvals for this override
+		approvals, err := s.approvalRepo.GetApprovalsByOverride(overrides[i].ID)
+		if err != nil {
+			slog.Error("Failed to load approvals", "error", err, "override_id", overrides[i].ID)
+			continue
+		}
+		overrides[i].Approvals = approvals
+		overrides[i].ApprovalCount = len(approvals)
+	}
+
+	applyAveragedQuorum(averagedResponses, policy, panelSize)
+	applyOverrideQuorum(overrides, policy, panelSize)
+
+	return averagedResponses, overrides, nil
+}
+
 // getAssessment loads an assessment and checks if it exists
 func (s *ConsolidationService) getAssessment(assessmentID uint) (*models.SelfAssessment, error) {
 	assessment, err := s.assessmentRepo.GetByID(assessmentID)
@@ -142,26 +244,11 @@ func (s *ConsolidationService) decryptCategoryDiscussionComments(comments []mode
 
 // GetConsolidationData retrieves all data needed for consolidation page
 func (s *ConsolidationService) GetConsolidationData(assessmentID uint, currentUserID uint) (*models.ConsolidationData, error) {
-	// Get assessment
-	assessment, err := s.getAssessment(assessmentID)
+	assessment, err := s.checkConsolidationAccess(assessmentID, currentUserID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check that assessment is in consolidation, reviewed, or discussion status
-	if assessment.Status != "review_consolidation" && assessment.Status != "reviewed" && assessment.Status != "discussion" {
-		return nil, fmt.Errorf("assessment must be in review_consolidation, reviewed, or discussion status")
-	}
-
-	// Check if current user has completed a review for this assessment
-	hasCompleteReview, err := s.HasCompleteReview(assessmentID, currentUserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check review completion: %w", err)
-	}
-	if !hasCompleteReview {
-		return nil, fmt.Errorf("permission denied: only reviewers who completed their review can access consolidation")
-	}
-
 	// Get catalog with details
 	catalog, err := s.catalogRepo.GetCatalogWithDetails(assessment.CatalogID)
 	if err != nil {
@@ -183,60 +270,21 @@ func (s *ConsolidationService) GetConsolidationData(assessmentID uint, currentUs
 		userResponses[i] = *resp
 	}
 
-	// Get all reviewer responses for this assessment
-	reviewerResponses, err := s.reviewerRepo.GetAllByAssessment(assessmentID)
+	// Quorum policy of the catalog decides when categories and the final consolidation are approved
+	policy, panelSize, err := s.quorumFor(assessment)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewer responses: %w", err)
-	}
-
-	// Decrypt reviewer justifications
-	s.decryptJustifications(reviewerResponses)
-
-	// Calculate averaged responses per category (without justifications for security)
-	averagedResponses := calculateAveragedResponses(reviewerResponses, catalog, false)
-
-	// Load approvals for averaged responses
-	allAveragedApprovals, err := s.averagedApprovalRepo.GetApprovalsByAssessment(assessmentID)
-	if err != nil {
-		slog.Error("Failed to load averaged approvals", "error", err)
-	} else {
-		// Group approvals by category
-		approvalsByCategory := make(map[uint][]models.ConsolidationAveragedApproval)
-		for _, approval := range allAveragedApprovals {
-			approvalsByCategory[approval.CategoryID] = append(approvalsByCategory[approval.CategoryID], approval)
-		}
-
-		// Attach approvals to averaged responses
-		for i := range averagedResponses {
-			approvals := approvalsByCategory[averagedResponses[i].CategoryID]
-			averagedResponses[i].Approvals = approvals
-			averagedResponses[i].ApprovalCount = len(approvals)
-			averagedResponses[i].IsApproved = len(approvals) >= 2 // Need at least 2 approvals
-		}
+		return nil, err
 	}
 
-	// Get consolidation overrides
-	overrides, err := s.consolidationRepo.GetByAssessment(assessmentID)
+	// Averaged responses and overrides with their approvals
+	averagedResponses, overrides, err := s.loadCategoryApprovals(assessmentID, catalog, policy, panelSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get overrides: %w", err)
+		return nil, err
 	}
 
-	// Decrypt override justifications and load approvals
+	// Decrypt override justifications
 	s.decryptOverrideJustifications(overrides)
 
-	for i := range overrides {
-
-		// Load approvals for this override
-		approvals, err := s.approvalRepo.GetApprovalsByOverride(overrides[i].ID)
-		if err != nil {
-			slog.Error("Failed to load approvals", "error", err, "override_id", overrides[i].ID)
-			continue
-		}
-		overrides[i].Approvals = approvals
-		overrides[i].ApprovalCount = len(approvals)
-		overrides[i].IsApproved = len(approvals) > 0
-	}
-
 	// Get current user's own reviewer responses (decrypted)
 	currentUserResponses, err := s.reviewerRepo.GetByAssessmentAndReviewer(assessmentID, currentUserID)
 	if err != nil {
@@ -274,14 +322,8 @@ func (s *ConsolidationService) GetConsolidationData(assessmentID uint, currentUs
 			fc.ApprovalCount = len(approvals)
 		}
 
-		// Get required approval count (number of reviewers who completed review)
-		reviewers, err := s.reviewerRepo.GetCompleteReviewers(assessmentID)
-		if err != nil {
-			slog.Error("Failed to get reviewer count", "error", err)
-		} else {
-			fc.RequiredApprovals = len(reviewers)
-			fc.IsFullyApproved = fc.ApprovalCount >= fc.RequiredApprovals
-		}
+		fc.RequiredApprovals = requiredFinalQuorum(policy, panelSize)
+		fc.IsFullyApproved = fc.ApprovalCount >= fc.RequiredApprovals
 
 		finalConsolidation = fc
 	}
@@ -293,6 +335,9 @@ func (s *ConsolidationService) GetConsolidationData(assessmentID uint, currentUs
 		categoryDiscussionComments = []models.CategoryDiscussionComment{} // Empty slice on error
 	}
 
+	// Flag categories where the reviewers disagree strongly
+	markDisagreements(averagedResponses, categoryDiscussionComments, s.disagreementThreshold)
+
 	return &models.ConsolidationData{
 		Assessment:                 *assessment,
 		UserResponses:              userResponses,
@@ -303,9 +348,81 @@ func (s *ConsolidationService) GetConsolidationData(assessmentID uint, currentUs
 		FinalConsolidation:         finalConsolidation,
 		CategoryDiscussionComments: categoryDiscussionComments,
 		AllCategoriesApproved:      allCategoriesApproved,
+		DisagreementThreshold:      s.disagreementThreshold,
+		QuorumPolicy:               *policy,
 	}, nil
 }
 
+// checkConsolidationAccess loads the assessment and checks that the user may access its consolidation
+func (s *ConsolidationService) checkConsolidationAccess(assessmentID, userID uint) (*models.SelfAssessment, error) {
+	// Get assessment
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that assessment is in consolidation, reviewed, or discussion status
+	if assessment.Status != "review_consolidation" && assessment.Status != "reviewed" && assessment.Status != "discussion" {
+		return nil, fmt.Errorf("assessment must be in review_consolidation, reviewed, or discussion status")
+	}
+
+	// Once a panel is assigned, only its members have access (a reviewer removed from the panel loses it)
+	panelSize, err := s.assignmentRepo.CountByAssessmentID(assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get panel size: %w", err)
+	}
+	if panelSize > 0 {
+		assigned, err := s.assignmentRepo.IsAssigned(assessmentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !assigned {
+			return nil, fmt.Errorf("permission denied: you are not assigned as reviewer of this self-assessment")
+		}
+	}
+
+	// Check if current user has completed a review for this assessment
+	hasCompleteReview, err := s.HasCompleteReview(assessmentID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check review completion: %w", err)
+	}
+	if !hasCompleteReview {
+		return nil, fmt.Errorf("permission denied: only reviewers who completed their review can access consolidation")
+	}
+
+	return assessment, nil
+}
+
+// SubscribeEvents subscribes a reviewer to the live events of an assessment's consolidation.
+// The same access rules as for GetConsolidationData apply; the returned function ends the subscription.
+func (s *ConsolidationService) SubscribeEvents(assessmentID, userID uint) (<-chan models.ConsolidationEvent, func(), error) {
+	if _, err := s.checkConsolidationAccess(assessmentID, userID); err != nil {
+		return nil, nil, err
+	}
+	events, unsubscribe := s.events.Subscribe(assessmentID)
+	return events, unsubscribe, nil
+}
+
+// CheckEventAccess re-checks whether a subscriber may still receive the events of an assessment's
+// consolidation, e.g. after the reviewer was removed from the panel
+func (s *ConsolidationService) CheckEventAccess(assessmentID, userID uint) error {
+	_, err := s.checkConsolidationAccess(assessmentID, userID)
+	return err
+}
+
+// publish notifies subscribers of the consolidation about a change
+func (s *ConsolidationService) publish(eventType string, assessmentID uint, categoryID *uint, userID uint) {
+	if s.events == nil {
+		return
+	}
+	s.events.Publish(models.ConsolidationEvent{
+		Type:         eventType,
+		AssessmentID: assessmentID,
+		CategoryID:   categoryID,
+		UserID:       userID,
+	})
+}
+
 // areAllCategoriesApproved checks if all categories have required approvals
 func (s *ConsolidationService) areAllCategoriesApproved(categories []models.CategoryWithPaths, averagedResponses []models.AveragedReviewerResponse, overrides []models.ConsolidationOverride) bool {
 	for _, category := range categories {
@@ -322,7 +439,7 @@ func (s *ConsolidationService) areAllCategoriesApproved(categories []models.Cate
 			continue
 		}
 
-		// Check if averaged response has 2+ approvals
+		// Check if averaged response reached the quorum
 		averagedApproved := false
 		for _, averaged := range averagedResponses {
 			if averaged.CategoryID == category.ID && averaged.IsApproved {
@@ -382,6 +499,50 @@ func (s *ConsolidationService) checkEditingAllowed(assessmentID uint) error {
 	return nil
 }
 
+// checkDisagreementDiscussed blocks overrides and averaged approvals of a category with strong
+// reviewer disagreement until a category discussion comment has been recorded
+func (s *ConsolidationService) checkDisagreementDiscussed(assessmentID, categoryID uint) error {
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogWithDetails(assessment.CatalogID)
+	if err != nil {
+		return fmt.Errorf("failed to get catalog: %w", err)
+	}
+	if catalog == nil {
+		return fmt.Errorf("catalog not found")
+	}
+
+	reviewerResponses, err := s.reviewerRepo.GetAllByAssessment(assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get reviewer responses: %w", err)
+	}
+
+	for _, averaged := range calculateAveragedResponses(reviewerResponses, catalog, false) {
+		if averaged.CategoryID != categoryID || !needsDiscussion(&averaged, s.disagreementThreshold) {
+			continue
+		}
+
+		comment, err := s.categoryDiscussionRepo.GetByAssessmentAndCategory(assessmentID, categoryID)
+		if err != nil {
+			return err
+		}
+		if comment != nil {
+			comments := []models.CategoryDiscussionComment{*comment}
+			s.decryptCategoryDiscussionComments(comments)
+			comment = &comments[0]
+		}
+		if !isDiscussionRecorded(comment) {
+			return fmt.Errorf("reviewers disagree on this category (level spread %d, threshold %d): record a category discussion comment first",
+				averaged.LevelSpread, s.disagreementThreshold)
+		}
+	}
+
+	return nil
+}
+
 // HasCompleteReview checks if a user has completed their review for an assessment
 func (s *ConsolidationService) HasCompleteReview(assessmentID, userID uint) (bool, error) {
 	// Get assessment to find catalog
@@ -410,12 +571,17 @@ func (s *ConsolidationService) HasCompleteReview(assessmentID, userID uint) (boo
 }
 
 // CreateOrUpdateOverride creates or updates a consolidation override with encryption
-func (s *ConsolidationService) CreateOrUpdateOverride(override *models.ConsolidationOverride, userID uint) error {
+func (s *ConsolidationService) CreateOrUpdateOverride(override *models.ConsolidationOverride, userID uint, expectedVersion *int) error {
 	// Check if editing is allowed
 	if err := s.checkEditingAllowed(override.AssessmentID); err != nil {
 		return err
 	}
 
+	// Strong disagreement has to be discussed before the result is overridden
+	if err := s.checkDisagreementDiscussed(override.AssessmentID, override.CategoryID); err != nil {
+		return err
+	}
+
 	// Verify assessment is in review_consolidation status
 	assessment, err := s.getAssessment(override.AssessmentID)
 	if err != nil {
@@ -430,8 +596,13 @@ func (s *ConsolidationService) CreateOrUpdateOverride(override *models.Consolida
 	if err != nil && err.Error() != "override not found" {
 		return fmt.Errorf("failed to check existing override: %w", err)
 	}
+	if existingOverride != nil && !versionMatches(expectedVersion, true, existingOverride.Version) ||
+		existingOverride == nil && !versionMatches(expectedVersion, false, 0) {
+		return s.overrideConflict(existingOverride)
+	}
 
 	// If override exists and either the user is different OR the data changed, reset approvals
+	resetApprovals := false
 	if existingOverride != nil {
 		authorChanged := existingOverride.CreatedByUserID != userID
 		dataChanged := existingOverride.PathID != override.PathID ||
@@ -439,19 +610,11 @@ func (s *ConsolidationService) CreateOrUpdateOverride(override *models.Consolida
 			existingOverride.Justification != override.Justification
 
 		if authorChanged || dataChanged {
-			// Delete all approvals for this override
-			if err := s.approvalRepo.DeleteAllApprovalsForOverride(existingOverride.ID); err != nil {
-				return fmt.Errorf("failed to reset approvals: %w", err)
-			}
+			resetApprovals = true
 
 			// Update the author to the current user (whoever makes changes becomes the new author)
 			override.CreatedByUserID = userID
 		}
-	} else {
-		// This is a new override - delete any averaged approvals for this category
-		if err := s.averagedApprovalRepo.DeleteAllApprovalsForCategory(override.AssessmentID, override.CategoryID); err != nil {
-			slog.Error("Failed to delete averaged approvals", "error", err)
-		}
 	}
 
 	// Ensure user key exists
@@ -465,6 +628,42 @@ func (s *ConsolidationService) CreateOrUpdateOverride(override *models.Consolida
 		return fmt.Errorf("failed to ensure process key: %w", err)
 	}
 
+	// The approval reset, the encrypted justification and the version-checked write are kept only
+	// together, so a save that loses a concurrent edit leaves no trace
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		return s.writeOverride(tx, override, existingOverride, resetApprovals, userID, processID, expectedVersion)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			current, err := s.consolidationRepo.GetByAssessmentAndCategory(override.AssessmentID, override.CategoryID)
+			if err != nil {
+				return err
+			}
+			return s.overrideConflict(current)
+		}
+		return err
+	}
+
+	s.publish(EventOverrideSaved, override.AssessmentID, &override.CategoryID, userID)
+	return nil
+}
+
+// writeOverride resets the affected approvals, encrypts the justification and stores the override in tx
+func (s *ConsolidationService) writeOverride(tx *sql.Tx, override, existingOverride *models.ConsolidationOverride, resetApprovals bool, userID uint, processID string, expectedVersion *int) error {
+	if existingOverride != nil {
+		if resetApprovals {
+			// Delete all approvals for this override
+			if err := s.approvalRepo.WithTx(tx).DeleteAllApprovalsForOverride(existingOverride.ID); err != nil {
+				return fmt.Errorf("failed to reset approvals: %w", err)
+			}
+		}
+	} else {
+		// This is a new override - delete any averaged approvals for this category
+		if err := s.averagedApprovalRepo.WithTx(tx).DeleteAllApprovalsForCategory(override.AssessmentID, override.CategoryID); err != nil {
+			return fmt.Errorf("failed to delete averaged approvals: %w", err)
+		}
+	}
+
 	// Encrypt justification if provided
 	if override.Justification != "" {
 		data := &securestore.PlainData{
@@ -479,7 +678,7 @@ func (s *ConsolidationService) CreateOrUpdateOverride(override *models.Consolida
 			},
 		}
 
-		record, err := s.secureStore.CreateRecord(
+		record, err := s.secureStore.WithTx(tx).CreateRecord(
 			processID,
 			int64(userID),
 			"CONSOLIDATION_JUSTIFICATION",
@@ -499,7 +698,18 @@ func (s *ConsolidationService) CreateOrUpdateOverride(override *models.Consolida
 		override.CreatedByUserID = userID
 	}
 
-	return s.consolidationRepo.CreateOrUpdate(override)
+	return s.consolidationRepo.WithTx(tx).CreateOrUpdate(override, expectedVersion)
+}
+
+// overrideConflict returns a version conflict carrying the current (decrypted) override
+func (s *ConsolidationService) overrideConflict(current *models.ConsolidationOverride) error {
+	conflict := &VersionConflictError{Resource: "override"}
+	if current != nil {
+		overrides := []models.ConsolidationOverride{*current}
+		s.decryptOverrideJustifications(overrides)
+		conflict.Current = overrides[0]
+	}
+	return conflict
 }
 
 // ensureUserKey ensures a user encryption key exists
@@ -550,13 +760,28 @@ func (s *ConsolidationService) ApproveOverride(assessmentID, categoryID, userID
 		return fmt.Errorf("override not found")
 	}
 
-	// Verify user is not the override author
+	// The quorum policy decides whether the override author may approve
 	if override.CreatedByUserID == userID {
-		return fmt.Errorf("cannot approve your own override")
+		assessment, err := s.getAssessment(assessmentID)
+		if err != nil {
+			return err
+		}
+		policy, err := s.quorumSvc.GetPolicyForCatalog(assessment.CatalogID)
+		if err != nil {
+			return fmt.Errorf("failed to get quorum policy: %w", err)
+		}
+		if !policy.AllowSelfApproval {
+			return fmt.Errorf("cannot approve your own override")
+		}
 	}
 
 	// Create approval (idempotent due to ON CONFLICT DO NOTHING)
-	return s.approvalRepo.CreateApproval(override.ID, userID)
+	if err := s.approvalRepo.CreateApproval(override.ID, userID); err != nil {
+		return err
+	}
+
+	s.publish(EventOverrideApproved, assessmentID, &categoryID, userID)
+	return nil
 }
 
 // ApproveAveragedResponse approves an averaged reviewer response (when no override exists)
@@ -579,8 +804,18 @@ func (s *ConsolidationService) ApproveAveragedResponse(assessmentID, categoryID,
 		return fmt.Errorf("cannot approve averaged response when override exists - approve the override instead")
 	}
 
+	// Strong disagreement has to be discussed before the averaged result is approved
+	if err := s.checkDisagreementDiscussed(assessmentID, categoryID); err != nil {
+		return err
+	}
+
 	// Create approval (idempotent due to ON CONFLICT DO NOTHING)
-	return s.averagedApprovalRepo.CreateApproval(assessmentID, categoryID, userID)
+	if err := s.averagedApprovalRepo.CreateApproval(assessmentID, categoryID, userID); err != nil {
+		return err
+	}
+
+	s.publish(EventAveragedApproved, assessmentID, &categoryID, userID)
+	return nil
 }
 
 // DeleteOverride deletes a consolidation override (any reviewer can delete)
@@ -609,7 +844,12 @@ func (s *ConsolidationService) DeleteOverride(assessmentID, categoryID, userID u
 	}
 
 	// Delete the override
-	return s.consolidationRepo.Delete(override.ID)
+	if err := s.consolidationRepo.Delete(override.ID); err != nil {
+		return err
+	}
+
+	s.publish(EventOverrideDeleted, assessmentID, &categoryID, userID)
+	return nil
 }
 
 // RevokeOverrideApproval removes a user's approval from an override
@@ -638,7 +878,12 @@ func (s *ConsolidationService) RevokeOverrideApproval(assessmentID, categoryID,
 	}
 
 	// Delete the user's approval
-	return s.approvalRepo.DeleteApproval(override.ID, userID)
+	if err := s.approvalRepo.DeleteApproval(override.ID, userID); err != nil {
+		return err
+	}
+
+	s.publish(EventOverrideApprovalRevoked, assessmentID, &categoryID, userID)
+	return nil
 }
 
 // RevokeAveragedApproval removes a user's approval from an averaged response
@@ -658,26 +903,42 @@ func (s *ConsolidationService) RevokeAveragedApproval(assessmentID, categoryID,
 	}
 
 	// Delete the user's approval
-	return s.averagedApprovalRepo.DeleteApproval(assessmentID, categoryID, userID)
+	if err := s.averagedApprovalRepo.DeleteApproval(assessmentID, categoryID, userID); err != nil {
+		return err
+	}
+
+	s.publish(EventAveragedApprovalRevoked, assessmentID, &categoryID, userID)
+	return nil
 }
 
-// CreateOrUpdateFinalConsolidation creates or updates the final consolidation comment
-func (s *ConsolidationService) CreateOrUpdateFinalConsolidation(assessmentID uint, comment string, userID uint) error {
+// CreateOrUpdateFinalConsolidation creates or updates the final consolidation comment.
+// With expectedVersion set, a changed final consolidation yields a VersionConflictError.
+func (s *ConsolidationService) CreateOrUpdateFinalConsolidation(assessmentID uint, comment string, userID uint, expectedVersion *int) (*models.FinalConsolidation, error) {
 	// Check if editing is allowed
 	if err := s.checkEditingAllowed(assessmentID); err != nil {
-		return err
+		return nil, err
+	}
+
+	// Check if final consolidation already exists
+	existing, err := s.finalConsolidationRepo.GetByAssessment(assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing final consolidation: %w", err)
+	}
+	if existing != nil && !versionMatches(expectedVersion, true, existing.Version) ||
+		existing == nil && !versionMatches(expectedVersion, false, 0) {
+		return nil, s.finalConsolidationConflict(existing)
 	}
 
 	processID := fmt.Sprintf("assessment-%d", assessmentID)
 
 	// Ensure process key exists
 	if err := s.ensureProcessKey(processID); err != nil {
-		return fmt.Errorf("failed to ensure process key: %w", err)
+		return nil, fmt.Errorf("failed to ensure process key: %w", err)
 	}
 
 	// Ensure user key exists
 	if err := s.ensureUserKey(int64(userID)); err != nil {
-		return fmt.Errorf("failed to ensure user key: %w", err)
+		return nil, fmt.Errorf("failed to ensure user key: %w", err)
 	}
 
 	// Encrypt the comment
@@ -687,34 +948,59 @@ func (s *ConsolidationService) CreateOrUpdateFinalConsolidation(assessmentID uin
 		},
 	}
 
-	encryptedRecord, err := s.secureStore.CreateRecord(processID, int64(userID), "final_consolidation", plainData, "active")
-	if err != nil {
-		return fmt.Errorf("failed to encrypt comment: %w", err)
+	// The encrypted comment, the approval reset and the version-checked write are kept only
+	// together, so a save that loses a concurrent edit leaves no trace
+	finalConsolidation := &models.FinalConsolidation{
+		AssessmentID:    assessmentID,
+		CreatedByUserID: userID,
 	}
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		encryptedRecord, err := s.secureStore.WithTx(tx).CreateRecord(processID, int64(userID), "final_consolidation", plainData, "active")
+		if err != nil {
+			return fmt.Errorf("failed to encrypt comment: %w", err)
+		}
+		finalConsolidation.EncryptedCommentID = &encryptedRecord.ID
 
-	encryptedID := encryptedRecord.ID
+		// If it exists and comment changed, delete all approvals
+		if existing != nil {
+			if err := s.finalApprovalRepo.WithTx(tx).DeleteAllApprovalsForAssessment(assessmentID); err != nil {
+				return fmt.Errorf("failed to delete approvals: %w", err)
+			}
+		}
 
-	// Check if final consolidation already exists
-	existing, err := s.finalConsolidationRepo.GetByAssessment(assessmentID)
+		// Create or update final consolidation
+		return s.finalConsolidationRepo.WithTx(tx).CreateOrUpdate(finalConsolidation, expectedVersion)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to check existing final consolidation: %w", err)
-	}
-
-	// If it exists and comment changed, delete all approvals
-	if existing != nil {
-		if err := s.finalApprovalRepo.DeleteAllApprovalsForAssessment(assessmentID); err != nil {
-			return fmt.Errorf("failed to delete approvals: %w", err)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			current, err := s.finalConsolidationRepo.GetByAssessment(assessmentID)
+			if err != nil {
+				return nil, err
+			}
+			return nil, s.finalConsolidationConflict(current)
 		}
+		return nil, err
 	}
 
-	// Create or update final consolidation
-	finalConsolidation := &models.FinalConsolidation{
-		AssessmentID:       assessmentID,
-		EncryptedCommentID: &encryptedID,
-		CreatedByUserID:    userID,
-	}
+	s.publish(EventFinalSaved, assessmentID, nil, userID)
+	return finalConsolidation, nil
+}
 
-	return s.finalConsolidationRepo.CreateOrUpdate(finalConsolidation)
+// finalConsolidationConflict returns a version conflict carrying the current (decrypted) final consolidation
+func (s *ConsolidationService) finalConsolidationConflict(current *models.FinalConsolidation) error {
+	conflict := &VersionConflictError{Resource: "final consolidation"}
+	if current != nil {
+		if current.EncryptedCommentID != nil {
+			comment, err := s.decryptField(*current.EncryptedCommentID, "comment")
+			if err != nil {
+				slog.Error("Failed to decrypt final consolidation comment", "error", err)
+			} else {
+				current.Comment = comment
+			}
+		}
+		conflict.Current = current
+	}
+	return conflict
 }
 
 // ApproveFinalConsolidation approves the final consolidation
@@ -742,10 +1028,36 @@ func (s *ConsolidationService) ApproveFinalConsolidation(assessmentID, userID ui
 		return fmt.Errorf("final consolidation not found")
 	}
 
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return err
+	}
+	policy, panelSize, err := s.quorumFor(assessment)
+	if err != nil {
+		return err
+	}
+
+	// All categories need their quorum before the final consolidation can be approved
+	catalog, err := s.catalogRepo.GetCatalogWithDetails(assessment.CatalogID)
+	if err != nil {
+		return fmt.Errorf("failed to get catalog: %w", err)
+	}
+	if catalog == nil {
+		return fmt.Errorf("catalog not found")
+	}
+	averagedResponses, overrides, err := s.loadCategoryApprovals(assessmentID, catalog, policy, panelSize)
+	if err != nil {
+		return err
+	}
+	if !s.areAllCategoriesApproved(catalog.Categories, averagedResponses, overrides) {
+		return fmt.Errorf("all categories must be approved before approving the final consolidation")
+	}
+
 	// Create the approval
 	if err := s.finalApprovalRepo.CreateApproval(assessmentID, userID); err != nil {
 		return fmt.Errorf("failed to create approval: %w", err)
 	}
+	s.publish(EventFinalApproved, assessmentID, nil, userID)
 
 	// Check if all required reviewers have approved
 	approvalCount, err := s.finalApprovalRepo.GetApprovalCount(assessmentID)
@@ -753,41 +1065,31 @@ func (s *ConsolidationService) ApproveFinalConsolidation(assessmentID, userID ui
 		return fmt.Errorf("failed to get approval count: %w", err)
 	}
 
-	// Get required approval count (number of reviewers who completed review)
-	reviewers, err := s.reviewerRepo.GetCompleteReviewers(assessmentID)
-	if err != nil {
-		return fmt.Errorf("failed to get reviewers: %w", err)
-	}
-
-	requiredApprovals := len(reviewers)
-
-	// If all reviewers approved, change status to 'reviewed'
-	if approvalCount >= requiredApprovals {
-		assessment, err := s.getAssessment(assessmentID)
-		if err != nil {
-			return err
-		}
-
+	// Once the final quorum is reached, change status to 'reviewed'
+	if approvalCount >= requiredFinalQuorum(policy, panelSize) {
 		// Get assessment details with user info for email
 		assessmentDetails, err := s.assessmentRepo.GetByIDWithDetails(assessmentID)
 		if err != nil {
 			slog.Error("Failed to get assessment details for email notification", "error", err)
 		}
 
-		// Get catalog info for email
-		catalog, err := s.catalogRepo.GetCatalogByID(assessment.CatalogID)
-		if err != nil {
-			slog.Error("Failed to get catalog for email notification", "error", err)
-		}
-
 		// Update status to 'reviewed'
 		assessment.Status = "reviewed"
 		if err := s.assessmentRepo.Update(assessment); err != nil {
 			return fmt.Errorf("failed to update assessment status: %w", err)
 		}
+		s.workflowSvc.OnEnter(assessment)
+		if s.events != nil {
+			s.events.Publish(models.ConsolidationEvent{
+				Type:         EventStatusChanged,
+				AssessmentID: assessmentID,
+				UserID:       userID,
+				Status:       assessment.Status,
+			})
+		}
 
 		// Send notification email to the assessed user
-		if assessmentDetails != nil && catalog != nil {
+		if assessmentDetails != nil {
 			if err := s.emailService.SendReviewCompletedNotification(assessmentDetails.UserEmail, assessmentDetails.UserName, catalog.Name, assessmentID); err != nil {
 				slog.Error("Failed to send review completion email", "error", err, "assessment_id", assessmentID, "user_email", assessmentDetails.UserEmail)
 				// Don't fail the approval if email sending fails
@@ -828,7 +1130,12 @@ func (s *ConsolidationService) RevokeFinalApproval(assessmentID, userID uint) er
 	}
 
 	// Delete the user's approval
-	return s.finalApprovalRepo.DeleteApproval(assessmentID, userID)
+	if err := s.finalApprovalRepo.DeleteApproval(assessmentID, userID); err != nil {
+		return err
+	}
+
+	s.publish(EventFinalApprovalRevoked, assessmentID, nil, userID)
+	return nil
 }
 
 // SaveCategoryDiscussionComment saves or updates a category-specific discussion comment
@@ -891,17 +1198,24 @@ func (s *ConsolidationService) saveCategoryDiscussionCommentInternal(assessmentI
 	if existing != nil {
 		// Update existing comment
 		existing.EncryptedCommentID = &record.ID
-		return s.categoryDiscussionRepo.Update(existing)
+		if err := s.categoryDiscussionRepo.Update(existing); err != nil {
+			return err
+		}
+	} else {
+		// Create new comment
+		newComment := &models.CategoryDiscussionComment{
+			AssessmentID:       assessmentID,
+			CategoryID:         categoryID,
+			EncryptedCommentID: &record.ID,
+			CreatedByUserID:    userID,
+		}
+		if err := s.categoryDiscussionRepo.Create(newComment); err != nil {
+			return err
+		}
 	}
 
-	// Create new comment
-	newComment := &models.CategoryDiscussionComment{
-		AssessmentID:       assessmentID,
-		CategoryID:         categoryID,
-		EncryptedCommentID: &record.ID,
-		CreatedByUserID:    userID,
-	}
-	return s.categoryDiscussionRepo.Create(newComment)
+	s.publish(EventCategoryCommentSaved, assessmentID, &categoryID, userID)
+	return nil
 }
 
 // GenerateConsolidationProposals generates proposals for category discussion comments using LLM
@@ -966,7 +1280,7 @@ func (s *ConsolidationService) GenerateConsolidationProposals(assessmentID uint)
 		// Save as category discussion comment
 		if creatorID != 0 {
 			// Prepend "Vorschlag (KI): " to indicate it's AI generated
-			summary = "Vorschlag (KI): " + summary
+			summary = aiProposalPrefix + summary
 			if err := s.saveCategoryDiscussionCommentInternal(assessmentID, category.ID, creatorID, summary); err != nil {
 				slog.Error("Failed to save generated proposal", "error", err, "category_id", category.ID)
 			} else {
@@ -977,6 +1291,7 @@ func (s *ConsolidationService) GenerateConsolidationProposals(assessmentID uint)
 		}
 	}
 
+	s.publish(EventProposalsGenerated, assessmentID, nil, 0)
 	return nil
 }
 
@@ -1027,7 +1342,7 @@ func (s *ConsolidationService) GenerateFinalConsolidationProposal(assessmentID u
 	summary = "Vorschlag (KI): " + summary
 
 	// Save as final consolidation
-	if err := s.CreateOrUpdateFinalConsolidation(assessmentID, summary, userID); err != nil {
+	if _, err := s.CreateOrUpdateFinalConsolidation(assessmentID, summary, userID, nil); err != nil {
 		return fmt.Errorf("failed to save final consolidation: %w", err)
 	}
 
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"new-pay/internal/keymanager"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+	"new-pay/internal/securestore"
+)
+
+const (
+	dataErasureScopeUser       = "user"
+	dataErasureScopeAssessment = "assessment"
+)
+
+// DataErasureService erases a user's assessment data by crypto-shredding: the process keys of
+// the assessments (and the user key where possible) are destroyed, so the encrypted records become
+// permanently unreadable while their signatures and hash chains stay verifiable. Every erasure is
+// recorded as a hash-chained tombstone.
+type DataErasureService struct {
+	erasureRepo        *repository.DataErasureRepository
+	legalHoldRepo      *repository.LegalHoldRepository
+	selfAssessmentRepo *repository.SelfAssessmentRepository
+	userRepo           *repository.UserRepository
+	keyManager         *keymanager.KeyManager
+	secureStore        *securestore.SecureStore
+	auditSvc           *AuditService
+}
+
+// NewDataErasureService creates a new data erasure service
+func NewDataErasureService(
+	erasureRepo *repository.DataErasureRepository,
+	legalHoldRepo *repository.LegalHoldRepository,
+	selfAssessmentRepo *repository.SelfAssessmentRepository,
+	userRepo *repository.UserRepository,
+	keyManager *keymanager.KeyManager,
+	secureStore *securestore.SecureStore,
+	auditSvc *AuditService,
+) *DataErasureService {
+	return &DataErasureService{
+		erasureRepo:        erasureRepo,
+		legalHoldRepo:      legalHoldRepo,
+		selfAssessmentRepo: selfAssessmentRepo,
+		userRepo:           userRepo,
+		keyManager:         keyManager,
+		secureStore:        secureStore,
+		auditSvc:           auditSvc,
+	}
+}
+
+// SetLegalHold places a user under legal hold, which blocks the erasure of their data
+func (s *DataErasureService) SetLegalHold(userID uint, reason string, actorID uint) (*models.LegalHold, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	hold := &models.LegalHold{UserID: userID, Reason: reason, CreatedBy: &actorID}
+	if err := s.legalHoldRepo.Set(hold); err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Log(actorID, "set_legal_hold", "legal_hold", fmt.Sprintf("Placed user %d under legal hold: %s", userID, reason))
+	return hold, nil
+}
+
+// ReleaseLegalHold removes the legal hold of a user
+func (s *DataErasureService) ReleaseLegalHold(userID uint, actorID uint) error {
+	if err := s.legalHoldRepo.Release(userID); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(actorID, "release_legal_hold", "legal_hold", fmt.Sprintf("Released legal hold of user %d", userID))
+	return nil
+}
+
+// ListLegalHolds lists all legal holds
+func (s *DataErasureService) ListLegalHolds() ([]models.LegalHold, error) {
+	return s.legalHoldRepo.List()
+}
+
+// EraseAssessment destroys the process key of a self-assessment. The user key is kept,
+// because the owner's other data depends on it.
+func (s *DataErasureService) EraseAssessment(assessmentID uint, reason string, actorID uint) (*models.DataErasure, error) {
+	assessment, err := s.selfAssessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("self-assessment not found")
+	}
+
+	erased, err := s.erasureRepo.IsProcessErased(assessmentProcessID(assessmentID))
+	if err != nil {
+		return nil, err
+	}
+	if erased {
+		return nil, fmt.Errorf("self-assessment %d has already been erased", assessmentID)
+	}
+
+	return s.erase(assessment.UserID, dataErasureScopeAssessment, []models.SelfAssessment{*assessment}, reason, actorID)
+}
+
+// EraseUser destroys the process keys of all self-assessments and of the salary data of a user and
+// the user key, unless the user key still protects records in processes that are not erased.
+func (s *DataErasureService) EraseUser(userID uint, reason string, actorID uint) (*models.DataErasure, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	assessments, err := s.selfAssessmentRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load self-assessments: %w", err)
+	}
+
+	return s.erase(userID, dataErasureScopeUser, assessments, reason, actorID)
+}
+
+// erase destroys the keys and records the tombstone. Key destruction is idempotent: if an
+// erasure fails halfway, repeating it destroys the remaining keys and records the tombstone.
+func (s *DataErasureService) erase(ownerID uint, scope string, assessments []models.SelfAssessment, reason string, actorID uint) (*models.DataErasure, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	hold, err := s.legalHoldRepo.Get(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if hold != nil {
+		return nil, fmt.Errorf("erasure blocked: user %d is under legal hold (%s)", ownerID, hold.Reason)
+	}
+
+	for _, assessment := range assessments {
+		if err := validateErasableAssessment(assessment); err != nil {
+			return nil, err
+		}
+	}
+
+	erasure := &models.DataErasure{
+		UserID:    ownerID,
+		Scope:     scope,
+		Reason:    reason,
+		ErasedBy:  &actorID,
+		ErasedAt:  time.Now().UTC(),
+		Processes: []models.DataErasureProcess{},
+	}
+
+	for _, assessment := range assessments {
+		processID := assessmentProcessID(assessment.ID)
+		process, err := s.destroyProcess(processID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to erase self-assessment %d: %w", assessment.ID, err)
+		}
+		if process != nil {
+			erasure.Processes = append(erasure.Processes, *process)
+		}
+	}
+
+	// Salary data and pay recommendations of the employee are stored under the employee's user
+	if scope == dataErasureScopeUser {
+		process, err := s.destroyProcess(salaryProcessID(ownerID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to erase salary data: %w", err)
+		}
+		if process != nil {
+			erasure.Processes = append(erasure.Processes, *process)
+		}
+	}
+
+	_, userKeyErr := s.keyManager.GetUserPublicKey(int64(ownerID))
+	readable := 0
+	if scope == dataErasureScopeUser && userKeyErr == nil {
+		readable, err = s.secureStore.CountReadableRecordsByUser(int64(ownerID))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if retained := userKeyRetention(scope, userKeyErr == nil, readable); retained != "" {
+		erasure.UserKeyRetainedReason = &retained
+	} else {
+		if err := s.keyManager.DestroyUserKey(int64(ownerID)); err != nil {
+			return nil, err
+		}
+		erasure.UserKeyDestroyed = true
+	}
+
+	if len(erasure.Processes) == 0 && !erasure.UserKeyDestroyed {
+		return nil, fmt.Errorf("no encrypted data left to erase for user %d", ownerID)
+	}
+
+	if err := s.erasureRepo.Create(erasure, func(prevHash string) string {
+		return dataErasureHash(prevHash, erasure)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Log(actorID, "erase_data", "data_erasure",
+		fmt.Sprintf("Erased %d processes of user %d (scope %s, user key destroyed: %t), tombstone %d: %s",
+			len(erasure.Processes), ownerID, scope, erasure.UserKeyDestroyed, erasure.ID, reason))
+
+	return erasure, nil
+}
+
+// destroyProcess verifies the hash chain of a process and destroys its key.
+// It returns nil if the process has no key (no encrypted data) or was erased before.
+func (s *DataErasureService) destroyProcess(processID string) (*models.DataErasureProcess, error) {
+	erased, err := s.erasureRepo.IsProcessErased(processID)
+	if err != nil {
+		return nil, err
+	}
+	if erased {
+		return nil, nil
+	}
+
+	head, count, err := s.secureStore.GetChainHead(processID)
+	if err != nil {
+		return nil, err
+	}
+	valid, _, err := s.secureStore.VerifyChain(processID)
+	if err != nil {
+		return nil, err
+	}
+
+	keyHashes, err := s.keyManager.DestroyProcessKey(processID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if keyHashes == nil {
+		keyHashes = []string{}
+	}
+
+	return &models.DataErasureProcess{
+		ProcessID:   processID,
+		RecordCount: count,
+		ChainHead:   head,
+		ChainValid:  valid,
+		KeyHashes:   keyHashes,
+	}, nil
+}
+
+// GetErasure retrieves a tombstone
+func (s *DataErasureService) GetErasure(id uint) (*models.DataErasure, error) {
+	return s.erasureRepo.GetByID(id)
+}
+
+// ListErasures lists tombstones, optionally filtered by user (0 = all)
+func (s *DataErasureService) ListErasures(userID uint) ([]models.DataErasure, error) {
+	return s.erasureRepo.List(userID)
+}
+
+// VerifyErasures verifies the tombstone chain and that the erased processes are still shredded:
+// their keys are destroyed and their hash chains are unchanged and intact.
+func (s *DataErasureService) VerifyErasures() (*models.DataErasureVerification, error) {
+	erasures, err := s.erasureRepo.ListChain()
+	if err != nil {
+		return nil, err
+	}
+
+	errs := verifyDataErasureChain(erasures)
+
+	processes := map[string]models.DataErasureProcess{}
+	for _, erasure := range erasures {
+		for _, process := range erasure.Processes {
+			processes[process.ProcessID] = process
+		}
+	}
+	processIDs := make([]string, 0, len(processes))
+	for processID := range processes {
+		processIDs = append(processIDs, processID)
+	}
+	sort.Strings(processIDs)
+
+	for _, processID := range processIDs {
+		process := processes[processID]
+
+		destroyed, err := s.keyManager.IsProcessKeyDestroyed(processID)
+		if err != nil {
+			return nil, err
+		}
+		if !destroyed {
+			errs = append(errs, fmt.Sprintf("process %s: key material exists again", processID))
+		}
+
+		head, count, err := s.secureStore.GetChainHead(processID)
+		if err != nil {
+			return nil, err
+		}
+		if head != process.ChainHead || count != process.RecordCount {
+			errs = append(errs, fmt.Sprintf("process %s: hash chain changed since the erasure", processID))
+		}
+
+		valid, _, err := s.secureStore.VerifyChain(processID)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			errs = append(errs, fmt.Sprintf("process %s: hash chain verification failed", processID))
+		}
+	}
+
+	return &models.DataErasureVerification{
+		Valid:      len(errs) == 0,
+		Tombstones: len(erasures),
+		Errors:     errs,
+	}, nil
+}
+
+// assessmentProcessID returns the secure store process of a self-assessment
+func assessmentProcessID(assessmentID uint) string {
+	return fmt.Sprintf("assessment-%d", assessmentID)
+}
+
+// validateErasableAssessment only allows the erasure of finished self-assessments,
+// running workflows would fail on the unreadable records
+func validateErasableAssessment(assessment models.SelfAssessment) error {
+	if assessment.Status != "closed" && assessment.Status != "archived" {
+		return fmt.Errorf("self-assessment %d must be closed or archived before erasure (status: %s)", assessment.ID, assessment.Status)
+	}
+	return nil
+}
+
+// userKeyRetention decides whether the owner's key may be destroyed and returns the reason if not.
+// The data encryption keys of all records of a user are derived from the user key.
+func userKeyRetention(scope string, hasUserKey bool, readableRecords int) string {
+	switch {
+	case scope != dataErasureScopeUser:
+		return "the user key is only destroyed when all data of the user is erased"
+	case !hasUserKey:
+		return "the user has no active key"
+	case readableRecords > 0:
+		return fmt.Sprintf("the user key still protects %d records in processes that are not erased", readableRecords)
+	}
+	return ""
+}
+
+// dataErasureHash calculates the tombstone hash over the previous hash and all fields of an
+// erasure. The fields are encoded as JSON, the processes sorted by process ID.
+func dataErasureHash(prevHash string, erasure *models.DataErasure) string {
+	processes := append([]models.DataErasureProcess(nil), erasure.Processes...)
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ProcessID < processes[j].ProcessID
+	})
+
+	retained := ""
+	if erasure.UserKeyRetainedReason != nil {
+		retained = *erasure.UserKeyRetainedReason
+	}
+	var erasedBy uint
+	if erasure.ErasedBy != nil {
+		erasedBy = *erasure.ErasedBy
+	}
+
+	canonical, _ := json.Marshal(struct {
+		PrevHash              string                      `json:"prev_hash"`
+		UserID                uint                        `json:"user_id"`
+		Scope                 string                      `json:"scope"`
+		Reason                string                      `json:"reason"`
+		UserKeyDestroyed      bool                        `json:"user_key_destroyed"`
+		UserKeyRetainedReason string                      `json:"user_key_retained_reason"`
+		ErasedBy              uint                        `json:"erased_by"`
+		ErasedAt              int64                       `json:"erased_at"`
+		Processes             []models.DataErasureProcess `json:"processes"`
+	}{
+		PrevHash:              prevHash,
+		UserID:                erasure.UserID,
+		Scope:                 erasure.Scope,
+		Reason:                erasure.Reason,
+		UserKeyDestroyed:      erasure.UserKeyDestroyed,
+		UserKeyRetainedReason: retained,
+		ErasedBy:              erasedBy,
+		ErasedAt:              erasure.ErasedAt.Unix(),
+		Processes:             processes,
+	})
+
+	hash := sha256.Sum256(canonical)
+	return hex.EncodeToString(hash[:])
+}
+
+// verifyDataErasureChain checks the links and hashes of tombstones in chain order
+func verifyDataErasureChain(erasures []models.DataErasure) []string {
+	var errs []string
+	prevHash := repository.DataErasureGenesisHash
+	for i := range erasures {
+		erasure := &erasures[i]
+		if erasure.PrevHash != prevHash {
+			errs = append(errs, fmt.Sprintf("tombstone %d: chain broken, expected prev_hash=%s, got=%s", erasure.ID, prevHash, erasure.PrevHash))
+		}
+		if dataErasureHash(erasure.PrevHash, erasure) != erasure.TombstoneHash {
+			errs = append(errs, fmt.Sprintf("tombstone %d: hash mismatch, tombstone was modified", erasure.ID))
+		}
+		prevHash = erasure.TombstoneHash
+	}
+	return errs
+}
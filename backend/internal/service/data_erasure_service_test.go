@@ -0,0 +1,178 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"new-pay/internal/keymanager"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+	"new-pay/internal/securestore"
+	"new-pay/internal/testutil"
+)
+
+func TestValidateErasableAssessment(t *testing.T) {
+	for _, status := range []string{"closed", "archived"} {
+		if err := validateErasableAssessment(models.SelfAssessment{ID: 1, Status: status}); err != nil {
+			t.Errorf("status %s: unexpected error: %v", status, err)
+		}
+	}
+	for _, status := range []string{"draft", "submitted", "in_review", "discussion", "appeal"} {
+		if err := validateErasableAssessment(models.SelfAssessment{ID: 1, Status: status}); err == nil {
+			t.Errorf("status %s: expected error", status)
+		}
+	}
+}
+
+func TestUserKeyRetention(t *testing.T) {
+	tests := []struct {
+		name       string
+		scope      string
+		hasUserKey bool
+		readable   int
+		destroy    bool
+	}{
+		{name: "user erasure without other records", scope: dataErasureScopeUser, hasUserKey: true, destroy: true},
+		{name: "user erasure with other records", scope: dataErasureScopeUser, hasUserKey: true, readable: 3},
+		{name: "user without key", scope: dataErasureScopeUser},
+		{name: "assessment erasure", scope: dataErasureScopeAssessment, hasUserKey: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := userKeyRetention(tt.scope, tt.hasUserKey, tt.readable)
+			if (reason == "") != tt.destroy {
+				t.Errorf("userKeyRetention() = %q, destroy = %t", reason, tt.destroy)
+			}
+		})
+	}
+}
+
+func testErasure(reason string) models.DataErasure {
+	erasedBy := uint(1)
+	return models.DataErasure{
+		UserID:   42,
+		Scope:    dataErasureScopeUser,
+		Reason:   reason,
+		ErasedBy: &erasedBy,
+		ErasedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Processes: []models.DataErasureProcess{
+			{ProcessID: "assessment-7", RecordCount: 3, ChainHead: "aa", ChainValid: true, KeyHashes: []string{"v1:11"}},
+			{ProcessID: "assessment-12", RecordCount: 5, ChainHead: "bb", ChainValid: true, KeyHashes: []string{"v1:22", "v2:33"}},
+		},
+		UserKeyDestroyed: true,
+	}
+}
+
+func TestDataErasureHash(t *testing.T) {
+	erasure := testErasure("GDPR request")
+	hash := dataErasureHash(repository.DataErasureGenesisHash, &erasure)
+
+	reordered := testErasure("GDPR request")
+	reordered.Processes[0], reordered.Processes[1] = reordered.Processes[1], reordered.Processes[0]
+	if got := dataErasureHash(repository.DataErasureGenesisHash, &reordered); got != hash {
+		t.Error("hash should not depend on the order of the processes")
+	}
+
+	changed := testErasure("GDPR request")
+	changed.Processes[1].KeyHashes = []string{"v1:22"}
+	if got := dataErasureHash(repository.DataErasureGenesisHash, &changed); got == hash {
+		t.Error("hash should change when a key hash changes")
+	}
+
+	if got := dataErasureHash("ff", &erasure); got == hash {
+		t.Error("hash should change with the previous hash")
+	}
+}
+
+func TestVerifyDataErasureChain(t *testing.T) {
+	first := testErasure("first")
+	first.ID = 1
+	first.PrevHash = repository.DataErasureGenesisHash
+	first.TombstoneHash = dataErasureHash(first.PrevHash, &first)
+
+	second := testErasure("second")
+	second.ID = 2
+	second.PrevHash = first.TombstoneHash
+	second.TombstoneHash = dataErasureHash(second.PrevHash, &second)
+
+	if errs := verifyDataErasureChain([]models.DataErasure{first, second}); len(errs) != 0 {
+		t.Errorf("valid chain reported errors: %v", errs)
+	}
+
+	tampered := second
+	tampered.Reason = "changed"
+	if errs := verifyDataErasureChain([]models.DataErasure{first, tampered}); len(errs) != 1 {
+		t.Errorf("modified tombstone: got %d errors, want 1", len(errs))
+	}
+
+	if errs := verifyDataErasureChain([]models.DataErasure{second}); len(errs) != 1 {
+		t.Errorf("removed tombstone: got %d errors, want 1", len(errs))
+	}
+}
+
+// TestEraseUserWithSalaryData verifies that a user erasure also shreds the salary process of the employee
+func TestEraseUserWithSalaryData(t *testing.T) {
+	containers := testutil.SetupTestContainers(t)
+	defer containers.Cleanup(t)
+
+	db := containers.DB
+	fixtures := testutil.SetupFixtures(t, db)
+	userID := fixtures.RegularUser.ID
+
+	keyManager, err := keymanager.NewKeyManager(db, keymanager.NewMemoryProvider())
+	if err != nil {
+		t.Fatalf("Failed to create key manager: %v", err)
+	}
+	secureStore := securestore.NewSecureStore(db, keyManager)
+
+	processID := salaryProcessID(userID)
+	if _, err := keyManager.CreateUserKey(int64(userID)); err != nil {
+		t.Fatalf("Failed to create user key: %v", err)
+	}
+	if err := keyManager.CreateProcessKey(processID, nil); err != nil {
+		t.Fatalf("Failed to create salary process key: %v", err)
+	}
+	for _, recordType := range []string{"SALARY", "PAY_RECOMMENDATION"} {
+		data := &securestore.PlainData{Fields: map[string]interface{}{"amount": 65000}}
+		if _, err := secureStore.CreateRecord(processID, int64(userID), recordType, data, ""); err != nil {
+			t.Fatalf("Failed to create %s record: %v", recordType, err)
+		}
+	}
+
+	erasureSvc := NewDataErasureService(
+		repository.NewDataErasureRepository(db),
+		repository.NewLegalHoldRepository(db),
+		repository.NewSelfAssessmentRepository(db),
+		repository.NewUserRepository(db),
+		keyManager,
+		secureStore,
+		NewAuditService(repository.NewAuditRepository(db)),
+	)
+
+	erasure, err := erasureSvc.EraseUser(userID, "GDPR request", fixtures.AdminUser.ID)
+	if err != nil {
+		t.Fatalf("EraseUser failed: %v", err)
+	}
+
+	if len(erasure.Processes) != 1 || erasure.Processes[0].ProcessID != processID || erasure.Processes[0].RecordCount != 2 {
+		t.Errorf("erased processes = %+v, want %s with 2 records", erasure.Processes, processID)
+	}
+	if !erasure.UserKeyDestroyed {
+		t.Errorf("user key retained: %v", erasure.UserKeyRetainedReason)
+	}
+	destroyed, err := keyManager.IsProcessKeyDestroyed(processID)
+	if err != nil {
+		t.Fatalf("IsProcessKeyDestroyed failed: %v", err)
+	}
+	if !destroyed {
+		t.Error("salary process key still exists")
+	}
+	readable, err := secureStore.CountReadableRecordsByUser(int64(userID))
+	if err != nil {
+		t.Fatalf("CountReadableRecordsByUser failed: %v", err)
+	}
+	if readable != 0 {
+		t.Errorf("%d records of the user are still readable", readable)
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"math"
+	"strings"
+
+	"new-pay/internal/models"
+)
+
+// aiProposalPrefix marks category discussion comments generated by the LLM
+const aiProposalPrefix = "Vorschlag (KI): "
+
+// dispersion describes how far the levels chosen by the reviewers of a category are apart
+type dispersion struct {
+	min    int
+	max    int
+	stdDev float64 // Population standard deviation
+}
+
+// levelDispersion computes the dispersion of reviewer level numbers
+func levelDispersion(levelNumbers []float64) dispersion {
+	if len(levelNumbers) == 0 {
+		return dispersion{}
+	}
+
+	minLevel, maxLevel := levelNumbers[0], levelNumbers[0]
+	var sum float64
+	for _, n := range levelNumbers {
+		minLevel = math.Min(minLevel, n)
+		maxLevel = math.Max(maxLevel, n)
+		sum += n
+	}
+
+	mean := sum / float64(len(levelNumbers))
+	var squares float64
+	for _, n := range levelNumbers {
+		squares += (n - mean) * (n - mean)
+	}
+
+	return dispersion{
+		min:    int(minLevel),
+		max:    int(maxLevel),
+		stdDev: math.Sqrt(squares / float64(len(levelNumbers))),
+	}
+}
+
+// needsDiscussion reports whether reviewers disagree on a category by more than the threshold (level spread)
+func needsDiscussion(averaged *models.AveragedReviewerResponse, threshold int) bool {
+	return averaged.LevelSpread > threshold
+}
+
+// isDiscussionRecorded reports whether a category discussion comment was written by a reviewer;
+// unedited LLM proposals do not count
+func isDiscussionRecorded(comment *models.CategoryDiscussionComment) bool {
+	if comment == nil {
+		return false
+	}
+	text := strings.TrimSpace(comment.Comment)
+	return text != "" && !strings.HasPrefix(text, aiProposalPrefix)
+}
+
+// markDisagreements flags averaged responses that need a discussion before they can be approved
+func markDisagreements(averaged []models.AveragedReviewerResponse, comments []models.CategoryDiscussionComment, threshold int) {
+	commentsByCategory := make(map[uint]*models.CategoryDiscussionComment, len(comments))
+	for i := range comments {
+		commentsByCategory[comments[i].CategoryID] = &comments[i]
+	}
+
+	for i := range averaged {
+		averaged[i].NeedsDiscussion = needsDiscussion(&averaged[i], threshold)
+		averaged[i].DiscussionRecorded = isDiscussionRecorded(commentsByCategory[averaged[i].CategoryID])
+	}
+}
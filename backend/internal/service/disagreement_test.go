@@ -0,0 +1,90 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"new-pay/internal/models"
+)
+
+func TestLevelDispersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		levels     []float64
+		wantMin    int
+		wantMax    int
+		wantStdDev float64
+	}{
+		{name: "empty", levels: nil},
+		{name: "unanimous", levels: []float64{2, 2, 2}, wantMin: 2, wantMax: 2},
+		{name: "adjacent levels", levels: []float64{2, 3}, wantMin: 2, wantMax: 3, wantStdDev: 0.5},
+		{name: "A and D", levels: []float64{1, 4}, wantMin: 1, wantMax: 4, wantStdDev: 1.5},
+		{name: "outlier", levels: []float64{3, 3, 3, 1}, wantMin: 1, wantMax: 3, wantStdDev: math.Sqrt(0.75)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := levelDispersion(tt.levels)
+			if got.min != tt.wantMin || got.max != tt.wantMax {
+				t.Errorf("min/max = %d/%d, want %d/%d", got.min, got.max, tt.wantMin, tt.wantMax)
+			}
+			if math.Abs(got.stdDev-tt.wantStdDev) > 1e-9 {
+				t.Errorf("stdDev = %v, want %v", got.stdDev, tt.wantStdDev)
+			}
+		})
+	}
+}
+
+func TestCalculateAveragedResponsesDispersion(t *testing.T) {
+	catalog := &models.CatalogWithDetails{
+		Categories: []models.CategoryWithPaths{
+			{Category: models.Category{ID: 1}, Paths: []models.PathWithDescriptions{{Path: models.Path{ID: 10, CategoryID: 1}}}},
+		},
+		Levels: []models.Level{{ID: 1, LevelNumber: 1}, {ID: 2, LevelNumber: 2}, {ID: 3, LevelNumber: 3}, {ID: 4, LevelNumber: 4}},
+	}
+	responses := []models.ReviewerResponse{
+		{ReviewerUserID: 1, CategoryID: 1, PathID: 10, LevelID: 1},
+		{ReviewerUserID: 2, CategoryID: 1, PathID: 10, LevelID: 4},
+	}
+
+	averaged := calculateAveragedResponses(responses, catalog, false)
+	if len(averaged) != 1 {
+		t.Fatalf("got %d averaged responses, want 1", len(averaged))
+	}
+	got := averaged[0]
+	if got.MinLevelNumber != 1 || got.MaxLevelNumber != 4 || got.LevelSpread != 3 || got.StdDeviation != 1.5 {
+		t.Errorf("dispersion = min %d, max %d, spread %d, stddev %v; want 1, 4, 3, 1.5",
+			got.MinLevelNumber, got.MaxLevelNumber, got.LevelSpread, got.StdDeviation)
+	}
+}
+
+func TestMarkDisagreements(t *testing.T) {
+	averaged := []models.AveragedReviewerResponse{
+		{CategoryID: 1, LevelSpread: 0},
+		{CategoryID: 2, LevelSpread: 1},
+		{CategoryID: 3, LevelSpread: 3},
+		{CategoryID: 4, LevelSpread: 2},
+		{CategoryID: 5, LevelSpread: 2},
+	}
+	comments := []models.CategoryDiscussionComment{
+		{CategoryID: 3, Comment: "Level B nach Rücksprache im Panel"},
+		{CategoryID: 4, Comment: aiProposalPrefix + "Die Reviewer sind sich uneinig."},
+		{CategoryID: 5, Comment: "   "},
+	}
+
+	markDisagreements(averaged, comments, 1)
+
+	want := []struct{ needsDiscussion, recorded bool }{
+		{false, false},
+		{false, false},
+		{true, true},
+		{true, false}, // Unedited LLM proposal
+		{true, false}, // Blank comment
+	}
+	for i, w := range want {
+		if averaged[i].NeedsDiscussion != w.needsDiscussion || averaged[i].DiscussionRecorded != w.recorded {
+			t.Errorf("category %d: needs_discussion=%v discussion_recorded=%v, want %v/%v",
+				averaged[i].CategoryID, averaged[i].NeedsDiscussion, averaged[i].DiscussionRecorded, w.needsDiscussion, w.recorded)
+		}
+	}
+}
@@ -1,9 +1,10 @@
 package service
 
 import (
+	"database/sql"
 	"fmt"
 	"log/slog"
-	"math"
+	"strings"
 
 	"new-pay/internal/models"
 	"new-pay/internal/repository"
@@ -96,6 +97,67 @@ func (s *DiscussionService) populateLevelNames(categoryResults []models.Discussi
 	}
 }
 
+// categoryScore is the reviewer result of a category in the discussion result
+type categoryScore struct {
+	levelID     uint
+	levelNumber float64
+	pathWeight  float64 // Weight of the selected path (mean path weight of the reviewers for averaged results)
+	isOverride  bool
+}
+
+// resolveCategoryScore determines the reviewer result of a category; an override takes precedence
+// over the averaged reviewer responses
+func resolveCategoryScore(catalog *models.CatalogWithDetails, strategy ScoringStrategy, categoryID uint, overrides []models.ConsolidationOverride, averagedResponses []models.AveragedReviewerResponse) categoryScore {
+	score := categoryScore{pathWeight: 1}
+
+	for i := range overrides {
+		if overrides[i].CategoryID != categoryID {
+			continue
+		}
+		score.levelID = overrides[i].LevelID
+		score.pathWeight = pathWeight(findPathByID(catalog, overrides[i].PathID))
+		score.isOverride = true
+		if level := findLevelByID(catalog, overrides[i].LevelID); level != nil {
+			score.levelNumber = float64(level.LevelNumber)
+		}
+		return score
+	}
+
+	for _, avg := range averagedResponses {
+		if avg.CategoryID == categoryID {
+			// Resolve the level of the aggregated score
+			score.levelNumber = avg.AverageLevelNumber
+			score.pathWeight = avg.PathWeight
+			if level := strategy.ResolveLevel(catalog.Levels, avg.AverageLevelNumber); level != nil {
+				score.levelID = level.ID
+			}
+			break
+		}
+	}
+	return score
+}
+
+// categoryScores resolves the reviewer results of all categories from the consolidation
+func (s *DiscussionService) categoryScores(assessmentID uint, catalog *models.CatalogWithDetails) (map[uint]categoryScore, error) {
+	overrides, err := s.overrideRepo.GetByAssessment(assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overrides: %w", err)
+	}
+	reviewerResponses, err := s.reviewerRespRepo.GetAllByAssessment(assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer responses: %w", err)
+	}
+
+	averagedResponses := calculateAveragedResponses(reviewerResponses, catalog, false)
+	strategy := NewScoringStrategy(&catalog.CriteriaCatalog)
+
+	scores := make(map[uint]categoryScore, len(catalog.Categories))
+	for _, category := range catalog.Categories {
+		scores[category.ID] = resolveCategoryScore(catalog, strategy, category.ID, overrides, averagedResponses)
+	}
+	return scores, nil
+}
+
 // CreateDiscussionResult generates and stores discussion results when status changes to 'discussion'
 func (s *DiscussionService) CreateDiscussionResult(assessmentID uint) error {
 	// Check if discussion result already exists
@@ -202,9 +264,9 @@ func (s *DiscussionService) CreateDiscussionResult(assessmentID uint) error {
 		}
 	}
 
-	// Calculate weighted overall level and category results
-	var totalWeight float64
-	var weightedSum float64
+	// Calculate weighted overall level and category results with the catalog's scoring strategy
+	strategy := NewScoringStrategy(&catalog.CriteriaCatalog)
+	var weightedEntries []weightedScoreEntry
 	var categoryResults []models.DiscussionCategoryResult
 
 	for _, category := range catalog.Categories {
@@ -218,52 +280,8 @@ func (s *DiscussionService) CreateDiscussionResult(assessmentID uint) error {
 		}
 
 		// Determine reviewer level (override takes precedence)
-		var reviewerLevelID uint
-		var reviewerLevelNumber float64
+		score := resolveCategoryScore(catalog, strategy, category.ID, overrides, averagedResponses)
 		var encryptedJustificationID *int64
-		var isOverride bool
-
-		// Check for override first
-		var override *models.ConsolidationOverride
-		for i := range overrides {
-			if overrides[i].CategoryID == category.ID {
-				override = &overrides[i]
-				break
-			}
-		}
-
-		if override != nil {
-			// Use override
-			reviewerLevelID = override.LevelID
-			isOverride = true
-
-			// Find level number
-			if level := findLevelByID(catalog, override.LevelID); level != nil {
-				reviewerLevelNumber = float64(level.LevelNumber)
-			}
-		} else {
-			// Use averaged response
-			for _, avg := range averagedResponses {
-				if avg.CategoryID == category.ID {
-					// Find the level closest to average_level_number
-					reviewerLevelNumber = avg.AverageLevelNumber
-
-					var closestLevel *models.Level
-					minDiff := math.MaxFloat64
-					for _, level := range catalog.Levels {
-						diff := math.Abs(float64(level.LevelNumber) - avg.AverageLevelNumber)
-						if diff < minDiff {
-							minDiff = diff
-							closestLevel = &level
-						}
-					}
-					if closestLevel != nil {
-						reviewerLevelID = closestLevel.ID
-					}
-					break
-				}
-			}
-		}
 
 		// Encrypt category discussion comment if available (public comment)
 		if publicComment, exists := categoryCommentMap[category.ID]; exists && publicComment != "" {
@@ -287,37 +305,30 @@ func (s *DiscussionService) CreateDiscussionResult(assessmentID uint) error {
 			encryptedJustificationID = &record.ID
 		}
 
-		// Add to weighted calculation
-		weight := float64(1)
-		if category.Weight != nil && *category.Weight > 0 {
-			weight = *category.Weight
-		}
-		totalWeight += weight
-		weightedSum += reviewerLevelNumber * weight
+		// Add to weighted calculation (category weight x weight of the selected path)
+		weightedEntries = append(weightedEntries, weightedScoreEntry{
+			score:  score.levelNumber,
+			weight: categoryWeight(&category.Category) * score.pathWeight,
+		})
 
 		// Create category result
 		categoryResults = append(categoryResults, models.DiscussionCategoryResult{
 			CategoryID:               category.ID,
 			UserLevelID:              userLevelID,
-			ReviewerLevelID:          reviewerLevelID,
-			ReviewerLevelNumber:      reviewerLevelNumber,
+			ReviewerLevelID:          score.levelID,
+			ReviewerLevelNumber:      score.levelNumber,
 			EncryptedJustificationID: encryptedJustificationID,
-			IsOverride:               isOverride,
+			IsOverride:               score.isOverride,
 		})
 	}
 
 	// Calculate overall weighted level
-	averageLevel := weightedSum / totalWeight
+	averageLevel := weightedAverageScore(weightedEntries)
 
-	// Find the level closest to the average
+	// Resolve the overall level
 	var overallLevelID uint
-	minDiff := math.MaxFloat64
-	for _, level := range catalog.Levels {
-		diff := math.Abs(float64(level.LevelNumber) - averageLevel)
-		if diff < minDiff {
-			minDiff = diff
-			overallLevelID = level.ID
-		}
+	if level := strategy.ResolveLevel(catalog.Levels, averageLevel); level != nil {
+		overallLevelID = level.ID
 	}
 
 	// Encrypt final comment using secureStore
@@ -539,3 +550,128 @@ func (s *DiscussionService) UpdateDiscussionNote(assessmentID uint, note string)
 
 	return s.discussionRepo.UpdateDiscussionNote(result.ID, encryptedNoteID, nil)
 }
+
+// SupersedeResult replaces the active discussion result of a self-assessment after an appeal decision.
+// Category results are copied; changed categories take the path and level decided by the appeal panel
+// and the weighted overall level is recomputed. The previous result is kept and marked as superseded.
+// All writes run in tx, so the caller can store the appeal decision together with the new result.
+func (s *DiscussionService) SupersedeResult(tx *sql.Tx, assessmentID uint, changes []models.AppealCategoryChange) (*models.DiscussionResult, error) {
+	current, err := s.discussionRepo.GetByAssessmentID(assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discussion result: %w", err)
+	}
+	if current == nil {
+		return nil, fmt.Errorf("discussion result not found")
+	}
+
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment not found")
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogWithDetails(assessment.CatalogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog: %w", err)
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	currentResults, err := s.discussionRepo.GetCategoryResults(current.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category results: %w", err)
+	}
+	if err := validateAppealChanges(changes, catalog, currentResults); err != nil {
+		return nil, err
+	}
+
+	// Path weights of unchanged categories are not stored with the result and are resolved again
+	scores, err := s.categoryScores(assessmentID, catalog)
+	if err != nil {
+		return nil, err
+	}
+	categoryWeights := make(map[uint]float64, len(catalog.Categories))
+	for i := range catalog.Categories {
+		categoryWeights[catalog.Categories[i].ID] = categoryWeight(&catalog.Categories[i].Category)
+	}
+	changed := make(map[uint]models.AppealCategoryChange, len(changes))
+	for _, change := range changes {
+		changed[change.CategoryID] = change
+	}
+
+	processID := fmt.Sprintf("assessment-%d", assessmentID)
+	var weightedEntries []weightedScoreEntry
+	categoryResults := make([]models.DiscussionCategoryResult, 0, len(currentResults))
+
+	for _, result := range currentResults {
+		result.ID = 0
+		selectedPathWeight := scores[result.CategoryID].pathWeight
+
+		if change, ok := changed[result.CategoryID]; ok {
+			level := findLevelByID(catalog, change.LevelID)
+			result.ReviewerLevelID = level.ID
+			result.ReviewerLevelNumber = float64(level.LevelNumber)
+			result.IsOverride = true
+			selectedPathWeight = pathWeight(findPathByID(catalog, change.PathID))
+
+			plainData := securestore.PlainData{
+				Fields: map[string]interface{}{
+					"justification": strings.TrimSpace(change.Justification),
+				},
+			}
+			record, err := s.secureStore.WithTx(tx).CreateRecord(
+				processID,
+				int64(assessment.UserID),
+				"CATEGORY_JUSTIFICATION",
+				&plainData,
+				"",
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt category justification: %w", err)
+			}
+			result.EncryptedJustificationID = &record.ID
+		}
+
+		weight, ok := categoryWeights[result.CategoryID]
+		if !ok {
+			weight = 1
+		}
+		weightedEntries = append(weightedEntries, weightedScoreEntry{
+			score:  result.ReviewerLevelNumber,
+			weight: weight * selectedPathWeight,
+		})
+		categoryResults = append(categoryResults, result)
+	}
+
+	averageLevel := weightedAverageScore(weightedEntries)
+	var overallLevelID uint
+	if level := NewScoringStrategy(&catalog.CriteriaCatalog).ResolveLevel(catalog.Levels, averageLevel); level != nil {
+		overallLevelID = level.ID
+	}
+
+	reviewers, err := s.discussionRepo.GetReviewers(current.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+	}
+	for i := range reviewers {
+		reviewers[i].ID = 0
+	}
+
+	result := &models.DiscussionResult{
+		AssessmentID:              assessmentID,
+		WeightedOverallLevelNum:   averageLevel,
+		WeightedOverallLevelID:    overallLevelID,
+		EncryptedFinalCommentID:   current.EncryptedFinalCommentID,
+		EncryptedDiscussionNoteID: current.EncryptedDiscussionNoteID,
+	}
+	if err := s.discussionRepo.WithTx(tx).Supersede(current.ID, result, categoryResults, reviewers); err != nil {
+		return nil, err
+	}
+
+	slog.Info("Discussion result superseded", "assessment_id", assessmentID,
+		"discussion_result_id", current.ID, "new_discussion_result_id", result.ID)
+	return result, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -14,6 +15,7 @@ import (
 // EncryptedResponseService handles encryption/decryption of assessment response justifications
 type EncryptedResponseService struct {
 	db           *sql.DB
+	transactor   *repository.Transactor
 	responseRepo *repository.AssessmentResponseRepository
 	keyManager   *keymanager.KeyManager
 	secureStore  *securestore.SecureStore
@@ -28,6 +30,7 @@ func NewEncryptedResponseService(
 ) *EncryptedResponseService {
 	return &EncryptedResponseService{
 		db:           db,
+		transactor:   repository.NewTransactor(db),
 		responseRepo: responseRepo,
 		keyManager:   keyManager,
 		secureStore:  secureStore,
@@ -58,40 +61,44 @@ func (s *EncryptedResponseService) CreateResponse(response *models.AssessmentRes
 		},
 	}
 
-	record, err := s.secureStore.CreateRecord(
-		processID,
-		int64(userID),
-		"JUSTIFICATION",
-		data,
-		"",
-	)
-	if err != nil {
-		return fmt.Errorf("failed to encrypt justification: %w", err)
-	}
-
-	// Store with encrypted_justification_id
-	response.EncryptedJustificationID = &record.ID
-	response.Justification = "" // Clear plaintext
-
-	query := `
-		INSERT INTO assessment_responses (assessment_id, category_id, path_id, level_id, encrypted_justification_id)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at, updated_at
-	`
-	err = s.db.QueryRow(
-		query,
-		response.AssessmentID,
-		response.CategoryID,
-		response.PathID,
-		response.LevelID,
-		response.EncryptedJustificationID,
-	).Scan(&response.ID, &response.CreatedAt, &response.UpdatedAt)
+	// The encrypted justification is only kept if the response is stored
+	return s.transactor.InTx(func(tx *sql.Tx) error {
+		record, err := s.secureStore.WithTx(tx).CreateRecord(
+			processID,
+			int64(userID),
+			"JUSTIFICATION",
+			data,
+			"",
+		)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt justification: %w", err)
+		}
 
-	return err
+		// Store with encrypted_justification_id
+		response.EncryptedJustificationID = &record.ID
+		response.Justification = "" // Clear plaintext
+
+		query := `
+			INSERT INTO assessment_responses (assessment_id, category_id, path_id, level_id, encrypted_justification_id)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, version, created_at, updated_at
+		`
+		return tx.QueryRow(
+			query,
+			response.AssessmentID,
+			response.CategoryID,
+			response.PathID,
+			response.LevelID,
+			response.EncryptedJustificationID,
+		).Scan(&response.ID, &response.Version, &response.CreatedAt, &response.UpdatedAt)
+	})
 }
 
-// UpdateResponse updates an existing assessment response with encrypted justification
-func (s *EncryptedResponseService) UpdateResponse(response *models.AssessmentResponse, userID uint) error {
+// UpdateResponse updates an existing assessment response with encrypted justification.
+// With expectedVersion set, the response is only updated if it still has that version
+// (repository.ErrVersionConflict otherwise); a response that no longer exists is reported as not found.
+// The new justification record is only kept if the update succeeds.
+func (s *EncryptedResponseService) UpdateResponse(response *models.AssessmentResponse, userID uint, expectedVersion *int) error {
 	// Ensure user key exists
 	if err := s.ensureUserKey(int64(userID)); err != nil {
 		return fmt.Errorf("failed to ensure user key: %w", err)
@@ -115,36 +122,50 @@ func (s *EncryptedResponseService) UpdateResponse(response *models.AssessmentRes
 		},
 	}
 
-	record, err := s.secureStore.CreateRecord(
-		processID,
-		int64(userID),
-		"JUSTIFICATION",
-		data,
-		"",
-	)
-	if err != nil {
-		return fmt.Errorf("failed to encrypt justification: %w", err)
-	}
+	return s.transactor.InTx(func(tx *sql.Tx) error {
+		record, err := s.secureStore.WithTx(tx).CreateRecord(
+			processID,
+			int64(userID),
+			"JUSTIFICATION",
+			data,
+			"",
+		)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt justification: %w", err)
+		}
 
-	// Update with new encrypted_justification_id (creates new encrypted record, old one remains in chain)
-	response.EncryptedJustificationID = &record.ID
-	response.Justification = "" // Clear plaintext
-
-	query := `
-		UPDATE assessment_responses
-		SET path_id = $1, level_id = $2, encrypted_justification_id = $3, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $4
-		RETURNING updated_at
-	`
-	err = s.db.QueryRow(
-		query,
-		response.PathID,
-		response.LevelID,
-		response.EncryptedJustificationID,
-		response.ID,
-	).Scan(&response.UpdatedAt)
+		// Update with new encrypted_justification_id (creates new encrypted record, old one remains in chain)
+		response.EncryptedJustificationID = &record.ID
+		response.Justification = "" // Clear plaintext
+
+		query := `
+			UPDATE assessment_responses
+			SET path_id = $1, level_id = $2, encrypted_justification_id = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $4 AND ($5::INTEGER IS NULL OR version = $5)
+			RETURNING version, updated_at
+		`
+		err = tx.QueryRow(
+			query,
+			response.PathID,
+			response.LevelID,
+			response.EncryptedJustificationID,
+			response.ID,
+			expectedVersion,
+		).Scan(&response.Version, &response.UpdatedAt)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
 
-	return err
+		// No row updated: either the response is gone or its version changed
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM assessment_responses WHERE id = $1)`, response.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check response: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("response not found")
+		}
+		return repository.ErrVersionConflict
+	})
 }
 
 // DecryptResponse decrypts the justification field of an assessment response
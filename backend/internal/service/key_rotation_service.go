@@ -0,0 +1,195 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"new-pay/internal/keymanager"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+	"new-pay/internal/securestore"
+)
+
+const (
+	keyRotationStatusRunning   = "running"
+	keyRotationStatusCompleted = "completed"
+	keyRotationStatusFailed    = "failed"
+
+	// keyRotationProgressInterval is the number of re-encrypted records after which the progress is stored
+	keyRotationProgressInterval = 25
+)
+
+// KeyRotationService rotates process keys and re-encrypts the existing records in the background
+type KeyRotationService struct {
+	rotationRepo *repository.KeyRotationRepository
+	keyManager   *keymanager.KeyManager
+	secureStore  *securestore.SecureStore
+	auditSvc     *AuditService
+}
+
+// NewKeyRotationService creates a new key rotation service
+func NewKeyRotationService(
+	rotationRepo *repository.KeyRotationRepository,
+	keyManager *keymanager.KeyManager,
+	secureStore *securestore.SecureStore,
+	auditSvc *AuditService,
+) *KeyRotationService {
+	return &KeyRotationService{
+		rotationRepo: rotationRepo,
+		keyManager:   keyManager,
+		secureStore:  secureStore,
+		auditSvc:     auditSvc,
+	}
+}
+
+// StartRotation creates a new version of a process key and starts re-encrypting the
+// records of the process with it. The old versions are retired once all records are re-encrypted.
+func (s *KeyRotationService) StartRotation(processID string, userID uint) (*models.ProcessKeyRotation, error) {
+	processID = strings.TrimSpace(processID)
+	if processID == "" {
+		return nil, fmt.Errorf("process ID is required")
+	}
+
+	running, err := s.rotationRepo.GetRunningByProcess(processID)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		return nil, fmt.Errorf("a key rotation is already running for process %s", processID)
+	}
+
+	if _, _, err := s.keyManager.GetCurrentProcessKeyVersion(processID); err != nil {
+		return nil, fmt.Errorf("process key not found")
+	}
+
+	fromVersion, toVersion, err := s.keyManager.RotateProcessKey(processID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate process key: %w", err)
+	}
+
+	recordIDs, err := s.secureStore.ListRecordIDsBelowKeyVersion(processID, toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records: %w", err)
+	}
+
+	rotation := &models.ProcessKeyRotation{
+		ProcessID:    processID,
+		FromVersion:  fromVersion,
+		ToVersion:    toVersion,
+		TotalRecords: len(recordIDs),
+		StartedBy:    &userID,
+	}
+	if err := s.rotationRepo.Create(rotation); err != nil {
+		return nil, fmt.Errorf("failed to create key rotation: %w", err)
+	}
+
+	s.auditSvc.Log(userID, "rotate_process_key", "process_key",
+		fmt.Sprintf("Rotated key of process %s from version %d to %d, %d records to re-encrypt", processID, fromVersion, toVersion, len(recordIDs)))
+
+	go s.run(*rotation)
+
+	return rotation, nil
+}
+
+// GetRotation retrieves a rotation with its progress
+func (s *KeyRotationService) GetRotation(id uint) (*models.ProcessKeyRotation, error) {
+	return s.rotationRepo.GetByID(id)
+}
+
+// ListRotations lists rotations, optionally filtered by process and status
+func (s *KeyRotationService) ListRotations(processID, status string) ([]models.ProcessKeyRotation, error) {
+	if err := validateKeyRotationStatus(status); err != nil {
+		return nil, err
+	}
+	return s.rotationRepo.List(strings.TrimSpace(processID), status)
+}
+
+// ResumeRotations continues rotations that were interrupted, e.g. by a restart of the server
+func (s *KeyRotationService) ResumeRotations() {
+	rotations, err := s.rotationRepo.List("", keyRotationStatusRunning)
+	if err != nil {
+		slog.Error("Failed to load running key rotations", "error", err)
+		return
+	}
+
+	for _, rotation := range rotations {
+		slog.Info("Resuming key rotation", "rotation_id", rotation.ID, "process_id", rotation.ProcessID)
+		go s.run(rotation)
+	}
+}
+
+// run re-encrypts all records of the process below the new key version and retires the old versions
+func (s *KeyRotationService) run(rotation models.ProcessKeyRotation) {
+	logger := slog.With("rotation_id", rotation.ID, "process_id", rotation.ProcessID)
+	logger.Info("Re-encrypting records", "to_version", rotation.ToVersion)
+
+	done := rotation.ReencryptedRecords
+	for {
+		recordIDs, err := s.secureStore.ListRecordIDsBelowKeyVersion(rotation.ProcessID, rotation.ToVersion)
+		if err != nil {
+			s.fail(rotation, fmt.Sprintf("failed to list records: %v", err))
+			return
+		}
+
+		if len(recordIDs) == 0 {
+			retired, err := s.keyManager.RetireProcessKeyVersions(rotation.ProcessID, rotation.ToVersion)
+			if err != nil {
+				s.fail(rotation, err.Error())
+				return
+			}
+			if retired {
+				break
+			}
+			// A record was created with an old key version in the meantime
+			continue
+		}
+
+		total := done + len(recordIDs)
+		for _, recordID := range recordIDs {
+			if err := s.secureStore.ReencryptRecord(recordID, rotation.ToVersion, rotation.ID); err != nil {
+				s.fail(rotation, fmt.Sprintf("record %d: %v", recordID, err))
+				return
+			}
+			done++
+			if done%keyRotationProgressInterval == 0 {
+				if err := s.rotationRepo.UpdateProgress(rotation.ID, total, done); err != nil {
+					logger.Error("Failed to store key rotation progress", "error", err)
+				}
+			}
+		}
+		if err := s.rotationRepo.UpdateProgress(rotation.ID, total, done); err != nil {
+			logger.Error("Failed to store key rotation progress", "error", err)
+		}
+	}
+
+	if err := s.rotationRepo.Complete(rotation.ID); err != nil {
+		logger.Error("Failed to complete key rotation", "error", err)
+		return
+	}
+
+	logger.Info("Key rotation completed", "reencrypted_records", done)
+	s.auditSvc.LogSystem("complete_key_rotation", "process_key",
+		fmt.Sprintf("Re-encrypted %d records of process %s with key version %d, older versions retired", done, rotation.ProcessID, rotation.ToVersion))
+}
+
+// fail marks a rotation as failed. The records re-encrypted so far stay readable with the new
+// version, the remaining ones with their old version; a new rotation picks them up.
+func (s *KeyRotationService) fail(rotation models.ProcessKeyRotation, reason string) {
+	slog.Error("Key rotation failed", "rotation_id", rotation.ID, "process_id", rotation.ProcessID, "error", reason)
+
+	if err := s.rotationRepo.Fail(rotation.ID, reason); err != nil {
+		slog.Error("Failed to mark key rotation as failed", "rotation_id", rotation.ID, "error", err)
+	}
+	s.auditSvc.LogSystem("fail_key_rotation", "process_key",
+		fmt.Sprintf("Key rotation %d of process %s failed: %s", rotation.ID, rotation.ProcessID, reason))
+}
+
+// validateKeyRotationStatus checks a status filter (empty means all)
+func validateKeyRotationStatus(status string) error {
+	switch status {
+	case "", keyRotationStatusRunning, keyRotationStatusCompleted, keyRotationStatusFailed:
+		return nil
+	}
+	return fmt.Errorf("status must be running, completed or failed")
+}
@@ -0,0 +1,14 @@
+package service
+
+import "testing"
+
+func TestValidateKeyRotationStatus(t *testing.T) {
+	for _, status := range []string{"", "running", "completed", "failed"} {
+		if err := validateKeyRotationStatus(status); err != nil {
+			t.Errorf("status %q rejected: %v", status, err)
+		}
+	}
+	if err := validateKeyRotationStatus("cancelled"); err == nil {
+		t.Error("unknown status accepted")
+	}
+}
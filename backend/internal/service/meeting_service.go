@@ -0,0 +1,344 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"new-pay/internal/email"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+)
+
+// statusDiscussion is the status in which the discussion meeting is scheduled and held
+const statusDiscussion = "discussion"
+
+// MeetingService handles scheduling of discussion meetings and their calendar invitations
+type MeetingService struct {
+	meetingRepo      *repository.DiscussionMeetingRepository
+	assessmentRepo   *repository.SelfAssessmentRepository
+	assignmentRepo   *repository.ReviewerAssignmentRepository
+	reviewerRepo     *repository.ReviewerResponseRepository
+	confirmationRepo *repository.DiscussionConfirmationRepository
+	userRepo         *repository.UserRepository
+	auditSvc         *AuditService
+	emailService     *email.Service
+}
+
+// NewMeetingService creates a new meeting service
+func NewMeetingService(
+	meetingRepo *repository.DiscussionMeetingRepository,
+	assessmentRepo *repository.SelfAssessmentRepository,
+	assignmentRepo *repository.ReviewerAssignmentRepository,
+	reviewerRepo *repository.ReviewerResponseRepository,
+	confirmationRepo *repository.DiscussionConfirmationRepository,
+	userRepo *repository.UserRepository,
+	auditSvc *AuditService,
+	emailService *email.Service,
+) *MeetingService {
+	return &MeetingService{
+		meetingRepo:      meetingRepo,
+		assessmentRepo:   assessmentRepo,
+		assignmentRepo:   assignmentRepo,
+		reviewerRepo:     reviewerRepo,
+		confirmationRepo: confirmationRepo,
+		userRepo:         userRepo,
+		auditSvc:         auditSvc,
+		emailService:     emailService,
+	}
+}
+
+// GetMeeting retrieves the scheduled meeting of a self-assessment (owner and panel only; nil if none)
+func (s *MeetingService) GetMeeting(assessmentID, userID uint) (*models.DiscussionMeeting, error) {
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment.UserID != userID {
+		if err := s.checkPanelMember(assessmentID, userID); err != nil {
+			return nil, err
+		}
+	}
+	return s.meetingRepo.GetScheduledByAssessment(assessmentID)
+}
+
+// ScheduleMeeting proposes the discussion meeting of a self-assessment and invites owner and panel
+func (s *MeetingService) ScheduleMeeting(assessmentID, reviewerID uint, startsAt, endsAt time.Time, location string) (*models.DiscussionMeeting, error) {
+	location = strings.TrimSpace(location)
+	if err := validateMeeting(startsAt, endsAt, location, time.Now()); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkOrganizer(assessmentID, reviewerID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.meetingRepo.GetScheduledByAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("a meeting is already scheduled; update or cancel it instead")
+	}
+
+	uid, err := newMeetingUID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	meeting := &models.DiscussionMeeting{
+		AssessmentID:    assessmentID,
+		UID:             uid,
+		StartsAt:        startsAt,
+		EndsAt:          endsAt,
+		Location:        location,
+		OrganizerUserID: &reviewerID,
+	}
+	if err := s.meetingRepo.Create(meeting); err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Log(reviewerID, "schedule_meeting", "discussion_meeting",
+		fmt.Sprintf("Scheduled discussion meeting for self-assessment %d at %s", assessmentID, startsAt.UTC().Format(time.RFC3339)))
+	s.sendInvitations(meeting, email.CalendarMethodRequest)
+
+	return meeting, nil
+}
+
+// UpdateMeeting reschedules the discussion meeting and sends an updated invitation
+func (s *MeetingService) UpdateMeeting(assessmentID, reviewerID uint, startsAt, endsAt time.Time, location string) (*models.DiscussionMeeting, error) {
+	location = strings.TrimSpace(location)
+	if err := validateMeeting(startsAt, endsAt, location, time.Now()); err != nil {
+		return nil, err
+	}
+	meeting, err := s.getChangeableMeeting(assessmentID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	meeting.StartsAt = startsAt
+	meeting.EndsAt = endsAt
+	meeting.Location = location
+	meeting.OrganizerUserID = &reviewerID
+	if err := s.meetingRepo.Update(meeting); err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Log(reviewerID, "update_meeting", "discussion_meeting",
+		fmt.Sprintf("Rescheduled discussion meeting for self-assessment %d to %s", assessmentID, startsAt.UTC().Format(time.RFC3339)))
+	s.sendInvitations(meeting, email.CalendarMethodRequest)
+
+	return meeting, nil
+}
+
+// CancelMeeting cancels the discussion meeting and sends a cancellation to all invitees
+func (s *MeetingService) CancelMeeting(assessmentID, reviewerID uint) error {
+	meeting, err := s.getChangeableMeeting(assessmentID, reviewerID)
+	if err != nil {
+		return err
+	}
+	if err := s.meetingRepo.Cancel(meeting); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(reviewerID, "cancel_meeting", "discussion_meeting",
+		fmt.Sprintf("Cancelled discussion meeting for self-assessment %d", assessmentID))
+	s.sendInvitations(meeting, email.CalendarMethodCancel)
+
+	return nil
+}
+
+// GetConfirmableMeeting returns the discussion meeting of a self-assessment once it has started,
+// so its taking place can be confirmed
+func (s *MeetingService) GetConfirmableMeeting(assessmentID uint, now time.Time) (*models.DiscussionMeeting, error) {
+	meeting, err := s.meetingRepo.GetScheduledByAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkConfirmationTime(meeting, now); err != nil {
+		return nil, err
+	}
+	return meeting, nil
+}
+
+// checkOrganizer checks that the assessment is in discussion and the user is on its reviewer panel
+func (s *MeetingService) checkOrganizer(assessmentID, reviewerID uint) (*models.SelfAssessment, error) {
+	assessment, err := s.getAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment.Status != statusDiscussion {
+		return nil, fmt.Errorf("meetings can only be scheduled in discussion status")
+	}
+	if err := s.checkPanelMember(assessmentID, reviewerID); err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+// getChangeableMeeting returns the scheduled meeting if it may still be changed by the reviewer
+func (s *MeetingService) getChangeableMeeting(assessmentID, reviewerID uint) (*models.DiscussionMeeting, error) {
+	if _, err := s.checkOrganizer(assessmentID, reviewerID); err != nil {
+		return nil, err
+	}
+
+	meeting, err := s.meetingRepo.GetScheduledByAssessment(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if meeting == nil {
+		return nil, fmt.Errorf("meeting not found")
+	}
+
+	confirmed, err := s.confirmationRepo.HasReviewerConfirmation(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if confirmed {
+		return nil, fmt.Errorf("meeting has already been confirmed and cannot be changed")
+	}
+	return meeting, nil
+}
+
+func (s *MeetingService) getAssessment(assessmentID uint) (*models.SelfAssessment, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment not found")
+	}
+	return assessment, nil
+}
+
+// checkPanelMember checks that a user is on the reviewer panel of a self-assessment
+func (s *MeetingService) checkPanelMember(assessmentID, userID uint) error {
+	reviewerIDs, err := s.panelReviewerIDs(assessmentID)
+	if err != nil {
+		return err
+	}
+	for _, id := range reviewerIDs {
+		if id == userID {
+			return nil
+		}
+	}
+	return fmt.Errorf("permission denied: you are not on the reviewer panel of this self-assessment")
+}
+
+// panelReviewerIDs returns the reviewer panel of a self-assessment
+// (reviewers with a complete review if no panel is assigned)
+func (s *MeetingService) panelReviewerIDs(assessmentID uint) ([]uint, error) {
+	assignments, err := s.assignmentRepo.GetByAssessmentID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	var reviewerIDs []uint
+	for _, assignment := range assignments {
+		reviewerIDs = append(reviewerIDs, assignment.ReviewerUserID)
+	}
+	if len(reviewerIDs) > 0 {
+		return reviewerIDs, nil
+	}
+
+	reviewers, err := s.reviewerRepo.GetCompleteReviewers(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, reviewer := range reviewers {
+		reviewerIDs = append(reviewerIDs, reviewer.ReviewerID)
+	}
+	return reviewerIDs, nil
+}
+
+// sendInvitations sends the meeting as iCalendar invitation, update or cancellation to owner and panel
+func (s *MeetingService) sendInvitations(meeting *models.DiscussionMeeting, method string) {
+	if s.emailService == nil {
+		return
+	}
+
+	assessment, err := s.assessmentRepo.GetByIDWithDetails(meeting.AssessmentID)
+	if err != nil || assessment == nil {
+		slog.Error("Failed to get assessment details for meeting invitation", "assessment_id", meeting.AssessmentID, "error", err)
+		return
+	}
+	reviewerIDs, err := s.panelReviewerIDs(meeting.AssessmentID)
+	if err != nil {
+		slog.Error("Failed to get reviewer panel for meeting invitation", "assessment_id", meeting.AssessmentID, "error", err)
+		return
+	}
+
+	attendees := []email.CalendarAttendee{{Name: assessment.UserName, Email: assessment.UserEmail}}
+	var organizer email.CalendarAttendee
+	for _, id := range reviewerIDs {
+		reviewer, err := s.userRepo.GetByID(id)
+		if err != nil || reviewer == nil {
+			slog.Error("Failed to get reviewer for meeting invitation", "reviewer_id", id, "error", err)
+			continue
+		}
+		attendee := email.CalendarAttendee{Name: reviewer.FirstName + " " + reviewer.LastName, Email: reviewer.Email}
+		if meeting.OrganizerUserID != nil && *meeting.OrganizerUserID == id {
+			organizer = attendee
+		}
+		attendees = append(attendees, attendee)
+	}
+
+	event := email.CalendarEvent{
+		Method:      method,
+		UID:         meeting.UID,
+		Sequence:    meeting.Sequence,
+		Start:       meeting.StartsAt,
+		End:         meeting.EndsAt,
+		Stamp:       time.Now(),
+		Summary:     fmt.Sprintf("Gespräch zur Selbsteinschätzung #%d (%s)", meeting.AssessmentID, assessment.CatalogName),
+		Description: fmt.Sprintf("Besprechung der Ergebnisse der Selbsteinschätzung von %s für den Katalog %s.", assessment.UserName, assessment.CatalogName),
+		Location:    meeting.Location,
+		Organizer:   organizer,
+		Attendees:   attendees,
+	}
+	for _, attendee := range attendees {
+		if err := s.emailService.SendMeetingInvitation(attendee.Email, attendee.Name, event); err != nil {
+			slog.Error("Failed to send meeting invitation", "assessment_id", meeting.AssessmentID, "to", attendee.Email, "error", err)
+		}
+	}
+}
+
+// validateMeeting checks time and location of a proposed meeting
+func validateMeeting(startsAt, endsAt time.Time, location string, now time.Time) error {
+	if startsAt.IsZero() || endsAt.IsZero() {
+		return fmt.Errorf("start and end time are required")
+	}
+	if !endsAt.After(startsAt) {
+		return fmt.Errorf("end time must be after start time")
+	}
+	if !startsAt.After(now) {
+		return fmt.Errorf("start time must be in the future")
+	}
+	if location == "" {
+		return fmt.Errorf("location is required")
+	}
+	if len(location) > 500 {
+		return fmt.Errorf("location must not exceed 500 characters")
+	}
+	return nil
+}
+
+// checkConfirmationTime checks that a meeting is scheduled and has started
+func checkConfirmationTime(meeting *models.DiscussionMeeting, now time.Time) error {
+	if meeting == nil {
+		return fmt.Errorf("no discussion meeting scheduled")
+	}
+	if now.Before(meeting.StartsAt) {
+		return fmt.Errorf("the discussion meeting has not started yet (starts at %s)", meeting.StartsAt.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// newMeetingUID generates a globally unique iCalendar UID for a meeting
+func newMeetingUID(assessmentID uint) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate meeting UID: %w", err)
+	}
+	return fmt.Sprintf("discussion-%d-%s@new-pay", assessmentID, hex.EncodeToString(b)), nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+func TestValidateMeeting(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	start := now.Add(24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		location string
+		wantErr  string
+	}{
+		{name: "valid", start: start, end: start.Add(time.Hour), location: "Raum 1"},
+		{name: "missing times", location: "Raum 1", wantErr: "start and end time are required"},
+		{name: "end before start", start: start, end: start.Add(-time.Minute), location: "Raum 1", wantErr: "end time must be after start time"},
+		{name: "in the past", start: now.Add(-time.Hour), end: now, location: "Raum 1", wantErr: "start time must be in the future"},
+		{name: "missing location", start: start, end: start.Add(time.Hour), wantErr: "location is required"},
+		{name: "location too long", start: start, end: start.Add(time.Hour), location: strings.Repeat("x", 501), wantErr: "location must not exceed 500 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMeeting(tt.start, tt.end, tt.location, now)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckConfirmationTime(t *testing.T) {
+	start := time.Date(2026, 10, 20, 14, 0, 0, 0, time.UTC)
+	meeting := &models.DiscussionMeeting{StartsAt: start, EndsAt: start.Add(time.Hour)}
+
+	if err := checkConfirmationTime(nil, start); err == nil {
+		t.Error("confirmation allowed without meeting")
+	}
+	if err := checkConfirmationTime(meeting, start.Add(-time.Second)); err == nil {
+		t.Error("confirmation allowed before the meeting started")
+	}
+	if err := checkConfirmationTime(meeting, start); err != nil {
+		t.Errorf("confirmation at start time rejected: %v", err)
+	}
+	if err := checkConfirmationTime(meeting, start.Add(48*time.Hour)); err != nil {
+		t.Errorf("confirmation after the meeting rejected: %v", err)
+	}
+}
+
+func TestNewMeetingUID(t *testing.T) {
+	first, err := newMeetingUID(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := newMeetingUID(7)
+	if first == second {
+		t.Error("meeting UIDs are not unique")
+	}
+	if !strings.HasPrefix(first, "discussion-7-") || !strings.HasSuffix(first, "@new-pay") {
+		t.Errorf("unexpected UID %q", first)
+	}
+}
@@ -0,0 +1,542 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"new-pay/internal/keymanager"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+	"new-pay/internal/securestore"
+)
+
+// Position of the current salary relative to the salary band of the archived level
+const (
+	bandPositionBelow   = "below"
+	bandPositionWithin  = "within"
+	bandPositionAbove   = "above"
+	bandPositionUnknown = "unknown"
+)
+
+// Record types of salary data in the secure store
+const (
+	recordTypeSalary            = "SALARY"
+	recordTypePayRecommendation = "PAY_RECOMMENDATION"
+)
+
+// PayService handles salary bands, encrypted employee salaries and pay recommendations
+type PayService struct {
+	payRepo        *repository.PayRepository
+	catalogRepo    *repository.CatalogRepository
+	assessmentRepo *repository.SelfAssessmentRepository
+	discussionRepo *repository.DiscussionRepository
+	keyManager     *keymanager.KeyManager
+	secureStore    *securestore.SecureStore
+	auditSvc       *AuditService
+}
+
+// NewPayService creates a new pay service
+func NewPayService(
+	payRepo *repository.PayRepository,
+	catalogRepo *repository.CatalogRepository,
+	assessmentRepo *repository.SelfAssessmentRepository,
+	discussionRepo *repository.DiscussionRepository,
+	keyManager *keymanager.KeyManager,
+	secureStore *securestore.SecureStore,
+	auditSvc *AuditService,
+) *PayService {
+	return &PayService{
+		payRepo:        payRepo,
+		catalogRepo:    catalogRepo,
+		assessmentRepo: assessmentRepo,
+		discussionRepo: discussionRepo,
+		keyManager:     keyManager,
+		secureStore:    secureStore,
+		auditSvc:       auditSvc,
+	}
+}
+
+// salaryProcessID returns the secure store process of all salary data of an employee
+func salaryProcessID(userID uint) string {
+	return fmt.Sprintf("salary-%d", userID)
+}
+
+// GetSalaryBands retrieves all salary bands of a catalog
+func (s *PayService) GetSalaryBands(catalogID uint) ([]models.SalaryBand, error) {
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	return s.payRepo.GetSalaryBandsByCatalogID(catalogID)
+}
+
+// CreateSalaryBand creates a salary band for a level of a catalog
+func (s *PayService) CreateSalaryBand(band *models.SalaryBand, userID uint) error {
+	if err := s.checkSalaryBand(band); err != nil {
+		return err
+	}
+
+	band.CreatedBy = &userID
+	if err := s.payRepo.CreateSalaryBand(band); err != nil {
+		return err
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "create", "salary_band", fmt.Sprintf("Created salary band %d for level %d of catalog %d (%s)",
+		band.ID, band.LevelID, band.CatalogID, band.Currency))
+
+	return nil
+}
+
+// UpdateSalaryBand updates a salary band of a catalog
+func (s *PayService) UpdateSalaryBand(band *models.SalaryBand, userID uint) error {
+	existing, err := s.payRepo.GetSalaryBandByID(band.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.CatalogID != band.CatalogID {
+		return fmt.Errorf("salary band not found")
+	}
+
+	if err := s.checkSalaryBand(band); err != nil {
+		return err
+	}
+
+	band.CreatedBy = existing.CreatedBy
+	band.CreatedAt = existing.CreatedAt
+	if err := s.payRepo.UpdateSalaryBand(band); err != nil {
+		return err
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "update", "salary_band", fmt.Sprintf("Updated salary band %d of catalog %d", band.ID, band.CatalogID))
+
+	return nil
+}
+
+// DeleteSalaryBand deletes a salary band of a catalog.
+// Recommendations referring to the band keep their encrypted amounts but lose the band reference.
+func (s *PayService) DeleteSalaryBand(catalogID, bandID, userID uint) error {
+	existing, err := s.payRepo.GetSalaryBandByID(bandID)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.CatalogID != catalogID {
+		return fmt.Errorf("salary band not found")
+	}
+
+	if err := s.payRepo.DeleteSalaryBand(bandID); err != nil {
+		return err
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "delete", "salary_band", fmt.Sprintf("Deleted salary band %d of catalog %d", bandID, catalogID))
+
+	return nil
+}
+
+// checkSalaryBand validates a salary band against its catalog and the other bands of the level
+func (s *PayService) checkSalaryBand(band *models.SalaryBand) error {
+	band.Currency = strings.ToUpper(strings.TrimSpace(band.Currency))
+	if err := validateSalaryBand(band); err != nil {
+		return err
+	}
+
+	levels, err := s.catalogRepo.GetLevelsByCatalogID(band.CatalogID)
+	if err != nil {
+		return err
+	}
+	levelFound := false
+	for _, level := range levels {
+		if level.ID == band.LevelID {
+			levelFound = true
+			break
+		}
+	}
+	if !levelFound {
+		return fmt.Errorf("level %d does not belong to catalog %d", band.LevelID, band.CatalogID)
+	}
+
+	overlaps, err := s.payRepo.HasOverlappingSalaryBand(band)
+	if err != nil {
+		return err
+	}
+	if overlaps {
+		return fmt.Errorf("salary band overlaps with existing band of the same level and currency")
+	}
+
+	return nil
+}
+
+// validateSalaryBand checks currency, amounts and effective dates of a salary band
+func validateSalaryBand(band *models.SalaryBand) error {
+	if !isCurrencyCode(band.Currency) {
+		return fmt.Errorf("invalid currency %q (expected ISO 4217 code, e.g. EUR)", band.Currency)
+	}
+	if band.MinSalary < 0 {
+		return fmt.Errorf("min_salary must not be negative")
+	}
+	if band.MaxSalary < band.MinSalary {
+		return fmt.Errorf("max_salary must be greater than or equal to min_salary")
+	}
+	if band.EffectiveFrom.IsZero() {
+		return fmt.Errorf("effective_from is required")
+	}
+	if band.EffectiveUntil != nil && band.EffectiveUntil.Before(band.EffectiveFrom) {
+		return fmt.Errorf("effective_until must not be before effective_from")
+	}
+	return nil
+}
+
+// isCurrencyCode checks for a three letter upper case currency code
+func isCurrencyCode(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// SetEmployeeSalary records a salary of an employee; amount and currency are encrypted in the secure store
+func (s *PayService) SetEmployeeSalary(salary *models.EmployeeSalary, createdBy uint) error {
+	salary.Currency = strings.ToUpper(strings.TrimSpace(salary.Currency))
+	if !isCurrencyCode(salary.Currency) {
+		return fmt.Errorf("invalid currency %q (expected ISO 4217 code, e.g. EUR)", salary.Currency)
+	}
+	if salary.Amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	if salary.EffectiveFrom.IsZero() {
+		return fmt.Errorf("effective_from is required")
+	}
+
+	processID := salaryProcessID(salary.UserID)
+	if err := s.ensureKeys(int64(salary.UserID), processID); err != nil {
+		return err
+	}
+
+	plainData := &securestore.PlainData{
+		Fields: map[string]interface{}{
+			"amount":   salary.Amount,
+			"currency": salary.Currency,
+		},
+		Metadata: map[string]string{
+			"user_id":        fmt.Sprintf("%d", salary.UserID),
+			"effective_from": salary.EffectiveFrom.Format(time.DateOnly),
+		},
+	}
+	record, err := s.secureStore.CreateRecord(processID, int64(salary.UserID), recordTypeSalary, plainData, "")
+	if err != nil {
+		return fmt.Errorf("failed to encrypt salary: %w", err)
+	}
+
+	salary.EncryptedSalaryID = record.ID
+	salary.CreatedBy = &createdBy
+	if err := s.payRepo.CreateEmployeeSalary(salary); err != nil {
+		return err
+	}
+
+	// Audit log (without amount)
+	s.auditSvc.Log(createdBy, "create", "employee_salary", fmt.Sprintf("Recorded salary of user %d effective from %s",
+		salary.UserID, salary.EffectiveFrom.Format(time.DateOnly)))
+
+	return nil
+}
+
+// GetSalaryHistory retrieves the decrypted salary history of an employee, newest first
+func (s *PayService) GetSalaryHistory(userID uint) ([]models.EmployeeSalary, error) {
+	salaries, err := s.payRepo.GetSalariesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range salaries {
+		if err := s.decryptSalary(&salaries[i]); err != nil {
+			return nil, fmt.Errorf("failed to decrypt salary %d: %w", salaries[i].ID, err)
+		}
+	}
+
+	return salaries, nil
+}
+
+// decryptSalary populates amount and currency of a salary from the secure store
+func (s *PayService) decryptSalary(salary *models.EmployeeSalary) error {
+	plainData, err := s.secureStore.DecryptRecord(salary.EncryptedSalaryID)
+	if err != nil {
+		return err
+	}
+
+	amount, ok := plainData.Fields["amount"].(float64)
+	if !ok {
+		return fmt.Errorf("field amount not found or not a number")
+	}
+	currency, ok := plainData.Fields["currency"].(string)
+	if !ok {
+		return fmt.Errorf("field currency not found or not a string")
+	}
+
+	salary.Amount = amount
+	salary.Currency = currency
+	return nil
+}
+
+// OnAssessmentArchived maps the overall level of a newly archived self-assessment to its salary band.
+// It is registered as enter hook of the archived status; failures are logged and the recommendation
+// can be regenerated later.
+func (s *PayService) OnAssessmentArchived(assessment *models.SelfAssessment) {
+	if _, err := s.CreateRecommendation(assessment.ID); err != nil {
+		slog.Error("Failed to create pay recommendation", "assessment_id", assessment.ID, "error", err)
+		return
+	}
+	slog.Info("Pay recommendation created", "assessment_id", assessment.ID)
+}
+
+// CreateRecommendation maps the overall level of an archived assessment to its salary band
+// and stores the encrypted recommendation (current salary -> target salary).
+// An existing recommendation of the assessment is replaced and reopened.
+func (s *PayService) CreateRecommendation(assessmentID uint) (*models.PayRecommendation, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment not found")
+	}
+	if assessment.Status != "archived" {
+		return nil, fmt.Errorf("assessment must be archived to create a pay recommendation")
+	}
+
+	result, err := s.discussionRepo.GetByAssessmentID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("discussion result not found")
+	}
+
+	// Salary and band in effect when the assessment was archived
+	at := time.Now()
+	if assessment.ArchivedAt != nil {
+		at = *assessment.ArchivedAt
+	}
+
+	salary, err := s.payRepo.GetCurrentSalary(assessment.UserID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current salary: %w", err)
+	}
+	var currentSalary *float64
+	currency := ""
+	if salary != nil {
+		if err := s.decryptSalary(salary); err != nil {
+			return nil, fmt.Errorf("failed to decrypt salary: %w", err)
+		}
+		currentSalary = &salary.Amount
+		currency = salary.Currency
+	}
+
+	band, err := s.payRepo.GetEffectiveSalaryBand(result.WeightedOverallLevelID, currency, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get salary band: %w", err)
+	}
+	if band != nil {
+		currency = band.Currency
+	}
+
+	position, targetSalary := computeRecommendation(currentSalary, band)
+
+	processID := salaryProcessID(assessment.UserID)
+	if err := s.ensureKeys(int64(assessment.UserID), processID); err != nil {
+		return nil, err
+	}
+
+	plainData := &securestore.PlainData{
+		Fields: map[string]interface{}{
+			"current_salary": currentSalary,
+			"target_salary":  targetSalary,
+			"currency":       currency,
+		},
+		Metadata: map[string]string{
+			"assessment_id": fmt.Sprintf("%d", assessmentID),
+			"level_id":      fmt.Sprintf("%d", result.WeightedOverallLevelID),
+		},
+	}
+	record, err := s.secureStore.CreateRecord(processID, int64(assessment.UserID), recordTypePayRecommendation, plainData, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt pay recommendation: %w", err)
+	}
+
+	rec := &models.PayRecommendation{
+		AssessmentID:              assessmentID,
+		UserID:                    assessment.UserID,
+		LevelID:                   result.WeightedOverallLevelID,
+		EncryptedRecommendationID: record.ID,
+		BandPosition:              position,
+		Currency:                  currency,
+		CurrentSalary:             currentSalary,
+		TargetSalary:              targetSalary,
+	}
+	if band != nil {
+		rec.SalaryBandID = &band.ID
+		rec.BandMin = &band.MinSalary
+		rec.BandMax = &band.MaxSalary
+		rec.LevelName = band.LevelName
+	}
+
+	if err := s.payRepo.UpsertRecommendation(rec); err != nil {
+		return nil, err
+	}
+
+	// Audit log (without amounts)
+	s.auditSvc.LogSystem("create", "pay_recommendation", fmt.Sprintf("Created pay recommendation for assessment %d (band position: %s)",
+		assessmentID, position))
+
+	return rec, nil
+}
+
+// computeRecommendation determines the position of the current salary in the band and the target salary.
+// Salaries below the band are raised to the band minimum; salaries within or above the band are kept.
+// Without band or current salary (or with different currencies) the position is unknown.
+func computeRecommendation(currentSalary *float64, band *models.SalaryBand) (string, *float64) {
+	if band == nil || currentSalary == nil {
+		return bandPositionUnknown, nil
+	}
+
+	target := *currentSalary
+	switch {
+	case *currentSalary < band.MinSalary:
+		target = band.MinSalary
+		return bandPositionBelow, &target
+	case *currentSalary > band.MaxSalary:
+		return bandPositionAbove, &target
+	default:
+		return bandPositionWithin, &target
+	}
+}
+
+// GetRecommendations retrieves decrypted pay recommendations, optionally filtered by status (open, exported)
+func (s *PayService) GetRecommendations(status string) ([]models.PayRecommendation, error) {
+	if status != "" && status != "open" && status != "exported" {
+		return nil, fmt.Errorf("invalid status: %s", status)
+	}
+
+	recommendations, err := s.payRepo.GetRecommendations(status)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recommendations {
+		if err := s.decryptRecommendation(&recommendations[i]); err != nil {
+			return nil, fmt.Errorf("failed to decrypt pay recommendation %d: %w", recommendations[i].ID, err)
+		}
+	}
+
+	return recommendations, nil
+}
+
+// decryptRecommendation populates currency, current and target salary of a recommendation from the secure store
+func (s *PayService) decryptRecommendation(rec *models.PayRecommendation) error {
+	plainData, err := s.secureStore.DecryptRecord(rec.EncryptedRecommendationID)
+	if err != nil {
+		return err
+	}
+
+	if currency, ok := plainData.Fields["currency"].(string); ok {
+		rec.Currency = currency
+	}
+	if current, ok := plainData.Fields["current_salary"].(float64); ok {
+		rec.CurrentSalary = &current
+	}
+	if target, ok := plainData.Fields["target_salary"].(float64); ok {
+		rec.TargetSalary = &target
+	}
+	return nil
+}
+
+// ExportRecommendations returns the recommendations with the given status as spreadsheet rows.
+// If markExported is set, the exported recommendations are marked as exported.
+func (s *PayService) ExportRecommendations(status string, markExported bool, userID uint) ([][]string, error) {
+	recommendations, err := s.GetRecommendations(status)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := recommendationRows(recommendations)
+
+	if markExported {
+		ids := make([]uint, 0, len(recommendations))
+		for _, rec := range recommendations {
+			if rec.Status == "open" {
+				ids = append(ids, rec.ID)
+			}
+		}
+		if err := s.payRepo.MarkRecommendationsExported(ids); err != nil {
+			return nil, fmt.Errorf("failed to mark recommendations as exported: %w", err)
+		}
+	}
+
+	// Audit log
+	s.auditSvc.Log(userID, "export", "pay_recommendation", fmt.Sprintf("Exported %d pay recommendations", len(recommendations)))
+
+	return rows, nil
+}
+
+// recommendationRows converts recommendations to spreadsheet rows with a header row
+func recommendationRows(recommendations []models.PayRecommendation) [][]string {
+	rows := [][]string{{
+		"assessment_id", "user_id", "user_name", "user_email", "level", "currency",
+		"band_min", "band_max", "current_salary", "target_salary", "band_position", "status",
+	}}
+
+	for _, rec := range recommendations {
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(rec.AssessmentID), 10),
+			strconv.FormatUint(uint64(rec.UserID), 10),
+			rec.UserName,
+			rec.UserEmail,
+			rec.LevelName,
+			rec.Currency,
+			formatAmount(rec.BandMin),
+			formatAmount(rec.BandMax),
+			formatAmount(rec.CurrentSalary),
+			formatAmount(rec.TargetSalary),
+			rec.BandPosition,
+			rec.Status,
+		})
+	}
+
+	return rows
+}
+
+// formatAmount formats an optional amount with two decimals (empty if unknown)
+func formatAmount(amount *float64) string {
+	if amount == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*amount, 'f', 2, 64)
+}
+
+// ensureKeys ensures the user key and the process key for salary data exist
+func (s *PayService) ensureKeys(userID int64, processID string) error {
+	if _, err := s.keyManager.GetUserPublicKey(userID); err != nil {
+		if _, err := s.keyManager.CreateUserKey(userID); err != nil {
+			return fmt.Errorf("failed to ensure user key: %w", err)
+		}
+	}
+	if _, err := s.keyManager.GetProcessKeyHash(processID); err != nil {
+		if err := s.keyManager.CreateProcessKey(processID, nil); err != nil {
+			return fmt.Errorf("failed to ensure process key: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+func TestComputeRecommendation(t *testing.T) {
+	band := &models.SalaryBand{MinSalary: 50000, MaxSalary: 60000}
+	amount := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name         string
+		current      *float64
+		band         *models.SalaryBand
+		wantPosition string
+		wantTarget   *float64
+	}{
+		{name: "below band", current: amount(45000), band: band, wantPosition: bandPositionBelow, wantTarget: amount(50000)},
+		{name: "at band minimum", current: amount(50000), band: band, wantPosition: bandPositionWithin, wantTarget: amount(50000)},
+		{name: "within band", current: amount(55000), band: band, wantPosition: bandPositionWithin, wantTarget: amount(55000)},
+		{name: "above band", current: amount(65000), band: band, wantPosition: bandPositionAbove, wantTarget: amount(65000)},
+		{name: "unknown salary", current: nil, band: band, wantPosition: bandPositionUnknown},
+		{name: "no band", current: amount(55000), band: nil, wantPosition: bandPositionUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, target := computeRecommendation(tt.current, tt.band)
+			if position != tt.wantPosition {
+				t.Errorf("position = %q, want %q", position, tt.wantPosition)
+			}
+			if (target == nil) != (tt.wantTarget == nil) || (target != nil && *target != *tt.wantTarget) {
+				t.Errorf("target = %v, want %v", target, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestValidateSalaryBand(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := from.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name    string
+		band    models.SalaryBand
+		wantErr bool
+	}{
+		{name: "valid open-ended", band: models.SalaryBand{Currency: "EUR", MinSalary: 40000, MaxSalary: 50000, EffectiveFrom: from}},
+		{name: "invalid currency", band: models.SalaryBand{Currency: "eur", MinSalary: 40000, MaxSalary: 50000, EffectiveFrom: from}, wantErr: true},
+		{name: "max below min", band: models.SalaryBand{Currency: "EUR", MinSalary: 50000, MaxSalary: 40000, EffectiveFrom: from}, wantErr: true},
+		{name: "missing start", band: models.SalaryBand{Currency: "EUR", MinSalary: 40000, MaxSalary: 50000}, wantErr: true},
+		{name: "end before start", band: models.SalaryBand{Currency: "EUR", MinSalary: 40000, MaxSalary: 50000, EffectiveFrom: from, EffectiveUntil: &before}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSalaryBand(&tt.band); (err != nil) != tt.wantErr {
+				t.Errorf("validateSalaryBand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
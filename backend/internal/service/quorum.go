@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+
+	"new-pay/internal/models"
+)
+
+// quorumSize returns the approvals needed for a quorum of count and percent of the panel:
+// the higher of both, capped at the reviewers eligible to approve, but at least one
+func quorumSize(count, percent, panelSize, eligible int) int {
+	required := count
+	if byPercent := (percent*panelSize + 99) / 100; byPercent > required {
+		required = byPercent
+	}
+	if required > eligible {
+		required = eligible
+	}
+	if required < 1 {
+		required = 1
+	}
+	return required
+}
+
+// requiredAveragedApprovals returns the approvals an averaged category result needs
+func requiredAveragedApprovals(policy *models.QuorumPolicy, panelSize int) int {
+	return quorumSize(policy.AveragedCount, policy.AveragedPercent, panelSize, panelSize)
+}
+
+// requiredOverrideApprovals returns the approvals an override needs; without self-approval
+// the creator is not eligible
+func requiredOverrideApprovals(policy *models.QuorumPolicy, panelSize int) int {
+	eligible := panelSize
+	if !policy.AllowSelfApproval {
+		eligible--
+	}
+	return quorumSize(policy.OverrideCount, policy.OverridePercent, panelSize, eligible)
+}
+
+// requiredFinalQuorum returns the approvals the final consolidation needs
+func requiredFinalQuorum(policy *models.QuorumPolicy, panelSize int) int {
+	return quorumSize(policy.FinalCount, policy.FinalPercent, panelSize, panelSize)
+}
+
+// applyAveragedQuorum sets required approvals and approval state of averaged category results
+func applyAveragedQuorum(averaged []models.AveragedReviewerResponse, policy *models.QuorumPolicy, panelSize int) {
+	required := requiredAveragedApprovals(policy, panelSize)
+	for i := range averaged {
+		averaged[i].RequiredApprovals = required
+		averaged[i].IsApproved = averaged[i].ApprovalCount >= required
+	}
+}
+
+// applyOverrideQuorum sets required approvals and approval state of overrides;
+// without self-approval an approval of the creator (given under an earlier policy) does not count
+func applyOverrideQuorum(overrides []models.ConsolidationOverride, policy *models.QuorumPolicy, panelSize int) {
+	required := requiredOverrideApprovals(policy, panelSize)
+	for i := range overrides {
+		counted := 0
+		for _, approval := range overrides[i].Approvals {
+			if policy.AllowSelfApproval || approval.ApprovedByUserID != overrides[i].CreatedByUserID {
+				counted++
+			}
+		}
+		overrides[i].RequiredApprovals = required
+		overrides[i].IsApproved = counted >= required
+	}
+}
+
+// validateQuorumPolicy checks counts and percentages of a quorum policy
+func validateQuorumPolicy(policy *models.QuorumPolicy) error {
+	for _, q := range []struct {
+		name           string
+		count, percent int
+	}{
+		{"averaged", policy.AveragedCount, policy.AveragedPercent},
+		{"override", policy.OverrideCount, policy.OverridePercent},
+		{"final", policy.FinalCount, policy.FinalPercent},
+	} {
+		if q.count < 0 {
+			return fmt.Errorf("%s count must not be negative", q.name)
+		}
+		if q.percent < 0 || q.percent > 100 {
+			return fmt.Errorf("%s percent must be between 0 and 100", q.name)
+		}
+		if q.count == 0 && q.percent == 0 {
+			return fmt.Errorf("%s quorum requires a count or a percentage", q.name)
+		}
+	}
+	return nil
+}
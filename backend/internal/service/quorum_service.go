@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+)
+
+// QuorumService manages the quorum policies for consolidation approvals
+type QuorumService struct {
+	quorumRepo    *repository.QuorumPolicyRepository
+	catalogRepo   *repository.CatalogRepository
+	auditSvc      *AuditService
+	defaultPolicy models.QuorumPolicy // Global default from configuration
+}
+
+// NewQuorumService creates a new quorum service
+func NewQuorumService(
+	quorumRepo *repository.QuorumPolicyRepository,
+	catalogRepo *repository.CatalogRepository,
+	auditSvc *AuditService,
+	defaultPolicy models.QuorumPolicy,
+) *QuorumService {
+	defaultPolicy.CatalogID = nil
+	defaultPolicy.IsDefault = true
+	return &QuorumService{
+		quorumRepo:    quorumRepo,
+		catalogRepo:   catalogRepo,
+		auditSvc:      auditSvc,
+		defaultPolicy: defaultPolicy,
+	}
+}
+
+// GetDefaultPolicy returns the global default quorum policy
+func (s *QuorumService) GetDefaultPolicy() *models.QuorumPolicy {
+	policy := s.defaultPolicy
+	return &policy
+}
+
+// GetPolicyForCatalog returns the quorum policy of a catalog or the global default
+func (s *QuorumService) GetPolicyForCatalog(catalogID uint) (*models.QuorumPolicy, error) {
+	policy, err := s.quorumRepo.GetByCatalogID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		return policy, nil
+	}
+
+	policy = s.GetDefaultPolicy()
+	policy.CatalogID = &catalogID
+	return policy, nil
+}
+
+// SetCatalogPolicy stores a catalog-specific quorum policy
+func (s *QuorumService) SetCatalogPolicy(catalogID uint, policy *models.QuorumPolicy, userID uint) error {
+	if err := validateQuorumPolicy(policy); err != nil {
+		return err
+	}
+
+	catalog, err := s.catalogRepo.GetCatalogByID(catalogID)
+	if err != nil {
+		return err
+	}
+	if catalog == nil {
+		return fmt.Errorf("catalog not found")
+	}
+
+	policy.CatalogID = &catalogID
+	policy.UpdatedBy = &userID
+	policy.IsDefault = false
+	if err := s.quorumRepo.Upsert(policy); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "set_quorum_policy", "catalog",
+		fmt.Sprintf("Set quorum policy of catalog %d (averaged %d/%d%%, override %d/%d%%, final %d/%d%%, self-approval %t)",
+			catalogID, policy.AveragedCount, policy.AveragedPercent, policy.OverrideCount, policy.OverridePercent,
+			policy.FinalCount, policy.FinalPercent, policy.AllowSelfApproval))
+
+	return nil
+}
+
+// DeleteCatalogPolicy removes the catalog-specific quorum policy so the global default applies
+func (s *QuorumService) DeleteCatalogPolicy(catalogID uint, userID uint) error {
+	if err := s.quorumRepo.Delete(catalogID); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "delete_quorum_policy", "catalog",
+		fmt.Sprintf("Reset quorum policy of catalog %d to the default", catalogID))
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"new-pay/internal/models"
+)
+
+func TestQuorumSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		percent   int
+		panelSize int
+		eligible  int
+		want      int
+	}{
+		{name: "count only", count: 2, panelSize: 5, eligible: 5, want: 2},
+		{name: "percent rounds up", percent: 50, panelSize: 5, eligible: 5, want: 3},
+		{name: "whole panel", percent: 100, panelSize: 4, eligible: 4, want: 4},
+		{name: "higher of both", count: 2, percent: 75, panelSize: 4, eligible: 4, want: 3},
+		{name: "count above percent", count: 4, percent: 50, panelSize: 5, eligible: 5, want: 4},
+		{name: "capped at eligible", count: 2, panelSize: 1, eligible: 1, want: 1},
+		{name: "at least one", percent: 100, panelSize: 0, eligible: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quorumSize(tt.count, tt.percent, tt.panelSize, tt.eligible); got != tt.want {
+				t.Errorf("quorumSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiredQuorumPerKind(t *testing.T) {
+	// Global defaults preserve the former fixed rules
+	policy := &models.QuorumPolicy{AveragedCount: 2, OverrideCount: 1, FinalPercent: 100}
+
+	if got := requiredAveragedApprovals(policy, 3); got != 2 {
+		t.Errorf("averaged = %d, want 2", got)
+	}
+	if got := requiredOverrideApprovals(policy, 3); got != 1 {
+		t.Errorf("override = %d, want 1", got)
+	}
+	if got := requiredFinalQuorum(policy, 3); got != 3 {
+		t.Errorf("final = %d, want 3", got)
+	}
+
+	// Without self-approval the override creator does not count as eligible approver
+	policy = &models.QuorumPolicy{OverridePercent: 100}
+	if got := requiredOverrideApprovals(policy, 3); got != 2 {
+		t.Errorf("override without self-approval = %d, want 2", got)
+	}
+	policy.AllowSelfApproval = true
+	if got := requiredOverrideApprovals(policy, 3); got != 3 {
+		t.Errorf("override with self-approval = %d, want 3", got)
+	}
+}
+
+func TestApplyQuorum(t *testing.T) {
+	policy := &models.QuorumPolicy{AveragedPercent: 50, OverrideCount: 1}
+
+	averaged := []models.AveragedReviewerResponse{
+		{CategoryID: 1, ApprovalCount: 1},
+		{CategoryID: 2, ApprovalCount: 2},
+	}
+	applyAveragedQuorum(averaged, policy, 4)
+	if averaged[0].RequiredApprovals != 2 || averaged[0].IsApproved || !averaged[1].IsApproved {
+		t.Errorf("averaged quorum = %+v", averaged)
+	}
+
+	overrides := []models.ConsolidationOverride{
+		{CategoryID: 1, CreatedByUserID: 7, Approvals: []models.ConsolidationOverrideApproval{{ApprovedByUserID: 7}}},
+		{CategoryID: 2, CreatedByUserID: 7, Approvals: []models.ConsolidationOverrideApproval{{ApprovedByUserID: 8}}},
+	}
+	applyOverrideQuorum(overrides, policy, 4)
+	if overrides[0].IsApproved {
+		t.Error("approval of the creator counted without self-approval")
+	}
+	if !overrides[1].IsApproved {
+		t.Error("approval of another reviewer not counted")
+	}
+
+	policy.AllowSelfApproval = true
+	applyOverrideQuorum(overrides, policy, 4)
+	if !overrides[0].IsApproved {
+		t.Error("approval of the creator not counted with self-approval")
+	}
+}
+
+func TestValidateQuorumPolicy(t *testing.T) {
+	valid := func() *models.QuorumPolicy {
+		return &models.QuorumPolicy{AveragedCount: 2, OverrideCount: 1, FinalPercent: 100}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(p *models.QuorumPolicy)
+		wantErr string
+	}{
+		{name: "valid", modify: func(p *models.QuorumPolicy) {}},
+		{name: "negative count", modify: func(p *models.QuorumPolicy) { p.OverrideCount = -1 }, wantErr: "override count must not be negative"},
+		{name: "percent above 100", modify: func(p *models.QuorumPolicy) { p.AveragedPercent = 120 }, wantErr: "averaged percent must be between 0 and 100"},
+		{name: "empty quorum", modify: func(p *models.QuorumPolicy) { p.FinalPercent = 0 }, wantErr: "final quorum requires a count or a percentage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := valid()
+			tt.modify(policy)
+			err := validateQuorumPolicy(policy)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"fmt"
+
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+)
+
+// panelEditableStatuses are the assessment states in which the reviewer panel may still change
+var panelEditableStatuses = map[string]bool{
+	"draft":             true,
+	"submitted":         true,
+	"changes_requested": true,
+	"in_review":         true,
+}
+
+// ReviewAssignmentService handles the reviewer panels of self-assessments
+type ReviewAssignmentService struct {
+	assignmentRepo *repository.ReviewerAssignmentRepository
+	assessmentRepo *repository.SelfAssessmentRepository
+	reviewerRepo   *repository.ReviewerResponseRepository
+	userRepo       *repository.UserRepository
+	auditSvc       *AuditService
+	minPanelSize   int
+	maxPanelSize   int
+	autoAssign     bool // Assign a balanced panel when an assessment is submitted
+}
+
+// NewReviewAssignmentService creates a new review assignment service
+func NewReviewAssignmentService(
+	assignmentRepo *repository.ReviewerAssignmentRepository,
+	assessmentRepo *repository.SelfAssessmentRepository,
+	reviewerRepo *repository.ReviewerResponseRepository,
+	userRepo *repository.UserRepository,
+	auditSvc *AuditService,
+	minPanelSize, maxPanelSize int,
+	autoAssign bool,
+) *ReviewAssignmentService {
+	return &ReviewAssignmentService{
+		assignmentRepo: assignmentRepo,
+		assessmentRepo: assessmentRepo,
+		reviewerRepo:   reviewerRepo,
+		userRepo:       userRepo,
+		auditSvc:       auditSvc,
+		minPanelSize:   minPanelSize,
+		maxPanelSize:   maxPanelSize,
+		autoAssign:     autoAssign,
+	}
+}
+
+// GetPanel returns the reviewer panel of an assessment
+func (s *ReviewAssignmentService) GetPanel(assessmentID uint) (*models.ReviewerPanel, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("self-assessment not found")
+	}
+
+	reviewers, err := s.assignmentRepo.GetByAssessmentID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReviewerPanel{
+		AssessmentID: assessmentID,
+		Reviewers:    reviewers,
+		MinSize:      s.minPanelSize,
+		MaxSize:      s.maxPanelSize,
+	}, nil
+}
+
+// GetIncompletePanels returns the submitted and in-review self-assessments whose panel is smaller than
+// the minimum size. Panels backfilled from existing reviewer responses may be smaller, and assessments
+// that were submitted before panels existed have none until they are assigned.
+func (s *ReviewAssignmentService) GetIncompletePanels() ([]models.IncompletePanel, error) {
+	return s.assignmentRepo.GetOpenWithIncompletePanel(s.minPanelSize)
+}
+
+// GetCandidates returns the active reviewers who may be assigned to an assessment (everyone but the owner)
+func (s *ReviewAssignmentService) GetCandidates(assessmentID uint) ([]models.User, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("self-assessment not found")
+	}
+
+	reviewers, err := s.userRepo.GetUsersByRoleName("reviewer")
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []models.User{}
+	for _, reviewer := range reviewers {
+		if reviewer.ID != assessment.UserID {
+			candidates = append(candidates, reviewer)
+		}
+	}
+	return candidates, nil
+}
+
+// SetPanel replaces the reviewer panel of an assessment (admins and team leads)
+func (s *ReviewAssignmentService) SetPanel(assessmentID uint, reviewerIDs []uint, userID uint, userRoles []string) (*models.ReviewerPanel, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("self-assessment not found")
+	}
+
+	if !contains(userRoles, "admin") {
+		if !contains(userRoles, "team_lead") {
+			return nil, fmt.Errorf("permission denied: only admins and team leads can assign reviewers")
+		}
+		if assessment.UserID == userID {
+			return nil, fmt.Errorf("permission denied: cannot assign reviewers to your own self-assessment")
+		}
+	}
+
+	if !panelEditableStatuses[assessment.Status] {
+		return nil, fmt.Errorf("cannot change reviewer panel in %s status", assessment.Status)
+	}
+
+	if err := validatePanel(reviewerIDs, assessment.UserID, s.minPanelSize, s.maxPanelSize); err != nil {
+		return nil, err
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		roles, err := s.userRepo.GetUserRoles(reviewerID)
+		if err != nil {
+			return nil, err
+		}
+		isReviewer := false
+		for _, role := range roles {
+			if role.Name == "reviewer" {
+				isReviewer = true
+				break
+			}
+		}
+		if !isReviewer {
+			return nil, fmt.Errorf("user %d does not have the reviewer role", reviewerID)
+		}
+	}
+
+	// The exclusions of the balanced proposal apply to manual assignments as well
+	if err := s.checkPanelExclusions(assessment, reviewerIDs); err != nil {
+		return nil, err
+	}
+
+	// Reviewers who already started their review cannot be removed
+	current, err := s.assignmentRepo.GetByAssessmentID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range current {
+		if containsUint(reviewerIDs, assignment.ReviewerUserID) {
+			continue
+		}
+		count, err := s.reviewerRepo.CountByAssessmentAndReviewer(assessmentID, assignment.ReviewerUserID)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("cannot remove reviewer %s: review already started", assignment.ReviewerName)
+		}
+	}
+
+	if err := s.assignmentRepo.ReplacePanel(assessmentID, reviewerIDs, &userID); err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Log(userID, "assign_reviewers", "self_assessment",
+		fmt.Sprintf("Assigned reviewer panel %v to self-assessment %d", reviewerIDs, assessmentID))
+
+	return s.GetPanel(assessmentID)
+}
+
+// CheckAssigned returns an error if the user is not on the reviewer panel of the assessment
+func (s *ReviewAssignmentService) CheckAssigned(assessmentID, userID uint) error {
+	assigned, err := s.assignmentRepo.IsAssigned(assessmentID, userID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return fmt.Errorf("permission denied: you are not assigned as reviewer of this self-assessment")
+	}
+	return nil
+}
+
+// validatePanel checks size and members of a reviewer panel
+func validatePanel(reviewerIDs []uint, ownerID uint, minSize, maxSize int) error {
+	if len(reviewerIDs) < minSize || len(reviewerIDs) > maxSize {
+		return fmt.Errorf("reviewer panel must have between %d and %d reviewers (got %d)", minSize, maxSize, len(reviewerIDs))
+	}
+
+	seen := make(map[uint]bool, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		if id == ownerID {
+			return fmt.Errorf("the owner of a self-assessment cannot review it")
+		}
+		if seen[id] {
+			return fmt.Errorf("reviewer %d is assigned more than once", id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package service
+
+import "testing"
+
+func TestValidatePanel(t *testing.T) {
+	tests := []struct {
+		name      string
+		reviewers []uint
+		wantErr   bool
+	}{
+		{name: "valid panel", reviewers: []uint{2, 3, 4}},
+		{name: "maximum size", reviewers: []uint{2, 3, 4, 5}},
+		{name: "too small", reviewers: []uint{2, 3}, wantErr: true},
+		{name: "too large", reviewers: []uint{2, 3, 4, 5, 6}, wantErr: true},
+		{name: "owner on panel", reviewers: []uint{1, 2, 3}, wantErr: true},
+		{name: "duplicate reviewer", reviewers: []uint{2, 2, 3}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePanel(tt.reviewers, 1, 3, 4)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePanel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+)
+
+// Reasons why a reviewer is excluded from a panel
+const (
+	exclusionSameTeam           = "same_team"
+	exclusionConflictOfInterest = "conflict_of_interest"
+)
+
+// ProposePanel computes a balanced reviewer panel for an assessment without saving it.
+// Reviewers of the owner's team and reviewers with a declared conflict of interest are excluded;
+// the remaining reviewers are ranked by open workload and, for equal workload, by the
+// longest time since their last assignment (round-robin).
+func (s *ReviewAssignmentService) ProposePanel(assessmentID uint) (*models.PanelProposal, error) {
+	assessment, err := s.assessmentRepo.GetByID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("self-assessment not found")
+	}
+
+	owner, err := s.userRepo.GetByID(assessment.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner: %w", err)
+	}
+
+	reviewers, err := s.userRepo.GetUsersByRoleName("reviewer")
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.assignmentRepo.GetConflictedReviewerIDs(owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := reviewerWorkloads(s.assignmentRepo, s.reviewerRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible, excluded := filterPanelCandidates(reviewers, owner, conflicts)
+
+	candidates := make([]models.ReviewerWorkload, 0, len(eligible))
+	for _, reviewer := range eligible {
+		workload := workloads[reviewer.ID]
+		workload.ReviewerUserID = reviewer.ID
+		workload.ReviewerName = reviewer.FirstName + " " + reviewer.LastName
+		candidates = append(candidates, workload)
+	}
+
+	selected := selectPanel(candidates, s.minPanelSize)
+
+	return &models.PanelProposal{
+		AssessmentID: assessmentID,
+		Size:         s.minPanelSize,
+		Reviewers:    selected,
+		Excluded:     excluded,
+		Complete:     len(selected) >= s.minPanelSize,
+	}, nil
+}
+
+// ApplyProposal assigns the proposed panel to an assessment (admins and team leads).
+// The same rules as for a manual assignment apply.
+func (s *ReviewAssignmentService) ApplyProposal(assessmentID uint, userID uint, userRoles []string) (*models.ReviewerPanel, error) {
+	proposal, err := s.ProposePanel(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !proposal.Complete {
+		return nil, fmt.Errorf("not enough eligible reviewers: %d of %d", len(proposal.Reviewers), proposal.Size)
+	}
+
+	return s.SetPanel(assessmentID, reviewerIDs(proposal.Reviewers), userID, userRoles)
+}
+
+// AssignPanelOnSubmit assigns a balanced panel to a newly submitted assessment if automatic
+// assignment is enabled and no panel was assigned yet
+func (s *ReviewAssignmentService) AssignPanelOnSubmit(assessmentID uint) error {
+	if !s.autoAssign {
+		return nil
+	}
+	return s.autoAssignPanel(assessmentID)
+}
+
+// AssignPendingPanels assigns balanced panels to all submitted assessments without panel (scheduler)
+func (s *ReviewAssignmentService) AssignPendingPanels() (int, error) {
+	ids, err := s.assignmentRepo.GetSubmittedWithoutPanel()
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for _, id := range ids {
+		// Workloads change with every assignment, so each panel is computed on the current state
+		if err := s.autoAssignPanel(id); err != nil {
+			slog.Error("Failed to assign reviewer panel", "assessment_id", id, "error", err)
+			continue
+		}
+		assigned++
+	}
+
+	return assigned, nil
+}
+
+// autoAssignPanel saves the proposed panel as system assignment if the assessment has no panel yet
+func (s *ReviewAssignmentService) autoAssignPanel(assessmentID uint) error {
+	panelSize, err := s.assignmentRepo.CountByAssessmentID(assessmentID)
+	if err != nil {
+		return err
+	}
+	if panelSize > 0 {
+		return nil
+	}
+
+	proposal, err := s.ProposePanel(assessmentID)
+	if err != nil {
+		return err
+	}
+	if !proposal.Complete {
+		return fmt.Errorf("not enough eligible reviewers: %d of %d", len(proposal.Reviewers), proposal.Size)
+	}
+
+	ids := reviewerIDs(proposal.Reviewers)
+	if err := s.assignmentRepo.ReplacePanel(assessmentID, ids, nil); err != nil {
+		return err
+	}
+
+	s.auditSvc.LogSystem("assign_reviewers", "self_assessment",
+		fmt.Sprintf("Automatically assigned reviewer panel %v to self-assessment %d", ids, assessmentID))
+
+	return nil
+}
+
+// GetConflicts returns the declared conflicts of interest; admins see all, reviewers only their own
+func (s *ReviewAssignmentService) GetConflicts(userID uint, userRoles []string) ([]models.ReviewerConflict, error) {
+	if contains(userRoles, "admin") {
+		return s.assignmentRepo.GetConflicts(nil)
+	}
+	return s.assignmentRepo.GetConflicts(&userID)
+}
+
+// DeclareConflict records a conflict of interest between a reviewer and an employee.
+// Reviewers declare their own conflicts; admins can declare them for any reviewer.
+func (s *ReviewAssignmentService) DeclareConflict(conflict *models.ReviewerConflict, userID uint, userRoles []string) error {
+	isAdmin := contains(userRoles, "admin")
+	if conflict.ReviewerUserID == 0 || !isAdmin {
+		conflict.ReviewerUserID = userID
+	}
+	if conflict.UserID == 0 {
+		return fmt.Errorf("user_id is required")
+	}
+	if conflict.UserID == conflict.ReviewerUserID {
+		return fmt.Errorf("a reviewer cannot declare a conflict of interest with themselves")
+	}
+
+	if _, err := s.userRepo.GetByID(conflict.UserID); err != nil {
+		return fmt.Errorf("user not found")
+	}
+
+	conflict.CreatedBy = &userID
+	if err := s.assignmentRepo.CreateConflict(conflict); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "declare_conflict", "reviewer_conflict",
+		fmt.Sprintf("Declared conflict of interest between reviewer %d and user %d", conflict.ReviewerUserID, conflict.UserID))
+
+	return nil
+}
+
+// DeleteConflict removes a conflict of interest (own conflicts or any as admin)
+func (s *ReviewAssignmentService) DeleteConflict(id uint, userID uint, userRoles []string) error {
+	conflict, err := s.assignmentRepo.GetConflictByID(id)
+	if err != nil {
+		return err
+	}
+	if conflict == nil {
+		return fmt.Errorf("conflict of interest not found")
+	}
+	if !contains(userRoles, "admin") && conflict.ReviewerUserID != userID {
+		return fmt.Errorf("permission denied: cannot delete conflicts of other reviewers")
+	}
+
+	if err := s.assignmentRepo.DeleteConflict(id); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "delete_conflict", "reviewer_conflict",
+		fmt.Sprintf("Removed conflict of interest between reviewer %d and user %d", conflict.ReviewerUserID, conflict.UserID))
+
+	return nil
+}
+
+// reviewerWorkloads returns the open reviews and the last assignment of all reviewers with assignments.
+// An assigned review is open until GetReviewStats counts it as completed, so panel balancing and the
+// review statistics of the assessment lists use the same numbers.
+func reviewerWorkloads(assignmentRepo *repository.ReviewerAssignmentRepository, reviewerRepo *repository.ReviewerResponseRepository) (map[uint]models.ReviewerWorkload, error) {
+	assignments, err := assignmentRepo.GetAssignmentsInReview()
+	if err != nil {
+		return nil, err
+	}
+	lastAssigned, err := assignmentRepo.GetLastAssignedAt()
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make(map[uint]map[uint]bool) // [assessment ID][reviewer ID]
+	for _, assignment := range assignments {
+		if _, ok := completed[assignment.AssessmentID]; ok {
+			continue
+		}
+		completed[assignment.AssessmentID] = make(map[uint]bool)
+
+		_, completedCount, err := reviewerRepo.GetReviewStats(assignment.AssessmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get review stats: %w", err)
+		}
+		if completedCount == 0 {
+			continue
+		}
+		reviewers, err := reviewerRepo.GetCompleteReviewers(assignment.AssessmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get complete reviewers: %w", err)
+		}
+		for _, reviewer := range reviewers {
+			completed[assignment.AssessmentID][reviewer.ReviewerID] = true
+		}
+	}
+
+	return countReviewerWorkloads(assignments, completed, lastAssigned), nil
+}
+
+// countReviewerWorkloads counts the assignments without completed review per reviewer
+func countReviewerWorkloads(assignments []models.ReviewerAssignment, completed map[uint]map[uint]bool, lastAssigned map[uint]time.Time) map[uint]models.ReviewerWorkload {
+	workloads := make(map[uint]models.ReviewerWorkload, len(lastAssigned))
+	for reviewerID, assignedAt := range lastAssigned {
+		workloads[reviewerID] = models.ReviewerWorkload{ReviewerUserID: reviewerID, LastAssignedAt: &assignedAt}
+	}
+
+	for _, assignment := range assignments {
+		if completed[assignment.AssessmentID][assignment.ReviewerUserID] {
+			continue
+		}
+		workload := workloads[assignment.ReviewerUserID]
+		workload.ReviewerUserID = assignment.ReviewerUserID
+		workload.OpenReviews++
+		workloads[assignment.ReviewerUserID] = workload
+	}
+
+	return workloads
+}
+
+// checkPanelExclusions applies the exclusions of the balanced proposal to a manually chosen panel:
+// reviewers of the owner's team and reviewers with a conflict of interest are rejected
+func (s *ReviewAssignmentService) checkPanelExclusions(assessment *models.SelfAssessment, reviewerIDs []uint) error {
+	owner, err := s.userRepo.GetByID(assessment.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get owner: %w", err)
+	}
+
+	conflicts, err := s.assignmentRepo.GetConflictedReviewerIDs(owner.ID)
+	if err != nil {
+		return err
+	}
+
+	reviewers := make([]models.User, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		reviewer, err := s.userRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("failed to get reviewer %d: %w", id, err)
+		}
+		reviewers = append(reviewers, *reviewer)
+	}
+
+	_, excluded := filterPanelCandidates(reviewers, owner, conflicts)
+	return panelExclusionError(excluded)
+}
+
+// panelExclusionError describes the excluded reviewers of a manually chosen panel (nil if there are none)
+func panelExclusionError(excluded []models.ReviewerExclusion) error {
+	if len(excluded) == 0 {
+		return nil
+	}
+	reasons := make([]string, len(excluded))
+	for i, exclusion := range excluded {
+		reasons[i] = fmt.Sprintf("%s (%s)", exclusion.ReviewerName, exclusion.Reason)
+	}
+	return fmt.Errorf("reviewers excluded from this self-assessment: %s", strings.Join(reasons, ", "))
+}
+
+// filterPanelCandidates removes the owner, reviewers of the owner's team and reviewers with a conflict of interest
+func filterPanelCandidates(reviewers []models.User, owner *models.User, conflicts map[uint]bool) ([]models.User, []models.ReviewerExclusion) {
+	var eligible []models.User
+	excluded := []models.ReviewerExclusion{}
+
+	for _, reviewer := range reviewers {
+		if reviewer.ID == owner.ID {
+			continue
+		}
+
+		reason := ""
+		switch {
+		case conflicts[reviewer.ID]:
+			reason = exclusionConflictOfInterest
+		case sameTeam(reviewer.Team, owner.Team):
+			reason = exclusionSameTeam
+		}
+
+		if reason != "" {
+			excluded = append(excluded, models.ReviewerExclusion{
+				ReviewerUserID: reviewer.ID,
+				ReviewerName:   reviewer.FirstName + " " + reviewer.LastName,
+				Reason:         reason,
+			})
+			continue
+		}
+		eligible = append(eligible, reviewer)
+	}
+
+	return eligible, excluded
+}
+
+// sameTeam reports whether both users belong to the same (non-empty) team
+func sameTeam(a, b *string) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	teamA, teamB := strings.TrimSpace(*a), strings.TrimSpace(*b)
+	return teamA != "" && strings.EqualFold(teamA, teamB)
+}
+
+// selectPanel picks up to size reviewers with the lowest open workload;
+// ties go to the reviewer who was assigned least recently (never assigned first), then to the lower ID
+func selectPanel(candidates []models.ReviewerWorkload, size int) []models.ReviewerWorkload {
+	ranked := append([]models.ReviewerWorkload(nil), candidates...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.OpenReviews != b.OpenReviews {
+			return a.OpenReviews < b.OpenReviews
+		}
+		if (a.LastAssignedAt == nil) != (b.LastAssignedAt == nil) {
+			return a.LastAssignedAt == nil
+		}
+		if a.LastAssignedAt != nil && !a.LastAssignedAt.Equal(*b.LastAssignedAt) {
+			return a.LastAssignedAt.Before(*b.LastAssignedAt)
+		}
+		return a.ReviewerUserID < b.ReviewerUserID
+	})
+
+	if len(ranked) > size {
+		ranked = ranked[:size]
+	}
+	return ranked
+}
+
+// reviewerIDs returns the user IDs of the reviewers
+func reviewerIDs(workloads []models.ReviewerWorkload) []uint {
+	ids := make([]uint, len(workloads))
+	for i, w := range workloads {
+		ids[i] = w.ReviewerUserID
+	}
+	return ids
+}
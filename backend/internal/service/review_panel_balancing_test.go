@@ -0,0 +1,123 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+func TestFilterPanelCandidates(t *testing.T) {
+	team := func(name string) *string { return &name }
+
+	owner := &models.User{ID: 1, Team: team("Platform")}
+	reviewers := []models.User{
+		{ID: 1, Team: team("Platform")},
+		{ID: 2, Team: team("platform ")},
+		{ID: 3, Team: team("Payments")},
+		{ID: 4},
+		{ID: 5, Team: team("Data")},
+	}
+
+	eligible, excluded := filterPanelCandidates(reviewers, owner, map[uint]bool{5: true})
+
+	var eligibleIDs []uint
+	for _, u := range eligible {
+		eligibleIDs = append(eligibleIDs, u.ID)
+	}
+	if !reflect.DeepEqual(eligibleIDs, []uint{3, 4}) {
+		t.Errorf("eligible = %v, want [3 4]", eligibleIDs)
+	}
+
+	reasons := map[uint]string{}
+	for _, e := range excluded {
+		reasons[e.ReviewerUserID] = e.Reason
+	}
+	want := map[uint]string{2: exclusionSameTeam, 5: exclusionConflictOfInterest}
+	if !reflect.DeepEqual(reasons, want) {
+		t.Errorf("excluded = %v, want %v", reasons, want)
+	}
+}
+
+func TestFilterPanelCandidatesWithoutTeam(t *testing.T) {
+	empty := ""
+	owner := &models.User{ID: 1, Team: &empty}
+	reviewers := []models.User{{ID: 2, Team: &empty}, {ID: 3}}
+
+	eligible, excluded := filterPanelCandidates(reviewers, owner, nil)
+	if len(eligible) != 2 || len(excluded) != 0 {
+		t.Errorf("got %d eligible and %d excluded, want 2 and 0", len(eligible), len(excluded))
+	}
+}
+
+func TestSelectPanel(t *testing.T) {
+	earlier := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(24 * time.Hour)
+
+	candidates := []models.ReviewerWorkload{
+		{ReviewerUserID: 1, OpenReviews: 2},
+		{ReviewerUserID: 2, OpenReviews: 0, LastAssignedAt: &later},
+		{ReviewerUserID: 3, OpenReviews: 0, LastAssignedAt: &earlier},
+		{ReviewerUserID: 4, OpenReviews: 1},
+		{ReviewerUserID: 5, OpenReviews: 0},
+		{ReviewerUserID: 6, OpenReviews: 1},
+	}
+
+	tests := []struct {
+		name string
+		size int
+		want []uint
+	}{
+		{name: "lowest workload, never assigned first", size: 3, want: []uint{5, 3, 2}},
+		{name: "equal workload falls back to ID", size: 5, want: []uint{5, 3, 2, 4, 6}},
+		{name: "fewer candidates than size", size: 10, want: []uint{5, 3, 2, 4, 6, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reviewerIDs(selectPanel(candidates, tt.size))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectPanel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if candidates[0].ReviewerUserID != 1 {
+		t.Error("selectPanel() must not reorder the input")
+	}
+}
+
+func TestCountReviewerWorkloads(t *testing.T) {
+	assignedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	assignments := []models.ReviewerAssignment{
+		{AssessmentID: 10, ReviewerUserID: 1},
+		{AssessmentID: 10, ReviewerUserID: 2},
+		{AssessmentID: 11, ReviewerUserID: 1},
+	}
+	// Reviewer 2 completed the review of assessment 10, reviewer 3 only has finished assessments
+	completed := map[uint]map[uint]bool{10: {2: true}}
+	lastAssigned := map[uint]time.Time{1: assignedAt, 2: assignedAt, 3: assignedAt}
+
+	workloads := countReviewerWorkloads(assignments, completed, lastAssigned)
+
+	want := map[uint]int{1: 2, 2: 0, 3: 0}
+	for reviewerID, open := range want {
+		workload := workloads[reviewerID]
+		if workload.OpenReviews != open || workload.LastAssignedAt == nil || !workload.LastAssignedAt.Equal(assignedAt) {
+			t.Errorf("reviewer %d: workload = %+v, want %d open reviews", reviewerID, workload, open)
+		}
+	}
+}
+
+func TestPanelExclusionError(t *testing.T) {
+	if err := panelExclusionError(nil); err != nil {
+		t.Errorf("panelExclusionError(nil) = %v, want nil", err)
+	}
+
+	err := panelExclusionError([]models.ReviewerExclusion{{ReviewerUserID: 2, ReviewerName: "Jane Doe", Reason: exclusionConflictOfInterest}})
+	if err == nil || !strings.Contains(err.Error(), "Jane Doe (conflict_of_interest)") {
+		t.Errorf("panelExclusionError() = %v, want the excluded reviewer and reason", err)
+	}
+}
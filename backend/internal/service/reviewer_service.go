@@ -2,6 +2,7 @@ package service
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -14,34 +15,43 @@ import (
 // ReviewerService handles business logic for reviewer responses
 type ReviewerService struct {
 	db             *sql.DB
+	transactor     *repository.Transactor
 	reviewerRepo   *repository.ReviewerResponseRepository
+	assignmentRepo *repository.ReviewerAssignmentRepository
 	assessmentRepo *repository.SelfAssessmentRepository
 	responseRepo   *repository.AssessmentResponseRepository
 	keyManager     *keymanager.KeyManager
 	secureStore    *securestore.SecureStore
+	workflowSvc    *WorkflowService
 }
 
 // NewReviewerService creates a new reviewer service
 func NewReviewerService(
 	db *sql.DB,
 	reviewerRepo *repository.ReviewerResponseRepository,
+	assignmentRepo *repository.ReviewerAssignmentRepository,
 	assessmentRepo *repository.SelfAssessmentRepository,
 	responseRepo *repository.AssessmentResponseRepository,
 	keyManager *keymanager.KeyManager,
 	secureStore *securestore.SecureStore,
+	workflowSvc *WorkflowService,
 ) *ReviewerService {
 	return &ReviewerService{
 		db:             db,
+		transactor:     repository.NewTransactor(db),
 		reviewerRepo:   reviewerRepo,
+		assignmentRepo: assignmentRepo,
 		assessmentRepo: assessmentRepo,
 		responseRepo:   responseRepo,
 		keyManager:     keyManager,
 		secureStore:    secureStore,
+		workflowSvc:    workflowSvc,
 	}
 }
 
-// CreateOrUpdateResponse creates or updates a reviewer response with encryption
-func (s *ReviewerService) CreateOrUpdateResponse(response *models.ReviewerResponse, reviewerUserID uint) error {
+// CreateOrUpdateResponse creates or updates a reviewer response with encryption.
+// With expectedVersion set, a changed response yields a VersionConflictError.
+func (s *ReviewerService) CreateOrUpdateResponse(response *models.ReviewerResponse, reviewerUserID uint, expectedVersion *int) error {
 	// Check assessment status - reviews cannot be created/modified in review_consolidation status
 	assessment, err := s.assessmentRepo.GetByID(response.AssessmentID)
 	if err != nil {
@@ -54,6 +64,18 @@ func (s *ReviewerService) CreateOrUpdateResponse(response *models.ReviewerRespon
 		return fmt.Errorf("cannot create or modify reviews for assessments in %s status", assessment.Status)
 	}
 
+	// Check the expected version before anything is written
+	if expectedVersion != nil {
+		existing, err := s.reviewerRepo.GetByCategoryAndReviewer(response.AssessmentID, response.CategoryID, reviewerUserID)
+		if err != nil {
+			return fmt.Errorf("failed to check existing response: %w", err)
+		}
+		if existing != nil && !versionMatches(expectedVersion, true, existing.Version) ||
+			existing == nil && !versionMatches(expectedVersion, false, 0) {
+			return s.responseConflict(existing)
+		}
+	}
+
 	// Ensure user key exists for reviewer
 	if err := s.ensureUserKey(int64(reviewerUserID)); err != nil {
 		return fmt.Errorf("failed to ensure reviewer key: %w", err)
@@ -65,6 +87,44 @@ func (s *ReviewerService) CreateOrUpdateResponse(response *models.ReviewerRespon
 		return fmt.Errorf("failed to ensure process key: %w", err)
 	}
 
+	response.ReviewerUserID = reviewerUserID
+
+	// Transition from submitted to in_review on any reviewer response (if the workflow allows it)
+	startReview := false
+	if assessment.Status == "submitted" {
+		allowed, err := s.workflowSvc.HasTransition(assessment, "in_review")
+		if err != nil || !allowed {
+			slog.Warn("Workflow does not allow automatic transition to in_review", "assessment_id", response.AssessmentID, "error", err)
+		}
+		startReview = err == nil && allowed
+	}
+
+	// The encrypted justification, the status change and the version-checked write are kept only
+	// together, so a save that loses a concurrent edit leaves no trace
+	err = s.transactor.InTx(func(tx *sql.Tx) error {
+		return s.writeResponse(tx, response, processID, startReview, expectedVersion)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			current, err := s.reviewerRepo.GetByCategoryAndReviewer(response.AssessmentID, response.CategoryID, reviewerUserID)
+			if err != nil {
+				return err
+			}
+			return s.responseConflict(current)
+		}
+		return err
+	}
+
+	if startReview {
+		slog.Info("Automatically transitioned assessment to in_review", "assessment_id", response.AssessmentID, "reviewer_id", reviewerUserID)
+		assessment.Status = "in_review"
+		s.workflowSvc.OnEnter(assessment)
+	}
+	return nil
+}
+
+// writeResponse encrypts the justification, starts the review if requested and stores the response in tx
+func (s *ReviewerService) writeResponse(tx *sql.Tx, response *models.ReviewerResponse, processID string, startReview bool, expectedVersion *int) error {
 	// Encrypt justification if provided
 	if response.Justification != "" {
 		data := &securestore.PlainData{
@@ -74,13 +134,13 @@ func (s *ReviewerService) CreateOrUpdateResponse(response *models.ReviewerRespon
 			Metadata: map[string]string{
 				"assessment_id": fmt.Sprintf("%d", response.AssessmentID),
 				"category_id":   fmt.Sprintf("%d", response.CategoryID),
-				"reviewer_id":   fmt.Sprintf("%d", reviewerUserID),
+				"reviewer_id":   fmt.Sprintf("%d", response.ReviewerUserID),
 			},
 		}
 
-		record, err := s.secureStore.CreateRecord(
+		record, err := s.secureStore.WithTx(tx).CreateRecord(
 			processID,
-			int64(reviewerUserID),
+			int64(response.ReviewerUserID),
 			"REVIEWER_JUSTIFICATION",
 			data,
 			"",
@@ -93,19 +153,25 @@ func (s *ReviewerService) CreateOrUpdateResponse(response *models.ReviewerRespon
 		response.Justification = "" // Clear plaintext
 	}
 
-	response.ReviewerUserID = reviewerUserID
-
-	// Transition from submitted to in_review on any reviewer response
-	if assessment.Status == "submitted" {
-		if err := s.assessmentRepo.UpdateStatus(response.AssessmentID, "in_review"); err != nil {
-			slog.Error("Failed to update assessment status to in_review", "error", err, "assessment_id", response.AssessmentID)
-			// Don't fail the response creation if status update fails
-		} else {
-			slog.Info("Automatically transitioned assessment to in_review", "assessment_id", response.AssessmentID, "reviewer_id", reviewerUserID)
+	if startReview {
+		if err := s.assessmentRepo.WithTx(tx).UpdateStatus(response.AssessmentID, "in_review"); err != nil {
+			return fmt.Errorf("failed to update assessment status to in_review: %w", err)
 		}
 	}
 
-	return s.reviewerRepo.CreateOrUpdate(response)
+	return s.reviewerRepo.WithTx(tx).CreateOrUpdate(response, expectedVersion)
+}
+
+// responseConflict returns a version conflict carrying the current (decrypted) reviewer response
+func (s *ReviewerService) responseConflict(current *models.ReviewerResponse) error {
+	conflict := &VersionConflictError{Resource: "reviewer response"}
+	if current != nil {
+		if err := s.decryptJustification(current); err != nil {
+			slog.Error("Failed to decrypt justification", "error", err, "response_id", current.ID)
+		}
+		conflict.Current = current
+	}
+	return conflict
 }
 
 // GetResponsesByAssessment retrieves reviewer responses for an assessment
@@ -183,10 +249,16 @@ func (s *ReviewerService) GetCompletionStatus(assessmentID uint) (*models.Review
 		return nil, err
 	}
 
+	panelSize, err := s.assignmentRepo.CountByAssessmentID(assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.ReviewCompletionStatus{
 		TotalReviewers:               totalReviewers,
 		CompleteReviews:              len(completeReviewers),
-		CanConsolidate:               len(completeReviewers) >= 3,
+		PanelSize:                    panelSize,
+		CanConsolidate:               panelSize > 0 && len(completeReviewers) >= panelSize,
 		ReviewersWithCompleteReviews: completeReviewers,
 	}, nil
 }
@@ -231,12 +303,17 @@ func (s *ReviewerService) IsReviewComplete(assessmentID, reviewerUserID uint) (b
 }
 
 // CanTransitionToConsolidation checks if an assessment can move to review_consolidation status
+// (all reviewers of the panel have completed their review)
 func (s *ReviewerService) CanTransitionToConsolidation(assessmentID uint) (bool, error) {
 	completeReviews, err := s.reviewerRepo.CountCompleteReviews(assessmentID)
 	if err != nil {
 		return false, err
 	}
-	return completeReviews >= 3, nil
+	panelSize, err := s.assignmentRepo.CountByAssessmentID(assessmentID)
+	if err != nil {
+		return false, err
+	}
+	return panelSize > 0 && completeReviews >= panelSize, nil
 }
 
 // Helper methods
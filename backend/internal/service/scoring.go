@@ -0,0 +1,222 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"new-pay/internal/models"
+)
+
+// Scoring strategies and level rounding modes selectable per catalog
+const (
+	ScoringMean        = "mean"
+	ScoringMedian      = "median"
+	ScoringTrimmedMean = "trimmed_mean"
+	ScoringMode        = "mode"
+
+	LevelRoundingNearest = "nearest"
+	LevelRoundingDown    = "down"
+)
+
+// ScoringStrategy aggregates reviewer ratings and maps scores to catalog levels.
+// Consolidation, weighted score and discussion results use the strategy of the catalog,
+// so all views of an assessment show the same numbers.
+type ScoringStrategy interface {
+	// Aggregate combines the level numbers of several ratings into one score
+	Aggregate(levelNumbers []float64) float64
+	// ResolveLevel maps a score to a level (nil if there are no levels)
+	ResolveLevel(levels []models.Level, score float64) *models.Level
+}
+
+// scoringAggregators contains the available aggregation functions by strategy name
+var scoringAggregators = map[string]func([]float64) float64{
+	ScoringMean:        meanScore,
+	ScoringMedian:      medianScore,
+	ScoringTrimmedMean: trimmedMeanScore,
+	ScoringMode:        modeScore,
+}
+
+// catalogScoring is the ScoringStrategy configured on a catalog
+type catalogScoring struct {
+	aggregate func([]float64) float64
+	roundDown bool
+}
+
+// NewScoringStrategy returns the scoring strategy of a catalog.
+// Empty or unknown settings fall back to the arithmetic mean and the nearest level.
+func NewScoringStrategy(catalog *models.CriteriaCatalog) ScoringStrategy {
+	aggregate, ok := scoringAggregators[catalog.ScoringStrategy]
+	if !ok {
+		aggregate = meanScore
+	}
+	return &catalogScoring{
+		aggregate: aggregate,
+		roundDown: catalog.LevelRounding == LevelRoundingDown,
+	}
+}
+
+// validateScoringConfig checks the scoring settings of a catalog and fills in defaults
+func validateScoringConfig(catalog *models.CriteriaCatalog) error {
+	if catalog.ScoringStrategy == "" {
+		catalog.ScoringStrategy = ScoringMean
+	}
+	if catalog.LevelRounding == "" {
+		catalog.LevelRounding = LevelRoundingNearest
+	}
+	if _, ok := scoringAggregators[catalog.ScoringStrategy]; !ok {
+		return fmt.Errorf("invalid scoring_strategy: %s (expected mean, median, trimmed_mean or mode)", catalog.ScoringStrategy)
+	}
+	if catalog.LevelRounding != LevelRoundingNearest && catalog.LevelRounding != LevelRoundingDown {
+		return fmt.Errorf("invalid level_rounding: %s (expected nearest or down)", catalog.LevelRounding)
+	}
+	return nil
+}
+
+// Aggregate combines level numbers with the configured aggregation (0 if empty)
+func (s *catalogScoring) Aggregate(levelNumbers []float64) float64 {
+	if len(levelNumbers) == 0 {
+		return 0
+	}
+	return s.aggregate(levelNumbers)
+}
+
+// ResolveLevel maps a score to the nearest level (half up) or, when rounding down, to the highest level not above the score
+func (s *catalogScoring) ResolveLevel(levels []models.Level, score float64) *models.Level {
+	if len(levels) == 0 {
+		return nil
+	}
+
+	if s.roundDown {
+		var result, lowest *models.Level
+		for i := range levels {
+			level := &levels[i]
+			if lowest == nil || level.LevelNumber < lowest.LevelNumber {
+				lowest = level
+			}
+			// Tolerance for scores rounded to two decimals
+			if float64(level.LevelNumber) <= score+1e-9 && (result == nil || level.LevelNumber > result.LevelNumber) {
+				result = level
+			}
+		}
+		if result == nil {
+			return lowest
+		}
+		return result
+	}
+
+	// Scores exactly between two levels round half up, as the weighted score always did
+	closest := &levels[0]
+	minDiff := math.Abs(float64(closest.LevelNumber) - score)
+	for i := range levels[1:] {
+		level := &levels[i+1]
+		diff := math.Abs(float64(level.LevelNumber) - score)
+		if diff < minDiff-1e-9 || (diff <= minDiff+1e-9 && level.LevelNumber > closest.LevelNumber) {
+			minDiff = diff
+			closest = level
+		}
+	}
+	return closest
+}
+
+// meanScore returns the arithmetic mean
+func meanScore(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// medianScore returns the median (mean of the two middle values for even counts)
+func medianScore(values []float64) float64 {
+	sorted := sortedCopy(values)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// trimmedMeanScore returns the mean without the lowest and highest value (plain mean for fewer than three values)
+func trimmedMeanScore(values []float64) float64 {
+	if len(values) < 3 {
+		return meanScore(values)
+	}
+	sorted := sortedCopy(values)
+	return meanScore(sorted[1 : len(sorted)-1])
+}
+
+// modeScore returns the most frequent value; ties are resolved by the mean of the most frequent values
+func modeScore(values []float64) float64 {
+	counts := make(map[float64]int)
+	maxCount := 0
+	for _, v := range values {
+		counts[v]++
+		if counts[v] > maxCount {
+			maxCount = counts[v]
+		}
+	}
+
+	var modes []float64
+	for v, count := range counts {
+		if count == maxCount {
+			modes = append(modes, v)
+		}
+	}
+	return meanScore(modes)
+}
+
+// sortedCopy returns a sorted copy of the values
+func sortedCopy(values []float64) []float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// weightedScoreEntry is the score of a category together with its effective weight
+type weightedScoreEntry struct {
+	score  float64
+	weight float64
+}
+
+// weightedAverageScore returns the weighted average of category scores (0 if the total weight is 0)
+func weightedAverageScore(entries []weightedScoreEntry) float64 {
+	var totalWeight, weightedSum float64
+	for _, entry := range entries {
+		totalWeight += entry.weight
+		weightedSum += entry.score * entry.weight
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	return weightedSum / totalWeight
+}
+
+// categoryWeight returns the weight of a category (1 if not set)
+func categoryWeight(category *models.Category) float64 {
+	if category.Weight != nil && *category.Weight > 0 {
+		return *category.Weight
+	}
+	return 1
+}
+
+// pathWeight returns the weight of a path (1 if not set)
+func pathWeight(path *models.Path) float64 {
+	if path != nil && path.Weight != nil && *path.Weight > 0 {
+		return *path.Weight
+	}
+	return 1
+}
+
+// findPathByID finds a path by ID in catalog
+func findPathByID(catalog *models.CatalogWithDetails, pathID uint) *models.Path {
+	for i := range catalog.Categories {
+		for j := range catalog.Categories[i].Paths {
+			if catalog.Categories[i].Paths[j].ID == pathID {
+				return &catalog.Categories[i].Paths[j].Path
+			}
+		}
+	}
+	return nil
+}
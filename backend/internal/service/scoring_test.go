@@ -0,0 +1,115 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"new-pay/internal/models"
+)
+
+func TestScoringAggregate(t *testing.T) {
+	values := []float64{1, 2, 2, 4, 6}
+
+	tests := []struct {
+		strategy string
+		values   []float64
+		want     float64
+	}{
+		{strategy: ScoringMean, values: values, want: 3},
+		{strategy: ScoringMedian, values: values, want: 2},
+		{strategy: ScoringMedian, values: []float64{1, 2, 3, 4}, want: 2.5},
+		{strategy: ScoringTrimmedMean, values: values, want: 8.0 / 3},
+		{strategy: ScoringTrimmedMean, values: []float64{1, 3}, want: 2},
+		{strategy: ScoringMode, values: values, want: 2},
+		{strategy: ScoringMode, values: []float64{1, 3}, want: 2},
+		{strategy: "", values: values, want: 3},
+		{strategy: ScoringMedian, values: nil, want: 0},
+	}
+
+	for _, tt := range tests {
+		strategy := NewScoringStrategy(&models.CriteriaCatalog{ScoringStrategy: tt.strategy})
+		if got := strategy.Aggregate(tt.values); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%q: Aggregate(%v) = %v, want %v", tt.strategy, tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestScoringResolveLevel(t *testing.T) {
+	levels := []models.Level{
+		{Name: "Junior", LevelNumber: 1},
+		{Name: "Mid", LevelNumber: 2},
+		{Name: "Senior", LevelNumber: 3},
+	}
+
+	tests := []struct {
+		rounding string
+		score    float64
+		want     string
+	}{
+		{rounding: LevelRoundingNearest, score: 1.6, want: "Mid"},
+		{rounding: LevelRoundingNearest, score: 2.4, want: "Mid"},
+		{rounding: LevelRoundingNearest, score: 1.5, want: "Mid"},
+		{rounding: LevelRoundingNearest, score: 2.5, want: "Senior"},
+		{rounding: "", score: 2.5, want: "Senior"},
+		{rounding: LevelRoundingDown, score: 2.5, want: "Mid"},
+		{rounding: LevelRoundingDown, score: 1.6, want: "Junior"},
+		{rounding: LevelRoundingDown, score: 2.99, want: "Mid"},
+		{rounding: LevelRoundingDown, score: 3, want: "Senior"},
+		{rounding: LevelRoundingDown, score: 0.5, want: "Junior"},
+	}
+
+	for _, tt := range tests {
+		strategy := NewScoringStrategy(&models.CriteriaCatalog{LevelRounding: tt.rounding})
+		level := strategy.ResolveLevel(levels, tt.score)
+		if level == nil || level.Name != tt.want {
+			t.Errorf("%s: ResolveLevel(%v) = %v, want %s", tt.rounding, tt.score, level, tt.want)
+		}
+	}
+
+	if level := NewScoringStrategy(&models.CriteriaCatalog{}).ResolveLevel(nil, 2); level != nil {
+		t.Errorf("ResolveLevel without levels = %v, want nil", level)
+	}
+}
+
+func TestWeightedAverageScore(t *testing.T) {
+	got := weightedAverageScore([]weightedScoreEntry{
+		{score: 2, weight: 0.5},
+		{score: 4, weight: 0.25 * 2},
+	})
+	if got != 3 {
+		t.Errorf("weightedAverageScore = %v, want 3", got)
+	}
+
+	if got := weightedAverageScore(nil); got != 0 {
+		t.Errorf("weightedAverageScore(nil) = %v, want 0", got)
+	}
+
+	// A weighted score exactly between two levels keeps rounding half up with the default strategy
+	levels := []models.Level{{Name: "A", LevelNumber: 1}, {Name: "B", LevelNumber: 2}, {Name: "C", LevelNumber: 3}}
+	half := weightedAverageScore([]weightedScoreEntry{
+		{score: 2, weight: 0.4},
+		{score: 3, weight: 0.3},
+		{score: 2, weight: 0.1},
+		{score: 3, weight: 0.2},
+	})
+	if level := NewScoringStrategy(&models.CriteriaCatalog{}).ResolveLevel(levels, half); level == nil || level.Name != "C" {
+		t.Errorf("ResolveLevel(%v) = %v, want C", half, level)
+	}
+}
+
+func TestValidateScoringConfig(t *testing.T) {
+	catalog := &models.CriteriaCatalog{}
+	if err := validateScoringConfig(catalog); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if catalog.ScoringStrategy != ScoringMean || catalog.LevelRounding != LevelRoundingNearest {
+		t.Errorf("defaults = %q/%q, want mean/nearest", catalog.ScoringStrategy, catalog.LevelRounding)
+	}
+
+	if err := validateScoringConfig(&models.CriteriaCatalog{ScoringStrategy: "max"}); err == nil {
+		t.Error("expected error for unknown scoring strategy")
+	}
+	if err := validateScoringConfig(&models.CriteriaCatalog{LevelRounding: "up"}); err == nil {
+		t.Error("expected error for unknown level rounding")
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"new-pay/internal/models"
@@ -16,6 +17,15 @@ type SelfAssessmentService struct {
 	responseRepo         *repository.AssessmentResponseRepository
 	encryptedResponseSvc *EncryptedResponseService
 	reviewerRepo         *repository.ReviewerResponseRepository
+	assignmentRepo       *repository.ReviewerAssignmentRepository
+	workflowSvc          *WorkflowService
+	changeRequestRepo    *repository.ChangeRequestRepository
+}
+
+// editableStatuses are the states in which the owner may edit responses
+var editableStatuses = map[string]bool{
+	"draft":                true,
+	statusChangesRequested: true,
 }
 
 // NewSelfAssessmentService creates a new self-assessment service
@@ -26,6 +36,9 @@ func NewSelfAssessmentService(
 	responseRepo *repository.AssessmentResponseRepository,
 	encryptedResponseSvc *EncryptedResponseService,
 	reviewerRepo *repository.ReviewerResponseRepository,
+	assignmentRepo *repository.ReviewerAssignmentRepository,
+	workflowSvc *WorkflowService,
+	changeRequestRepo *repository.ChangeRequestRepository,
 ) *SelfAssessmentService {
 	return &SelfAssessmentService{
 		selfAssessmentRepo:   selfAssessmentRepo,
@@ -34,6 +47,9 @@ func NewSelfAssessmentService(
 		responseRepo:         responseRepo,
 		encryptedResponseSvc: encryptedResponseSvc,
 		reviewerRepo:         reviewerRepo,
+		assignmentRepo:       assignmentRepo,
+		workflowSvc:          workflowSvc,
+		changeRequestRepo:    changeRequestRepo,
 	}
 }
 
@@ -283,59 +299,22 @@ func (s *SelfAssessmentService) UpdateSelfAssessmentStatus(assessmentID uint, ne
 	}
 
 	oldStatus := assessment.Status
-	isOwner := assessment.UserID == userID
-	isAdmin := contains(userRoles, "admin")
-	isReviewer := contains(userRoles, "reviewer")
-
-	// Archived assessments cannot be closed
-	if oldStatus == "archived" && newStatus == "closed" {
-		return fmt.Errorf("cannot close archived self-assessments")
-	}
-
-	// Check ownership for draft/submitted transitions
-	// Only owners can submit their assessments
-	if newStatus == "submitted" && !isOwner {
-		return fmt.Errorf("permission denied: only the owner can submit their self-assessment")
-	}
 
-	// Users can close their own draft self-assessments
-	// Users cannot close self-assessments that have been submitted
-	if newStatus == "closed" && isOwner && !isAdmin {
-		if oldStatus != "draft" {
-			return fmt.Errorf("permission denied: users can only close their own self-assessments in draft status")
-		}
+	// Requesting changes needs a message for the owner (restoring a closed assessment does not)
+	if newStatus == statusChangesRequested && oldStatus != "closed" {
+		return fmt.Errorf("changes must be requested with a message via the request-changes endpoint")
 	}
 
-	// Admins (without reviewer role) can close assessments or reopen closed assessments (within 24h)
-	// Admins cannot submit assessments for other users or perform review-related status changes
-	if isAdmin && !isOwner && !isReviewer {
-		if newStatus == "submitted" {
-			return fmt.Errorf("permission denied: admins cannot submit self-assessments for other users")
-		}
-		// Allow reopening closed assessments
-		if oldStatus == "closed" && newStatus != "closed" {
-			// This is allowed - admin is reopening a closed assessment
-		} else if oldStatus != "closed" && newStatus != "closed" {
-			// Admin trying to change status from non-closed to non-closed (not allowed, except closing)
-			return fmt.Errorf("permission denied: admins can only close or reopen self-assessments")
-		}
+	// Appeals need a reason and are decided by the appeal panel (restoring a closed assessment does not)
+	if newStatus == statusAppeal && oldStatus != "closed" {
+		return fmt.Errorf("appeals must be filed with a reason via the appeal endpoint")
 	}
 
-	// Validate status transitions
-	if err := s.validateStatusTransition(oldStatus, newStatus, userRoles, isOwner); err != nil {
+	// Transitions, allowed roles and guards (e.g. reverting closed within 24h) are defined by the workflow
+	if err := s.workflowSvc.CheckTransition(assessment, newStatus, userID, userRoles); err != nil {
 		return err
 	}
 
-	// Handle closed status reversal within 24h
-	if oldStatus == "closed" && assessment.ClosedAt != nil {
-		if time.Since(*assessment.ClosedAt) > 24*time.Hour {
-			return fmt.Errorf("cannot revert closed status after 24 hours")
-		}
-		if assessment.PreviousStatus != nil {
-			newStatus = *assessment.PreviousStatus
-		}
-	}
-
 	// Update timestamps based on new status
 	now := time.Now()
 	switch newStatus {
@@ -363,54 +342,19 @@ func (s *SelfAssessmentService) UpdateSelfAssessmentStatus(assessmentID uint, ne
 		return err
 	}
 
-	// Audit log
-	s.auditSvc.Log(userID, "update_status", "self_assessment",
-		fmt.Sprintf("Self-assessment %d status changed: %s -> %s", assessmentID, oldStatus, newStatus))
-
-	return nil
-}
-
-// validateStatusTransition validates if a status transition is allowed
-func (s *SelfAssessmentService) validateStatusTransition(fromStatus, toStatus string, userRoles []string, isOwner bool) error {
-	isReviewer := contains(userRoles, "reviewer")
-
-	// Define allowed transitions
-	allowedTransitions := map[string][]string{
-		"draft":                {"submitted", "closed"},
-		"submitted":            {"in_review", "closed"},
-		"in_review":            {"review_consolidation", "reviewed", "closed"},
-		"review_consolidation": {"in_review", "reviewed", "closed"},
-		"reviewed":             {"discussion", "closed"},
-		"discussion":           {"archived", "closed"},
-		"archived":             {},                                                                                    // Final state
-		"closed":               {"draft", "submitted", "in_review", "review_consolidation", "reviewed", "discussion"}, // Can revert within 24h
-	}
-
-	// Check if user has permission for this transition
-	if toStatus == "in_review" || toStatus == "review_consolidation" || toStatus == "reviewed" || toStatus == "discussion" || toStatus == "archived" {
-		if !isReviewer {
-			return fmt.Errorf("permission denied: only reviewers can transition to %s status", toStatus)
+	if oldStatus == statusChangesRequested && newStatus == "submitted" {
+		if err := s.changeRequestRepo.ResolveOpen(assessmentID); err != nil {
+			slog.Error("Failed to resolve change requests", "assessment_id", assessmentID, "error", err)
 		}
 	}
 
-	// Owners can submit their own assessments or close them if still in draft
-	// Owners cannot close assessments that have been submitted
-	if toStatus == "submitted" && !isOwner {
-		return fmt.Errorf("permission denied: only the owner can submit their self-assessment")
-	}
+	// Audit log
+	s.auditSvc.Log(userID, "update_status", "self_assessment",
+		fmt.Sprintf("Self-assessment %d status changed: %s -> %s", assessmentID, oldStatus, newStatus))
 
-	allowed, ok := allowedTransitions[fromStatus]
-	if !ok {
-		return fmt.Errorf("invalid current status: %s", fromStatus)
-	}
+	s.workflowSvc.OnEnter(assessment)
 
-	for _, validStatus := range allowed {
-		if toStatus == validStatus {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("cannot transition from %s to %s status", fromStatus, toStatus)
+	return nil
 }
 
 // DeleteSelfAssessment deletes a self-assessment (admin only, only if closed without submission)
@@ -454,7 +398,7 @@ func (s *SelfAssessmentService) DeleteSelfAssessment(assessmentID uint, userID u
 }
 
 // SaveResponse saves or updates an assessment response
-func (s *SelfAssessmentService) SaveResponse(userID, assessmentID uint, response *models.AssessmentResponse) (*models.AssessmentResponse, error) {
+func (s *SelfAssessmentService) SaveResponse(userID, assessmentID uint, response *models.AssessmentResponse, expectedVersion *int) (*models.AssessmentResponse, error) {
 	// Get assessment and verify ownership and status
 	assessment, err := s.selfAssessmentRepo.GetByID(assessmentID)
 	if err != nil {
@@ -466,8 +410,8 @@ func (s *SelfAssessmentService) SaveResponse(userID, assessmentID uint, response
 	if assessment.UserID != userID {
 		return nil, fmt.Errorf("permission denied: not owner of assessment")
 	}
-	if assessment.Status != "draft" {
-		return nil, fmt.Errorf("can only edit responses in draft status")
+	if !editableStatuses[assessment.Status] {
+		return nil, fmt.Errorf("can only edit responses in draft or changes_requested status")
 	}
 
 	// Get catalog ID
@@ -512,14 +456,27 @@ func (s *SelfAssessmentService) SaveResponse(userID, assessmentID uint, response
 
 	// Check if encryption service is available
 	if s.encryptedResponseSvc == nil {
-		return nil, fmt.Errorf("encryption service not available - Vault must be enabled")
+		return nil, fmt.Errorf("encryption service not available - a key provider must be configured")
+	}
+
+	// Reject stale edits before a new justification record is written
+	if existing != nil && !versionMatches(expectedVersion, true, existing.Version) ||
+		existing == nil && !versionMatches(expectedVersion, false, 0) {
+		return nil, s.responseConflict(existing)
 	}
 
 	if existing != nil {
 		// Update existing response using encrypted service
 		response.ID = existing.ID
 		response.CreatedAt = existing.CreatedAt
-		if err := s.encryptedResponseSvc.UpdateResponse(response, userID); err != nil {
+		if err := s.encryptedResponseSvc.UpdateResponse(response, userID, expectedVersion); err != nil {
+			if errors.Is(err, repository.ErrVersionConflict) {
+				current, err := s.responseRepo.GetByAssessmentAndCategory(assessmentID, response.CategoryID)
+				if err != nil {
+					return nil, err
+				}
+				return nil, s.responseConflict(current)
+			}
 			return nil, err
 		}
 
@@ -540,6 +497,18 @@ func (s *SelfAssessmentService) SaveResponse(userID, assessmentID uint, response
 	return response, nil
 }
 
+// responseConflict returns a version conflict carrying the current (decrypted) response
+func (s *SelfAssessmentService) responseConflict(current *models.AssessmentResponse) error {
+	conflict := &VersionConflictError{Resource: "response"}
+	if current != nil {
+		if err := s.encryptedResponseSvc.DecryptResponse(current); err != nil {
+			slog.Error("Failed to decrypt justification", "error", err, "response_id", current.ID)
+		}
+		conflict.Current = current
+	}
+	return conflict
+}
+
 // DeleteResponse deletes an assessment response
 func (s *SelfAssessmentService) DeleteResponse(userID, assessmentID, categoryID uint) error {
 	// Get assessment and verify ownership and status
@@ -547,8 +516,8 @@ func (s *SelfAssessmentService) DeleteResponse(userID, assessmentID, categoryID
 	if err != nil {
 		return err
 	}
-	if assessment.Status != "draft" {
-		return fmt.Errorf("can only delete responses in draft status")
+	if !editableStatuses[assessment.Status] {
+		return fmt.Errorf("can only delete responses in draft or changes_requested status")
 	}
 
 	// Get response
@@ -684,8 +653,9 @@ func (s *SelfAssessmentService) CalculateWeightedScore(userID uint, assessmentID
 		responseMap[response.CategoryID] = response
 	}
 
-	// Calculate weighted score
-	weightedSum := 0.0
+	// Calculate weighted score (category weight x weight of the selected path)
+	strategy := NewScoringStrategy(&catalogDetails.CriteriaCatalog)
+	var weightedEntries []weightedScoreEntry
 	hasAllResponses := true
 
 	for _, category := range catalogDetails.Categories {
@@ -695,30 +665,23 @@ func (s *SelfAssessmentService) CalculateWeightedScore(userID uint, assessmentID
 			continue
 		}
 
-		// weighted_sum += level_number * weight
-		weightedSum += float64(response.LevelNumber) * (*category.Weight)
+		weightedEntries = append(weightedEntries, weightedScoreEntry{
+			score:  float64(response.LevelNumber),
+			weight: categoryWeight(&category.Category) * pathWeight(findPathByID(catalogDetails, response.PathID)),
+		})
 	}
+	weightedAverage := weightedAverageScore(weightedEntries)
 
 	// Determine overall level based on weighted average
-	overallLevelNumber := int(weightedSum + 0.5) // Round to nearest integer
-	if overallLevelNumber < 1 {
-		overallLevelNumber = 1
-	}
-	if overallLevelNumber > len(catalogDetails.Levels) {
-		overallLevelNumber = len(catalogDetails.Levels)
-	}
-
-	// Find the level name (letter)
 	overallLevelName := ""
-	for _, level := range catalogDetails.Levels {
-		if level.LevelNumber == overallLevelNumber {
-			overallLevelName = level.Name
-			break
-		}
+	overallLevelNumber := 0
+	if level := strategy.ResolveLevel(catalogDetails.Levels, weightedAverage); level != nil {
+		overallLevelName = level.Name
+		overallLevelNumber = level.LevelNumber
 	}
 
 	return &models.WeightedScore{
-		WeightedAverage: weightedSum,
+		WeightedAverage: weightedAverage,
 		OverallLevel:    overallLevelName,
 		LevelNumber:     overallLevelNumber,
 		IsComplete:      hasAllResponses,
@@ -730,6 +693,12 @@ func (s *SelfAssessmentService) CalculateWeightedScore(userID uint, assessmentID
 func (s *SelfAssessmentService) filterAssessmentsByReviewCompletion(assessments []models.SelfAssessmentWithDetails, reviewerUserID uint) ([]models.SelfAssessmentWithDetails, error) {
 	var filtered []models.SelfAssessmentWithDetails
 
+	// Reviewers only see assessments they are assigned to
+	assigned, err := s.assignmentRepo.GetAssessmentIDsByReviewer(reviewerUserID)
+	if err != nil {
+		return nil, err
+	}
+
 	consolidationPhases := map[string]bool{
 		"review_consolidation": true,
 		"reviewed":             true,
@@ -738,6 +707,10 @@ func (s *SelfAssessmentService) filterAssessmentsByReviewCompletion(assessments
 	}
 
 	for _, assessment := range assessments {
+		if !assigned[assessment.ID] {
+			continue
+		}
+
 		// If assessment is in consolidation phase or later, check if reviewer completed their review
 		if consolidationPhases[assessment.Status] {
 			// Check if reviewer has completed all categories
@@ -796,21 +769,14 @@ func (s *SelfAssessmentService) SubmitAssessment(userID, assessmentID uint) erro
 	if err != nil {
 		return err
 	}
-	if assessment.Status != "draft" {
-		return fmt.Errorf("can only submit assessments in draft status")
-	}
 
-	// Check completeness
-	completeness, err := s.responseRepo.GetCompleteness(assessmentID)
-	if err != nil {
+	// The workflow decides from which states an assessment can be submitted and checks its guards (e.g. completeness)
+	if err := s.workflowSvc.CheckTransition(assessment, "submitted", userID, []string{}); err != nil {
 		return err
 	}
-	if !completeness.IsComplete {
-		return fmt.Errorf("assessment is incomplete: %d of %d categories completed",
-			completeness.CompletedCategories, completeness.TotalCategories)
-	}
 
-	// Update status to submitted
+	// Update status to submitted (reviewer responses of a previous round are kept on resubmission)
+	resubmitted := assessment.Status == statusChangesRequested
 	now := time.Now()
 	assessment.Status = "submitted"
 	assessment.SubmittedAt = &now
@@ -819,9 +785,18 @@ func (s *SelfAssessmentService) SubmitAssessment(userID, assessmentID uint) erro
 		return err
 	}
 
-	// Audit log
-	s.auditSvc.Log(userID, "submit", "self_assessment",
-		fmt.Sprintf("Submitted self-assessment %d for review", assessmentID))
+	if resubmitted {
+		if err := s.changeRequestRepo.ResolveOpen(assessmentID); err != nil {
+			slog.Error("Failed to resolve change requests", "assessment_id", assessmentID, "error", err)
+		}
+		s.auditSvc.Log(userID, "resubmit", "self_assessment",
+			fmt.Sprintf("Resubmitted self-assessment %d after requested changes", assessmentID))
+	} else {
+		s.auditSvc.Log(userID, "submit", "self_assessment",
+			fmt.Sprintf("Submitted self-assessment %d for review", assessmentID))
+	}
+
+	s.workflowSvc.OnEnter(assessment)
 
 	return nil
 }
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"new-pay/internal/keymanager"
+	"new-pay/internal/models"
+)
+
+// SystemKeyService rotates the system master key in the key provider and rewraps the user and process keys
+type SystemKeyService struct {
+	keyManager *keymanager.KeyManager
+	auditSvc   *AuditService
+
+	mu            sync.Mutex
+	rewrapRunning bool
+}
+
+// NewSystemKeyService creates a new system key service
+func NewSystemKeyService(keyManager *keymanager.KeyManager, auditSvc *AuditService) *SystemKeyService {
+	return &SystemKeyService{
+		keyManager: keyManager,
+		auditSvc:   auditSvc,
+	}
+}
+
+// GetStatus reports the system key versions and how many wrapped keys still use old versions
+func (s *SystemKeyService) GetStatus() (*models.SystemKeyStatus, error) {
+	latest, minDecryption, err := s.keyManager.GetSystemKeyVersions()
+	if err != nil {
+		return nil, err
+	}
+	userKeys, processKeys, err := s.keyManager.CountWrappedKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	running := s.rewrapRunning
+	s.mu.Unlock()
+
+	return &models.SystemKeyStatus{
+		KeyID:                s.keyManager.GetActiveSystemKeyID(),
+		LatestVersion:        latest,
+		MinDecryptionVersion: minDecryption,
+		UserKeys:             userKeys,
+		ProcessKeys:          processKeys,
+		OutdatedUserKeys:     countBelowVersion(userKeys, latest),
+		OutdatedProcessKeys:  countBelowVersion(processKeys, latest),
+		RewrapRunning:        running,
+	}, nil
+}
+
+// RotateSystemKey creates a new version of the system master key and rewraps all keys in the background
+func (s *SystemKeyService) RotateSystemKey(userID uint) (*models.SystemKeyStatus, error) {
+	version, err := s.keyManager.RotateSystemKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate system key: %w", err)
+	}
+
+	s.auditSvc.Log(userID, "rotate_system_key", "system_key",
+		fmt.Sprintf("Rotated system key %s to version %d", s.keyManager.GetActiveSystemKeyID(), version))
+
+	if err := s.startRewrap(); err != nil {
+		// A rewrap started before the rotation does not cover the new version; it can be started again afterwards
+		slog.Warn("System key rewrap not started", "error", err)
+	}
+
+	return s.GetStatus()
+}
+
+// RewrapKeys rewraps all keys below the latest system key version in the background
+func (s *SystemKeyService) RewrapKeys(userID uint) (*models.SystemKeyStatus, error) {
+	if err := s.startRewrap(); err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Log(userID, "rewrap_system_key", "system_key",
+		fmt.Sprintf("Started rewrap of wrapped keys with system key %s", s.keyManager.GetActiveSystemKeyID()))
+
+	return s.GetStatus()
+}
+
+// SetMinDecryptionVersion sets the oldest system key version the key provider still decrypts.
+// It is refused while wrapped keys use an older version, since they would become unreadable.
+func (s *SystemKeyService) SetMinDecryptionVersion(version int, userID uint) (*models.SystemKeyStatus, error) {
+	latest, minDecryption, err := s.keyManager.GetSystemKeyVersions()
+	if err != nil {
+		return nil, err
+	}
+	userKeys, processKeys, err := s.keyManager.CountWrappedKeys()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinDecryptionVersion(version, latest, userKeys, processKeys); err != nil {
+		return nil, err
+	}
+
+	if err := s.keyManager.SetSystemKeyMinDecryptionVersion(version); err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Log(userID, "update_min_decryption_version", "system_key",
+		fmt.Sprintf("Changed minimum decryption version of system key %s from %d to %d", s.keyManager.GetActiveSystemKeyID(), minDecryption, version))
+
+	return s.GetStatus()
+}
+
+// startRewrap starts the rewrap unless one is already running
+func (s *SystemKeyService) startRewrap() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rewrapRunning {
+		return fmt.Errorf("a rewrap is already running")
+	}
+	s.rewrapRunning = true
+
+	go s.rewrap()
+	return nil
+}
+
+func (s *SystemKeyService) rewrap() {
+	defer func() {
+		s.mu.Lock()
+		s.rewrapRunning = false
+		s.mu.Unlock()
+	}()
+
+	slog.Info("Rewrapping keys with the latest system key version")
+	rewrapped, err := s.keyManager.RewrapKeys()
+	if err != nil {
+		slog.Error("System key rewrap failed", "rewrapped", rewrapped, "error", err)
+		s.auditSvc.LogSystem("rewrap_system_key", "system_key",
+			fmt.Sprintf("Rewrapped %d keys, rewrap failed: %v", rewrapped, err))
+		return
+	}
+
+	slog.Info("System key rewrap completed", "rewrapped", rewrapped)
+	s.auditSvc.LogSystem("rewrap_system_key", "system_key", fmt.Sprintf("Rewrapped %d keys", rewrapped))
+}
+
+// countBelowVersion returns the number of wrapped keys with a system key version below the given version
+func countBelowVersion(counts map[int]int, version int) int {
+	total := 0
+	for v, count := range counts {
+		if v < version {
+			total += count
+		}
+	}
+	return total
+}
+
+// validateMinDecryptionVersion checks that a minimum decryption version exists and leaves no wrapped key unreadable
+func validateMinDecryptionVersion(version, latestVersion int, userKeys, processKeys map[int]int) error {
+	if version < 1 || version > latestVersion {
+		return fmt.Errorf("minimum decryption version must be between 1 and %d", latestVersion)
+	}
+	if outdated := countBelowVersion(userKeys, version) + countBelowVersion(processKeys, version); outdated > 0 {
+		return fmt.Errorf("%d wrapped keys still use a system key version below %d, rewrap them first", outdated, version)
+	}
+	return nil
+}
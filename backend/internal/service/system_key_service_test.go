@@ -0,0 +1,49 @@
+package service
+
+import "testing"
+
+func TestCountBelowVersion(t *testing.T) {
+	counts := map[int]int{1: 3, 2: 5, 3: 7}
+
+	if got := countBelowVersion(counts, 3); got != 8 {
+		t.Errorf("countBelowVersion(3) = %d, want 8", got)
+	}
+	if got := countBelowVersion(counts, 1); got != 0 {
+		t.Errorf("countBelowVersion(1) = %d, want 0", got)
+	}
+	if got := countBelowVersion(nil, 2); got != 0 {
+		t.Errorf("countBelowVersion(nil) = %d, want 0", got)
+	}
+}
+
+func TestValidateMinDecryptionVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     int
+		userKeys    map[int]int
+		processKeys map[int]int
+		wantErr     string
+	}{
+		{name: "all rewrapped", version: 3, userKeys: map[int]int{3: 4}, processKeys: map[int]int{3: 9}},
+		{name: "lower than needed", version: 2, userKeys: map[int]int{2: 1, 3: 4}},
+		{name: "zero", version: 0, wantErr: "minimum decryption version must be between 1 and 3"},
+		{name: "above latest", version: 4, wantErr: "minimum decryption version must be between 1 and 3"},
+		{name: "outdated keys", version: 3, userKeys: map[int]int{2: 1, 3: 4}, processKeys: map[int]int{1: 2},
+			wantErr: "3 wrapped keys still use a system key version below 3, rewrap them first"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMinDecryptionVersion(tt.version, 3, tt.userKeys, tt.processKeys)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
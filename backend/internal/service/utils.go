@@ -15,6 +15,16 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// containsUint checks if a slice contains a specific uint
+func containsUint(slice []uint, item uint) bool {
+	for _, v := range slice {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
 // removeString removes a specific string from a slice
 func removeString(slice []string, item string) []string {
 	var result []string
@@ -46,30 +56,12 @@ func findLevelNumber(catalog *models.CatalogWithDetails, levelID uint) int {
 	return 0
 }
 
-// findClosestLevelName finds the closest level name for an average level number
-func findClosestLevelName(catalog *models.CatalogWithDetails, avgNumber float64) string {
-	if len(catalog.Levels) == 0 {
-		return ""
-	}
-
-	closestLevel := catalog.Levels[0]
-	minDiff := math.Abs(float64(closestLevel.LevelNumber) - avgNumber)
-
-	for _, level := range catalog.Levels[1:] {
-		diff := math.Abs(float64(level.LevelNumber) - avgNumber)
-		if diff < minDiff {
-			minDiff = diff
-			closestLevel = level
-		}
-	}
-
-	return closestLevel.Name
-}
-
-// calculateAveragedResponses calculates averaged reviewer responses per category.
+// calculateAveragedResponses calculates averaged reviewer responses per category using the scoring strategy of the catalog.
 // Only includes reviewers who have completed ALL categories.
 // If includeJustifications is true, collects all justifications for each category.
 func calculateAveragedResponses(reviewerResponses []models.ReviewerResponse, catalog *models.CatalogWithDetails, includeJustifications bool) []models.AveragedReviewerResponse {
+	strategy := NewScoringStrategy(&catalog.CriteriaCatalog)
+
 	// Store category info from catalog
 	categoryInfo := make(map[uint]struct {
 		Name      string
@@ -119,14 +111,15 @@ func calculateAveragedResponses(reviewerResponses []models.ReviewerResponse, cat
 			continue
 		}
 
-		// Calculate average level number
-		var sum float64
+		// Aggregate level numbers with the catalog's scoring strategy
+		var levelNumbers []float64
+		var pathWeightSum float64
 		var justifications []string
 
 		for _, resp := range responses {
 			// Find level number from catalog
-			levelNumber := findLevelNumber(catalog, resp.LevelID)
-			sum += float64(levelNumber)
+			levelNumbers = append(levelNumbers, float64(findLevelNumber(catalog, resp.LevelID)))
+			pathWeightSum += pathWeight(findPathByID(catalog, resp.PathID))
 
 			// Collect justifications if requested and present
 			if includeJustifications && resp.Justification != "" {
@@ -134,10 +127,14 @@ func calculateAveragedResponses(reviewerResponses []models.ReviewerResponse, cat
 			}
 		}
 
-		avgLevelNumber := sum / float64(len(responses))
+		avgLevelNumber := strategy.Aggregate(levelNumbers)
+		dispersion := levelDispersion(levelNumbers)
 
-		// Find closest level name
-		avgLevelName := findClosestLevelName(catalog, avgLevelNumber)
+		// Resolve level name
+		avgLevelName := ""
+		if level := strategy.ResolveLevel(catalog.Levels, avgLevelNumber); level != nil {
+			avgLevelName = level.Name
+		}
 
 		info := categoryInfo[categoryID]
 		avgResponse := models.AveragedReviewerResponse{
@@ -147,6 +144,11 @@ func calculateAveragedResponses(reviewerResponses []models.ReviewerResponse, cat
 			AverageLevelNumber: math.Round(avgLevelNumber*100) / 100, // Round to 2 decimals
 			AverageLevelName:   avgLevelName,
 			ReviewerCount:      len(responses), // Number of complete reviewers who rated this category
+			PathWeight:         pathWeightSum / float64(len(responses)),
+			MinLevelNumber:     dispersion.min,
+			MaxLevelNumber:     dispersion.max,
+			LevelSpread:        dispersion.max - dispersion.min,
+			StdDeviation:       math.Round(dispersion.stdDev*100) / 100,
 		}
 
 		if includeJustifications {
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"fmt"
+)
+
+// VersionConflictError is returned when a resource was changed by someone else since the
+// client read it. Current holds the current server state (nil if the resource no longer exists)
+// so the client can merge.
+type VersionConflictError struct {
+	Resource string
+	Current  interface{}
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict: %s was modified concurrently", e.Resource)
+}
+
+// versionMatches reports whether a write with the expected version may proceed.
+// No expected version means an unconditional write, 0 requires that the resource does not exist yet.
+func versionMatches(expected *int, exists bool, version int) bool {
+	if expected == nil {
+		return true
+	}
+	if !exists {
+		return *expected == 0
+	}
+	return *expected == version
+}
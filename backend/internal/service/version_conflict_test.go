@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestVersionMatches(t *testing.T) {
+	version := func(v int) *int { return &v }
+
+	tests := []struct {
+		name     string
+		expected *int
+		exists   bool
+		version  int
+		want     bool
+	}{
+		{name: "unconditional create", expected: nil, exists: false, want: true},
+		{name: "unconditional update", expected: nil, exists: true, version: 3, want: true},
+		{name: "create only", expected: version(0), exists: false, want: true},
+		{name: "create only but exists", expected: version(0), exists: true, version: 1, want: false},
+		{name: "matching version", expected: version(3), exists: true, version: 3, want: true},
+		{name: "stale version", expected: version(2), exists: true, version: 3, want: false},
+		{name: "deleted meanwhile", expected: version(2), exists: false, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionMatches(tt.expected, tt.exists, tt.version); got != tt.want {
+				t.Errorf("versionMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+// Pseudo role of a workflow transition that matches the owner of the self-assessment
+const workflowRoleOwner = "owner"
+
+// Workflow guards
+const (
+	guardAllCategoriesComplete = "all_categories_complete" // The owner has answered all categories
+	guardMinCompleteReviews    = "min_complete_reviews"    // At least N reviewers have completed their review
+	guardPanelReviewsComplete  = "panel_reviews_complete"  // All reviewers of the panel have completed their review
+	guardPreviousStatus        = "previous_status"         // Only the status before closing can be restored
+	guardMaxHoursInState       = "max_hours_in_state"      // At most N hours since the current status was entered
+	guardAppealDecided         = "appeal_decided"          // The appeal panel has decided the appeal
+)
+
+// Workflow on-enter actions
+const (
+	actionNotifyOwner     = "notify_owner"     // Email the owner about the new status
+	actionNotifyReviewers = "notify_reviewers" // Email the reviewers of the panel about the new status
+)
+
+// workflowGuards maps the known guards to whether they need a positive value
+var workflowGuards = map[string]bool{
+	guardAllCategoriesComplete: false,
+	guardMinCompleteReviews:    true,
+	guardPanelReviewsComplete:  false,
+	guardPreviousStatus:        false,
+	guardMaxHoursInState:       true,
+	guardAppealDecided:         false,
+}
+
+var workflowActions = map[string]bool{
+	actionNotifyOwner:     true,
+	actionNotifyReviewers: true,
+}
+
+// requiredWorkflowStates are used by built-in features (editing, reviews, discussion, archiving, closing)
+// and must exist in every workflow; review_consolidation, changes_requested, appeal and custom states are optional
+var requiredWorkflowStates = []string{"draft", "submitted", "in_review", "reviewed", "discussion", "archived", "closed"}
+
+// finalWorkflowStates must be marked as final in every workflow
+var finalWorkflowStates = []string{"archived", "closed"}
+
+// Status names are stored in self_assessments.status (VARCHAR(20))
+var workflowStateNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+// builtinWorkflow returns the built-in default workflow used when no default workflow is stored
+func builtinWorkflow() *models.WorkflowDefinition {
+	description := "Standard-Workflow: Einreichung, Review (mit Rückfragen), Konsolidierung, Besprechung (mit Widerspruch) und Archivierung"
+
+	reviewer := func(from, to string, guards ...models.WorkflowGuard) models.WorkflowTransition {
+		return models.WorkflowTransition{From: from, To: to, Roles: []string{"reviewer"}, ExcludeOwner: true, Guards: guards}
+	}
+	admin := func(from, to string, guards ...models.WorkflowGuard) models.WorkflowTransition {
+		return models.WorkflowTransition{From: from, To: to, Roles: []string{"admin"}, Guards: guards}
+	}
+	reopen := []models.WorkflowGuard{
+		{Type: guardPreviousStatus},
+		{Type: guardMaxHoursInState, Value: 24},
+	}
+
+	transitions := []models.WorkflowTransition{
+		{From: "draft", To: "submitted", Roles: []string{workflowRoleOwner}, Guards: []models.WorkflowGuard{{Type: guardAllCategoriesComplete}}},
+		{From: "draft", To: "closed", Roles: []string{workflowRoleOwner, "admin"}},
+		reviewer("submitted", "in_review"),
+		reviewer("submitted", "changes_requested"),
+		admin("submitted", "closed"),
+		{From: "changes_requested", To: "submitted", Roles: []string{workflowRoleOwner}, Guards: []models.WorkflowGuard{{Type: guardAllCategoriesComplete}}},
+		admin("changes_requested", "closed"),
+		reviewer("in_review", "review_consolidation", models.WorkflowGuard{Type: guardPanelReviewsComplete}),
+		reviewer("in_review", "reviewed"),
+		reviewer("in_review", "changes_requested"),
+		admin("in_review", "closed"),
+		reviewer("review_consolidation", "in_review"),
+		reviewer("review_consolidation", "reviewed"),
+		admin("review_consolidation", "closed"),
+		reviewer("reviewed", "discussion"),
+		admin("reviewed", "closed"),
+		reviewer("discussion", "archived"),
+		admin("discussion", "closed"),
+		{From: "discussion", To: statusAppeal, Roles: []string{workflowRoleOwner}},
+		reviewer(statusAppeal, "discussion", models.WorkflowGuard{Type: guardAppealDecided}),
+		admin(statusAppeal, "closed"),
+	}
+	for _, to := range []string{"draft", "submitted", "changes_requested", "in_review", "review_consolidation", "reviewed", "discussion", statusAppeal} {
+		transitions = append(transitions, admin("closed", to, reopen...))
+	}
+
+	return &models.WorkflowDefinition{
+		Name:        "Standard",
+		Description: &description,
+		IsDefault:   true,
+		States: []models.WorkflowState{
+			{Name: "draft"},
+			{Name: "submitted"},
+			{Name: "changes_requested"},
+			{Name: "in_review"},
+			{Name: "review_consolidation"},
+			{Name: "reviewed"},
+			{Name: "discussion"},
+			{Name: statusAppeal},
+			{Name: "archived", Final: true},
+			{Name: "closed", Final: true},
+		},
+		Transitions: transitions,
+	}
+}
+
+// validateWorkflow checks a workflow definition and returns all problems found
+func validateWorkflow(workflow *models.WorkflowDefinition) []string {
+	errors := []string{}
+	if workflow.Name == "" {
+		errors = append(errors, "name is required")
+	}
+
+	states := make(map[string]models.WorkflowState, len(workflow.States))
+	for _, state := range workflow.States {
+		if !workflowStateNamePattern.MatchString(state.Name) {
+			errors = append(errors, fmt.Sprintf("invalid state name %q (lowercase letters, digits and underscores, max. 20 characters)", state.Name))
+			continue
+		}
+		if _, exists := states[state.Name]; exists {
+			errors = append(errors, fmt.Sprintf("state %s is defined more than once", state.Name))
+			continue
+		}
+		states[state.Name] = state
+		for _, action := range state.OnEnter {
+			if !workflowActions[action] {
+				errors = append(errors, fmt.Sprintf("state %s: unknown action %q", state.Name, action))
+			}
+		}
+	}
+
+	for _, name := range requiredWorkflowStates {
+		if _, ok := states[name]; !ok {
+			errors = append(errors, fmt.Sprintf("required state %s is missing", name))
+		}
+	}
+	for _, name := range finalWorkflowStates {
+		if state, ok := states[name]; ok && !state.Final {
+			errors = append(errors, fmt.Sprintf("state %s must be final", name))
+		}
+	}
+
+	outgoing := make(map[string][]string)
+	incoming := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, t := range workflow.Transitions {
+		label := fmt.Sprintf("transition %s -> %s", t.From, t.To)
+		_, fromOK := states[t.From]
+		_, toOK := states[t.To]
+		if !fromOK || !toOK {
+			errors = append(errors, label+": unknown state")
+			continue
+		}
+		if t.From == t.To {
+			errors = append(errors, label+": source and target must differ")
+			continue
+		}
+		if seen[t.From+"->"+t.To] {
+			errors = append(errors, label+": defined more than once")
+			continue
+		}
+		seen[t.From+"->"+t.To] = true
+
+		if len(t.Roles) == 0 {
+			errors = append(errors, label+": at least one role is required")
+		}
+		for _, guard := range t.Guards {
+			needsValue, known := workflowGuards[guard.Type]
+			switch {
+			case !known:
+				errors = append(errors, fmt.Sprintf("%s: unknown guard %q", label, guard.Type))
+			case needsValue && guard.Value <= 0:
+				errors = append(errors, fmt.Sprintf("%s: guard %s requires a positive value", label, guard.Type))
+			}
+		}
+
+		outgoing[t.From] = append(outgoing[t.From], t.To)
+		incoming[t.To] = append(incoming[t.To], t.From)
+	}
+
+	if _, ok := states["draft"]; !ok {
+		return errors
+	}
+
+	// Every state must be reachable from draft
+	reachable := walkWorkflow([]string{"draft"}, outgoing)
+	for _, state := range workflow.States {
+		if _, ok := states[state.Name]; ok && !reachable[state.Name] {
+			errors = append(errors, fmt.Sprintf("state %s is unreachable from draft", state.Name))
+		}
+	}
+
+	// Every non-final state must lead to a final state
+	var finals []string
+	for _, state := range workflow.States {
+		if state.Final {
+			finals = append(finals, state.Name)
+		}
+	}
+	canFinish := walkWorkflow(finals, incoming)
+	for _, state := range workflow.States {
+		if _, ok := states[state.Name]; ok && !state.Final && !canFinish[state.Name] {
+			errors = append(errors, fmt.Sprintf("state %s is a dead end (no final state reachable)", state.Name))
+		}
+	}
+
+	return errors
+}
+
+// walkWorkflow returns all states reachable from the start states along the given edges
+func walkWorkflow(start []string, edges map[string][]string) map[string]bool {
+	visited := make(map[string]bool)
+	queue := append([]string(nil), start...)
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		if visited[state] {
+			continue
+		}
+		visited[state] = true
+		queue = append(queue, edges[state]...)
+	}
+	return visited
+}
+
+// findWorkflowTransition returns the transition between two states (nil if the workflow has none)
+func findWorkflowTransition(workflow *models.WorkflowDefinition, from, to string) *models.WorkflowTransition {
+	for i := range workflow.Transitions {
+		if workflow.Transitions[i].From == from && workflow.Transitions[i].To == to {
+			return &workflow.Transitions[i]
+		}
+	}
+	return nil
+}
+
+// findWorkflowState returns a state of the workflow (nil if unknown)
+func findWorkflowState(workflow *models.WorkflowDefinition, name string) *models.WorkflowState {
+	for i := range workflow.States {
+		if workflow.States[i].Name == name {
+			return &workflow.States[i]
+		}
+	}
+	return nil
+}
+
+// canPerformTransition reports whether a user may perform a transition based on roles and ownership
+func canPerformTransition(transition *models.WorkflowTransition, userRoles []string, isOwner bool) bool {
+	if isOwner && transition.ExcludeOwner {
+		return false
+	}
+	for _, role := range transition.Roles {
+		if role == workflowRoleOwner {
+			if isOwner {
+				return true
+			}
+			continue
+		}
+		if contains(userRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// stateEnteredAt returns when a self-assessment entered its current status
+// (last update for states without own timestamp)
+func stateEnteredAt(assessment *models.SelfAssessment) time.Time {
+	var at *time.Time
+	switch assessment.Status {
+	case "draft":
+		at = &assessment.CreatedAt
+	case "submitted":
+		at = assessment.SubmittedAt
+	case "in_review":
+		at = assessment.InReviewAt
+	case "review_consolidation":
+		at = assessment.ReviewConsolidationAt
+	case "reviewed":
+		at = assessment.ReviewedAt
+	case "discussion":
+		at = assessment.DiscussionStartedAt
+	case "archived":
+		at = assessment.ArchivedAt
+	case "closed":
+		at = assessment.ClosedAt
+	}
+	if at == nil {
+		return assessment.UpdatedAt
+	}
+	return *at
+}
+
+// missingWorkflowStates returns the statuses in use that the workflow does not define
+func missingWorkflowStates(workflow *models.WorkflowDefinition, statuses []string) []string {
+	var missing []string
+	for _, status := range statuses {
+		if findWorkflowState(workflow, status) == nil {
+			missing = append(missing, status)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,402 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"new-pay/internal/email"
+	"new-pay/internal/models"
+	"new-pay/internal/repository"
+)
+
+// WorkflowService manages assessment workflows and checks status transitions against them
+type WorkflowService struct {
+	workflowRepo   *repository.WorkflowRepository
+	assessmentRepo *repository.SelfAssessmentRepository
+	responseRepo   *repository.AssessmentResponseRepository
+	reviewerRepo   *repository.ReviewerResponseRepository
+	assignmentRepo *repository.ReviewerAssignmentRepository
+	appealRepo     *repository.AppealRepository
+	userRepo       *repository.UserRepository
+	auditSvc       *AuditService
+	emailService   *email.Service
+	enterHooks     map[string][]func(assessment *models.SelfAssessment) // [status]hooks
+}
+
+// NewWorkflowService creates a new workflow service
+func NewWorkflowService(
+	workflowRepo *repository.WorkflowRepository,
+	assessmentRepo *repository.SelfAssessmentRepository,
+	responseRepo *repository.AssessmentResponseRepository,
+	reviewerRepo *repository.ReviewerResponseRepository,
+	assignmentRepo *repository.ReviewerAssignmentRepository,
+	appealRepo *repository.AppealRepository,
+	userRepo *repository.UserRepository,
+	auditSvc *AuditService,
+	emailService *email.Service,
+) *WorkflowService {
+	return &WorkflowService{
+		workflowRepo:   workflowRepo,
+		assessmentRepo: assessmentRepo,
+		responseRepo:   responseRepo,
+		reviewerRepo:   reviewerRepo,
+		assignmentRepo: assignmentRepo,
+		appealRepo:     appealRepo,
+		userRepo:       userRepo,
+		auditSvc:       auditSvc,
+		emailService:   emailService,
+	}
+}
+
+// GetWorkflows returns all stored workflows
+func (s *WorkflowService) GetWorkflows() ([]models.WorkflowDefinition, error) {
+	return s.workflowRepo.GetAll()
+}
+
+// GetWorkflow returns a stored workflow
+func (s *WorkflowService) GetWorkflow(id uint) (*models.WorkflowDefinition, error) {
+	workflow, err := s.workflowRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if workflow == nil {
+		return nil, fmt.Errorf("workflow not found")
+	}
+	return workflow, nil
+}
+
+// GetBuiltinWorkflow returns the built-in default workflow, e.g. as template for own workflows
+func (s *WorkflowService) GetBuiltinWorkflow() *models.WorkflowDefinition {
+	return builtinWorkflow()
+}
+
+// ValidateWorkflow checks a workflow definition without saving it
+func (s *WorkflowService) ValidateWorkflow(workflow *models.WorkflowDefinition) *models.WorkflowValidationResult {
+	errors := validateWorkflow(workflow)
+	return &models.WorkflowValidationResult{
+		Valid:  len(errors) == 0,
+		Errors: errors,
+	}
+}
+
+// CreateWorkflow validates and stores a new workflow
+func (s *WorkflowService) CreateWorkflow(workflow *models.WorkflowDefinition, userID uint) error {
+	workflow.Name = strings.TrimSpace(workflow.Name)
+	if err := s.checkValid(workflow); err != nil {
+		return err
+	}
+
+	// A new default workflow takes over all catalogs without own workflow
+	if workflow.IsDefault {
+		if err := s.checkStatusesCovered(workflow, 0, true); err != nil {
+			return err
+		}
+	}
+
+	workflow.CreatedBy = &userID
+	if err := s.workflowRepo.Create(workflow); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "create_workflow", "workflow",
+		fmt.Sprintf("Created workflow %d (%s)", workflow.ID, workflow.Name))
+
+	return nil
+}
+
+// UpdateWorkflow validates and replaces a stored workflow.
+// Self-assessments in use must not end up in a state the workflow no longer defines.
+func (s *WorkflowService) UpdateWorkflow(workflow *models.WorkflowDefinition, userID uint) error {
+	existing, err := s.GetWorkflow(workflow.ID)
+	if err != nil {
+		return err
+	}
+
+	workflow.Name = strings.TrimSpace(workflow.Name)
+	if err := s.checkValid(workflow); err != nil {
+		return err
+	}
+
+	if err := s.checkStatusesCovered(workflow, workflow.ID, workflow.IsDefault); err != nil {
+		return err
+	}
+	// Catalogs without own workflow fall back to the built-in workflow
+	if existing.IsDefault && !workflow.IsDefault {
+		if err := s.checkStatusesCovered(builtinWorkflow(), 0, true); err != nil {
+			return err
+		}
+	}
+
+	if err := s.workflowRepo.Update(workflow); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "update_workflow", "workflow",
+		fmt.Sprintf("Updated workflow %d (%s)", workflow.ID, workflow.Name))
+
+	return nil
+}
+
+// DeleteWorkflow deletes a workflow that is not assigned to any catalog
+func (s *WorkflowService) DeleteWorkflow(id uint, userID uint) error {
+	workflow, err := s.GetWorkflow(id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.workflowRepo.CountCatalogsByWorkflow(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("workflow is assigned to %d catalog(s)", count)
+	}
+
+	if workflow.IsDefault {
+		if err := s.checkStatusesCovered(builtinWorkflow(), 0, true); err != nil {
+			return err
+		}
+	}
+
+	if err := s.workflowRepo.Delete(id); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "delete_workflow", "workflow",
+		fmt.Sprintf("Deleted workflow %d (%s)", id, workflow.Name))
+
+	return nil
+}
+
+// SetCatalogWorkflow assigns a workflow to a catalog (nil = default workflow)
+func (s *WorkflowService) SetCatalogWorkflow(catalogID uint, workflowID *uint, userID uint) error {
+	var workflow *models.WorkflowDefinition
+	var err error
+	if workflowID != nil {
+		workflow, err = s.GetWorkflow(*workflowID)
+	} else {
+		workflow, err = s.getDefaultWorkflow()
+	}
+	if err != nil {
+		return err
+	}
+
+	statuses, err := s.workflowRepo.GetStatusesByCatalog(catalogID)
+	if err != nil {
+		return err
+	}
+	if missing := missingWorkflowStates(workflow, statuses); len(missing) > 0 {
+		return fmt.Errorf("workflow does not define states in use by self-assessments of this catalog: %s", strings.Join(missing, ", "))
+	}
+
+	if err := s.workflowRepo.SetCatalogWorkflow(catalogID, workflowID); err != nil {
+		return err
+	}
+
+	s.auditSvc.Log(userID, "assign_workflow", "catalog",
+		fmt.Sprintf("Assigned workflow %s to catalog %d", workflow.Name, catalogID))
+
+	return nil
+}
+
+// GetWorkflowForCatalog returns the workflow of a catalog: its own, the stored default or the built-in workflow
+func (s *WorkflowService) GetWorkflowForCatalog(catalogID uint) (*models.WorkflowDefinition, error) {
+	workflow, err := s.workflowRepo.GetByCatalogID(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if workflow != nil {
+		return workflow, nil
+	}
+	return s.getDefaultWorkflow()
+}
+
+// CheckTransition checks whether a user may move a self-assessment to a new status:
+// the workflow must define the transition, the user must have one of its roles and all guards must hold
+func (s *WorkflowService) CheckTransition(assessment *models.SelfAssessment, toStatus string, userID uint, userRoles []string) error {
+	workflow, err := s.GetWorkflowForCatalog(assessment.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	transition := findWorkflowTransition(workflow, assessment.Status, toStatus)
+	if transition == nil {
+		return fmt.Errorf("cannot transition from %s to %s status", assessment.Status, toStatus)
+	}
+
+	if !canPerformTransition(transition, userRoles, assessment.UserID == userID) {
+		return fmt.Errorf("permission denied: cannot transition from %s to %s status", assessment.Status, toStatus)
+	}
+
+	for _, guard := range transition.Guards {
+		if err := s.checkGuard(guard, assessment, toStatus); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HasTransition reports whether the workflow of a self-assessment allows an automatic status change
+func (s *WorkflowService) HasTransition(assessment *models.SelfAssessment, toStatus string) (bool, error) {
+	workflow, err := s.GetWorkflowForCatalog(assessment.CatalogID)
+	if err != nil {
+		return false, err
+	}
+	return findWorkflowTransition(workflow, assessment.Status, toStatus) != nil, nil
+}
+
+// RegisterEnterHook registers a function that runs whenever a self-assessment enters the given
+// status, independent of the on-enter actions of its workflow. Hooks are registered at startup.
+func (s *WorkflowService) RegisterEnterHook(status string, hook func(assessment *models.SelfAssessment)) {
+	if s.enterHooks == nil {
+		s.enterHooks = make(map[string][]func(assessment *models.SelfAssessment))
+	}
+	s.enterHooks[status] = append(s.enterHooks[status], hook)
+}
+
+// OnEnter runs the registered hooks and the on-enter actions of the current status of a self-assessment.
+// Every service that changes the status calls it. Failures are logged; they never undo the status change.
+func (s *WorkflowService) OnEnter(assessment *models.SelfAssessment) {
+	s.runEnterHooks(assessment)
+
+	workflow, err := s.GetWorkflowForCatalog(assessment.CatalogID)
+	if err != nil {
+		slog.Error("Failed to get workflow for on-enter actions", "assessment_id", assessment.ID, "error", err)
+		return
+	}
+
+	state := findWorkflowState(workflow, assessment.Status)
+	if state == nil || len(state.OnEnter) == 0 {
+		return
+	}
+
+	details, err := s.assessmentRepo.GetByIDWithDetails(assessment.ID)
+	if err != nil || details == nil {
+		slog.Error("Failed to get assessment details for on-enter actions", "assessment_id", assessment.ID, "error", err)
+		return
+	}
+
+	for _, action := range state.OnEnter {
+		switch action {
+		case actionNotifyOwner:
+			s.notify(details.UserEmail, details.UserName, details)
+		case actionNotifyReviewers:
+			reviewers, err := s.assignmentRepo.GetByAssessmentID(assessment.ID)
+			if err != nil {
+				slog.Error("Failed to get reviewers for notification", "assessment_id", assessment.ID, "error", err)
+				continue
+			}
+			for _, reviewer := range reviewers {
+				user, err := s.userRepo.GetByID(reviewer.ReviewerUserID)
+				if err != nil {
+					slog.Error("Failed to get reviewer for notification", "reviewer_id", reviewer.ReviewerUserID, "error", err)
+					continue
+				}
+				s.notify(user.Email, reviewer.ReviewerName, details)
+			}
+		}
+	}
+}
+
+// runEnterHooks runs the hooks registered for the current status of a self-assessment
+func (s *WorkflowService) runEnterHooks(assessment *models.SelfAssessment) {
+	for _, hook := range s.enterHooks[assessment.Status] {
+		hook(assessment)
+	}
+}
+
+func (s *WorkflowService) notify(to, name string, details *models.SelfAssessmentWithDetails) {
+	if s.emailService == nil {
+		return
+	}
+	if err := s.emailService.SendAssessmentStatusNotification(to, name, details.CatalogName, details.UserName, details.Status, details.ID); err != nil {
+		slog.Error("Failed to send status notification", "assessment_id", details.ID, "to", to, "error", err)
+	}
+}
+
+// checkGuard evaluates a guard of a transition
+func (s *WorkflowService) checkGuard(guard models.WorkflowGuard, assessment *models.SelfAssessment, toStatus string) error {
+	switch guard.Type {
+	case guardAllCategoriesComplete:
+		completeness, err := s.responseRepo.GetCompleteness(assessment.ID)
+		if err != nil {
+			return err
+		}
+		if !completeness.IsComplete {
+			return fmt.Errorf("assessment is incomplete: %d of %d categories completed",
+				completeness.CompletedCategories, completeness.TotalCategories)
+		}
+	case guardMinCompleteReviews:
+		complete, err := s.reviewerRepo.CountCompleteReviews(assessment.ID)
+		if err != nil {
+			return err
+		}
+		if complete < guard.Value {
+			return fmt.Errorf("at least %d complete reviews are required (got %d)", guard.Value, complete)
+		}
+	case guardPanelReviewsComplete:
+		complete, err := s.reviewerRepo.CountCompleteReviews(assessment.ID)
+		if err != nil {
+			return err
+		}
+		panelSize, err := s.assignmentRepo.CountByAssessmentID(assessment.ID)
+		if err != nil {
+			return err
+		}
+		if panelSize == 0 || complete < panelSize {
+			return fmt.Errorf("all reviewers of the panel must complete their review")
+		}
+	case guardPreviousStatus:
+		if assessment.PreviousStatus != nil && *assessment.PreviousStatus != toStatus {
+			return fmt.Errorf("can only restore the previous status %s", *assessment.PreviousStatus)
+		}
+	case guardMaxHoursInState:
+		if time.Since(stateEnteredAt(assessment)) > time.Duration(guard.Value)*time.Hour {
+			return fmt.Errorf("cannot leave %s status after %d hours", assessment.Status, guard.Value)
+		}
+	case guardAppealDecided:
+		appeal, err := s.appealRepo.GetByAssessment(assessment.ID)
+		if err != nil {
+			return err
+		}
+		if appeal == nil || appeal.Status == appealStatusOpen {
+			return fmt.Errorf("the appeal must be decided by the appeal panel first")
+		}
+	default:
+		return fmt.Errorf("unknown workflow guard %s", guard.Type)
+	}
+	return nil
+}
+
+func (s *WorkflowService) getDefaultWorkflow() (*models.WorkflowDefinition, error) {
+	workflow, err := s.workflowRepo.GetDefault()
+	if err != nil {
+		return nil, err
+	}
+	if workflow == nil {
+		return builtinWorkflow(), nil
+	}
+	return workflow, nil
+}
+
+func (s *WorkflowService) checkValid(workflow *models.WorkflowDefinition) error {
+	if errors := validateWorkflow(workflow); len(errors) > 0 {
+		return fmt.Errorf("invalid workflow: %s", strings.Join(errors, "; "))
+	}
+	return nil
+}
+
+// checkStatusesCovered ensures that self-assessments governed by a workflow are in states it defines
+func (s *WorkflowService) checkStatusesCovered(workflow *models.WorkflowDefinition, workflowID uint, includeUnassigned bool) error {
+	statuses, err := s.workflowRepo.GetStatusesInUse(workflowID, includeUnassigned)
+	if err != nil {
+		return err
+	}
+	if missing := missingWorkflowStates(workflow, statuses); len(missing) > 0 {
+		return fmt.Errorf("workflow does not define states in use by self-assessments: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
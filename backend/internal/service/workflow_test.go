@@ -0,0 +1,229 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"new-pay/internal/models"
+)
+
+func TestBuiltinWorkflowIsValid(t *testing.T) {
+	if errors := validateWorkflow(builtinWorkflow()); len(errors) > 0 {
+		t.Fatalf("built-in workflow is invalid: %v", errors)
+	}
+}
+
+func TestValidateWorkflow(t *testing.T) {
+	withoutConsolidation := func() *models.WorkflowDefinition {
+		w := builtinWorkflow()
+		var states []models.WorkflowState
+		for _, s := range w.States {
+			if s.Name != "review_consolidation" {
+				states = append(states, s)
+			}
+		}
+		var transitions []models.WorkflowTransition
+		for _, tr := range w.Transitions {
+			if tr.From != "review_consolidation" && tr.To != "review_consolidation" {
+				transitions = append(transitions, tr)
+			}
+		}
+		w.States, w.Transitions = states, transitions
+		return w
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(w *models.WorkflowDefinition)
+		wantErr string
+	}{
+		{
+			name:   "skipping review_consolidation is allowed",
+			modify: func(w *models.WorkflowDefinition) { *w = *withoutConsolidation() },
+		},
+		{
+			name: "calibration step",
+			modify: func(w *models.WorkflowDefinition) {
+				w.States = append(w.States, models.WorkflowState{Name: "calibration", OnEnter: []string{actionNotifyReviewers}})
+				w.Transitions = append(w.Transitions,
+					models.WorkflowTransition{From: "reviewed", To: "calibration", Roles: []string{"reviewer"}},
+					models.WorkflowTransition{From: "calibration", To: "discussion", Roles: []string{"reviewer"}, Guards: []models.WorkflowGuard{{Type: guardMinCompleteReviews, Value: 2}}},
+				)
+			},
+		},
+		{
+			name: "unreachable state",
+			modify: func(w *models.WorkflowDefinition) {
+				w.States = append(w.States, models.WorkflowState{Name: "calibration"})
+				w.Transitions = append(w.Transitions, models.WorkflowTransition{From: "calibration", To: "discussion", Roles: []string{"reviewer"}})
+			},
+			wantErr: "state calibration is unreachable from draft",
+		},
+		{
+			name: "dead end",
+			modify: func(w *models.WorkflowDefinition) {
+				w.States = append(w.States, models.WorkflowState{Name: "calibration"})
+				w.Transitions = append(w.Transitions, models.WorkflowTransition{From: "reviewed", To: "calibration", Roles: []string{"reviewer"}})
+			},
+			wantErr: "state calibration is a dead end",
+		},
+		{
+			name:    "missing required state",
+			modify:  func(w *models.WorkflowDefinition) { w.States = w.States[1:] },
+			wantErr: "required state draft is missing",
+		},
+		{
+			name:    "final state not marked",
+			modify:  func(w *models.WorkflowDefinition) { w.States[len(w.States)-1].Final = false },
+			wantErr: "state closed must be final",
+		},
+		{
+			name: "invalid state name",
+			modify: func(w *models.WorkflowDefinition) {
+				w.States = append(w.States, models.WorkflowState{Name: "Calibration Step"})
+			},
+			wantErr: "invalid state name",
+		},
+		{
+			name: "unknown state in transition",
+			modify: func(w *models.WorkflowDefinition) {
+				w.Transitions = append(w.Transitions, models.WorkflowTransition{From: "reviewed", To: "unknown", Roles: []string{"reviewer"}})
+			},
+			wantErr: "transition reviewed -> unknown: unknown state",
+		},
+		{
+			name: "duplicate transition",
+			modify: func(w *models.WorkflowDefinition) {
+				w.Transitions = append(w.Transitions, w.Transitions[0])
+			},
+			wantErr: "defined more than once",
+		},
+		{
+			name:    "transition without roles",
+			modify:  func(w *models.WorkflowDefinition) { w.Transitions[0].Roles = nil },
+			wantErr: "at least one role is required",
+		},
+		{
+			name: "unknown guard",
+			modify: func(w *models.WorkflowDefinition) {
+				w.Transitions[0].Guards = []models.WorkflowGuard{{Type: "full_moon"}}
+			},
+			wantErr: `unknown guard "full_moon"`,
+		},
+		{
+			name: "guard without value",
+			modify: func(w *models.WorkflowDefinition) {
+				w.Transitions[0].Guards = []models.WorkflowGuard{{Type: guardMinCompleteReviews}}
+			},
+			wantErr: "requires a positive value",
+		},
+		{
+			name:    "unknown action",
+			modify:  func(w *models.WorkflowDefinition) { w.States[1].OnEnter = []string{"send_fax"} },
+			wantErr: `unknown action "send_fax"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := builtinWorkflow()
+			tt.modify(w)
+			errors := validateWorkflow(w)
+
+			if tt.wantErr == "" {
+				if len(errors) > 0 {
+					t.Fatalf("validateWorkflow() = %v, want no errors", errors)
+				}
+				return
+			}
+			for _, err := range errors {
+				if strings.Contains(err, tt.wantErr) {
+					return
+				}
+			}
+			t.Errorf("validateWorkflow() = %v, want error containing %q", errors, tt.wantErr)
+		})
+	}
+}
+
+func TestCanPerformTransition(t *testing.T) {
+	w := builtinWorkflow()
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		roles   []string
+		isOwner bool
+		want    bool
+	}{
+		{name: "owner submits", from: "draft", to: "submitted", roles: []string{"user"}, isOwner: true, want: true},
+		{name: "admin cannot submit for others", from: "draft", to: "submitted", roles: []string{"admin"}, want: false},
+		{name: "reviewer starts review", from: "submitted", to: "in_review", roles: []string{"reviewer"}, want: true},
+		{name: "reviewer cannot review own assessment", from: "submitted", to: "in_review", roles: []string{"reviewer"}, isOwner: true, want: false},
+		{name: "admin cannot start review", from: "submitted", to: "in_review", roles: []string{"admin"}, want: false},
+		{name: "owner closes draft", from: "draft", to: "closed", roles: []string{"user"}, isOwner: true, want: true},
+		{name: "owner cannot close submitted", from: "submitted", to: "closed", roles: []string{"user"}, isOwner: true, want: false},
+		{name: "admin closes submitted", from: "submitted", to: "closed", roles: []string{"admin"}, want: true},
+		{name: "admin reopens", from: "closed", to: "in_review", roles: []string{"admin"}, want: true},
+		{name: "reviewer requests changes", from: "in_review", to: "changes_requested", roles: []string{"reviewer"}, want: true},
+		{name: "owner cannot request changes", from: "submitted", to: "changes_requested", roles: []string{"reviewer"}, isOwner: true, want: false},
+		{name: "owner resubmits", from: "changes_requested", to: "submitted", roles: []string{"user"}, isOwner: true, want: true},
+		{name: "reviewer cannot resubmit", from: "changes_requested", to: "submitted", roles: []string{"reviewer"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition := findWorkflowTransition(w, tt.from, tt.to)
+			if transition == nil {
+				t.Fatalf("no transition %s -> %s", tt.from, tt.to)
+			}
+			if got := canPerformTransition(transition, tt.roles, tt.isOwner); got != tt.want {
+				t.Errorf("canPerformTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if findWorkflowTransition(w, "archived", "closed") != nil {
+		t.Error("archived self-assessments must not be closable")
+	}
+}
+
+func TestStateEnteredAt(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(48 * time.Hour)
+	closed := created.Add(24 * time.Hour)
+
+	assessment := &models.SelfAssessment{Status: "closed", CreatedAt: created, UpdatedAt: updated, ClosedAt: &closed}
+	if got := stateEnteredAt(assessment); !got.Equal(closed) {
+		t.Errorf("closed: got %v, want %v", got, closed)
+	}
+
+	assessment.Status = "calibration"
+	if got := stateEnteredAt(assessment); !got.Equal(updated) {
+		t.Errorf("custom state: got %v, want %v", got, updated)
+	}
+}
+
+func TestMissingWorkflowStates(t *testing.T) {
+	missing := missingWorkflowStates(builtinWorkflow(), []string{"draft", "calibration", "in_review"})
+	if len(missing) != 1 || missing[0] != "calibration" {
+		t.Errorf("missingWorkflowStates() = %v, want [calibration]", missing)
+	}
+}
+
+func TestEnterHooks(t *testing.T) {
+	s := &WorkflowService{}
+
+	var archived, closed []uint
+	s.RegisterEnterHook("archived", func(assessment *models.SelfAssessment) { archived = append(archived, assessment.ID) })
+	s.RegisterEnterHook("closed", func(assessment *models.SelfAssessment) { closed = append(closed, assessment.ID) })
+
+	s.runEnterHooks(&models.SelfAssessment{ID: 1, Status: "discussion"})
+	s.runEnterHooks(&models.SelfAssessment{ID: 2, Status: "archived"})
+
+	if len(archived) != 1 || archived[0] != 2 || len(closed) != 0 {
+		t.Errorf("archived hooks ran for %v, closed hooks for %v, want only archived for assessment 2", archived, closed)
+	}
+}
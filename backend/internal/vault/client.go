@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -167,6 +168,106 @@ func (c *Client) Decrypt(keyName string, ciphertext string, ctx map[string]strin
 	return plaintext, nil
 }
 
+// RotateKey creates a new version of a transit key. New ciphertexts use the new version,
+// existing ciphertexts stay decryptable down to the minimum decryption version.
+func (c *Client) RotateKey(keyName string) error {
+	ctx := context.Background()
+
+	path := fmt.Sprintf("%s/keys/%s/rotate", c.transitMount, keyName)
+
+	if _, err := c.client.Logical().WriteWithContext(ctx, path, nil); err != nil {
+		return fmt.Errorf("failed to rotate key %s: %w", keyName, err)
+	}
+
+	return nil
+}
+
+// GetKeyVersions returns the latest version and the minimum decryption version of a transit key
+func (c *Client) GetKeyVersions(keyName string) (latestVersion, minDecryptionVersion int, err error) {
+	ctx := context.Background()
+
+	path := fmt.Sprintf("%s/keys/%s", c.transitMount, keyName)
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read key %s: %w", keyName, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return 0, 0, fmt.Errorf("key %s not found", keyName)
+	}
+
+	latestVersion, err = intValue(secret.Data["latest_version"])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid latest_version in response: %w", err)
+	}
+	minDecryptionVersion, err = intValue(secret.Data["min_decryption_version"])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid min_decryption_version in response: %w", err)
+	}
+
+	return latestVersion, minDecryptionVersion, nil
+}
+
+// SetMinDecryptionVersion sets the oldest key version that may still be used for decryption
+func (c *Client) SetMinDecryptionVersion(keyName string, version int) error {
+	ctx := context.Background()
+
+	path := fmt.Sprintf("%s/keys/%s/config", c.transitMount, keyName)
+
+	data := map[string]interface{}{
+		"min_decryption_version": version,
+	}
+
+	if _, err := c.client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return fmt.Errorf("failed to set minimum decryption version of key %s: %w", keyName, err)
+	}
+
+	return nil
+}
+
+// Rewrap re-encrypts a ciphertext with the latest version of a transit key without exposing the plaintext
+func (c *Client) Rewrap(keyName string, ciphertext string, ctx map[string]string) (string, error) {
+	bgCtx := context.Background()
+
+	path := fmt.Sprintf("%s/rewrap/%s", c.transitMount, keyName)
+
+	data := map[string]interface{}{
+		"ciphertext": ciphertext,
+	}
+
+	// Context must match the one used for encryption
+	if len(ctx) > 0 {
+		contextStr := c.encodeContext(ctx)
+		data["context"] = base64.StdEncoding.EncodeToString([]byte(contextStr))
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(bgCtx, path, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to rewrap: %w", err)
+	}
+
+	rewrapped, ok := secret.Data["ciphertext"].(string)
+	if !ok {
+		return "", fmt.Errorf("invalid ciphertext response")
+	}
+
+	return rewrapped, nil
+}
+
+// intValue converts a numeric value of a Vault response
+func intValue(v interface{}) (int, error) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err
+	case float64:
+		return int(n), nil
+	case int:
+		return n, nil
+	}
+	return 0, fmt.Errorf("unexpected type %T", v)
+}
+
 // GenerateDataKey generates a new data encryption key (DEK)
 func (c *Client) GenerateDataKey(keyName string, bits int) (plaintext []byte, ciphertext string, err error) {
 	ctx := context.Background()
@@ -20,6 +20,7 @@ import (
 	"new-pay/internal/keymanager"
 	"new-pay/internal/logger"
 	"new-pay/internal/middleware"
+	"new-pay/internal/models"
 	"new-pay/internal/repository"
 	"new-pay/internal/scheduler"
 	"new-pay/internal/securestore"
@@ -112,59 +113,115 @@ func main() {
 	categoryDiscussionCommentRepo := repository.NewCategoryDiscussionCommentRepository(db.DB)
 	discussionRepo := repository.NewDiscussionRepository(db.DB)
 	discussionConfirmationRepo := repository.NewDiscussionConfirmationRepository(db.DB)
+	discussionMeetingRepo := repository.NewDiscussionMeetingRepository(db.DB)
+	payRepo := repository.NewPayRepository(db.DB)
+	reviewerAssignmentRepo := repository.NewReviewerAssignmentRepository(db.DB)
+	workflowRepo := repository.NewWorkflowRepository(db.DB)
+	changeRequestRepo := repository.NewChangeRequestRepository(db.DB)
+	appealRepo := repository.NewAppealRepository(db.DB)
+	keyRotationRepo := repository.NewKeyRotationRepository(db.DB)
+	legalHoldRepo := repository.NewLegalHoldRepository(db.DB)
+	dataErasureRepo := repository.NewDataErasureRepository(db.DB)
+	quorumPolicyRepo := repository.NewQuorumPolicyRepository(db.DB)
+	transactor := repository.NewTransactor(db.DB)
 
 	// Initialize services
 	authService := auth.NewService(&cfg.JWT)
 	emailService := email.NewService(&cfg.Email)
 	auditService := service.NewAuditService(auditRepo)
 	authSvc := service.NewAuthService(userRepo, tokenRepo, roleRepo, sessionRepo, oauthConnRepo, authService, emailService)
-	catalogService := service.NewCatalogService(catalogRepo, selfAssessmentRepo, auditService, emailService)
+	catalogLocalizer := service.NewCatalogLocalizer(catalogRepo, cfg.Catalog.Locales, cfg.Catalog.RequireTranslations)
+	catalogService := service.NewCatalogService(catalogRepo, selfAssessmentRepo, transactor, auditService, emailService, catalogLocalizer, cfg.Catalog.RequiredApprovals)
 	llmService := service.NewLLMService(cfg.LLM.BaseURL, cfg.LLM.Model, cfg.LLM.Enabled)
+	workflowService := service.NewWorkflowService(workflowRepo, selfAssessmentRepo, assessmentResponseRepo, reviewerResponseRepo, reviewerAssignmentRepo, appealRepo, userRepo, auditService, emailService)
+	meetingService := service.NewMeetingService(discussionMeetingRepo, selfAssessmentRepo, reviewerAssignmentRepo, reviewerResponseRepo, discussionConfirmationRepo, userRepo, auditService, emailService)
+	quorumService := service.NewQuorumService(quorumPolicyRepo, catalogRepo, auditService, models.QuorumPolicy{
+		AveragedCount:     cfg.Review.QuorumAveragedCount,
+		AveragedPercent:   cfg.Review.QuorumAveragedPercent,
+		OverrideCount:     cfg.Review.QuorumOverrideCount,
+		OverridePercent:   cfg.Review.QuorumOverridePercent,
+		FinalCount:        cfg.Review.QuorumFinalCount,
+		FinalPercent:      cfg.Review.QuorumFinalPercent,
+		AllowSelfApproval: cfg.Review.QuorumSelfApproval,
+	})
+	consolidationEvents := service.NewConsolidationEventHub()
 
 	// Ensure LLM model is available (in background)
 	if cfg.LLM.Enabled {
 		go llmService.PullModel()
 	}
 
-	// Initialize encryption services (if Vault is enabled)
+	// Initialize encryption services (unless KEY_PROVIDER is none)
 	var encryptedResponseSvc *service.EncryptedResponseService
 	var reviewerService *service.ReviewerService
 	var consolidationService *service.ConsolidationService
 	var discussionService *service.DiscussionService
+	var payService *service.PayService
+	var changeRequestService *service.ChangeRequestService
+	var appealService *service.AppealService
+	var keyRotationService *service.KeyRotationService
+	var systemKeyService *service.SystemKeyService
+	var dataErasureService *service.DataErasureService
 	var secureStore *securestore.SecureStore
-	if cfg.Vault.Enabled {
-		slog.Info("Vault is enabled - initializing encryption services")
-		vaultClient, err := vault.NewClient(&vault.Config{
-			Address:      cfg.Vault.Address,
-			Token:        cfg.Vault.Token,
-			TransitMount: cfg.Vault.TransitMount,
-		})
+	keyProvider, err := newKeyProvider(cfg.Keys.Provider, cfg)
+	if err != nil {
+		slog.Error("Failed to initialize key provider", "provider", cfg.Keys.Provider, "error", err)
+		os.Exit(1)
+	}
+	if keyProvider != nil {
+		slog.Info("Initializing encryption services", "key_provider", keyProvider.Name())
+		keyManager, err := keymanager.NewKeyManager(db.DB, keyProvider)
 		if err != nil {
-			slog.Error("Failed to initialize Vault client", "error", err)
+			slog.Error("Failed to initialize KeyManager", "error", err)
 			os.Exit(1)
 		}
 
-		keyManager, err := keymanager.NewKeyManager(db.DB, vaultClient)
-		if err != nil {
-			slog.Error("Failed to initialize KeyManager", "error", err)
+		// Move keys wrapped by the previous provider before anything reads them
+		if cfg.Keys.MigrateFrom != "" {
+			source, err := newKeyProvider(cfg.Keys.MigrateFrom, cfg)
+			if err != nil {
+				slog.Error("Failed to initialize source key provider", "provider", cfg.Keys.MigrateFrom, "error", err)
+				os.Exit(1)
+			}
+			migrated, err := keyManager.MigrateFrom(source)
+			if err != nil {
+				slog.Error("Failed to migrate keys", "from", source.Name(), "to", keyProvider.Name(), "error", err)
+				os.Exit(1)
+			}
+			slog.Info("Migrated wrapped keys", "from", source.Name(), "to", keyProvider.Name(), "keys", migrated)
+		}
+		if err := keyManager.CheckProvider(); err != nil {
+			slog.Error("Key provider mismatch - set KEY_PROVIDER_MIGRATE_FROM to migrate the keys", "key_provider", keyProvider.Name(), "error", err)
 			os.Exit(1)
 		}
 
 		secureStore = securestore.NewSecureStore(db.DB, keyManager)
 		encryptedResponseSvc = service.NewEncryptedResponseService(db.DB, assessmentResponseRepo, keyManager, secureStore)
-		reviewerService = service.NewReviewerService(db.DB, reviewerResponseRepo, selfAssessmentRepo, assessmentResponseRepo, keyManager, secureStore)
-		consolidationService = service.NewConsolidationService(db.DB, consolidationOverrideRepo, consolidationOverrideApprovalRepo, consolidationAveragedApprovalRepo, finalConsolidationRepo, finalConsolidationApprovalRepo, selfAssessmentRepo, assessmentResponseRepo, reviewerResponseRepo, catalogRepo, categoryDiscussionCommentRepo, encryptedResponseSvc, keyManager, secureStore, emailService, llmService)
+		reviewerService = service.NewReviewerService(db.DB, reviewerResponseRepo, reviewerAssignmentRepo, selfAssessmentRepo, assessmentResponseRepo, keyManager, secureStore, workflowService)
+		consolidationService = service.NewConsolidationService(db.DB, consolidationOverrideRepo, consolidationOverrideApprovalRepo, consolidationAveragedApprovalRepo, finalConsolidationRepo, finalConsolidationApprovalRepo, selfAssessmentRepo, assessmentResponseRepo, reviewerResponseRepo, reviewerAssignmentRepo, catalogRepo, categoryDiscussionCommentRepo, encryptedResponseSvc, keyManager, secureStore, emailService, llmService, workflowService, cfg.Review.DisagreementThreshold, quorumService, consolidationEvents)
 		discussionService = service.NewDiscussionService(discussionRepo, selfAssessmentRepo, reviewerResponseRepo, assessmentResponseRepo, consolidationOverrideRepo, finalConsolidationRepo, catalogRepo, userRepo, categoryDiscussionCommentRepo, discussionConfirmationRepo, secureStore)
+		payService = service.NewPayService(payRepo, catalogRepo, selfAssessmentRepo, discussionRepo, keyManager, secureStore, auditService)
+		// Map the overall level of every archived assessment to its salary band, whichever path archives it
+		workflowService.RegisterEnterHook("archived", payService.OnAssessmentArchived)
+		changeRequestService = service.NewChangeRequestService(changeRequestRepo, selfAssessmentRepo, reviewerAssignmentRepo, userRepo, keyManager, secureStore, transactor, workflowService, auditService, emailService)
+		appealService = service.NewAppealService(appealRepo, selfAssessmentRepo, reviewerAssignmentRepo, reviewerResponseRepo, discussionRepo, discussionMeetingRepo, discussionConfirmationRepo, userRepo, discussionService, workflowService, keyManager, secureStore, transactor, auditService, emailService, cfg.Review.AppealWindowDays, cfg.Review.AppealPanelSize)
+		keyRotationService = service.NewKeyRotationService(keyRotationRepo, keyManager, secureStore, auditService)
+		systemKeyService = service.NewSystemKeyService(keyManager, auditService)
+		dataErasureService = service.NewDataErasureService(dataErasureRepo, legalHoldRepo, selfAssessmentRepo, userRepo, keyManager, secureStore, auditService)
+
+		// Continue key rotations interrupted by a restart
+		go keyRotationService.ResumeRotations()
 
-		slog.Info("Encryption services initialized", "vault_addr", cfg.Vault.Address)
+		slog.Info("Encryption services initialized", "key_provider", keyProvider.Name())
 	} else {
-		slog.Warn("Vault is disabled - encrypted responses will not work")
+		slog.Warn("Encryption is disabled (KEY_PROVIDER=none) - encrypted responses will not work")
 	}
 
-	selfAssessmentService := service.NewSelfAssessmentService(selfAssessmentRepo, catalogRepo, auditService, assessmentResponseRepo, encryptedResponseSvc, reviewerResponseRepo)
+	selfAssessmentService := service.NewSelfAssessmentService(selfAssessmentRepo, catalogRepo, auditService, assessmentResponseRepo, encryptedResponseSvc, reviewerResponseRepo, reviewerAssignmentRepo, workflowService, changeRequestRepo)
+	reviewAssignmentService := service.NewReviewAssignmentService(reviewerAssignmentRepo, selfAssessmentRepo, reviewerResponseRepo, userRepo, auditService, cfg.Review.MinPanelSize, cfg.Review.MaxPanelSize, cfg.Review.AutoAssign)
 
 	// Initialize scheduler
-	schedulerService := scheduler.NewScheduler(selfAssessmentRepo, userRepo, roleRepo, emailService, secureStore, db.DB, &cfg.Scheduler)
+	schedulerService := scheduler.NewScheduler(selfAssessmentRepo, userRepo, roleRepo, reviewerAssignmentRepo, catalogService, reviewAssignmentService, workflowService, auditService, emailService, secureStore, db.DB, &cfg.Scheduler)
 	schedulerService.Start()
 	defer schedulerService.Stop()
 
@@ -181,12 +238,22 @@ func main() {
 	auditHandler := handlers.NewAuditHandler(auditRepo)
 	sessionHandler := handlers.NewSessionHandler(sessionRepo, authSvc, auditMw, db.DB)
 	configHandler := handlers.NewConfigHandler(cfg)
-	catalogHandler := handlers.NewCatalogHandler(catalogService, auditMw)
-	selfAssessmentHandler := handlers.NewSelfAssessmentHandler(selfAssessmentService, discussionService, discussionConfirmationRepo, selfAssessmentRepo, consolidationService)
-	reviewerHandler := handlers.NewReviewerHandler(reviewerService, selfAssessmentRepo, discussionService)
-	consolidationHandler := handlers.NewConsolidationHandler(consolidationService)
+	catalogHandler := handlers.NewCatalogHandler(catalogService, catalogLocalizer, auditMw)
+	selfAssessmentHandler := handlers.NewSelfAssessmentHandler(selfAssessmentService, discussionService, discussionConfirmationRepo, selfAssessmentRepo, consolidationService, catalogLocalizer, reviewAssignmentService)
+	reviewerHandler := handlers.NewReviewerHandler(reviewerService, reviewAssignmentService, workflowService, selfAssessmentRepo, discussionService)
+	reviewAssignmentHandler := handlers.NewReviewAssignmentHandler(reviewAssignmentService)
+	consolidationHandler := handlers.NewConsolidationHandler(consolidationService, catalogLocalizer)
 	discussionHandler := handlers.NewDiscussionHandler(discussionService)
-	discussionConfirmationHandler := handlers.NewDiscussionConfirmationHandler(discussionConfirmationRepo, selfAssessmentRepo, userRepo)
+	discussionConfirmationHandler := handlers.NewDiscussionConfirmationHandler(discussionConfirmationRepo, selfAssessmentRepo, userRepo, meetingService)
+	meetingHandler := handlers.NewMeetingHandler(meetingService)
+	appealHandler := handlers.NewAppealHandler(appealService)
+	payHandler := handlers.NewPayHandler(payService)
+	workflowHandler := handlers.NewWorkflowHandler(workflowService)
+	quorumHandler := handlers.NewQuorumHandler(quorumService)
+	keyRotationHandler := handlers.NewKeyRotationHandler(keyRotationService)
+	systemKeyHandler := handlers.NewSystemKeyHandler(systemKeyService)
+	dataErasureHandler := handlers.NewDataErasureHandler(dataErasureService)
+	changeRequestHandler := handlers.NewChangeRequestHandler(changeRequestService)
 
 	// Setup router
 	mux := http.NewServeMux()
@@ -353,6 +420,43 @@ func main() {
 			),
 		),
 	)
+	mux.Handle("GET /api/v1/catalogs/diff",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "reviewer")(
+				http.HandlerFunc(catalogHandler.DiffCatalogs),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/catalogs/{id}/at",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "reviewer")(
+				http.HandlerFunc(catalogHandler.GetCatalogAt),
+			),
+		),
+	)
+
+	// Catalog review (admins and designated approvers)
+	mux.Handle("GET /api/v1/catalogs/{id}/review",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "catalog_approver")(
+				http.HandlerFunc(catalogHandler.GetReviewStatus),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/catalogs/{id}/review/approve",
+		authMw.Authenticate(
+			rbacMw.RequireRole("catalog_approver")(
+				http.HandlerFunc(catalogHandler.ApproveCatalog),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/catalogs/{id}/review/comments",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "catalog_approver")(
+				http.HandlerFunc(catalogHandler.AddReviewComment),
+			),
+		),
+	)
 
 	// Catalog routes - Admin only
 	// Admin can list all catalogs without filtering
@@ -366,7 +470,7 @@ func main() {
 	mux.Handle("GET /api/v1/admin/catalogs/{id}",
 		authMw.Authenticate(
 			rbacMw.RequireRole("admin")(
-				http.HandlerFunc(catalogHandler.GetCatalogByID),
+				http.HandlerFunc(catalogHandler.GetCatalogByIDAdmin),
 			),
 		),
 	)
@@ -398,6 +502,20 @@ func main() {
 			),
 		),
 	)
+	mux.Handle("POST /api/v1/admin/catalogs/{id}/submit-for-review",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.SubmitForReview),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/catalogs/{id}/return-to-draft",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.ReturnToDraft),
+			),
+		),
+	)
 	mux.Handle("POST /api/v1/admin/catalogs/{id}/transition-to-active",
 		authMw.Authenticate(
 			rbacMw.RequireRole("admin")(
@@ -489,6 +607,335 @@ func main() {
 			),
 		),
 	)
+	mux.Handle("POST /api/v1/admin/catalogs/{id}/changes/{changeId}/revert",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.RevertChange),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/catalogs/{id}/changes/revert",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.RevertChangesSince),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/catalogs/{id}/clone",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.CloneCatalog),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/catalogs/{id}/lineage",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.GetCatalogLineage),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/catalogs/{id}/export",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.ExportCatalog),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/catalogs/import",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.ImportCatalog),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/catalogs/{id}/matrix",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.ExportCatalogMatrix),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/catalogs/{id}/matrix",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.ImportCatalogMatrix),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/catalogs/{id}/translations",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.GetTranslations),
+			),
+		),
+	)
+	mux.Handle("PUT /api/v1/admin/catalogs/{id}/translations",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(catalogHandler.SetTranslations),
+			),
+		),
+	)
+
+	// Salary band routes - Admin only
+	mux.Handle("GET /api/v1/admin/catalogs/{id}/salary-bands",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(payHandler.GetSalaryBands),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/catalogs/{id}/salary-bands",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(payHandler.CreateSalaryBand),
+			),
+		),
+	)
+	mux.Handle("PUT /api/v1/admin/catalogs/{id}/salary-bands/{bandId}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(payHandler.UpdateSalaryBand),
+			),
+		),
+	)
+	mux.Handle("DELETE /api/v1/admin/catalogs/{id}/salary-bands/{bandId}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(payHandler.DeleteSalaryBand),
+			),
+		),
+	)
+
+	// Assessment workflow routes - Admin only
+	mux.Handle("GET /api/v1/admin/workflows",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.GetWorkflows),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/workflows",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.CreateWorkflow),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/workflows/builtin",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.GetBuiltinWorkflow),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/workflows/validate",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.ValidateWorkflow),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/workflows/{id}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.GetWorkflow),
+			),
+		),
+	)
+	mux.Handle("PUT /api/v1/admin/workflows/{id}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.UpdateWorkflow),
+			),
+		),
+	)
+	mux.Handle("DELETE /api/v1/admin/workflows/{id}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.DeleteWorkflow),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/catalogs/{id}/workflow",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.GetCatalogWorkflow),
+			),
+		),
+	)
+	mux.Handle("PUT /api/v1/admin/catalogs/{id}/workflow",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(workflowHandler.SetCatalogWorkflow),
+			),
+		),
+	)
+
+	// Consolidation quorum policy routes - Admin only
+	mux.Handle("GET /api/v1/admin/quorum",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(quorumHandler.GetDefaultQuorumPolicy),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/catalogs/{id}/quorum",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(quorumHandler.GetCatalogQuorumPolicy),
+			),
+		),
+	)
+	mux.Handle("PUT /api/v1/admin/catalogs/{id}/quorum",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(quorumHandler.SetCatalogQuorumPolicy),
+			),
+		),
+	)
+	mux.Handle("DELETE /api/v1/admin/catalogs/{id}/quorum",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(quorumHandler.DeleteCatalogQuorumPolicy),
+			),
+		),
+	)
+
+	// Process key rotation routes - Admin only
+	mux.Handle("GET /api/v1/admin/key-rotations",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(keyRotationHandler.ListKeyRotations),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/key-rotations",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(keyRotationHandler.StartKeyRotation),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/key-rotations/{id}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(keyRotationHandler.GetKeyRotation),
+			),
+		),
+	)
+
+	// System master key rotation routes - Admin only
+	mux.Handle("GET /api/v1/admin/system-key",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(systemKeyHandler.GetSystemKeyStatus),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/system-key/rotate",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(systemKeyHandler.RotateSystemKey),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/admin/system-key/rewrap",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(systemKeyHandler.RewrapSystemKeys),
+			),
+		),
+	)
+	mux.Handle("PUT /api/v1/admin/system-key/min-decryption-version",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(systemKeyHandler.SetMinDecryptionVersion),
+			),
+		),
+	)
+
+	// Data erasure (crypto-shredding) and legal hold routes - Admin only
+	mux.Handle("POST /api/v1/admin/erasures",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(dataErasureHandler.EraseData),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/erasures",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(dataErasureHandler.ListErasures),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/erasures/verify",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(dataErasureHandler.VerifyErasures),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/erasures/{id}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(dataErasureHandler.GetErasure),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/admin/legal-holds",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(dataErasureHandler.ListLegalHolds),
+			),
+		),
+	)
+	mux.Handle("PUT /api/v1/admin/legal-holds/{user_id}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(dataErasureHandler.SetLegalHold),
+			),
+		),
+	)
+	mux.Handle("DELETE /api/v1/admin/legal-holds/{user_id}",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(dataErasureHandler.ReleaseLegalHold),
+			),
+		),
+	)
+
+	// Salary and pay recommendation routes - HR only
+	mux.Handle("GET /api/v1/hr/users/{id}/salaries",
+		authMw.Authenticate(
+			rbacMw.RequireRole("hr")(
+				http.HandlerFunc(payHandler.GetSalaryHistory),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/hr/users/{id}/salaries",
+		authMw.Authenticate(
+			rbacMw.RequireRole("hr")(
+				http.HandlerFunc(payHandler.SetEmployeeSalary),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/hr/pay-recommendations",
+		authMw.Authenticate(
+			rbacMw.RequireRole("hr")(
+				http.HandlerFunc(payHandler.GetRecommendations),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/hr/pay-recommendations/export",
+		authMw.Authenticate(
+			rbacMw.RequireRole("hr")(
+				http.HandlerFunc(payHandler.ExportRecommendations),
+			),
+		),
+	)
 
 	// Self-Assessment routes - Require user role only
 	// Get active catalogs (available only to users with user role)
@@ -567,6 +1014,15 @@ func main() {
 		),
 	)
 
+	// Change requests of reviewers (owner and reviewers of the panel)
+	mux.Handle("GET /api/v1/self-assessments/{id}/change-requests",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("reviewer", "user")(
+				http.HandlerFunc(changeRequestHandler.GetChangeRequests),
+			),
+		),
+	)
+
 	// Generic self-assessment routes
 	// Get specific self-assessment
 	mux.Handle("GET /api/v1/self-assessments/{id}",
@@ -593,6 +1049,75 @@ func main() {
 		),
 	)
 
+	// Reviewer panel of a self-assessment (admins and team leads)
+	mux.Handle("GET /api/v1/self-assessments/{id}/reviewers",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "team_lead")(
+				http.HandlerFunc(reviewAssignmentHandler.GetPanel),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/self-assessments/{id}/reviewers/candidates",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "team_lead")(
+				http.HandlerFunc(reviewAssignmentHandler.GetCandidates),
+			),
+		),
+	)
+	mux.Handle("PUT /api/v1/self-assessments/{id}/reviewers",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "team_lead")(
+				http.HandlerFunc(reviewAssignmentHandler.SetPanel),
+			),
+		),
+	)
+	mux.Handle("GET /api/v1/self-assessments/{id}/reviewers/proposal",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "team_lead")(
+				http.HandlerFunc(reviewAssignmentHandler.GetProposal),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/self-assessments/{id}/reviewers/auto-assign",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "team_lead")(
+				http.HandlerFunc(reviewAssignmentHandler.AutoAssign),
+			),
+		),
+	)
+
+	// Open self-assessments whose panel is below the minimum size (e.g. panels backfilled by migration 029)
+	mux.Handle("GET /api/v1/review/panels/incomplete",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "team_lead")(
+				http.HandlerFunc(reviewAssignmentHandler.GetIncompletePanels),
+			),
+		),
+	)
+
+	// Conflicts of interest between reviewers and employees (reviewers declare their own, admins manage all)
+	mux.Handle("GET /api/v1/review/conflicts",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "reviewer")(
+				http.HandlerFunc(reviewAssignmentHandler.GetConflicts),
+			),
+		),
+	)
+	mux.Handle("POST /api/v1/review/conflicts",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "reviewer")(
+				http.HandlerFunc(reviewAssignmentHandler.DeclareConflict),
+			),
+		),
+	)
+	mux.Handle("DELETE /api/v1/review/conflicts/{id}",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("admin", "reviewer")(
+				http.HandlerFunc(reviewAssignmentHandler.DeleteConflict),
+			),
+		),
+	)
+
 	// Admin routes for self-assessments
 	mux.Handle("GET /api/v1/admin/self-assessments",
 		authMw.Authenticate(
@@ -654,6 +1179,13 @@ func main() {
 			),
 		),
 	)
+	mux.Handle("POST /api/v1/review/assessment/{id}/request-changes",
+		authMw.Authenticate(
+			rbacMw.RequireRole("reviewer")(
+				http.HandlerFunc(changeRequestHandler.RequestChanges),
+			),
+		),
+	)
 	mux.Handle("GET /api/v1/review/assessment/{id}/completion-status",
 		authMw.Authenticate(
 			rbacMw.RequireRole("reviewer")(
@@ -670,6 +1202,13 @@ func main() {
 			),
 		),
 	)
+	mux.Handle("GET /api/v1/review/consolidation/{id}/events",
+		authMw.Authenticate(
+			rbacMw.RequireRole("reviewer")(
+				http.HandlerFunc(consolidationHandler.StreamConsolidationEvents),
+			),
+		),
+	)
 	mux.Handle("POST /api/v1/review/consolidation/{id}/override",
 		authMw.Authenticate(
 			rbacMw.RequireRole("reviewer")(
@@ -772,6 +1311,72 @@ func main() {
 		),
 	)
 
+	// Discussion meeting endpoints
+	mux.Handle("GET /api/v1/discussion/{id}/meeting",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("user", "reviewer")(
+				http.HandlerFunc(meetingHandler.GetMeeting),
+			),
+		),
+	)
+
+	mux.Handle("POST /api/v1/discussion/{id}/meeting",
+		authMw.Authenticate(
+			rbacMw.RequireRole("reviewer")(
+				http.HandlerFunc(meetingHandler.ScheduleMeeting),
+			),
+		),
+	)
+
+	mux.Handle("PUT /api/v1/discussion/{id}/meeting",
+		authMw.Authenticate(
+			rbacMw.RequireRole("reviewer")(
+				http.HandlerFunc(meetingHandler.UpdateMeeting),
+			),
+		),
+	)
+
+	mux.Handle("DELETE /api/v1/discussion/{id}/meeting",
+		authMw.Authenticate(
+			rbacMw.RequireRole("reviewer")(
+				http.HandlerFunc(meetingHandler.CancelMeeting),
+			),
+		),
+	)
+
+	// Appeal endpoints
+	mux.Handle("GET /api/v1/discussion/{id}/appeal",
+		authMw.Authenticate(
+			rbacMw.RequireAnyRole("user", "reviewer", "admin")(
+				http.HandlerFunc(appealHandler.GetAppeal),
+			),
+		),
+	)
+
+	mux.Handle("POST /api/v1/discussion/{id}/appeal",
+		authMw.Authenticate(
+			rbacMw.RequireRole("user")(
+				http.HandlerFunc(appealHandler.FileAppeal),
+			),
+		),
+	)
+
+	mux.Handle("PUT /api/v1/discussion/{id}/appeal/panel",
+		authMw.Authenticate(
+			rbacMw.RequireRole("admin")(
+				http.HandlerFunc(appealHandler.SetAppealPanel),
+			),
+		),
+	)
+
+	mux.Handle("POST /api/v1/discussion/{id}/appeal/decision",
+		authMw.Authenticate(
+			rbacMw.RequireRole("reviewer")(
+				http.HandlerFunc(appealHandler.DecideAppeal),
+			),
+		),
+	)
+
 	// Discussion confirmation endpoints
 	mux.Handle("POST /api/v1/discussion/{id}/confirm",
 		authMw.Authenticate(
@@ -829,6 +1434,8 @@ func main() {
 		WriteTimeout: cfg.Server.TimeoutWrite,
 		IdleTimeout:  cfg.Server.TimeoutIdle,
 	}
+	// End open consolidation event streams so shutdown does not wait for them
+	server.RegisterOnShutdown(consolidationEvents.Close)
 
 	// Start server in a goroutine
 	go func() {
@@ -857,3 +1464,24 @@ func main() {
 
 	slog.Info("Server stopped")
 }
+
+// newKeyProvider creates the key provider that wraps user and process keys (nil for "none")
+func newKeyProvider(name string, cfg *config.Config) (keymanager.KeyProvider, error) {
+	switch name {
+	case "vault":
+		vaultClient, err := vault.NewClient(&vault.Config{
+			Address:      cfg.Vault.Address,
+			Token:        cfg.Vault.Token,
+			TransitMount: cfg.Vault.TransitMount,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Vault client: %w", err)
+		}
+		return keymanager.NewVaultProvider(vaultClient), nil
+	case "local":
+		return keymanager.NewLocalProvider(cfg.Keys.KeyringPath, cfg.Keys.KeyringPassphrase)
+	case "none":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown key provider %q", name)
+}
@@ -0,0 +1,3 @@
+-- Remove catalog lineage tracking
+DROP INDEX IF EXISTS idx_criteria_catalogs_source;
+ALTER TABLE criteria_catalogs DROP COLUMN IF EXISTS source_catalog_id;
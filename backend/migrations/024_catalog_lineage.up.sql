@@ -0,0 +1,6 @@
+-- Track the catalog a catalog was cloned from (lineage across validity periods)
+ALTER TABLE criteria_catalogs ADD COLUMN source_catalog_id INTEGER REFERENCES criteria_catalogs(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_criteria_catalogs_source ON criteria_catalogs(source_catalog_id);
+
+COMMENT ON COLUMN criteria_catalogs.source_catalog_id IS 'Catalog this catalog was cloned from (NULL if created from scratch or imported)';
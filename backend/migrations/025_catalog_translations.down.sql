@@ -0,0 +1,3 @@
+-- Remove catalog translations
+DROP TRIGGER IF EXISTS update_catalog_translations_updated_at ON catalog_translations;
+DROP TABLE IF EXISTS catalog_translations;
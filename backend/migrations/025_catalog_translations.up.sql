@@ -0,0 +1,22 @@
+-- Per-locale translations of catalog texts
+-- The name/description columns of the catalog tables hold the texts of the default locale
+CREATE TABLE catalog_translations (
+    id SERIAL PRIMARY KEY,
+    catalog_id INTEGER NOT NULL REFERENCES criteria_catalogs(id) ON DELETE CASCADE,
+    entity_type VARCHAR(50) NOT NULL CHECK (entity_type IN ('category', 'level', 'path', 'description')),
+    entity_id INTEGER NOT NULL,
+    locale VARCHAR(10) NOT NULL,
+    field_name VARCHAR(100) NOT NULL CHECK (field_name IN ('name', 'description')),
+    value TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(entity_type, entity_id, locale, field_name)
+);
+
+CREATE INDEX idx_catalog_translations_catalog ON catalog_translations(catalog_id, locale);
+
+CREATE TRIGGER update_catalog_translations_updated_at BEFORE UPDATE ON catalog_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE catalog_translations IS 'Translations of category, level, path and matrix cell texts for non-default locales';
@@ -0,0 +1,11 @@
+-- Remove catalog review phase
+DELETE FROM roles WHERE name = 'catalog_approver';
+
+DROP TABLE IF EXISTS catalog_review_comments;
+DROP TABLE IF EXISTS catalog_approvals;
+
+-- Catalogs under review go back to draft
+UPDATE criteria_catalogs SET phase = 'draft' WHERE phase = 'review';
+
+ALTER TABLE criteria_catalogs DROP CONSTRAINT criteria_catalogs_phase_check;
+ALTER TABLE criteria_catalogs ADD CONSTRAINT criteria_catalogs_phase_check CHECK (phase IN ('draft', 'active', 'archived'));
@@ -0,0 +1,34 @@
+-- Formal review phase between draft and active
+ALTER TABLE criteria_catalogs DROP CONSTRAINT criteria_catalogs_phase_check;
+ALTER TABLE criteria_catalogs ADD CONSTRAINT criteria_catalogs_phase_check CHECK (phase IN ('draft', 'review', 'active', 'archived'));
+
+-- Approvals of a catalog under review (reset when the catalog returns to draft)
+CREATE TABLE catalog_approvals (
+    id SERIAL PRIMARY KEY,
+    catalog_id INTEGER NOT NULL REFERENCES criteria_catalogs(id) ON DELETE CASCADE,
+    approved_by_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approved_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(catalog_id, approved_by_user_id)
+);
+
+CREATE INDEX idx_catalog_approvals_catalog ON catalog_approvals(catalog_id);
+
+-- Review comments of approvers and admins
+CREATE TABLE catalog_review_comments (
+    id SERIAL PRIMARY KEY,
+    catalog_id INTEGER NOT NULL REFERENCES criteria_catalogs(id) ON DELETE CASCADE,
+    created_by_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    comment TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_catalog_review_comments_catalog ON catalog_review_comments(catalog_id);
+
+-- Designated approvers (e.g. works council members)
+INSERT INTO roles (name, description) VALUES
+    ('catalog_approver', 'Approver who reviews and signs off catalogs before activation')
+ON CONFLICT (name) DO NOTHING;
+
+COMMENT ON TABLE catalog_approvals IS 'Approvals of catalogs in review phase; activation requires the configured number of approvals';
+COMMENT ON TABLE catalog_review_comments IS 'Comments on catalogs made during review';
@@ -0,0 +1,6 @@
+-- Remove pay band subsystem (encrypted records stay in the append-only encrypted_records table)
+DELETE FROM roles WHERE name = 'hr';
+
+DROP TABLE IF EXISTS pay_recommendations;
+DROP TABLE IF EXISTS employee_salaries;
+DROP TABLE IF EXISTS salary_bands;
@@ -0,0 +1,65 @@
+-- Salary bands per catalog level
+CREATE TABLE salary_bands (
+    id SERIAL PRIMARY KEY,
+    catalog_id INTEGER NOT NULL REFERENCES criteria_catalogs(id) ON DELETE CASCADE,
+    level_id INTEGER NOT NULL REFERENCES levels(id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL,
+    min_salary NUMERIC(12, 2) NOT NULL CHECK (min_salary >= 0),
+    max_salary NUMERIC(12, 2) NOT NULL,
+    effective_from DATE NOT NULL,
+    effective_until DATE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (max_salary >= min_salary),
+    CHECK (effective_until IS NULL OR effective_until >= effective_from)
+);
+
+CREATE INDEX idx_salary_bands_catalog ON salary_bands(catalog_id);
+CREATE INDEX idx_salary_bands_level ON salary_bands(level_id, effective_from);
+
+CREATE TRIGGER update_salary_bands_updated_at BEFORE UPDATE ON salary_bands
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Current salaries of employees (amount and currency are stored encrypted in encrypted_records)
+CREATE TABLE employee_salaries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    encrypted_salary_id BIGINT NOT NULL REFERENCES encrypted_records(id),
+    effective_from DATE NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_employee_salaries_user ON employee_salaries(user_id, effective_from);
+
+-- Pay recommendations produced when a discussion result is archived
+-- (current and target salary are stored encrypted in encrypted_records)
+CREATE TABLE pay_recommendations (
+    id SERIAL PRIMARY KEY,
+    assessment_id INTEGER NOT NULL UNIQUE REFERENCES self_assessments(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    level_id INTEGER NOT NULL REFERENCES levels(id) ON DELETE CASCADE,
+    salary_band_id INTEGER REFERENCES salary_bands(id) ON DELETE SET NULL,
+    encrypted_recommendation_id BIGINT NOT NULL REFERENCES encrypted_records(id),
+    band_position VARCHAR(20) NOT NULL CHECK (band_position IN ('below', 'within', 'above', 'unknown')),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'exported')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    exported_at TIMESTAMP
+);
+
+CREATE INDEX idx_pay_recommendations_status ON pay_recommendations(status);
+
+CREATE TRIGGER update_pay_recommendations_updated_at BEFORE UPDATE ON pay_recommendations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- HR staff maintain salaries and export pay recommendations
+INSERT INTO roles (name, description) VALUES
+    ('hr', 'HR staff who maintain employee salaries and export pay recommendations')
+ON CONFLICT (name) DO NOTHING;
+
+COMMENT ON TABLE salary_bands IS 'Salary range per catalog level, valid for a period and currency';
+COMMENT ON TABLE employee_salaries IS 'Salary history of employees; amounts are encrypted via securestore';
+COMMENT ON TABLE pay_recommendations IS 'Recommendation from current salary to the band of the archived overall level, exported by HR';
@@ -0,0 +1,4 @@
+-- Remove scoring strategy and path weights
+ALTER TABLE paths DROP COLUMN IF EXISTS weight;
+ALTER TABLE criteria_catalogs DROP COLUMN IF EXISTS level_rounding;
+ALTER TABLE criteria_catalogs DROP COLUMN IF EXISTS scoring_strategy;
@@ -0,0 +1,12 @@
+-- Scoring strategy per catalog: how reviewer ratings are aggregated and how scores map to levels
+ALTER TABLE criteria_catalogs ADD COLUMN scoring_strategy VARCHAR(20) NOT NULL DEFAULT 'mean'
+    CHECK (scoring_strategy IN ('mean', 'median', 'trimmed_mean', 'mode'));
+ALTER TABLE criteria_catalogs ADD COLUMN level_rounding VARCHAR(20) NOT NULL DEFAULT 'nearest'
+    CHECK (level_rounding IN ('nearest', 'down'));
+
+-- Optional weight of a path, multiplied with the category weight when the path is selected
+ALTER TABLE paths ADD COLUMN weight DECIMAL(6,4) CHECK (weight > 0);
+
+COMMENT ON COLUMN criteria_catalogs.scoring_strategy IS 'Aggregation of reviewer level numbers per category: mean, median, trimmed_mean or mode';
+COMMENT ON COLUMN criteria_catalogs.level_rounding IS 'Mapping of scores to levels: nearest level or round down';
+COMMENT ON COLUMN paths.weight IS 'Optional multiplier of the category weight (defaults to 1)';
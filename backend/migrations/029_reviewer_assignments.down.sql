@@ -0,0 +1,4 @@
+-- Remove reviewer panels
+DELETE FROM roles WHERE name = 'team_lead';
+
+DROP TABLE IF EXISTS assessment_reviewer_assignments;
@@ -0,0 +1,27 @@
+-- Reviewer panels assigned to self-assessments
+CREATE TABLE assessment_reviewer_assignments (
+    id SERIAL PRIMARY KEY,
+    assessment_id INTEGER NOT NULL REFERENCES self_assessments(id) ON DELETE CASCADE,
+    reviewer_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(assessment_id, reviewer_user_id)
+);
+
+CREATE INDEX idx_reviewer_assignments_assessment ON assessment_reviewer_assignments(assessment_id);
+CREATE INDEX idx_reviewer_assignments_reviewer ON assessment_reviewer_assignments(reviewer_user_id);
+
+-- Reviewers who already started a review keep access to it
+INSERT INTO assessment_reviewer_assignments (assessment_id, reviewer_user_id, assigned_at)
+SELECT assessment_id, reviewer_user_id, MIN(created_at)
+FROM reviewer_responses
+GROUP BY assessment_id, reviewer_user_id
+ON CONFLICT (assessment_id, reviewer_user_id) DO NOTHING;
+
+-- Team leads may assign reviewer panels
+INSERT INTO roles (name, description) VALUES
+    ('team_lead', 'Team lead who assigns reviewer panels to self-assessments')
+ON CONFLICT (name) DO NOTHING;
+
+COMMENT ON TABLE assessment_reviewer_assignments IS 'Reviewer panel of a self-assessment; only assigned reviewers may review it and the panel size is the number of required final approvals';
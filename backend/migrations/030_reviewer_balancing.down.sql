@@ -0,0 +1,5 @@
+-- Remove reviewer balancing data
+DROP TABLE IF EXISTS reviewer_conflicts;
+
+DROP INDEX IF EXISTS idx_users_team;
+ALTER TABLE users DROP COLUMN IF EXISTS team;
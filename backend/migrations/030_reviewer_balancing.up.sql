@@ -0,0 +1,22 @@
+-- Team of a user; reviewers are not assigned to assessments of their own team
+ALTER TABLE users ADD COLUMN team VARCHAR(100);
+
+CREATE INDEX idx_users_team ON users(team);
+
+-- Declared conflicts of interest between a reviewer and an employee
+CREATE TABLE reviewer_conflicts (
+    id SERIAL PRIMARY KEY,
+    reviewer_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(reviewer_user_id, user_id),
+    CHECK (reviewer_user_id <> user_id)
+);
+
+CREATE INDEX idx_reviewer_conflicts_user ON reviewer_conflicts(user_id);
+
+COMMENT ON COLUMN users.team IS 'Team of the user, used to exclude reviewers of the same team from automatic panel assignment';
+COMMENT ON TABLE reviewer_conflicts IS 'Declared conflicts of interest; the reviewer is never assigned to self-assessments of the user';
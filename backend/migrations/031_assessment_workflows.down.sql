@@ -0,0 +1,16 @@
+-- Remove data-driven assessment workflows
+-- Note: fails if self-assessments are in custom workflow states
+
+ALTER TABLE self_assessments
+DROP CONSTRAINT IF EXISTS self_assessments_status_check;
+
+ALTER TABLE self_assessments
+ADD CONSTRAINT self_assessments_status_check
+CHECK (status IN ('draft', 'submitted', 'in_review', 'review_consolidation', 'reviewed', 'discussion', 'archived', 'closed'));
+
+ALTER TABLE criteria_catalogs DROP COLUMN IF EXISTS workflow_id;
+
+DROP TABLE IF EXISTS workflow_transition_guards;
+DROP TABLE IF EXISTS workflow_transitions;
+DROP TABLE IF EXISTS workflow_states;
+DROP TABLE IF EXISTS workflow_definitions;
@@ -0,0 +1,64 @@
+-- Data-driven assessment workflows (states, transitions, guards and on-enter actions)
+CREATE TABLE workflow_definitions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one stored workflow replaces the built-in default
+CREATE UNIQUE INDEX idx_workflow_definitions_default ON workflow_definitions(is_default) WHERE is_default;
+
+CREATE TABLE workflow_states (
+    id SERIAL PRIMARY KEY,
+    workflow_id INTEGER NOT NULL REFERENCES workflow_definitions(id) ON DELETE CASCADE,
+    name VARCHAR(20) NOT NULL,
+    is_final BOOLEAN NOT NULL DEFAULT FALSE,
+    on_enter TEXT[] NOT NULL DEFAULT '{}',
+    sort_order INTEGER NOT NULL DEFAULT 0,
+
+    UNIQUE(workflow_id, name)
+);
+
+CREATE TABLE workflow_transitions (
+    id SERIAL PRIMARY KEY,
+    workflow_id INTEGER NOT NULL REFERENCES workflow_definitions(id) ON DELETE CASCADE,
+    from_state VARCHAR(20) NOT NULL,
+    to_state VARCHAR(20) NOT NULL,
+    roles TEXT[] NOT NULL DEFAULT '{}',
+    exclude_owner BOOLEAN NOT NULL DEFAULT FALSE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+
+    UNIQUE(workflow_id, from_state, to_state)
+);
+
+CREATE TABLE workflow_transition_guards (
+    id SERIAL PRIMARY KEY,
+    transition_id INTEGER NOT NULL REFERENCES workflow_transitions(id) ON DELETE CASCADE,
+    guard_type VARCHAR(50) NOT NULL,
+    value INTEGER
+);
+
+CREATE INDEX idx_workflow_states_workflow ON workflow_states(workflow_id);
+CREATE INDEX idx_workflow_transitions_workflow ON workflow_transitions(workflow_id);
+CREATE INDEX idx_workflow_transition_guards_transition ON workflow_transition_guards(transition_id);
+
+-- Catalogs may use their own workflow (NULL = default workflow)
+ALTER TABLE criteria_catalogs ADD COLUMN workflow_id INTEGER REFERENCES workflow_definitions(id) ON DELETE RESTRICT;
+
+-- Status values are defined by the workflow, only the format is checked
+ALTER TABLE self_assessments
+DROP CONSTRAINT IF EXISTS self_assessments_status_check;
+
+ALTER TABLE self_assessments
+ADD CONSTRAINT self_assessments_status_check
+CHECK (status ~ '^[a-z][a-z0-9_]*$');
+
+COMMENT ON TABLE workflow_definitions IS 'Assessment workflows; catalogs without workflow use the default workflow (or the built-in one)';
+COMMENT ON COLUMN workflow_states.on_enter IS 'Actions executed when a self-assessment enters the state, e.g. notify_owner';
+COMMENT ON COLUMN workflow_transitions.roles IS 'Roles allowed to perform the transition; owner stands for the owner of the self-assessment';
+COMMENT ON COLUMN workflow_transitions.exclude_owner IS 'The owner of the self-assessment may not perform the transition even with a matching role';
+COMMENT ON COLUMN criteria_catalogs.workflow_id IS 'Workflow of self-assessments of this catalog (NULL = default workflow)';
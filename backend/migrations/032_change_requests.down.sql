@@ -0,0 +1,4 @@
+-- Remove reviewer change requests
+-- Note: self-assessments in status changes_requested must be moved to another status first
+
+DROP TABLE IF EXISTS assessment_change_requests;
@@ -0,0 +1,16 @@
+-- Change requests of reviewers: the self-assessment goes back to the owner for rework
+CREATE TABLE assessment_change_requests (
+    id SERIAL PRIMARY KEY,
+    assessment_id INTEGER NOT NULL REFERENCES self_assessments(id) ON DELETE CASCADE,
+    requested_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    from_status VARCHAR(20) NOT NULL,
+    encrypted_message_id BIGINT REFERENCES encrypted_records(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP
+);
+
+CREATE INDEX idx_assessment_change_requests_assessment ON assessment_change_requests(assessment_id);
+
+COMMENT ON TABLE assessment_change_requests IS 'Changes requested by reviewers; the message is stored encrypted in encrypted_records';
+COMMENT ON COLUMN assessment_change_requests.from_status IS 'Status of the self-assessment when the changes were requested';
+COMMENT ON COLUMN assessment_change_requests.resolved_at IS 'Set when the owner resubmits the self-assessment';
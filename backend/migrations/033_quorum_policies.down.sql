@@ -0,0 +1,3 @@
+-- Remove catalog-specific quorum rules
+
+DROP TABLE IF EXISTS catalog_quorum_policies;
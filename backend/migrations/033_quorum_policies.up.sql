@@ -0,0 +1,21 @@
+-- Catalog-specific quorum rules for consolidation approvals
+-- Catalogs without a row use the global defaults (REVIEW_QUORUM_* settings)
+CREATE TABLE catalog_quorum_policies (
+    catalog_id INTEGER PRIMARY KEY REFERENCES criteria_catalogs(id) ON DELETE CASCADE,
+    averaged_count INTEGER NOT NULL DEFAULT 0 CHECK (averaged_count >= 0),
+    averaged_percent INTEGER NOT NULL DEFAULT 0 CHECK (averaged_percent BETWEEN 0 AND 100),
+    override_count INTEGER NOT NULL DEFAULT 0 CHECK (override_count >= 0),
+    override_percent INTEGER NOT NULL DEFAULT 0 CHECK (override_percent BETWEEN 0 AND 100),
+    final_count INTEGER NOT NULL DEFAULT 0 CHECK (final_count >= 0),
+    final_percent INTEGER NOT NULL DEFAULT 0 CHECK (final_percent BETWEEN 0 AND 100),
+    allow_self_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (averaged_count > 0 OR averaged_percent > 0),
+    CHECK (override_count > 0 OR override_percent > 0),
+    CHECK (final_count > 0 OR final_percent > 0)
+);
+
+COMMENT ON TABLE catalog_quorum_policies IS 'Required approvals for averaged results, overrides and the final consolidation; the higher of count and percentage of the reviewer panel applies';
+COMMENT ON COLUMN catalog_quorum_policies.allow_self_approval IS 'Whether the creator of an override may approve it';
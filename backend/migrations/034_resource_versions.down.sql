@@ -0,0 +1,6 @@
+-- Remove version numbers for optimistic concurrency control
+
+ALTER TABLE final_consolidations DROP COLUMN IF EXISTS version;
+ALTER TABLE consolidation_overrides DROP COLUMN IF EXISTS version;
+ALTER TABLE reviewer_responses DROP COLUMN IF EXISTS version;
+ALTER TABLE assessment_responses DROP COLUMN IF EXISTS version;
@@ -0,0 +1,11 @@
+-- Version numbers for optimistic concurrency control (If-Match / ETag)
+-- Every update increments the version; writes with an outdated version are rejected with 409 Conflict
+ALTER TABLE assessment_responses ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE reviewer_responses ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE consolidation_overrides ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE final_consolidations ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN assessment_responses.version IS 'Incremented on every update (optimistic concurrency)';
+COMMENT ON COLUMN reviewer_responses.version IS 'Incremented on every update (optimistic concurrency)';
+COMMENT ON COLUMN consolidation_overrides.version IS 'Incremented on every update (optimistic concurrency)';
+COMMENT ON COLUMN final_consolidations.version IS 'Incremented on every update (optimistic concurrency)';
@@ -0,0 +1,5 @@
+-- Remove discussion meetings
+
+ALTER TABLE discussion_confirmations DROP COLUMN IF EXISTS meeting_id;
+
+DROP TABLE IF EXISTS discussion_meetings;
@@ -0,0 +1,27 @@
+-- Discussion meetings: appointment for the discussion of a self-assessment, sent as iCalendar invitation
+CREATE TABLE discussion_meetings (
+    id SERIAL PRIMARY KEY,
+    assessment_id INTEGER NOT NULL REFERENCES self_assessments(id) ON DELETE CASCADE,
+    uid VARCHAR(255) NOT NULL UNIQUE,
+    sequence INTEGER NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    location VARCHAR(500) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    organizer_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    CONSTRAINT chk_discussion_meetings_status CHECK (status IN ('scheduled', 'cancelled')),
+    CONSTRAINT chk_discussion_meetings_time CHECK (ends_at > starts_at)
+);
+
+-- At most one scheduled meeting per self-assessment; cancelled meetings are kept
+CREATE UNIQUE INDEX idx_discussion_meetings_scheduled ON discussion_meetings(assessment_id) WHERE status = 'scheduled';
+
+-- Confirmations refer to the meeting that took place
+ALTER TABLE discussion_confirmations ADD COLUMN meeting_id INTEGER REFERENCES discussion_meetings(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE discussion_meetings IS 'Discussion meetings proposed by reviewers; invitations are sent as RFC 5545 iCalendar';
+COMMENT ON COLUMN discussion_meetings.uid IS 'iCalendar UID, stable across updates and cancellation';
+COMMENT ON COLUMN discussion_meetings.sequence IS 'iCalendar SEQUENCE, incremented on every update and on cancellation';
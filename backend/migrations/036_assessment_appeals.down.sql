@@ -0,0 +1,12 @@
+-- Remove appeals
+-- Note: superseded discussion results must be removed and self-assessments in status appeal
+-- must be moved to another status first
+
+DROP INDEX IF EXISTS idx_discussion_results_active;
+
+DROP TABLE IF EXISTS appeal_panel_members;
+DROP TABLE IF EXISTS assessment_appeals;
+
+ALTER TABLE discussion_results DROP COLUMN IF EXISTS superseded_by_id;
+ALTER TABLE discussion_results DROP COLUMN IF EXISTS superseded_at;
+ALTER TABLE discussion_results ADD CONSTRAINT discussion_results_assessment_id_key UNIQUE (assessment_id);
@@ -0,0 +1,40 @@
+-- Appeals: the owner objects to the discussion result; an appeal panel upholds or changes it
+CREATE TABLE assessment_appeals (
+    id SERIAL PRIMARY KEY,
+    assessment_id INTEGER NOT NULL UNIQUE REFERENCES self_assessments(id) ON DELETE CASCADE,
+    filed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    encrypted_reason_id BIGINT REFERENCES encrypted_records(id) ON DELETE SET NULL,
+    original_result_id INTEGER NOT NULL REFERENCES discussion_results(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    decided_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    encrypted_decision_id BIGINT REFERENCES encrypted_records(id) ON DELETE SET NULL,
+    result_id INTEGER REFERENCES discussion_results(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_assessment_appeals_status CHECK (status IN ('open', 'upheld', 'changed'))
+);
+
+-- Reviewers deciding an appeal (not the reviewers of the original panel)
+CREATE TABLE appeal_panel_members (
+    appeal_id INTEGER NOT NULL REFERENCES assessment_appeals(id) ON DELETE CASCADE,
+    reviewer_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (appeal_id, reviewer_user_id)
+);
+
+CREATE INDEX idx_appeal_panel_members_reviewer ON appeal_panel_members(reviewer_user_id);
+
+-- A decided appeal supersedes the discussion result; superseded results are kept
+ALTER TABLE discussion_results DROP CONSTRAINT IF EXISTS discussion_results_assessment_id_key;
+ALTER TABLE discussion_results ADD COLUMN superseded_at TIMESTAMP;
+ALTER TABLE discussion_results ADD COLUMN superseded_by_id INTEGER REFERENCES discussion_results(id) ON DELETE SET NULL;
+
+-- At most one active discussion result per self-assessment
+CREATE UNIQUE INDEX idx_discussion_results_active ON discussion_results(assessment_id) WHERE superseded_at IS NULL;
+
+COMMENT ON TABLE assessment_appeals IS 'Appeals of owners against the discussion result; reason and decision are stored encrypted in encrypted_records';
+COMMENT ON COLUMN assessment_appeals.original_result_id IS 'Discussion result the appeal was filed against';
+COMMENT ON COLUMN assessment_appeals.result_id IS 'Discussion result created by the decision (a copy if the result was upheld)';
+COMMENT ON COLUMN appeal_panel_members.assigned_by IS 'NULL for automatic assignment by the system';
+COMMENT ON COLUMN discussion_results.superseded_by_id IS 'Discussion result that replaced this one after an appeal decision';
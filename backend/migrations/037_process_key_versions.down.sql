@@ -0,0 +1,19 @@
+-- Remove process key versions and rotations
+-- Note: records re-encrypted under a newer key version become unreadable, only version 1 is restored
+
+DROP TABLE IF EXISTS encrypted_record_reencryptions;
+DROP TABLE IF EXISTS process_key_rotations;
+
+ALTER TABLE process_keys ADD COLUMN encrypted_key_material TEXT;
+ALTER TABLE process_keys ADD COLUMN key_hash VARCHAR(64);
+
+UPDATE process_keys k
+SET encrypted_key_material = v.encrypted_key_material, key_hash = v.key_hash
+FROM process_key_versions v
+WHERE v.process_id = k.process_id AND v.version = 1;
+
+ALTER TABLE process_keys ALTER COLUMN encrypted_key_material SET NOT NULL;
+ALTER TABLE process_keys ALTER COLUMN key_hash SET NOT NULL;
+ALTER TABLE process_keys DROP COLUMN current_version;
+
+DROP TABLE IF EXISTS process_key_versions;
@@ -0,0 +1,62 @@
+-- Versioned process keys: a rotation adds a new version, encrypted_records.key_version selects the version
+CREATE TABLE process_key_versions (
+    process_id VARCHAR(100) NOT NULL REFERENCES process_keys(process_id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    encrypted_key_material TEXT NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    retired_at TIMESTAMP,
+    PRIMARY KEY (process_id, version)
+);
+
+INSERT INTO process_key_versions (process_id, version, encrypted_key_material, key_hash, created_at)
+SELECT process_id, 1, encrypted_key_material, key_hash, created_at
+FROM process_keys;
+
+ALTER TABLE process_keys ADD COLUMN current_version INT NOT NULL DEFAULT 1;
+ALTER TABLE process_keys DROP COLUMN encrypted_key_material;
+ALTER TABLE process_keys DROP COLUMN key_hash;
+
+-- expires_at was only ever set by the previous rotation, which left the key unusable
+-- while no new key was created. The key material is unchanged, so the records become readable again.
+UPDATE process_keys SET expires_at = NULL WHERE expires_at IS NOT NULL;
+
+-- Rotations of a process key and the progress of the re-encryption job
+CREATE TABLE process_key_rotations (
+    id SERIAL PRIMARY KEY,
+    process_id VARCHAR(100) NOT NULL REFERENCES process_keys(process_id) ON DELETE CASCADE,
+    from_version INT NOT NULL,
+    to_version INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    total_records INT NOT NULL DEFAULT 0,
+    reencrypted_records INT NOT NULL DEFAULT 0,
+    error TEXT,
+    started_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    CONSTRAINT chk_process_key_rotations_status CHECK (status IN ('running', 'completed', 'failed')),
+    CONSTRAINT chk_process_key_rotations_versions CHECK (to_version > from_version)
+);
+
+-- At most one running rotation per process
+CREATE UNIQUE INDEX idx_process_key_rotations_running ON process_key_rotations(process_id) WHERE status = 'running';
+
+-- Re-encrypted payloads. encrypted_records stays append-only: the original ciphertext, signature
+-- and chain hash are kept, the current payload under the newest key version is stored here.
+CREATE TABLE encrypted_record_reencryptions (
+    record_id BIGINT PRIMARY KEY REFERENCES encrypted_records(id) ON DELETE CASCADE,
+    key_version INT NOT NULL,
+    process_key_hash VARCHAR(64) NOT NULL,
+    encrypted_data BYTEA NOT NULL,
+    encryption_nonce BYTEA NOT NULL,
+    encryption_tag BYTEA NOT NULL,
+    data_signature TEXT NOT NULL,
+    rotation_id INTEGER REFERENCES process_key_rotations(id) ON DELETE SET NULL,
+    reencrypted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE process_key_versions IS 'Key versions of a process key (encrypted with Vault); retired versions are no longer used for decryption';
+COMMENT ON COLUMN process_keys.current_version IS 'Key version used for new records';
+COMMENT ON TABLE process_key_rotations IS 'Process key rotations and progress of the re-encryption of existing records';
+COMMENT ON TABLE encrypted_record_reencryptions IS 'Current payload of re-encrypted records; the original row in encrypted_records stays unchanged for the hash chain';
+COMMENT ON COLUMN encrypted_record_reencryptions.data_signature IS 'Ed25519 signature of the record owner over encrypted data, nonce, tag and the chain hash of the original record';
@@ -0,0 +1,7 @@
+-- Remove system key versions of wrapped keys
+
+DROP INDEX IF EXISTS idx_process_key_versions_system_key_version;
+DROP INDEX IF EXISTS idx_user_keys_system_key_version;
+
+ALTER TABLE process_key_versions DROP COLUMN IF EXISTS system_key_version;
+ALTER TABLE user_keys DROP COLUMN IF EXISTS system_key_version;
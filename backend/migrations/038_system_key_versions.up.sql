@@ -0,0 +1,20 @@
+-- Version of the system master key (Vault transit) each wrapped key is encrypted with
+ALTER TABLE user_keys ADD COLUMN system_key_version INT;
+ALTER TABLE process_key_versions ADD COLUMN system_key_version INT;
+
+-- Transit ciphertexts carry their key version as prefix, e.g. "vault:v1:..."
+UPDATE user_keys
+SET system_key_version = COALESCE(CAST(substring(encrypted_private_key FROM '^vault:v([0-9]+):') AS INT), 1);
+UPDATE process_key_versions
+SET system_key_version = COALESCE(CAST(substring(encrypted_key_material FROM '^vault:v([0-9]+):') AS INT), 1);
+
+ALTER TABLE user_keys ALTER COLUMN system_key_version SET NOT NULL;
+ALTER TABLE user_keys ALTER COLUMN system_key_version SET DEFAULT 1;
+ALTER TABLE process_key_versions ALTER COLUMN system_key_version SET NOT NULL;
+ALTER TABLE process_key_versions ALTER COLUMN system_key_version SET DEFAULT 1;
+
+CREATE INDEX idx_user_keys_system_key_version ON user_keys(system_key_version);
+CREATE INDEX idx_process_key_versions_system_key_version ON process_key_versions(system_key_version);
+
+COMMENT ON COLUMN user_keys.system_key_version IS 'Version of the system master key the private key is wrapped with';
+COMMENT ON COLUMN process_key_versions.system_key_version IS 'Version of the system master key the key material is wrapped with';
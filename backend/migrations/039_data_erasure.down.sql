@@ -0,0 +1,12 @@
+-- Remove erasures and legal holds
+-- Note: destroyed keys cannot be restored; the NOT NULL constraints are only restored if no key was destroyed
+
+DROP TABLE IF EXISTS data_erasure_processes;
+DROP TABLE IF EXISTS data_erasures;
+DROP FUNCTION IF EXISTS prevent_data_erasure_modifications();
+DROP TABLE IF EXISTS legal_holds;
+
+ALTER TABLE process_keys DROP COLUMN IF EXISTS destroyed_at;
+ALTER TABLE process_key_versions ALTER COLUMN encrypted_key_material SET NOT NULL;
+ALTER TABLE user_keys DROP COLUMN IF EXISTS destroyed_at;
+ALTER TABLE user_keys ALTER COLUMN encrypted_private_key SET NOT NULL;
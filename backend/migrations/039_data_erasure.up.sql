@@ -0,0 +1,68 @@
+-- Crypto-shredding: a destroyed key loses its wrapped key material, its metadata (public key,
+-- key hashes) is kept so signatures and the hash chain of the unreadable records stay verifiable
+ALTER TABLE user_keys ALTER COLUMN encrypted_private_key DROP NOT NULL;
+ALTER TABLE user_keys ADD COLUMN destroyed_at TIMESTAMP;
+ALTER TABLE process_key_versions ALTER COLUMN encrypted_key_material DROP NOT NULL;
+ALTER TABLE process_keys ADD COLUMN destroyed_at TIMESTAMP;
+
+-- A legal hold blocks the erasure of a user's data
+CREATE TABLE legal_holds (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tombstones of erasures. No foreign keys: the tombstones outlive users and assessments.
+CREATE TABLE data_erasures (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    scope VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    user_key_destroyed BOOLEAN NOT NULL,
+    user_key_retained_reason TEXT,
+    erased_by INTEGER,
+    erased_at TIMESTAMP NOT NULL,
+    prev_hash VARCHAR(64) NOT NULL,
+    tombstone_hash VARCHAR(64) NOT NULL UNIQUE,
+    CONSTRAINT chk_data_erasures_scope CHECK (scope IN ('user', 'assessment'))
+);
+
+CREATE INDEX idx_data_erasures_user_id ON data_erasures(user_id);
+
+-- Processes whose keys were destroyed by an erasure
+CREATE TABLE data_erasure_processes (
+    erasure_id INTEGER NOT NULL REFERENCES data_erasures(id),
+    process_id VARCHAR(100) NOT NULL,
+    record_count INT NOT NULL,
+    chain_head VARCHAR(64) NOT NULL,
+    chain_valid BOOLEAN NOT NULL,
+    key_hashes TEXT[] NOT NULL,
+    PRIMARY KEY (erasure_id, process_id)
+);
+
+CREATE INDEX idx_data_erasure_processes_process_id ON data_erasure_processes(process_id);
+
+-- Tombstones are append-only
+CREATE OR REPLACE FUNCTION prevent_data_erasure_modifications()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'Modifications not allowed on % - tombstones are append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER enforce_data_erasures_append_only
+    BEFORE UPDATE OR DELETE ON data_erasures
+    FOR EACH ROW EXECUTE FUNCTION prevent_data_erasure_modifications();
+
+CREATE TRIGGER enforce_data_erasure_processes_append_only
+    BEFORE UPDATE OR DELETE ON data_erasure_processes
+    FOR EACH ROW EXECUTE FUNCTION prevent_data_erasure_modifications();
+
+COMMENT ON COLUMN user_keys.destroyed_at IS 'Set when the private key was destroyed by an erasure; records signed with it stay verifiable via the public key';
+COMMENT ON COLUMN process_keys.destroyed_at IS 'Set when the key material of all versions was destroyed by an erasure; the records of the process are unreadable';
+COMMENT ON TABLE legal_holds IS 'Users whose data must not be erased, e.g. during litigation or statutory retention';
+COMMENT ON TABLE data_erasures IS 'Append-only, hash-chained tombstones of crypto-shredding erasures';
+COMMENT ON COLUMN data_erasures.tombstone_hash IS 'SHA-256 over the previous tombstone hash and all fields of the erasure including its processes';
+COMMENT ON COLUMN data_erasure_processes.chain_head IS 'Chain hash of the last record of the process at the time of the erasure';
+COMMENT ON COLUMN data_erasure_processes.key_hashes IS 'Hashes of the destroyed process key versions ("v<version>:<hash>")';
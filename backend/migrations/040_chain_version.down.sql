@@ -0,0 +1,5 @@
+-- Remove the hash chain version
+-- Note: records with chain version 2 no longer verify under the version 1 rules
+
+ALTER TABLE encrypted_records DROP CONSTRAINT IF EXISTS chk_encrypted_records_chain_version;
+ALTER TABLE encrypted_records DROP COLUMN IF EXISTS chain_version;
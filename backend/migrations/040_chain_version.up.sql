@@ -0,0 +1,8 @@
+-- Versioned hash chain format of encrypted_records.
+-- Version 1 hashes prev_hash:signature:user_id:process_id:unix_time. Version 2 commits to all
+-- fields of the record including a digest of the ciphertext. Existing records keep version 1
+-- and are verified under its rules; encrypted_records is append-only, so they cannot be rehashed.
+ALTER TABLE encrypted_records ADD COLUMN chain_version SMALLINT NOT NULL DEFAULT 1;
+ALTER TABLE encrypted_records ADD CONSTRAINT chk_encrypted_records_chain_version CHECK (chain_version IN (1, 2));
+
+COMMENT ON COLUMN encrypted_records.chain_version IS 'Format of chain_hash: 1 = signature, user, process and time only, 2 = all record fields and ciphertext digest';
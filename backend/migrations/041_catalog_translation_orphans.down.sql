@@ -0,0 +1 @@
+-- Deleted orphan translations cannot be restored
@@ -0,0 +1,8 @@
+-- Remove translations whose category, level, path or matrix cell has been deleted.
+-- catalog_translations.entity_id references a different table per entity_type and has no
+-- foreign key; since this migration the repository deletes translations with their entity.
+DELETE FROM catalog_translations t
+WHERE (t.entity_type = 'category' AND NOT EXISTS (SELECT 1 FROM categories c WHERE c.id = t.entity_id))
+   OR (t.entity_type = 'level' AND NOT EXISTS (SELECT 1 FROM levels l WHERE l.id = t.entity_id))
+   OR (t.entity_type = 'path' AND NOT EXISTS (SELECT 1 FROM paths p WHERE p.id = t.entity_id))
+   OR (t.entity_type = 'description' AND NOT EXISTS (SELECT 1 FROM path_level_descriptions d WHERE d.id = t.entity_id));